@@ -32,9 +32,11 @@ import (
 	"github.com/ClusterCockpit/cc-backend/internal/graph/generated"
 	"github.com/ClusterCockpit/cc-backend/internal/importer"
 	"github.com/ClusterCockpit/cc-backend/internal/metricdata"
+	"github.com/ClusterCockpit/cc-backend/internal/metrics"
 	"github.com/ClusterCockpit/cc-backend/internal/repository"
 	"github.com/ClusterCockpit/cc-backend/internal/routerConfig"
 	"github.com/ClusterCockpit/cc-backend/internal/runtimeEnv"
+	"github.com/ClusterCockpit/cc-backend/internal/tracing"
 	"github.com/ClusterCockpit/cc-backend/internal/util"
 	"github.com/ClusterCockpit/cc-backend/pkg/archive"
 	"github.com/ClusterCockpit/cc-backend/pkg/log"
@@ -134,10 +136,11 @@ func initEnv() {
 }
 
 func main() {
-	var flagReinitDB, flagInit, flagServer, flagSyncLDAP, flagGops, flagMigrateDB, flagRevertDB, flagForceDB, flagDev, flagVersion, flagLogDateTime bool
-	var flagNewUser, flagDelUser, flagGenJWT, flagConfigFile, flagImportJob, flagLogLevel string
+	var flagReinitDB, flagImportNewJobs, flagInit, flagServer, flagSyncLDAP, flagGops, flagMigrateDB, flagRevertDB, flagForceDB, flagDev, flagVersion, flagLogDateTime, flagCleanupArchive, flagApplyRetentionTiers, flagDryRun bool
+	var flagNewUser, flagDelUser, flagGenJWT, flagConfigFile, flagImportJob, flagLogLevel, flagRecomputeFootprints string
 	flag.BoolVar(&flagInit, "init", false, "Setup var directory, initialize swlite database file, config.json and .env")
 	flag.BoolVar(&flagReinitDB, "init-db", false, "Go through job-archive and re-initialize the 'job', 'tag', and 'jobtag' tables (all running jobs will be lost!)")
+	flag.BoolVar(&flagImportNewJobs, "import-new-jobs", false, "Go through job-archive and add the jobs missing in the 'job' table, existing jobs and tags are kept")
 	flag.BoolVar(&flagSyncLDAP, "sync-ldap", false, "Sync the 'user' table with ldap")
 	flag.BoolVar(&flagServer, "server", false, "Start a server, continues listening on port after initialization and argument handling")
 	flag.BoolVar(&flagGops, "gops", false, "Listen via github.com/google/gops/agent (for debugging)")
@@ -152,6 +155,10 @@ func main() {
 	flag.StringVar(&flagDelUser, "del-user", "", "Remove user by `username`")
 	flag.StringVar(&flagGenJWT, "jwt", "", "Generate and print a JWT for the user specified by its `username`")
 	flag.StringVar(&flagImportJob, "import-job", "", "Import a job. Argument format: `<path-to-meta.json>:<path-to-data.json>,...`")
+	flag.StringVar(&flagRecomputeFootprints, "recompute-footprints", "", "Recompute the footprint columns of all archived jobs of `cluster` ('all' for every cluster) from the job-archive. Resumes an interrupted run")
+	flag.BoolVar(&flagCleanupArchive, "cleanup-archive", false, "Remove the job directories from the job-archive whose jobs are not in the 'job' table")
+	flag.BoolVar(&flagApplyRetentionTiers, "apply-retention-tiers", false, "Downsample the archived metric data or reduce it to the statistics according to the retention tiers of the archive config")
+	flag.BoolVar(&flagDryRun, "dry-run", false, "Only log the job directories -cleanup-archive would remove or the jobs -apply-retention-tiers would reduce")
 	flag.StringVar(&flagLogLevel, "loglevel", "warn", "Sets the logging level: `[debug,info,warn (default),err,fatal,crit]`")
 	flag.Parse()
 
@@ -191,6 +198,17 @@ func main() {
 	// The order here is important!
 	config.Init(flagConfigFile)
 
+	// The log level of the config applies unless -loglevel was given.
+	logLevelFlagSet := false
+	flag.Visit(func(f *flag.Flag) { logLevelFlagSet = logLevelFlagSet || f.Name == "loglevel" })
+	if config.Keys.LogLevel != "" && !logLevelFlagSet {
+		log.Init(config.Keys.LogLevel, flagLogDateTime)
+	}
+	if err := log.SetFormat(config.Keys.LogFormat); err != nil {
+		log.Fatal(err)
+	}
+	schema.AddJobStates(config.Keys.JobStates)
+
 	// As a special case for `db`, allow using an environment variable instead of the value
 	// stored in the config. This can be done for people having security concerns about storing
 	// the password for their mysql database in config.json.
@@ -290,6 +308,15 @@ func main() {
 		log.Fatal("arguments --add-user and --del-user can only be used if authentication is enabled")
 	}
 
+	// Without configuration, all spans are no-ops.
+	shutdownTracing := func(context.Context) error { return nil }
+	if config.Keys.Tracing != nil {
+		var err error
+		if shutdownTracing, err = tracing.Init(config.Keys.Tracing); err != nil {
+			log.Fatalf("failed to initialize tracing: %s", err.Error())
+		}
+	}
+
 	if err := archive.Init(config.Keys.Archive, config.Keys.DisableArchive); err != nil {
 		log.Fatalf("failed to initialize archive: %s", err.Error())
 	}
@@ -298,12 +325,62 @@ func main() {
 		log.Fatalf("failed to initialize metricdata repository: %s", err.Error())
 	}
 
+	if !config.Keys.DisableTagRules {
+		if err := repository.InitTagRules(config.Keys.TagRules); err != nil {
+			log.Fatalf("failed to initialize tag rules: %s", err.Error())
+		}
+	}
+
+	if err := repository.InitWebhooks(config.Keys.Webhooks); err != nil {
+		log.Fatalf("failed to initialize webhooks: %s", err.Error())
+	}
+
+	if err := repository.InitMetadataRedaction(config.Keys.MetadataRedactPatterns); err != nil {
+		log.Fatalf("failed to initialize metadata redaction: %s", err.Error())
+	}
+
 	if flagReinitDB {
 		if err := importer.InitDB(); err != nil {
 			log.Fatalf("failed to re-initialize repository DB: %s", err.Error())
 		}
 	}
 
+	if flagImportNewJobs {
+		if _, err := importer.ImportNewJobs(); err != nil {
+			log.Fatalf("failed to import new jobs: %s", err.Error())
+		}
+	}
+
+	if flagRecomputeFootprints != "" {
+		cluster := flagRecomputeFootprints
+		if cluster == "all" {
+			cluster = ""
+		}
+		if err := repository.GetJobRepository().RecomputeFootprints(cluster); err != nil {
+			log.Fatalf("recomputing footprints failed: %s", err.Error())
+		}
+	}
+
+	if flagCleanupArchive {
+		if _, err := importer.CleanupOrphanedArchives(flagDryRun); err != nil {
+			log.Fatalf("cleaning up job-archive failed: %s", err.Error())
+		}
+	}
+
+	if flagApplyRetentionTiers {
+		var cfg struct {
+			Retention schema.Retention `json:"retention"`
+		}
+		if err := json.Unmarshal(config.Keys.Archive, &cfg); err != nil {
+			log.Fatalf("parsing retention config failed: %s", err.Error())
+		}
+		downsampled, statsOnly, err := repository.GetJobRepository().ApplyRetentionTiers(cfg.Retention, time.Now(), flagDryRun)
+		if err != nil {
+			log.Fatalf("applying retention tiers failed: %s", err.Error())
+		}
+		log.Printf("Retention: %d jobs downsampled, %d jobs reduced to statistics", downsampled, statsOnly)
+	}
+
 	if flagImportJob != "" {
 		if err := importer.HandleImportFlag(flagImportJob); err != nil {
 			log.Fatalf("job import failed: %s", err.Error())
@@ -404,19 +481,39 @@ func main() {
 			}))).Methods(http.MethodPost)
 
 		secured.Use(func(next http.Handler) http.Handler {
-			return authentication.Auth(
+			onfailure := func(rw http.ResponseWriter, r *http.Request, err error) {
+				rw.WriteHeader(http.StatusUnauthorized)
+				web.RenderTemplate(rw, "login.tmpl", &web.Page{
+					Title:   "Authentication failed - ClusterCockpit",
+					MsgType: "alert-danger",
+					Message: err.Error(),
+					Build:   buildInfo,
+				})
+			}
+
+			authHandler := authentication.Auth(
 				// On success;
 				next,
 
-				// On failure:
+				// On failure: Try a cluster API key
 				func(rw http.ResponseWriter, r *http.Request, err error) {
-					rw.WriteHeader(http.StatusUnauthorized)
-					web.RenderTemplate(rw, "login.tmpl", &web.Page{
-						Title:   "Authentication failed - ClusterCockpit",
-						MsgType: "alert-danger",
-						Message: err.Error(),
-						Build:   buildInfo,
-					})
+					if r.Header.Get("X-API-Key") == "" {
+						onfailure(rw, r, err)
+						return
+					}
+					api.ApiKeyAuth(next, onfailure).ServeHTTP(rw, r)
+				})
+			if config.Keys.BearerAuth == nil {
+				return authHandler
+			}
+
+			return api.BearerAuth(
+				// On success;
+				next,
+
+				// On failure: No token of the identity provider, use the regular authentication
+				func(rw http.ResponseWriter, r *http.Request, err error) {
+					authHandler.ServeHTTP(rw, r)
 				})
 		})
 	}
@@ -428,6 +525,13 @@ func main() {
 	}
 	secured.Handle("/query", graphQLEndpoint)
 
+	// Counters and timings of cc-backend itself for Prometheus. They contain
+	// no job data, but as the endpoint is not protected by the
+	// authentication, it has to be enabled explicitly.
+	if config.Keys.EnableMetrics {
+		r.Handle("/metrics", metrics.Handler()).Methods(http.MethodGet)
+	}
+
 	// Send a searchId and then reply with a redirect to a user, or directly send query to job table for jobid and project.
 	secured.HandleFunc("/search", func(rw http.ResponseWriter, r *http.Request) {
 		routerConfig.HandleSearchBar(rw, r, buildInfo)
@@ -537,6 +641,23 @@ func main() {
 
 		// Then, wait for any async archivings still pending...
 		api.JobRepository.WaitForArchiving()
+		// ... and for the webhook requests of the last archived jobs.
+		api.JobRepository.WaitForWebhooks()
+
+		// Export the remaining spans.
+		if err := shutdownTracing(context.Background()); err != nil {
+			log.Warnf("shutting down tracing failed: %s", err.Error())
+		}
+	}()
+
+	// Reload the cluster configs of the job archive on SIGHUP
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		for range hup {
+			log.Info("SIGHUP received, reloading cluster configs")
+			archive.ReloadClusterConfig()
+		}
 	}()
 
 	s := gocron.NewScheduler(time.Local)
@@ -577,11 +698,11 @@ func main() {
 			archive.GetHandle().CleanUp(jobs)
 
 			if cfg.Retention.IncludeDB {
-				cnt, err := jobRepo.DeleteJobsBefore(startTime)
+				ids, err := jobRepo.DeleteJobsBefore(startTime, false)
 				if err != nil {
 					log.Errorf("Error while deleting retention jobs from db: %s", err.Error())
 				} else {
-					log.Infof("Retention: Removed %d jobs from db", cnt)
+					log.Infof("Retention: Removed %d jobs from db", len(ids))
 				}
 				if err = jobRepo.Optimize(); err != nil {
 					log.Errorf("Error occured in db optimization: %s", err.Error())
@@ -600,11 +721,11 @@ func main() {
 			archive.GetHandle().Move(jobs, cfg.Retention.Location)
 
 			if cfg.Retention.IncludeDB {
-				cnt, err := jobRepo.DeleteJobsBefore(startTime)
+				ids, err := jobRepo.DeleteJobsBefore(startTime, false)
 				if err != nil {
 					log.Errorf("Error while deleting retention jobs from db: %v", err)
 				} else {
-					log.Infof("Retention: Removed %d jobs from db", cnt)
+					log.Infof("Retention: Removed %d jobs from db", len(ids))
 				}
 				if err = jobRepo.Optimize(); err != nil {
 					log.Errorf("Error occured in db optimization: %v", err)
@@ -613,6 +734,19 @@ func main() {
 		})
 	}
 
+	if cfg.Retention.StatsOnlyAge > 0 || (cfg.Retention.DownsampleAge > 0 && cfg.Retention.DownsampleTimestep > 0) {
+		log.Info("Register retention tiers service")
+
+		s.Every(1).Day().At("4:30").Do(func() {
+			downsampled, statsOnly, err := jobRepo.ApplyRetentionTiers(cfg.Retention, time.Now(), false)
+			if err != nil {
+				log.Errorf("Error while applying retention tiers: %v", err)
+			} else {
+				log.Infof("Retention: %d jobs downsampled, %d jobs reduced to statistics", downsampled, statsOnly)
+			}
+		})
+	}
+
 	if cfg.Compression > 0 {
 		log.Info("Register compression service")
 
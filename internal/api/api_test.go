@@ -5,28 +5,47 @@
 package api_test
 
 import (
+	"archive/tar"
 	"bytes"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"database/sql"
+	"encoding/base64"
+	"encoding/csv"
 	"encoding/json"
+	"encoding/pem"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/ClusterCockpit/cc-backend/internal/api"
 	"github.com/ClusterCockpit/cc-backend/internal/config"
 	"github.com/ClusterCockpit/cc-backend/internal/graph"
+	"github.com/ClusterCockpit/cc-backend/internal/graph/model"
 	"github.com/ClusterCockpit/cc-backend/internal/metricdata"
+	"github.com/ClusterCockpit/cc-backend/internal/metrics"
 	"github.com/ClusterCockpit/cc-backend/internal/repository"
 	"github.com/ClusterCockpit/cc-backend/pkg/archive"
 	"github.com/ClusterCockpit/cc-backend/pkg/log"
 	"github.com/ClusterCockpit/cc-backend/pkg/schema"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/gorilla/mux"
+	"github.com/prometheus/common/expfmt"
 
 	_ "github.com/mattn/go-sqlite3"
 )
@@ -35,6 +54,9 @@ func setup(t *testing.T) *api.RestApi {
 	const testconfig = `{
 	"addr":            "0.0.0.0:8080",
 	"validate": false,
+	"auto-tag-hardware": true,
+	"archive-workers": 2,
+	"enable-metrics": true,
 	"archive": {
 		"kind": "file",
 		"path": "./var/job-archive"
@@ -46,6 +68,7 @@ func setup(t *testing.T) *api.RestApi {
 	{
 	   "name": "testcluster",
 	   "metricDataRepository": {"kind": "test", "url": "bla:8081"},
+	   "apiKeys": ["61372661cf51fbc346920c1886f5cf76d5acd0e1b223b555e357c461bea4d5f9"],
 	   "filterRanges": {
 		"numNodes": { "from": 1, "to": 64 },
 		"duration": { "from": 0, "to": 86400 },
@@ -93,6 +116,43 @@ func setup(t *testing.T) *api.RestApi {
 					"die": [[0, 1, 2, 3, 4, 5, 6, 7]],
 					"core": [[0], [1], [2], [3], [4], [5], [6], [7]]
 				}
+			},
+			{
+				"name": "sc2",
+				"nodes": "host200",
+				"processorType": "Intel Core i7-4770",
+				"socketsPerNode": 1,
+				"coresPerSocket": 4,
+				"threadsPerCore": 2,
+                "flopRateScalar": {
+                  "unit": {
+                    "prefix": "G",
+                    "base": "F/s"
+                  },
+                  "value": 14
+                },
+                "flopRateSimd": {
+                  "unit": {
+                    "prefix": "G",
+                    "base": "F/s"
+                  },
+                  "value": 112
+                },
+                "memoryBandwidth": {
+                  "unit": {
+                    "prefix": "G",
+                    "base": "B/s"
+                  },
+                  "value": 24
+                },
+                "numberOfNodes": 1,
+				"topology": {
+					"node": [0, 1, 2, 3, 4, 5, 6, 7],
+					"socket": [[0, 1, 2, 3, 4, 5, 6, 7]],
+					"memoryDomain": [[0, 1, 2, 3, 4, 5, 6, 7]],
+					"die": [[0, 1, 2, 3, 4, 5, 6, 7]],
+					"core": [[0], [1], [2], [3], [4], [5], [6], [7]]
+				}
 			}
 		],
 		"metricConfig": [
@@ -172,6 +232,44 @@ func cleanup() {
 * Do not run sub-tests in parallel! Tests should not be run in parallel at all, because
 * at least `setup` modifies global state.
  */
+// scrapeMetrics returns the values of the /metrics endpoint by series.
+func scrapeMetrics(t *testing.T) map[string]float64 {
+	recorder := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatal(recorder.Result().Status, recorder.Body.String())
+	}
+
+	families, err := new(expfmt.TextParser).TextToMetricFamilies(recorder.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values := make(map[string]float64)
+	for name, family := range families {
+		for _, m := range family.Metric {
+			labels := make([]string, 0, len(m.Label))
+			for _, l := range m.Label {
+				labels = append(labels, fmt.Sprintf("%s=%q", l.GetName(), l.GetValue()))
+			}
+			key := name
+			if len(labels) > 0 {
+				key += "{" + strings.Join(labels, ",") + "}"
+			}
+
+			switch {
+			case m.Counter != nil:
+				values[key] = m.Counter.GetValue()
+			case m.Gauge != nil:
+				values[key] = m.Gauge.GetValue()
+			case m.Histogram != nil:
+				values[strings.Replace(key, name, name+"_count", 1)] = float64(m.Histogram.GetSampleCount())
+			}
+		}
+	}
+	return values
+}
+
 func TestRestApi(t *testing.T) {
 	restapi := setup(t)
 	t.Cleanup(cleanup)
@@ -269,10 +367,21 @@ func TestRestApi(t *testing.T) {
 			t.Fatalf("unexpected job properties: %#v", job)
 		}
 
-		if len(job.Tags) != 1 || job.Tags[0].Type != "testTagType" || job.Tags[0].Name != "testTagName" {
+		tags := map[string]string{}
+		for _, tag := range job.Tags {
+			tags[tag.Type] = tag.Name
+		}
+		if len(job.Tags) != 2 || tags["testTagType"] != "testTagName" || tags["hw"] != "Intel Core i7-4770" {
 			t.Fatalf("unexpected tags: %#v", job.Tags)
 		}
 
+		if err := restapi.JobRepository.AddHardwareTag(job.ID, job.Cluster, job.SubCluster); err != nil {
+			t.Fatal(err)
+		}
+		if tags, err := restapi.JobRepository.GetTags(nil, &job.ID); err != nil || len(tags) != 2 {
+			t.Fatalf("unexpected tags after tagging twice: %#v", tags)
+		}
+
 		dbid = res.DBID
 	}); !ok {
 		return
@@ -317,7 +426,16 @@ func TestRestApi(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		if !reflect.DeepEqual(job.MetaData, map[string]string{"jobScript": "blablabla..."}) {
+		if job.MetaData["jobScript"] != "blablabla..." {
+			t.Fatalf("unexpected job.metaData: %#v", job.MetaData)
+		}
+
+		// load_one has no footprint column, its statistics end up in the metadata
+		var statistics map[string]schema.JobStatistics
+		if err := json.Unmarshal([]byte(job.MetaData["statistics"]), &statistics); err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := statistics["load_one"]; !ok || len(job.MetaData) != 2 {
 			t.Fatalf("unexpected job.metaData: %#v", job.MetaData)
 		}
 
@@ -326,6 +444,25 @@ func TestRestApi(t *testing.T) {
 		return
 	}
 
+	t.Run("StopJobTwice", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/jobs/stop_job/", bytes.NewBuffer([]byte(stopJobBody)))
+		recorder := httptest.NewRecorder()
+
+		r.ServeHTTP(recorder, req)
+		response := recorder.Result()
+		if response.StatusCode != http.StatusUnprocessableEntity {
+			t.Fatal(response.Status, recorder.Body.String())
+		}
+
+		job, err := restapi.JobRepository.FindById(stoppedJob.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if job.State != schema.JobStateCompleted || job.Duration != stoppedJob.Duration {
+			t.Fatalf("stopped job was modified: %#v", job)
+		}
+	})
+
 	t.Run("CheckArchive", func(t *testing.T) {
 		data, err := metricdata.LoadData(stoppedJob, []string{"load_one"}, []schema.MetricScope{schema.MetricScopeNode}, context.Background())
 		if err != nil {
@@ -337,63 +474,280 @@ func TestRestApi(t *testing.T) {
 		}
 	})
 
-	t.Run("CheckDoubleStart", func(t *testing.T) {
-		// Starting a job with the same jobId and cluster should only be allowed if the startTime is far appart!
-		body := strings.Replace(startJobBody, `"startTime": 123456789`, `"startTime": 123456790`, -1)
-
-		req := httptest.NewRequest(http.MethodPost, "/api/jobs/start_job/", bytes.NewBuffer([]byte(body)))
+	t.Run("StreamJobMetrics", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/jobs/metrics/%d/stream?metric=load_one", stoppedJob.ID), nil)
 		recorder := httptest.NewRecorder()
 
 		r.ServeHTTP(recorder, req)
 		response := recorder.Result()
-		if response.StatusCode != http.StatusUnprocessableEntity {
+		if response.StatusCode != http.StatusOK {
 			t.Fatal(response.Status, recorder.Body.String())
 		}
+
+		dec := json.NewDecoder(response.Body)
+		n := 0
+		for dec.More() {
+			var line api.JobMetricSeries
+			if err := dec.Decode(&line); err != nil {
+				t.Fatal(err)
+			}
+			if line.Metric != "load_one" || line.Scope != schema.MetricScopeNode || line.Series == nil {
+				t.Fatalf("unexpected series: %#v", line)
+			}
+			n++
+		}
+
+		if want := len(testData["load_one"][schema.MetricScopeNode].Series); n != want {
+			t.Fatalf("expected %d series, got %d", want, n)
+		}
 	})
 
-	const startJobBodyFailed string = `{
-        "jobId":            12345,
-		"user":             "testuser",
-		"project":          "testproj",
-		"cluster":          "testcluster",
-		"partition":        "default",
-		"walltime":         3600,
-		"numNodes":         1,
-		"exclusive":        1,
-		"monitoringStatus": 1,
-		"smt":              1,
-		"resources": [
-			{
-				"hostname": "host123"
+	t.Run("JobStats", func(t *testing.T) {
+		// Archived jobs must not hit the metric data repository.
+		metricdata.TestLoadDataCallback = func(job *schema.Job, metrics []string, scopes []schema.MetricScope, ctx context.Context) (schema.JobData, error) {
+			return nil, errors.New("metric data repository queried for archived job")
+		}
+		defer func() {
+			metricdata.TestLoadDataCallback = func(job *schema.Job, metrics []string, scopes []schema.MetricScope, ctx context.Context) (schema.JobData, error) {
+				return testData, nil
 			}
-		],
-		"startTime": 12345678
-	}`
+		}()
 
-	ok := t.Run("StartJobFailed", func(t *testing.T) {
-		req := httptest.NewRequest(http.MethodPost, "/api/jobs/start_job/", bytes.NewBuffer([]byte(startJobBodyFailed)))
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/jobs/%d/stats?metrics=load_one", stoppedJob.ID), nil)
 		recorder := httptest.NewRecorder()
 
 		r.ServeHTTP(recorder, req)
 		response := recorder.Result()
-		if response.StatusCode != http.StatusCreated {
+		if response.StatusCode != http.StatusOK {
+			t.Fatal(response.Status, recorder.Body.String())
+		}
+
+		var res api.GetJobStatsApiResponse
+		if err := json.NewDecoder(response.Body).Decode(&res); err != nil {
+			t.Fatal(err)
+		}
+		want := testData["load_one"][schema.MetricScopeNode].Series[0].Statistics
+		if res.ID != stoppedJob.ID || res.Stats["load_one"]["host123"] != want {
+			t.Fatalf("unexpected statistics: %#v", res)
+		}
+
+		req = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/jobs/%d/stats", stoppedJob.ID), nil)
+		req = req.WithContext(context.WithValue(req.Context(), repository.ContextUserKey, &schema.User{
+			Username: "otheruser",
+			Roles:    []string{schema.GetRoleString(schema.RoleUser), schema.GetRoleString(schema.RoleApi)},
+		}))
+		recorder = httptest.NewRecorder()
+
+		r.ServeHTTP(recorder, req)
+		if response := recorder.Result(); response.StatusCode != http.StatusForbidden {
+			t.Fatal(response.Status, recorder.Body.String())
+		}
+
+		req = httptest.NewRequest(http.MethodGet, "/api/jobs/987654321/stats", nil)
+		recorder = httptest.NewRecorder()
+		r.ServeHTTP(recorder, req)
+		if response := recorder.Result(); response.StatusCode != http.StatusUnprocessableEntity {
 			t.Fatal(response.Status, recorder.Body.String())
 		}
 	})
-	if !ok {
-		t.Fatal("subtest failed")
-	}
 
-	const stopJobBodyFailed string = `{
-        "jobId":     12345,
-		"cluster":   "testcluster",
+	t.Run("JobArchive", func(t *testing.T) {
+		getArchive := func() *httptest.ResponseRecorder {
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/jobs/%d/archive.tar", stoppedJob.ID), nil)
+			recorder := httptest.NewRecorder()
+			r.ServeHTTP(recorder, req)
+			return recorder
+		}
 
-		"jobState": "failed",
-		"stopTime": 12355678
-	}`
+		recorder := getArchive()
+		response := recorder.Result()
+		if response.StatusCode != http.StatusOK {
+			t.Fatal(response.Status, recorder.Body.String())
+		}
+		if ct := response.Header.Get("Content-Type"); ct != "application/x-tar" {
+			t.Fatalf("unexpected content type: %s", ct)
+		}
 
-	ok = t.Run("StopJobFailed", func(t *testing.T) {
-		req := httptest.NewRequest(http.MethodPost, "/api/jobs/stop_job/", bytes.NewBuffer([]byte(stopJobBodyFailed)))
+		files := map[string][]byte{}
+		tr := tar.NewReader(response.Body)
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if files[hdr.Name], err = io.ReadAll(tr); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if len(files) != 2 {
+			t.Fatalf("expected meta.json and data.json, got %d files", len(files))
+		}
+
+		var jobMeta schema.JobMeta
+		if err := json.Unmarshal(files["meta.json"], &jobMeta); err != nil {
+			t.Fatal(err)
+		}
+		if jobMeta.JobID != stoppedJob.JobID || jobMeta.Cluster != stoppedJob.Cluster || jobMeta.StartTime != stoppedJob.StartTimeUnix {
+			t.Fatalf("unexpected meta.json: %s", files["meta.json"])
+		}
+		var jobData schema.JobData
+		if err := json.Unmarshal(files["data.json"], &jobData); err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := jobData["load_one"][schema.MetricScopeNode]; !ok {
+			t.Fatalf("unexpected data.json: %s", files["data.json"])
+		}
+
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/jobs/%d/archive.tar", stoppedJob.ID), nil)
+		req = req.WithContext(context.WithValue(req.Context(), repository.ContextUserKey, &schema.User{
+			Username: "otheruser",
+			Roles:    []string{schema.GetRoleString(schema.RoleUser), schema.GetRoleString(schema.RoleApi)},
+		}))
+		recorder = httptest.NewRecorder()
+		r.ServeHTTP(recorder, req)
+		if response := recorder.Result(); response.StatusCode != http.StatusForbidden {
+			t.Fatal(response.Status, recorder.Body.String())
+		}
+
+		if err := restapi.JobRepository.UpdateMonitoringStatus(stoppedJob.ID, schema.MonitoringStatusArchivingFailed); err != nil {
+			t.Fatal(err)
+		}
+		defer restapi.JobRepository.UpdateMonitoringStatus(stoppedJob.ID, schema.MonitoringStatusArchivingSuccessful)
+		if recorder := getArchive(); recorder.Result().StatusCode != http.StatusConflict {
+			t.Fatal(recorder.Result().Status, recorder.Body.String())
+		}
+	})
+
+	t.Run("ImportJob", func(t *testing.T) {
+		readTar := func(body io.Reader) map[string][]byte {
+			files := map[string][]byte{}
+			tr := tar.NewReader(body)
+			for {
+				hdr, err := tr.Next()
+				if err == io.EOF {
+					return files
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				if files[hdr.Name], err = io.ReadAll(tr); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+		exportJob := func(id int64) map[string][]byte {
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/jobs/%d/archive.tar", id), nil)
+			recorder := httptest.NewRecorder()
+			r.ServeHTTP(recorder, req)
+			if response := recorder.Result(); response.StatusCode != http.StatusOK {
+				t.Fatal(response.Status, recorder.Body.String())
+			}
+			return readTar(recorder.Body)
+		}
+		importJob := func(user *schema.User, files map[string][]byte) *httptest.ResponseRecorder {
+			var buf bytes.Buffer
+			tw := tar.NewWriter(&buf)
+			for _, name := range []string{"meta.json", "data.json"} {
+				if err := tw.WriteHeader(&tar.Header{Name: "job/" + name, Mode: 0644, Size: int64(len(files[name]))}); err != nil {
+					t.Fatal(err)
+				}
+				if _, err := tw.Write(files[name]); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := tw.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/jobs/import", &buf)
+			if user != nil {
+				req = req.WithContext(context.WithValue(req.Context(), repository.ContextUserKey, user))
+			}
+			recorder := httptest.NewRecorder()
+			r.ServeHTTP(recorder, req)
+			return recorder
+		}
+
+		// The exported job is imported as a new job with another jobId, the
+		// test setup has only one database and job archive.
+		exported := exportJob(stoppedJob.ID)
+		var jobMeta schema.JobMeta
+		if err := json.Unmarshal(exported["meta.json"], &jobMeta); err != nil {
+			t.Fatal(err)
+		}
+		jobMeta.JobID = 4242
+		rawMeta, err := json.Marshal(jobMeta)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files := map[string][]byte{"meta.json": rawMeta, "data.json": exported["data.json"]}
+
+		user := &schema.User{Username: "testuser", Roles: []string{schema.GetRoleString(schema.RoleUser)}}
+		if recorder := importJob(user, files); recorder.Result().StatusCode != http.StatusForbidden {
+			t.Fatal(recorder.Result().Status, recorder.Body.String())
+		}
+
+		recorder := importJob(&schema.User{Username: "admin", Roles: []string{schema.GetRoleString(schema.RoleAdmin)}}, files)
+		if response := recorder.Result(); response.StatusCode != http.StatusCreated {
+			t.Fatal(response.Status, recorder.Body.String())
+		}
+		var res api.StartJobApiResponse
+		if err := json.NewDecoder(recorder.Body).Decode(&res); err != nil {
+			t.Fatal(err)
+		}
+		defer restapi.JobRepository.DeleteJobById(res.DBID, false)
+
+		job, err := restapi.JobRepository.FindById(res.DBID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if job.JobID != 4242 || job.User != stoppedJob.User || job.Cluster != stoppedJob.Cluster ||
+			job.StartTimeUnix != stoppedJob.StartTimeUnix || job.Duration != stoppedJob.Duration ||
+			job.State != stoppedJob.State || job.MonitoringStatus != schema.MonitoringStatusArchivingSuccessful {
+			t.Fatalf("unexpected imported job: %#v", job)
+		}
+
+		imported := exportJob(res.DBID)
+		if !bytes.Equal(imported["data.json"], exported["data.json"]) {
+			t.Fatal("data.json of imported job differs")
+		}
+		var importedMeta schema.JobMeta
+		if err := json.Unmarshal(imported["meta.json"], &importedMeta); err != nil {
+			t.Fatal(err)
+		}
+		jobMeta.MonitoringStatus = schema.MonitoringStatusArchivingSuccessful
+		if !reflect.DeepEqual(importedMeta, jobMeta) {
+			t.Fatalf("meta.json of imported job differs:\n%s\n%s", imported["meta.json"], rawMeta)
+		}
+
+		if recorder := importJob(nil, files); recorder.Result().StatusCode != http.StatusUnprocessableEntity {
+			t.Fatal(recorder.Result().Status, recorder.Body.String())
+		}
+		if recorder := importJob(nil, map[string][]byte{"meta.json": rawMeta}); recorder.Result().StatusCode != http.StatusBadRequest {
+			t.Fatal(recorder.Result().Status, recorder.Body.String())
+		}
+	})
+
+	t.Run("GetJobsOutOfFilterRange", func(t *testing.T) {
+		// testcluster declares startTime from 2022-01-01 on.
+		req := httptest.NewRequest(http.MethodGet, "/api/jobs/?cluster=testcluster&start-time=100000000-200000000", nil)
+		recorder := httptest.NewRecorder()
+
+		r.ServeHTTP(recorder, req)
+		response := recorder.Result()
+		if response.StatusCode != http.StatusBadRequest {
+			t.Fatal(response.Status, recorder.Body.String())
+		}
+		if !strings.Contains(recorder.Body.String(), "startTime") {
+			t.Fatalf("unexpected error message: %s", recorder.Body.String())
+		}
+	})
+
+	t.Run("GetTags", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/tags/?type=testTagType&minCount=1", nil)
 		recorder := httptest.NewRecorder()
 
 		r.ServeHTTP(recorder, req)
@@ -402,18 +756,2128 @@ func TestRestApi(t *testing.T) {
 			t.Fatal(response.Status, recorder.Body.String())
 		}
 
-		restapi.JobRepository.WaitForArchiving()
-		jobid, cluster := int64(12345), "testcluster"
-		job, err := restapi.JobRepository.Find(&jobid, &cluster, nil)
-		if err != nil {
+		var tags []api.TagCountApiResponse
+		if err := json.NewDecoder(response.Body).Decode(&tags); err != nil {
 			t.Fatal(err)
 		}
+		if len(tags) != 1 || tags[0].Name != "testTagName" || tags[0].Count < 1 {
+			t.Fatalf("unexpected tags: %#v", tags)
+		}
 
-		if job.State != schema.JobStateFailed {
-			t.Fatal("expected job to be failed")
+		req = httptest.NewRequest(http.MethodGet, "/api/tags/?minCount=many", nil)
+		recorder = httptest.NewRecorder()
+		r.ServeHTTP(recorder, req)
+		if response := recorder.Result(); response.StatusCode != http.StatusBadRequest {
+			t.Fatal(response.Status, recorder.Body.String())
+		}
+	})
+
+	t.Run("ExportJobs", func(t *testing.T) {
+		export := func(username string) [][]string {
+			req := httptest.NewRequest(http.MethodGet, "/api/jobs/export?format=csv&cluster=testcluster&state=completed", nil)
+			req = req.WithContext(context.WithValue(req.Context(), repository.ContextUserKey, &schema.User{
+				Username: username,
+				Roles:    []string{schema.GetRoleString(schema.RoleUser)},
+			}))
+			recorder := httptest.NewRecorder()
+
+			r.ServeHTTP(recorder, req)
+			response := recorder.Result()
+			if response.StatusCode != http.StatusOK {
+				t.Fatal(response.Status, recorder.Body.String())
+			}
+			if ct := response.Header.Get("Content-Type"); ct != "text/csv" {
+				t.Fatalf("unexpected content type: %s", ct)
+			}
+
+			records, err := csv.NewReader(response.Body).ReadAll()
+			if err != nil {
+				t.Fatal(err)
+			}
+			header := []string{"jobId", "user", "project", "cluster", "state", "startTime", "duration", "numNodes",
+				"flopsAnyAvg", "memBwAvg", "loadAvg", "netBwAvg", "fileBwAvg"}
+			if len(records) == 0 || !reflect.DeepEqual(records[0], header) {
+				t.Fatalf("unexpected header: %v", records)
+			}
+			return records[1:]
+		}
+
+		var row []string
+		for _, record := range export("testuser") {
+			if record[0] == "123" {
+				row = record
+			}
+		}
+		want := []string{"123", "testuser", "testproj", "testcluster", "completed",
+			time.Unix(123456789, 0).UTC().Format(time.RFC3339), "1000", "1"}
+		if row == nil || !reflect.DeepEqual(row[:8], want) {
+			t.Fatalf("unexpected row for job 123: %v", row)
+		}
+
+		// Users only get their own jobs.
+		if rows := export("otheruser"); len(rows) != 0 {
+			t.Fatalf("unexpected rows for otheruser: %v", rows)
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/api/jobs/export?format=xlsx", nil)
+		recorder := httptest.NewRecorder()
+		r.ServeHTTP(recorder, req)
+		if response := recorder.Result(); response.StatusCode != http.StatusBadRequest {
+			t.Fatal(response.Status, recorder.Body.String())
+		}
+	})
+
+	t.Run("GetCluster", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/clusters/testcluster", nil)
+		recorder := httptest.NewRecorder()
+
+		r.ServeHTTP(recorder, req)
+		response := recorder.Result()
+		if response.StatusCode != http.StatusOK {
+			t.Fatal(response.Status, recorder.Body.String())
+		}
+		body := recorder.Body.String()
+		if strings.Contains(body, "metricDataRepository") || strings.Contains(body, "apiKeys") {
+			t.Fatalf("program config leaked: %s", body)
+		}
+
+		var cluster api.ClusterApiResponse
+		if err := json.Unmarshal([]byte(body), &cluster); err != nil {
+			t.Fatal(err)
+		}
+		if cluster.Name != "testcluster" || len(cluster.SubClusters) != 2 || cluster.SubClusters[0].Name != "sc1" ||
+			!reflect.DeepEqual(cluster.SubClusters[0].Topology.Node, []int{0, 1, 2, 3, 4, 5, 6, 7}) {
+			t.Fatalf("unexpected cluster: %s", body)
+		}
+		if cluster.FilterRanges == nil || cluster.FilterRanges.NumNodes.To != 64 {
+			t.Fatalf("unexpected filterRanges: %s", body)
+		}
+
+		req = httptest.NewRequest(http.MethodGet, "/api/clusters/", nil)
+		recorder = httptest.NewRecorder()
+		r.ServeHTTP(recorder, req)
+		var clusters api.GetClustersApiResponse
+		if err := json.NewDecoder(recorder.Body).Decode(&clusters); err != nil {
+			t.Fatal(err)
+		}
+		if len(clusters.Clusters) != 1 || clusters.Clusters[0].FilterRanges == nil {
+			t.Fatalf("unexpected clusters: %#v", clusters)
+		}
+
+		req = httptest.NewRequest(http.MethodGet, "/api/clusters/unknown", nil)
+		recorder = httptest.NewRecorder()
+		r.ServeHTTP(recorder, req)
+		if response := recorder.Result(); response.StatusCode != http.StatusNotFound {
+			t.Fatal(response.Status, recorder.Body.String())
+		}
+	})
+
+	t.Run("Health", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/health", nil)
+		recorder := httptest.NewRecorder()
+
+		r.ServeHTTP(recorder, req)
+		response := recorder.Result()
+		if response.StatusCode != http.StatusOK {
+			t.Fatal(response.Status, recorder.Body.String())
+		}
+
+		var health api.HealthApiResponse
+		if err := json.NewDecoder(recorder.Body).Decode(&health); err != nil {
+			t.Fatal(err)
+		}
+		expected := []api.HealthCheckResult{
+			{Name: "database", Healthy: true},
+			{Name: "metricdata/testcluster", Healthy: true},
+		}
+		if !health.Healthy || !reflect.DeepEqual(health.Checks, expected) {
+			t.Fatalf("unexpected health: %#v", health)
+		}
+	})
+
+	t.Run("Metrics", func(t *testing.T) {
+		before := scrapeMetrics(t)
+
+		for i := 0; i < 2; i++ {
+			req := httptest.NewRequest(http.MethodGet, "/api/health", nil)
+			r.ServeHTTP(httptest.NewRecorder(), req)
+
+			req = httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/jobs/%d", stoppedJob.ID),
+				bytes.NewBufferString(`["load_one"]`))
+			recorder := httptest.NewRecorder()
+			r.ServeHTTP(recorder, req)
+			if recorder.Code != http.StatusOK {
+				t.Fatal(recorder.Result().Status, recorder.Body.String())
+			}
+		}
+
+		after := scrapeMetrics(t)
+		for _, series := range []string{
+			`cc_backend_api_requests_total{code="200",method="GET",route="/api/health"}`,
+			`cc_backend_api_requests_total{code="200",method="POST",route="/api/jobs/{id}"}`,
+		} {
+			if after[series] < before[series]+2 {
+				t.Errorf("%s: expected an increase by 2, got %v -> %v", series, before[series], after[series])
+			}
+		}
+		// The second load of the job data is served from the cache.
+		hits, misses := `cc_backend_metricdata_cache_requests_total{result="hit"}`, `cc_backend_metricdata_cache_requests_total{result="miss"}`
+		if after[hits] < before[hits]+1 || after[hits]+after[misses] != before[hits]+before[misses]+2 {
+			t.Errorf("unexpected cache lookups: %v hits, %v misses", after[hits]-before[hits], after[misses]-before[misses])
+		}
+		if series := `cc_backend_db_query_duration_seconds_count{statement="SELECT"}`; after[series] <= before[series] {
+			t.Errorf("%s: no queries measured", series)
+		}
+		if _, ok := after["cc_backend_archiving_pending"]; !ok {
+			t.Error("archiving queue depth missing")
+		}
+	})
+
+	t.Run("NodeMetrics", func(t *testing.T) {
+		var queried []string
+		metricdata.TestLoadNodeDataCallback = func(cluster string, metrics, nodes []string, scopes []schema.MetricScope, from, to time.Time, ctx context.Context) (map[string]map[string][]*schema.JobMetric, error) {
+			if cluster != "testcluster" || !reflect.DeepEqual(metrics, []string{"load_one"}) ||
+				!reflect.DeepEqual(scopes, []schema.MetricScope{schema.MetricScopeNode}) || from.Unix() != 1000 || to.Unix() != 2000 {
+				t.Errorf("unexpected arguments: %s %v %v %s %s", cluster, metrics, scopes, from, to)
+			}
+			queried = nodes
+
+			data := make(map[string]map[string][]*schema.JobMetric, len(nodes))
+			for _, node := range nodes {
+				data[node] = map[string][]*schema.JobMetric{
+					"load_one": {{Unit: schema.Unit{Base: "load"}, Timestep: 60, Series: []schema.Series{{Hostname: node, Data: []schema.Float{1, 2}}}}},
+				}
+			}
+			return data, nil
+		}
+
+		ctx := context.WithValue(context.Background(), repository.ContextUserKey,
+			&schema.User{Username: "admin", Roles: []string{schema.GetRoleString(schema.RoleAdmin)}})
+		nodeMetrics, err := restapi.Resolver.Query().NodeMetrics(ctx, "testcluster", nil, nil, nil,
+			time.Unix(1000, 0), time.Unix(2000, 0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(queried, []string{"host123", "host124", "host125", "host200"}) {
+			t.Fatalf("unexpected nodes: %v", queried)
+		}
+		if len(nodeMetrics) != 4 || nodeMetrics[0].Host != "host123" || nodeMetrics[0].SubCluster != "sc1" ||
+			nodeMetrics[3].Host != "host200" || nodeMetrics[3].SubCluster != "sc2" {
+			t.Fatalf("unexpected node metrics: %#v", nodeMetrics)
+		}
+		if m := nodeMetrics[3].Metrics; len(m) != 1 || m[0].Name != "load_one" || m[0].Metric.Series[0].Hostname != "host200" {
+			t.Fatalf("unexpected metrics: %#v", m)
+		}
+
+		nodeMetrics, err = restapi.Resolver.Query().NodeMetrics(ctx, "testcluster", []string{"host124"}, nil,
+			[]string{"load_one"}, time.Unix(1000, 0), time.Unix(2000, 0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(nodeMetrics) != 1 || nodeMetrics[0].Host != "host124" || !reflect.DeepEqual(queried, []string{"host124"}) {
+			t.Fatalf("unexpected node metrics: %#v", nodeMetrics)
+		}
+
+		if _, err := restapi.Resolver.Query().NodeMetrics(ctx, "unknown", nil, nil, nil,
+			time.Unix(1000, 0), time.Unix(2000, 0)); err == nil {
+			t.Fatal("expected error for unknown cluster")
+		}
+	})
+
+	t.Run("MonitoringStatus", func(t *testing.T) {
+		setStatus := func(role schema.Role, status string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/jobs/%d/monitoring_status", stoppedJob.ID),
+				bytes.NewBufferString(fmt.Sprintf(`{"monitoringStatus": %s}`, status)))
+			req = req.WithContext(context.WithValue(req.Context(), repository.ContextUserKey, &schema.User{
+				Username: "operator",
+				Roles:    []string{schema.GetRoleString(role)},
+			}))
+			recorder := httptest.NewRecorder()
+			r.ServeHTTP(recorder, req)
+			return recorder
+		}
+		listJobs := func(status int32) []int64 {
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/jobs/monitoring?status=%d&cluster=testcluster", status), nil)
+			req = req.WithContext(context.WithValue(req.Context(), repository.ContextUserKey, &schema.User{
+				Username: "operator",
+				Roles:    []string{schema.GetRoleString(schema.RoleApi)},
+			}))
+			recorder := httptest.NewRecorder()
+			r.ServeHTTP(recorder, req)
+			if response := recorder.Result(); response.StatusCode != http.StatusOK {
+				t.Fatal(response.Status, recorder.Body.String())
+			}
+
+			var res api.GetMonitoringStatusJobsApiResponse
+			if err := json.NewDecoder(recorder.Body).Decode(&res); err != nil {
+				t.Fatal(err)
+			}
+			ids := make([]int64, 0, len(res.Jobs))
+			for _, job := range res.Jobs {
+				if job.MonitoringStatus != status {
+					t.Fatalf("job %d has monitoring status %d, want %d", *job.ID, job.MonitoringStatus, status)
+				}
+				ids = append(ids, *job.ID)
+			}
+			return ids
+		}
+		contains := func(ids []int64, id int64) bool {
+			for _, x := range ids {
+				if x == id {
+					return true
+				}
+			}
+			return false
+		}
+
+		if ids := listJobs(schema.MonitoringStatusArchivingSuccessful); !contains(ids, stoppedJob.ID) {
+			t.Fatalf("job %d missing in %v", stoppedJob.ID, ids)
+		}
+
+		if recorder := setStatus(schema.RoleUser, "2"); recorder.Result().StatusCode != http.StatusForbidden {
+			t.Fatal(recorder.Result().Status, recorder.Body.String())
+		}
+		if recorder := setStatus(schema.RoleAdmin, "7"); recorder.Result().StatusCode != http.StatusBadRequest {
+			t.Fatal(recorder.Result().Status, recorder.Body.String())
+		}
+		if recorder := setStatus(schema.RoleAdmin, "5"); recorder.Result().StatusCode != http.StatusOK {
+			t.Fatal(recorder.Result().Status, recorder.Body.String())
+		}
+		if ids := listJobs(schema.MonitoringStatusArchiveStatsOnly); !contains(ids, stoppedJob.ID) {
+			t.Fatalf("job %d not listed with status 5", stoppedJob.ID)
+		}
+		if recorder := setStatus(schema.RoleAdmin, "2"); recorder.Result().StatusCode != http.StatusOK {
+			t.Fatal(recorder.Result().Status, recorder.Body.String())
+		}
+		defer setStatus(schema.RoleAdmin, "3")
+
+		if ids := listJobs(schema.MonitoringStatusArchivingFailed); !contains(ids, stoppedJob.ID) {
+			t.Fatalf("job %d missing in %v", stoppedJob.ID, ids)
+		}
+		if ids := listJobs(schema.MonitoringStatusArchivingSuccessful); contains(ids, stoppedJob.ID) {
+			t.Fatalf("job %d still listed with status 3", stoppedJob.ID)
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/api/jobs/monitoring?status=running", nil)
+		recorder := httptest.NewRecorder()
+		r.ServeHTTP(recorder, req)
+		if response := recorder.Result(); response.StatusCode != http.StatusBadRequest {
+			t.Fatal(response.Status, recorder.Body.String())
+		}
+	})
+
+	t.Run("Reindex", func(t *testing.T) {
+		reindex := func(role schema.Role, query string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(http.MethodPost, "/api/admin/reindex"+query, nil)
+			req = req.WithContext(context.WithValue(req.Context(), repository.ContextUserKey, &schema.User{
+				Username: "operator",
+				Roles:    []string{schema.GetRoleString(role)},
+			}))
+			recorder := httptest.NewRecorder()
+			r.ServeHTTP(recorder, req)
+			return recorder
+		}
+
+		if response := reindex(schema.RoleApi, "").Result(); response.StatusCode != http.StatusForbidden {
+			t.Fatalf("expected 403 for non-admin, got %s", response.Status)
+		}
+
+		for _, query := range []string{"", "?recreate=true"} {
+			recorder := reindex(schema.RoleAdmin, query)
+			if response := recorder.Result(); response.StatusCode != http.StatusOK {
+				t.Fatal(response.Status, recorder.Body.String())
+			}
+
+			var res api.ReindexApiResponse
+			if err := json.NewDecoder(recorder.Body).Decode(&res); err != nil {
+				t.Fatal(err)
+			}
+			if res.Recreated != (query != "") || res.TotalMs < res.ReindexMs+res.AnalyzeMs {
+				t.Errorf("unexpected response for '%s': %#v", query, res)
+			}
+		}
+
+		if _, err := restapi.JobRepository.FindById(stoppedJob.ID); err != nil {
+			t.Fatalf("job not found after reindexing: %v", err)
+		}
+	})
+
+	t.Run("SearchJobs", func(t *testing.T) {
+		search := func(term string) (*httptest.ResponseRecorder, api.SearchJobsApiResponse) {
+			req := httptest.NewRequest(http.MethodGet, "/api/jobs/search?meta="+url.QueryEscape(term), nil)
+			req = req.WithContext(context.WithValue(req.Context(), repository.ContextUserKey, &schema.User{
+				Username: "testuser",
+				Roles:    []string{schema.GetRoleString(schema.RoleUser)},
+			}))
+			recorder := httptest.NewRecorder()
+			r.ServeHTTP(recorder, req)
+
+			var res api.SearchJobsApiResponse
+			if recorder.Code == http.StatusOK {
+				if err := json.NewDecoder(recorder.Body).Decode(&res); err != nil {
+					t.Fatal(err)
+				}
+			}
+			return recorder, res
+		}
+
+		recorder, res := search("BlaBla")
+		if recorder.Code != http.StatusOK {
+			t.Fatal(recorder.Code, recorder.Body.String())
+		}
+		found := false
+		for _, job := range res.Jobs {
+			if job.User != "testuser" {
+				t.Fatalf("found job of other user: %#v", job)
+			}
+			if job.JobID == 123 && job.Cluster == "testcluster" {
+				found = true
+			}
+		}
+		if !found || res.Items != len(res.Jobs) {
+			t.Fatalf("job 123 not found: %#v", res)
+		}
+
+		if recorder, res = search("no such script"); recorder.Code != http.StatusOK || len(res.Jobs) != 0 {
+			t.Fatalf("unexpected result: %d %#v", recorder.Code, res)
+		}
+		if recorder, _ = search(""); recorder.Code != http.StatusBadRequest {
+			t.Fatalf("expected status 400, got %d", recorder.Code)
+		}
+	})
+
+	t.Run("GrafanaSearch", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/grafana/search", bytes.NewBuffer([]byte(`{"target": "load"}`)))
+		recorder := httptest.NewRecorder()
+
+		r.ServeHTTP(recorder, req)
+		response := recorder.Result()
+		if response.StatusCode != http.StatusOK {
+			t.Fatal(response.Status, recorder.Body.String())
+		}
+
+		var targets []string
+		if err := json.NewDecoder(response.Body).Decode(&targets); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(targets, []string{"node:testcluster:load_one"}) {
+			t.Fatalf("unexpected targets: %#v", targets)
+		}
+	})
+
+	t.Run("GrafanaQuery", func(t *testing.T) {
+		body := fmt.Sprintf(`{
+			"panelId": 1,
+			"range": { "from": "1973-11-29T21:00:00.000Z", "to": "1973-11-29T22:00:00.000Z" },
+			"interval": "30s",
+			"targets": [ { "target": "job:%d:load_one", "refId": "A", "type": "timeserie" } ],
+			"maxDataPoints": 550
+		}`, stoppedJob.ID)
+		req := httptest.NewRequest(http.MethodPost, "/api/grafana/query", bytes.NewBuffer([]byte(body)))
+		recorder := httptest.NewRecorder()
+
+		r.ServeHTTP(recorder, req)
+		response := recorder.Result()
+		if response.StatusCode != http.StatusOK {
+			t.Fatal(response.Status, recorder.Body.String())
+		}
+
+		var series []api.GrafanaTimeSeries
+		if err := json.NewDecoder(response.Body).Decode(&series); err != nil {
+			t.Fatal(err)
+		}
+
+		jm := testData["load_one"][schema.MetricScopeNode]
+		if len(series) != len(jm.Series) || series[0].Target != "host123:load_one" ||
+			len(series[0].Datapoints) != len(jm.Series[0].Data) {
+			t.Fatalf("unexpected series: %#v", series)
+		}
+		if dp := series[0].Datapoints[1]; dp[0] != jm.Series[0].Data[1] || int64(dp[1]) != (123456789+int64(jm.Timestep))*1000 {
+			t.Fatalf("unexpected datapoint: %#v", dp)
+		}
+
+		req = httptest.NewRequest(http.MethodPost, "/api/grafana/query", bytes.NewBuffer([]byte(`{"targets": [{"target": "foo"}]}`)))
+		recorder = httptest.NewRecorder()
+		r.ServeHTTP(recorder, req)
+		if recorder.Result().StatusCode != http.StatusBadRequest {
+			t.Fatal(recorder.Result().Status, recorder.Body.String())
+		}
+	})
+
+	t.Run("GrafanaAnnotations", func(t *testing.T) {
+		body := `{
+			"range": { "from": "1973-11-29T21:00:00.000Z", "to": "1973-11-29T22:00:00.000Z" },
+			"annotation": { "name": "Jobs", "datasource": "cc", "enable": true, "query": "testcluster" }
+		}`
+		req := httptest.NewRequest(http.MethodPost, "/api/grafana/annotations", bytes.NewBuffer([]byte(body)))
+		req = req.WithContext(context.WithValue(req.Context(), repository.ContextUserKey, &schema.User{
+			Username: "grafana",
+			Roles:    []string{schema.GetRoleString(schema.RoleApi)},
+		}))
+		recorder := httptest.NewRecorder()
+
+		r.ServeHTTP(recorder, req)
+		response := recorder.Result()
+		if response.StatusCode != http.StatusOK {
+			t.Fatal(response.Status, recorder.Body.String())
+		}
+
+		var annotations []api.GrafanaAnnotationResponse
+		if err := json.NewDecoder(response.Body).Decode(&annotations); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(annotations) != 2 ||
+			annotations[0].Time != 123456789*1000 || annotations[0].Title != "Job 123 started" ||
+			annotations[1].Time != 123457789*1000 || annotations[1].Title != "Job 123 completed" {
+			t.Fatalf("unexpected annotations: %#v", annotations)
+		}
+	})
+
+	t.Run("ArchiveJobAgain", func(t *testing.T) {
+		if err := restapi.JobRepository.UpdateMonitoringStatus(stoppedJob.ID, schema.MonitoringStatusArchivingFailed); err != nil {
+			t.Fatal(err)
+		}
+
+		dryRun := true
+		job, err := restapi.Resolver.Mutation().ArchiveJob(context.Background(), strconv.Itoa(int(stoppedJob.ID)), &dryRun)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if job.MonitoringStatus != schema.MonitoringStatusArchivingFailed {
+			t.Fatalf("dry run changed monitoring status: %d", job.MonitoringStatus)
+		}
+
+		job, err = restapi.Resolver.Mutation().ArchiveJob(context.Background(), strconv.Itoa(int(stoppedJob.ID)), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if job.MonitoringStatus != schema.MonitoringStatusArchivingSuccessful {
+			t.Fatalf("unexpected monitoring status: %d", job.MonitoringStatus)
+		}
+	})
+
+	t.Run("CheckDoubleStart", func(t *testing.T) {
+		// Starting a job with the same jobId and cluster should only be allowed if the startTime is far appart!
+		body := strings.Replace(startJobBody, `"startTime": 123456789`, `"startTime": 123456790`, -1)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/jobs/start_job/", bytes.NewBuffer([]byte(body)))
+		recorder := httptest.NewRecorder()
+
+		r.ServeHTTP(recorder, req)
+		response := recorder.Result()
+		if response.StatusCode != http.StatusUnprocessableEntity {
+			t.Fatal(response.Status, recorder.Body.String())
+		}
+
+		var res api.ErrorResponse
+		if err := json.NewDecoder(response.Body).Decode(&res); err != nil {
+			t.Fatal(err)
+		}
+		if res.Error.Code != "duplicate_job" || !strings.Contains(res.Error.Message, "already exists") {
+			t.Fatalf("unexpected error response: %#v", res)
+		}
+	})
+
+	t.Run("RunningJobs", func(t *testing.T) {
+		cluster, jobId := "testcluster", int64(5001)
+		t.Cleanup(func() {
+			if job, err := restapi.JobRepository.Find(context.Background(), &jobId, &cluster, nil); err == nil {
+				restapi.JobRepository.DeleteJobById(job.ID, false)
+			}
+		})
+
+		body := strings.Replace(startJobBody, `"jobId":            123,`, `"jobId": 5001,`, -1)
+		recorder := httptest.NewRecorder()
+		r.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/api/jobs/start_job/", bytes.NewBuffer([]byte(body))))
+		if response := recorder.Result(); response.StatusCode != http.StatusCreated {
+			t.Fatal(response.Status, recorder.Body.String())
+		}
+
+		getRunning := func(user *schema.User) api.GetRunningJobsApiResponse {
+			req := httptest.NewRequest(http.MethodGet, "/api/jobs/running?cluster=testcluster&metric=load_one", nil)
+			req = req.WithContext(context.WithValue(req.Context(), repository.ContextUserKey, user))
+			recorder := httptest.NewRecorder()
+			r.ServeHTTP(recorder, req)
+			if response := recorder.Result(); response.StatusCode != http.StatusOK {
+				t.Fatal(response.Status, recorder.Body.String())
+			}
+
+			var res api.GetRunningJobsApiResponse
+			if err := json.NewDecoder(recorder.Body).Decode(&res); err != nil {
+				t.Fatal(err)
+			}
+			return res
+		}
+
+		var found *api.RunningJobApiResponse
+		for _, job := range getRunning(&schema.User{
+			Username: "admin",
+			Roles:    []string{schema.GetRoleString(schema.RoleAdmin), schema.GetRoleString(schema.RoleApi)},
+		}).Jobs {
+			if job.JobID == jobId {
+				found = job
+			} else if job.State != schema.JobStateRunning {
+				t.Fatalf("job %d is not running: %s", job.JobID, job.State)
+			}
+		}
+		if found == nil {
+			t.Fatal("running job not found")
+		}
+		want := testData["load_one"][schema.MetricScopeNode].Series[0].Statistics.Avg
+		if len(found.Footprint) != 1 || found.Footprint["load_one"] != schema.Float(want) {
+			t.Fatalf("unexpected footprint: %#v", found.Footprint)
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/api/jobs/running?cluster=testcluster", nil)
+		req = req.WithContext(context.WithValue(req.Context(), repository.ContextUserKey, &schema.User{
+			Username: "otheruser",
+			Roles:    []string{schema.GetRoleString(schema.RoleUser)},
+		}))
+		recorder = httptest.NewRecorder()
+		r.ServeHTTP(recorder, req)
+		if response := recorder.Result(); response.StatusCode != http.StatusForbidden {
+			t.Fatal(response.Status, recorder.Body.String())
+		}
+
+		recorder = httptest.NewRecorder()
+		r.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/jobs/running", nil))
+		if response := recorder.Result(); response.StatusCode != http.StatusBadRequest {
+			t.Fatal(response.Status, recorder.Body.String())
+		}
+	})
+
+	t.Run("JobStateSubscription", func(t *testing.T) {
+		cluster, jobId := "testcluster", int64(4001)
+		t.Cleanup(func() {
+			restapi.JobRepository.WaitForArchiving()
+			if job, err := restapi.JobRepository.Find(context.Background(), &jobId, &cluster, nil); err == nil {
+				restapi.JobRepository.DeleteJobById(job.ID, false)
+			}
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		jobs, err := restapi.Resolver.Subscription().JobStateChanged(ctx, &cluster)
+		if err != nil {
+			t.Fatal(err)
+		}
+		otherCtx := context.WithValue(ctx, repository.ContextUserKey, &schema.User{
+			Username: "otheruser",
+			Roles:    []string{schema.GetRoleString(schema.RoleUser)},
+		})
+		otherJobs, err := restapi.Resolver.Subscription().JobStateChanged(otherCtx, &cluster)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		next := func() *schema.Job {
+			select {
+			case job := <-jobs:
+				return job
+			case <-time.After(5 * time.Second):
+				t.Fatal("no job state change received")
+				return nil
+			}
+		}
+
+		body := strings.Replace(startJobBody, `"jobId":            123,`, `"jobId": 4001,`, -1)
+		recorder := httptest.NewRecorder()
+		r.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/api/jobs/start_job/", bytes.NewBuffer([]byte(body))))
+		if response := recorder.Result(); response.StatusCode != http.StatusCreated {
+			t.Fatal(response.Status, recorder.Body.String())
+		}
+		if job := next(); job.JobID != jobId || job.State != schema.JobStateRunning {
+			t.Fatalf("unexpected job: %#v", job)
+		}
+
+		body = strings.Replace(stopJobBody, `"jobId":     123,`, `"jobId": 4001,`, -1)
+		recorder = httptest.NewRecorder()
+		r.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/api/jobs/stop_job/", bytes.NewBuffer([]byte(body))))
+		if response := recorder.Result(); response.StatusCode != http.StatusOK {
+			t.Fatal(response.Status, recorder.Body.String())
+		}
+		if job := next(); job.JobID != jobId || job.State != schema.JobStateCompleted {
+			t.Fatalf("unexpected job: %#v", job)
+		}
+
+		// The job belongs to testuser and must not be sent to otheruser.
+		select {
+		case job := <-otherJobs:
+			t.Fatalf("unexpected job for otheruser: %#v", job)
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		cancel()
+		if _, ok := <-jobs; ok {
+			t.Fatal("expected channel to be closed after cancel")
+		}
+	})
+
+	t.Run("DuplicateStartMerge", func(t *testing.T) {
+		mode := config.Keys.DuplicateStartJobMode
+		cluster, jobId := "testcluster", int64(3001)
+		t.Cleanup(func() {
+			config.Keys.DuplicateStartJobMode = mode
+			if job, err := restapi.JobRepository.Find(context.Background(), &jobId, &cluster, nil); err == nil {
+				restapi.JobRepository.DeleteJobById(job.ID, false)
+			}
+		})
+
+		startJob := func(metaData, tags string) *httptest.ResponseRecorder {
+			body := strings.Replace(startJobBody, `"jobId":            123,`, `"jobId": 3001,`, -1)
+			body = strings.Replace(body, `{ "jobScript": "blablabla..." }`, metaData, -1)
+			body = strings.Replace(body, `[{ "type": "testTagType", "name": "testTagName" }]`, tags, -1)
+			req := httptest.NewRequest(http.MethodPost, "/api/jobs/start_job/", bytes.NewBuffer([]byte(body)))
+			recorder := httptest.NewRecorder()
+			r.ServeHTTP(recorder, req)
+			return recorder
+		}
+
+		recorder := startJob(`{ "jobName": "first", "jobScript": "old" }`, `[{ "type": "testTagType", "name": "testTagName" }]`)
+		if response := recorder.Result(); response.StatusCode != http.StatusCreated {
+			t.Fatal(response.Status, recorder.Body.String())
+		}
+		var started api.StartJobApiResponse
+		if err := json.NewDecoder(recorder.Body).Decode(&started); err != nil {
+			t.Fatal(err)
+		}
+
+		resend := func() *httptest.ResponseRecorder {
+			return startJob(`{ "jobScript": "#!/bin/bash" }`,
+				`[{ "type": "testTagType", "name": "testTagName" }, { "type": "testTagType", "name": "resent" }]`)
+		}
+
+		config.Keys.DuplicateStartJobMode = "reject"
+		recorder = resend()
+		if response := recorder.Result(); response.StatusCode != http.StatusUnprocessableEntity {
+			t.Fatal(response.Status, recorder.Body.String())
+		}
+
+		config.Keys.DuplicateStartJobMode = "merge"
+		recorder = resend()
+		if response := recorder.Result(); response.StatusCode != http.StatusOK {
+			t.Fatal(response.Status, recorder.Body.String())
+		}
+		var merged api.StartJobApiResponse
+		if err := json.NewDecoder(recorder.Body).Decode(&merged); err != nil {
+			t.Fatal(err)
+		}
+		if merged.DBID != started.DBID {
+			t.Fatalf("expected merge into job %d, got %d", started.DBID, merged.DBID)
+		}
+
+		job, err := restapi.JobRepository.FindById(started.DBID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		metaData, err := restapi.JobRepository.FetchMetadata(job)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(metaData) != 2 || metaData["jobName"] != "first" || metaData["jobScript"] != "#!/bin/bash" {
+			t.Fatalf("unexpected metadata: %#v", metaData)
+		}
+
+		tags, err := restapi.JobRepository.GetTags(nil, &job.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		names := []string{}
+		for _, tag := range tags {
+			if tag.Type == "testTagType" {
+				names = append(names, tag.Name)
+			}
+		}
+		sort.Strings(names)
+		if strings.Join(names, ",") != "resent,testTagName" {
+			t.Fatalf("unexpected tags: %v", names)
+		}
+	})
+
+	t.Run("StartJobBodyTooLarge", func(t *testing.T) {
+		limit := config.Keys.MaxRequestBodySize
+		config.Keys.MaxRequestBodySize = 4096
+		t.Cleanup(func() { config.Keys.MaxRequestBodySize = limit })
+
+		body := strings.Replace(startJobBody, `"jobId":            123,`, `"jobId":            792,`, -1)
+		body = strings.Replace(body, `"blablabla..."`, `"`+strings.Repeat("x", 8192)+`"`, -1)
+		req := httptest.NewRequest(http.MethodPost, "/api/jobs/start_job/", bytes.NewBuffer([]byte(body)))
+		recorder := httptest.NewRecorder()
+
+		r.ServeHTTP(recorder, req)
+		response := recorder.Result()
+		if response.StatusCode != http.StatusRequestEntityTooLarge {
+			t.Fatal(response.Status, recorder.Body.String())
+		}
+
+		var res api.ErrorResponse
+		if err := json.NewDecoder(response.Body).Decode(&res); err != nil {
+			t.Fatal(err)
+		}
+		if res.Error.Code != "request_entity_too_large" {
+			t.Fatalf("unexpected error response: %#v", res)
+		}
+	})
+
+	t.Run("StartJobInvalidResources", func(t *testing.T) {
+		body := strings.Replace(startJobBody, `"jobId":            123,`, `"jobId":            789,`, -1)
+		body = strings.Replace(body, `"hwthreads": [0, 1, 2, 3, 4, 5, 6, 7]`, `"hwthreads": [0, 1, 2, 3, 4, 5, 6, 8]`, -1)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/jobs/start_job/", bytes.NewBuffer([]byte(body)))
+		recorder := httptest.NewRecorder()
+
+		r.ServeHTTP(recorder, req)
+		response := recorder.Result()
+		if response.StatusCode != http.StatusUnprocessableEntity {
+			t.Fatal(response.Status, recorder.Body.String())
+		}
+		if !strings.Contains(recorder.Body.String(), "hwthread 8") {
+			t.Fatalf("unexpected error message: %s", recorder.Body.String())
+		}
+	})
+
+	t.Run("StartJobSubClusterOverride", func(t *testing.T) {
+		body := strings.Replace(startJobBody, `"jobId":            123,`, `"jobId":            790,
+		"subCluster":       "sc2",`, -1)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/jobs/start_job/", bytes.NewBuffer([]byte(body)))
+		recorder := httptest.NewRecorder()
+
+		r.ServeHTTP(recorder, req)
+		response := recorder.Result()
+		if response.StatusCode != http.StatusCreated {
+			t.Fatal(response.Status, recorder.Body.String())
+		}
+
+		jobid, cluster := int64(790), "testcluster"
+		job, err := restapi.JobRepository.Find(context.Background(), &jobid, &cluster, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if job.SubCluster != "sc2" {
+			t.Fatalf("expected explicitly given subcluster sc2, got %s", job.SubCluster)
+		}
+	})
+
+	t.Run("JobStatsRunning", func(t *testing.T) {
+		jobid, cluster := int64(790), "testcluster"
+		job, err := restapi.JobRepository.Find(context.Background(), &jobid, &cluster, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/jobs/%d/stats?metrics=load_one", job.ID), nil)
+		recorder := httptest.NewRecorder()
+
+		r.ServeHTTP(recorder, req)
+		response := recorder.Result()
+		if response.StatusCode != http.StatusOK {
+			t.Fatal(response.Status, recorder.Body.String())
+		}
+
+		var res api.GetJobStatsApiResponse
+		if err := json.NewDecoder(response.Body).Decode(&res); err != nil {
+			t.Fatal(err)
+		}
+		if stats, ok := res.Stats["load_one"]["host123"]; !ok || stats.Avg != 0.2 {
+			t.Fatalf("unexpected statistics: %#v", res)
+		}
+	})
+
+	t.Run("StartJobUnknownHost", func(t *testing.T) {
+		body := strings.Replace(startJobBody, `"jobId":            123,`, `"jobId":            791,`, -1)
+		body = strings.Replace(body, `"hostname": "host123"`, `"hostname": "host999"`, -1)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/jobs/start_job/", bytes.NewBuffer([]byte(body)))
+		recorder := httptest.NewRecorder()
+
+		r.ServeHTTP(recorder, req)
+		response := recorder.Result()
+		if response.StatusCode != http.StatusBadRequest {
+			t.Fatal(response.Status, recorder.Body.String())
+		}
+		var res api.ErrorResponse
+		if err := json.NewDecoder(response.Body).Decode(&res); err != nil {
+			t.Fatal(err)
+		}
+		if res.Error.Code != "bad_request" || !strings.Contains(res.Error.Message, "set 'subCluster' explicitly") {
+			t.Fatalf("unexpected error response: %#v", res)
+		}
+	})
+
+	t.Run("ApiKeyAuth", func(t *testing.T) {
+		rk := mux.NewRouter()
+		rk.Use(func(next http.Handler) http.Handler {
+			return restapi.ApiKeyAuth(next, func(rw http.ResponseWriter, r *http.Request, err error) {
+				http.Error(rw, err.Error(), http.StatusUnauthorized)
+			})
+		})
+		restapi.MountRoutes(rk)
+
+		body := strings.Replace(startJobBody, `"jobId":            123,`, `"jobId":            456,`, -1)
+		for _, tc := range []struct {
+			name, method, path, key string
+			status                  int
+		}{
+			{"MissingKey", http.MethodPost, "/api/jobs/start_job/", "", http.StatusUnauthorized},
+			{"WrongKey", http.MethodPost, "/api/jobs/start_job/", "wrong-api-key", http.StatusUnauthorized},
+			{"NotAllowed", http.MethodGet, "/api/jobs/", "secret-api-key", http.StatusForbidden},
+			{"ValidKey", http.MethodPost, "/api/jobs/start_job/", "secret-api-key", http.StatusCreated},
+		} {
+			req := httptest.NewRequest(tc.method, tc.path, bytes.NewBuffer([]byte(body)))
+			if tc.key != "" {
+				req.Header.Set("X-API-Key", tc.key)
+			}
+			recorder := httptest.NewRecorder()
+
+			rk.ServeHTTP(recorder, req)
+			if response := recorder.Result(); response.StatusCode != tc.status {
+				t.Errorf("%s: unexpected status %s: %s", tc.name, response.Status, recorder.Body.String())
+			}
+		}
+
+		// The key of testcluster must not be usable for jobs of other clusters.
+		other := &schema.JobMeta{BaseJob: schema.JobDefaults, StartTime: 123456789}
+		other.JobID, other.User, other.Project, other.Cluster, other.SubCluster = 457, "testuser", "testproj", "othercluster", "sc1"
+		other.NumNodes, other.State = 1, schema.JobStateRunning
+		other.Resources = []*schema.Resource{{Hostname: "host123"}}
+		id, err := restapi.JobRepository.Start(context.Background(), other)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer restapi.JobRepository.DeleteJobById(id, false)
+
+		for _, tc := range []struct {
+			name, path, body string
+		}{
+			{"StartOtherCluster", "/api/jobs/start_job/", strings.Replace(body, `"cluster":          "testcluster",`, `"cluster": "othercluster",`, -1)},
+			{"StopByIdOtherCluster", fmt.Sprintf("/api/jobs/stop_job/%d", id), `{"stopTime": 123457789, "jobState": "completed"}`},
+			{"StopOtherCluster", "/api/jobs/stop_job/", `{"jobId": 457, "cluster": "othercluster", "startTime": 123456789, "stopTime": 123457789, "jobState": "completed"}`},
+		} {
+			req := httptest.NewRequest(http.MethodPost, tc.path, bytes.NewBuffer([]byte(tc.body)))
+			req.Header.Set("X-API-Key", "secret-api-key")
+			recorder := httptest.NewRecorder()
+
+			rk.ServeHTTP(recorder, req)
+			if response := recorder.Result(); response.StatusCode != http.StatusForbidden {
+				t.Errorf("%s: unexpected status %s: %s", tc.name, response.Status, recorder.Body.String())
+			}
+		}
+		if job, err := restapi.JobRepository.FindById(id); err != nil || job.State != schema.JobStateRunning {
+			t.Errorf("job of othercluster was modified: %v", err)
+		}
+	})
+
+	t.Run("BearerAuth", func(t *testing.T) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		jwks := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(rw).Encode(map[string]interface{}{
+				"keys": []map[string]string{{
+					"kty": "EC",
+					"kid": "test-key",
+					"crv": "P-256",
+					"x":   base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, 32))),
+					"y":   base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, 32))),
+				}},
+			})
+		}))
+		defer jwks.Close()
+
+		bearerAuth := config.Keys.BearerAuth
+		defer func() { config.Keys.BearerAuth = bearerAuth }()
+
+		sign := func(claims jwt.MapClaims) string {
+			token := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
+			token.Header["kid"] = "test-key"
+			signed, err := token.SignedString(key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return signed
+		}
+
+		now := time.Now()
+		valid := sign(jwt.MapClaims{
+			"sub": "bearer-user", "roles": []string{"api"}, "aud": "cc-backend", "iss": "idp", "exp": now.Add(time.Hour).Unix(),
+		})
+		expired := sign(jwt.MapClaims{
+			"sub": "bearer-user", "roles": []string{"api"}, "aud": "cc-backend", "iss": "idp", "exp": now.Add(-time.Hour).Unix(),
+		})
+		wrongAudience := sign(jwt.MapClaims{
+			"sub": "bearer-user", "roles": []string{"api"}, "aud": "other-service", "iss": "idp", "exp": now.Add(time.Hour).Unix(),
+		})
+		otherIssuer := sign(jwt.MapClaims{
+			"sub": "bearer-user", "roles": []string{"api"}, "aud": "cc-backend", "iss": "cc-backend", "exp": now.Add(time.Hour).Unix(),
+		})
+
+		for _, bc := range []*schema.BearerAuthConfig{
+			{PublicKey: string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})), Audience: "cc-backend", Issuer: "idp"},
+			{JwksUrl: jwks.URL, Audience: "cc-backend", Issuer: "idp"},
+		} {
+			config.Keys.BearerAuth = bc
+
+			// Requests not handled by BearerAuth get 407 to tell them apart
+			// from rejected tokens.
+			rb := mux.NewRouter()
+			rb.Use(func(next http.Handler) http.Handler {
+				return restapi.BearerAuth(next, func(rw http.ResponseWriter, r *http.Request, err error) {
+					http.Error(rw, err.Error(), http.StatusProxyAuthRequired)
+				})
+			})
+			var authUser *schema.User
+			rb.Use(func(next http.Handler) http.Handler {
+				return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+					authUser = repository.GetUserFromContext(r.Context())
+					next.ServeHTTP(rw, r)
+				})
+			})
+			restapi.MountRoutes(rb)
+
+			for _, tc := range []struct {
+				name, authorization string
+				status              int
+			}{
+				{"Valid", "Bearer " + valid, http.StatusOK},
+				{"Expired", "Bearer " + expired, http.StatusUnauthorized},
+				{"WrongAudience", "Bearer " + wrongAudience, http.StatusUnauthorized},
+				{"MissingToken", "", http.StatusProxyAuthRequired},
+				{"OtherIssuer", "Bearer " + otherIssuer, http.StatusProxyAuthRequired},
+				{"NoBearerPrefix", valid, http.StatusProxyAuthRequired},
+				{"BasicAuth", "Basic dXNlcjpwYXNzd29yZA==", http.StatusProxyAuthRequired},
+			} {
+				authUser = nil
+				req := httptest.NewRequest(http.MethodGet, "/api/jobs/", nil)
+				if tc.authorization != "" {
+					req.Header.Set("Authorization", tc.authorization)
+				}
+				recorder := httptest.NewRecorder()
+
+				rb.ServeHTTP(recorder, req)
+				if response := recorder.Result(); response.StatusCode != tc.status {
+					t.Errorf("%s: unexpected status %s: %s", tc.name, response.Status, recorder.Body.String())
+				}
+				if tc.status == http.StatusOK && (authUser == nil || authUser.Username != "bearer-user" ||
+					!reflect.DeepEqual(authUser.Roles, []string{"api"})) {
+					t.Errorf("%s: unexpected user in context: %#v", tc.name, authUser)
+				}
+			}
+		}
+	})
+
+	const startJobBodyFailed string = `{
+        "jobId":            12345,
+		"user":             "testuser",
+		"project":          "testproj",
+		"cluster":          "testcluster",
+		"partition":        "default",
+		"walltime":         3600,
+		"numNodes":         1,
+		"exclusive":        1,
+		"monitoringStatus": 1,
+		"smt":              1,
+		"resources": [
+			{
+				"hostname": "host123"
+			}
+		],
+		"startTime": 12345678
+	}`
+
+	ok := t.Run("StartJobFailed", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/jobs/start_job/", bytes.NewBuffer([]byte(startJobBodyFailed)))
+		recorder := httptest.NewRecorder()
+
+		r.ServeHTTP(recorder, req)
+		response := recorder.Result()
+		if response.StatusCode != http.StatusCreated {
+			t.Fatal(response.Status, recorder.Body.String())
+		}
+	})
+	if !ok {
+		t.Fatal("subtest failed")
+	}
+
+	const stopJobBodyFailed string = `{
+        "jobId":     12345,
+		"cluster":   "testcluster",
+
+		"jobState": "failed",
+		"stopTime": 12355678
+	}`
+
+	ok = t.Run("StopJobFailed", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/jobs/stop_job/", bytes.NewBuffer([]byte(stopJobBodyFailed)))
+		recorder := httptest.NewRecorder()
+
+		r.ServeHTTP(recorder, req)
+		response := recorder.Result()
+		if response.StatusCode != http.StatusOK {
+			t.Fatal(response.Status, recorder.Body.String())
+		}
+
+		restapi.JobRepository.WaitForArchiving()
+		jobid, cluster := int64(12345), "testcluster"
+		job, err := restapi.JobRepository.Find(context.Background(), &jobid, &cluster, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if job.State != schema.JobStateFailed {
+			t.Fatal("expected job to be failed")
+		}
+	})
+	if !ok {
+		t.Fatal("subtest failed")
+	}
+
+	t.Run("StopJobClockSkew", func(t *testing.T) {
+		body := strings.Replace(startJobBodyFailed, "12345,", "12346,", 1)
+		req := httptest.NewRequest(http.MethodPost, "/api/jobs/start_job/", bytes.NewBufferString(body))
+		recorder := httptest.NewRecorder()
+		r.ServeHTTP(recorder, req)
+		if response := recorder.Result(); response.StatusCode != http.StatusCreated {
+			t.Fatal(response.Status, recorder.Body.String())
+		}
+
+		// The stop time is before the start time of the job.
+		req = httptest.NewRequest(http.MethodPost, "/api/jobs/stop_job/", bytes.NewBufferString(`{
+			"jobId": 12346, "cluster": "testcluster", "startTime": 12345678, "jobState": "completed", "stopTime": 12345000
+		}`))
+		recorder = httptest.NewRecorder()
+		r.ServeHTTP(recorder, req)
+		if response := recorder.Result(); response.StatusCode != http.StatusOK {
+			t.Fatal(response.Status, recorder.Body.String())
+		}
+
+		restapi.JobRepository.WaitForArchiving()
+		jobid, cluster := int64(12346), "testcluster"
+		job, err := restapi.JobRepository.Find(context.Background(), &jobid, &cluster, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if job.State != schema.JobStateCompleted || job.Duration != 0 ||
+			job.MonitoringStatus != schema.MonitoringStatusArchivingFailed {
+			t.Fatalf("unexpected job properties: %#v", job)
+		}
+		if archive.GetHandle().Exists(job) {
+			t.Fatal("job without duration archived")
+		}
+	})
+
+	t.Run("StopJobUnknownState", func(t *testing.T) {
+		body := strings.Replace(startJobBodyFailed, "12345,", "12347,", 1)
+		req := httptest.NewRequest(http.MethodPost, "/api/jobs/start_job/", bytes.NewBufferString(body))
+		recorder := httptest.NewRecorder()
+		r.ServeHTTP(recorder, req)
+		if response := recorder.Result(); response.StatusCode != http.StatusCreated {
+			t.Fatal(response.Status, recorder.Body.String())
+		}
+
+		req = httptest.NewRequest(http.MethodPost, "/api/jobs/stop_job/", bytes.NewBufferString(`{
+			"jobId": 12347, "cluster": "testcluster", "startTime": 12345678, "jobState": "requeue_fed", "stopTime": 12355678
+		}`))
+		recorder = httptest.NewRecorder()
+		r.ServeHTTP(recorder, req)
+		if response := recorder.Result(); response.StatusCode != http.StatusOK {
+			t.Fatal(response.Status, recorder.Body.String())
+		}
+
+		restapi.JobRepository.WaitForArchiving()
+		jobid, cluster := int64(12347), "testcluster"
+		job, err := restapi.JobRepository.Find(context.Background(), &jobid, &cluster, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if job.State != schema.JobStateUnknown {
+			t.Fatalf("expected job state 'unknown', got %#v", job.State)
+		}
+	})
+
+	const resumeJobBody string = `{
+		"jobId":     12345,
+		"cluster":   "testcluster",
+		"startTime": 12345678
+	}`
+
+	t.Run("ResumeJob", func(t *testing.T) {
+		jobid, cluster := int64(12345), "testcluster"
+		job, err := restapi.JobRepository.Find(context.Background(), &jobid, &cluster, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !archive.GetHandle().Exists(job) {
+			t.Fatal("expected job to be archived")
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/api/jobs/resume_job/", bytes.NewBuffer([]byte(resumeJobBody)))
+		recorder := httptest.NewRecorder()
+
+		r.ServeHTTP(recorder, req)
+		response := recorder.Result()
+		if response.StatusCode != http.StatusOK {
+			t.Fatal(response.Status, recorder.Body.String())
+		}
+
+		job, err = restapi.JobRepository.Find(context.Background(), &jobid, &cluster, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if job.State != schema.JobStateRunning || job.MonitoringStatus != schema.MonitoringStatusRunningOrArchiving {
+			t.Fatalf("unexpected job properties: %#v", job)
+		}
+
+		if archive.GetHandle().Exists(job) {
+			t.Fatal("expected job archive to be removed")
+		}
+	})
+
+	t.Run("ResumeRunningJob", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/jobs/resume_job/", bytes.NewBuffer([]byte(resumeJobBody)))
+		recorder := httptest.NewRecorder()
+
+		r.ServeHTTP(recorder, req)
+		response := recorder.Result()
+		if response.StatusCode != http.StatusBadRequest {
+			t.Fatal(response.Status, recorder.Body.String())
+		}
+	})
+
+	const stopUnknownJobBody string = `{
+		"jobId":     99999,
+		"cluster":   "testcluster",
+		"startTime": 12345678,
+		"jobState":  "completed",
+		"stopTime":  12349678
+	}`
+
+	t.Run("StopUnknownJobStrict", func(t *testing.T) {
+		config.Keys.StopJobMode = "strict"
+
+		req := httptest.NewRequest(http.MethodPost, "/api/jobs/stop_job/", bytes.NewBuffer([]byte(stopUnknownJobBody)))
+		recorder := httptest.NewRecorder()
+
+		r.ServeHTTP(recorder, req)
+		response := recorder.Result()
+		if response.StatusCode != http.StatusNotFound {
+			t.Fatal(response.Status, recorder.Body.String())
+		}
+
+		jobid, cluster := int64(99999), "testcluster"
+		if _, err := restapi.JobRepository.Find(context.Background(), &jobid, &cluster, nil); err == nil {
+			t.Fatal("expected no job to be created")
+		}
+	})
+
+	t.Run("StopUnknownJobLenient", func(t *testing.T) {
+		config.Keys.StopJobMode = "lenient"
+		defer func() { config.Keys.StopJobMode = "strict" }()
+
+		req := httptest.NewRequest(http.MethodPost, "/api/jobs/stop_job/", bytes.NewBuffer([]byte(stopUnknownJobBody)))
+		recorder := httptest.NewRecorder()
+
+		r.ServeHTTP(recorder, req)
+		response := recorder.Result()
+		if response.StatusCode != http.StatusOK {
+			t.Fatal(response.Status, recorder.Body.String())
+		}
+
+		jobid, cluster := int64(99999), "testcluster"
+		job, err := restapi.JobRepository.Find(context.Background(), &jobid, &cluster, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if job.State != schema.JobStateCompleted || job.Duration != 4000 ||
+			job.MonitoringStatus != schema.MonitoringStatusDisabled {
+			t.Fatalf("unexpected job properties: %#v", job)
+		}
+	})
+
+	t.Run("DeleteJobsBeforeDryRun", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/jobs/delete_job_before/%d", stoppedJob.StartTime.Unix()+1), nil)
+		req.Header.Set("X-Dry-Run", "true")
+		recorder := httptest.NewRecorder()
+
+		r.ServeHTTP(recorder, req)
+		response := recorder.Result()
+		if response.StatusCode != http.StatusOK {
+			t.Fatal(response.Status, recorder.Body.String())
+		}
+
+		var res api.DeleteJobApiResponse
+		if err := json.NewDecoder(response.Body).Decode(&res); err != nil {
+			t.Fatal(err)
+		}
+		found := false
+		for _, id := range res.JobIds {
+			found = found || id == stoppedJob.ID
+		}
+		if !res.DryRun || !found {
+			t.Fatalf("unexpected response: %#v", res)
+		}
+		if _, err := restapi.JobRepository.FindById(stoppedJob.ID); err != nil {
+			t.Fatal("dry run deleted job:", err)
+		}
+	})
+
+	t.Run("DeleteJobDryRun", func(t *testing.T) {
+		jobid, cluster := int64(99999), "testcluster"
+		job, err := restapi.JobRepository.Find(context.Background(), &jobid, &cluster, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/jobs/delete_job/%d?dryRun=true", job.ID), nil)
+		recorder := httptest.NewRecorder()
+		r.ServeHTTP(recorder, req)
+		if response := recorder.Result(); response.StatusCode != http.StatusOK {
+			t.Fatal(response.Status, recorder.Body.String())
+		}
+		if _, err := restapi.JobRepository.FindById(job.ID); err != nil {
+			t.Fatal("dry run deleted job:", err)
+		}
+
+		req = httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/jobs/delete_job/%d", job.ID), nil)
+		recorder = httptest.NewRecorder()
+		r.ServeHTTP(recorder, req)
+		if response := recorder.Result(); response.StatusCode != http.StatusOK {
+			t.Fatal(response.Status, recorder.Body.String())
+		}
+		if _, err := restapi.JobRepository.FindById(job.ID); err == nil {
+			t.Fatal("expected job to be deleted")
+		}
+	})
+
+	t.Run("StartJobRunningJobsLimit", func(t *testing.T) {
+		limit, exempt := config.Keys.MaxRunningJobsPerUser, config.Keys.RunningJobsLimitExempt
+		config.Keys.MaxRunningJobsPerUser, config.Keys.RunningJobsLimitExempt = 2, []string{"machineuser"}
+		t.Cleanup(func() {
+			config.Keys.MaxRunningJobsPerUser, config.Keys.RunningJobsLimitExempt = limit, exempt
+			cluster := "testcluster"
+			for jobId := int64(1001); jobId <= 1006; jobId++ {
+				if job, err := restapi.JobRepository.Find(context.Background(), &jobId, &cluster, nil); err == nil {
+					restapi.JobRepository.DeleteJobById(job.ID, false)
+				}
+			}
+		})
+
+		startJob := func(jobId int, user string, submitter *schema.User) int {
+			body := strings.Replace(startJobBody, `"jobId":            123,`, fmt.Sprintf(`"jobId": %d,`, jobId), -1)
+			body = strings.Replace(body, `"user":             "testuser",`, fmt.Sprintf(`"user": "%s",`, user), -1)
+			req := httptest.NewRequest(http.MethodPost, "/api/jobs/start_job/", bytes.NewBuffer([]byte(body)))
+			if submitter != nil {
+				req = req.WithContext(context.WithValue(req.Context(), repository.ContextUserKey, submitter))
+			}
+			recorder := httptest.NewRecorder()
+			r.ServeHTTP(recorder, req)
+			return recorder.Result().StatusCode
+		}
+
+		apiUser := func(username string) *schema.User {
+			return &schema.User{Username: username, Roles: []string{schema.GetRoleString(schema.RoleApi)}}
+		}
+
+		// Below the limit
+		if status := startJob(1001, "quotauser", apiUser("scheduler")); status != http.StatusCreated {
+			t.Fatalf("first job: unexpected status %d", status)
+		}
+		// Reaching the limit
+		if status := startJob(1002, "quotauser", apiUser("scheduler")); status != http.StatusCreated {
+			t.Fatalf("second job: unexpected status %d", status)
+		}
+		// Above the limit
+		if status := startJob(1003, "quotauser", apiUser("scheduler")); status != http.StatusTooManyRequests {
+			t.Fatalf("third job: expected status 429, got %d", status)
+		}
+
+		// The exempt submitter is not limited.
+		for i := 0; i < 3; i++ {
+			if status := startJob(1004+i, "quotauser", apiUser("machineuser")); status != http.StatusCreated {
+				t.Fatalf("exempt submitter: unexpected status %d", status)
+			}
+		}
+	})
+
+	t.Run("ArchivingWorkers", func(t *testing.T) {
+		const jobs = 8
+		cluster := "testcluster"
+		t.Cleanup(func() {
+			metricdata.TestLoadDataCallback = func(job *schema.Job, metrics []string, scopes []schema.MetricScope, ctx context.Context) (schema.JobData, error) {
+				return testData, nil
+			}
+			for jobId := int64(2001); jobId <= 2000+jobs; jobId++ {
+				if job, err := restapi.JobRepository.Find(context.Background(), &jobId, &cluster, nil); err == nil {
+					restapi.JobRepository.DeleteJobById(job.ID, false)
+				}
+			}
+		})
+
+		var lock sync.Mutex
+		running, maxRunning := 0, 0
+		metricdata.TestLoadDataCallback = func(job *schema.Job, metrics []string, scopes []schema.MetricScope, ctx context.Context) (schema.JobData, error) {
+			lock.Lock()
+			running++
+			if running > maxRunning {
+				maxRunning = running
+			}
+			lock.Unlock()
+
+			time.Sleep(20 * time.Millisecond)
+
+			lock.Lock()
+			running--
+			lock.Unlock()
+			return testData, nil
+		}
+
+		for jobId := 2001; jobId <= 2000+jobs; jobId++ {
+			body := strings.Replace(startJobBody, `"jobId":            123,`, fmt.Sprintf(`"jobId": %d,`, jobId), -1)
+			req := httptest.NewRequest(http.MethodPost, "/api/jobs/start_job/", bytes.NewBuffer([]byte(body)))
+			recorder := httptest.NewRecorder()
+			r.ServeHTTP(recorder, req)
+			if response := recorder.Result(); response.StatusCode != http.StatusCreated {
+				t.Fatal(response.Status, recorder.Body.String())
+			}
+		}
+		for jobId := 2001; jobId <= 2000+jobs; jobId++ {
+			body := strings.Replace(stopJobBody, `"jobId":     123,`, fmt.Sprintf(`"jobId": %d,`, jobId), -1)
+			req := httptest.NewRequest(http.MethodPost, "/api/jobs/stop_job/", bytes.NewBuffer([]byte(body)))
+			recorder := httptest.NewRecorder()
+			r.ServeHTTP(recorder, req)
+			if response := recorder.Result(); response.StatusCode != http.StatusOK {
+				t.Fatal(response.Status, recorder.Body.String())
+			}
+		}
+
+		restapi.JobRepository.WaitForArchiving()
+		if maxRunning < 1 || maxRunning > config.Keys.ArchiveWorkers {
+			t.Fatalf("expected at most %d concurrent archivings, got %d", config.Keys.ArchiveWorkers, maxRunning)
+		}
+		for jobId := int64(2001); jobId <= 2000+jobs; jobId++ {
+			job, err := restapi.JobRepository.Find(context.Background(), &jobId, &cluster, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if job.MonitoringStatus != schema.MonitoringStatusArchivingSuccessful {
+				t.Fatalf("job %d not archived: %d", jobId, job.MonitoringStatus)
+			}
+		}
+	})
+
+	t.Run("ListAndCancelArchiving", func(t *testing.T) {
+		cluster, jobId := "testcluster", int64(2101)
+		t.Cleanup(func() {
+			metricdata.TestLoadDataCallback = func(job *schema.Job, metrics []string, scopes []schema.MetricScope, ctx context.Context) (schema.JobData, error) {
+				return testData, nil
+			}
+			if job, err := restapi.JobRepository.Find(context.Background(), &jobId, &cluster, nil); err == nil {
+				restapi.JobRepository.DeleteJobById(job.ID, false)
+			}
+		})
+
+		// Loading the metric data hangs until archiving is cancelled.
+		loading := make(chan struct{})
+		metricdata.TestLoadDataCallback = func(job *schema.Job, metrics []string, scopes []schema.MetricScope, ctx context.Context) (schema.JobData, error) {
+			close(loading)
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+
+		body := strings.Replace(startJobBody, `"jobId":            123,`, fmt.Sprintf(`"jobId": %d,`, jobId), -1)
+		req := httptest.NewRequest(http.MethodPost, "/api/jobs/start_job/", bytes.NewBuffer([]byte(body)))
+		recorder := httptest.NewRecorder()
+		r.ServeHTTP(recorder, req)
+		if recorder.Code != http.StatusCreated {
+			t.Fatal(recorder.Code, recorder.Body.String())
+		}
+		var started api.StartJobApiResponse
+		if err := json.Unmarshal(recorder.Body.Bytes(), &started); err != nil {
+			t.Fatal(err)
+		}
+
+		// LoadData caches by database ID and job state and the IDs of deleted
+		// jobs are reused, so a state not used by the other tests avoids
+		// cached data of earlier jobs.
+		body = strings.Replace(stopJobBody, `"jobId":     123,`, fmt.Sprintf(`"jobId": %d,`, jobId), -1)
+		body = strings.Replace(body, `"jobState": "completed",`, `"jobState": "timeout",`, -1)
+		req = httptest.NewRequest(http.MethodPost, "/api/jobs/stop_job/", bytes.NewBuffer([]byte(body)))
+		recorder = httptest.NewRecorder()
+		r.ServeHTTP(recorder, req)
+		if recorder.Code != http.StatusOK {
+			t.Fatal(recorder.Code, recorder.Body.String())
+		}
+
+		select {
+		case <-loading:
+		case <-time.After(5 * time.Second):
+			t.Fatal("archiving did not start")
+		}
+
+		req = httptest.NewRequest(http.MethodGet, "/api/admin/archiving", nil)
+		recorder = httptest.NewRecorder()
+		r.ServeHTTP(recorder, req)
+		if recorder.Code != http.StatusOK {
+			t.Fatal(recorder.Code, recorder.Body.String())
+		}
+		var archivings []api.ArchivingApiResponse
+		if err := json.Unmarshal(recorder.Body.Bytes(), &archivings); err != nil {
+			t.Fatal(err)
+		}
+		if len(archivings) != 1 || archivings[0].ID != started.DBID || archivings[0].JobID != jobId ||
+			archivings[0].Phase != repository.ArchivingLoading || archivings[0].StartedAt == 0 || archivings[0].Cancelled {
+			t.Fatalf("unexpected archivings: %#v", archivings)
+		}
+
+		// Waiting for another job returns immediately, waiting for this job
+		// only once its archiving is cancelled.
+		restapi.JobRepository.WaitForArchivingOf(started.DBID + 1000)
+		waited := make(chan struct{})
+		go func() {
+			restapi.JobRepository.WaitForArchivingOf(started.DBID)
+			close(waited)
+		}()
+		select {
+		case <-waited:
+			t.Fatal("waiting returned while the job is being archived")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		cancel := func() *httptest.ResponseRecorder {
+			req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/admin/archiving/%d", started.DBID), nil)
+			recorder := httptest.NewRecorder()
+			r.ServeHTTP(recorder, req)
+			return recorder
+		}
+		if recorder := cancel(); recorder.Code != http.StatusOK {
+			t.Fatal(recorder.Code, recorder.Body.String())
+		}
+
+		select {
+		case <-waited:
+		case <-time.After(5 * time.Second):
+			t.Fatal("archiving was not cancelled")
+		}
+		if archivings := restapi.JobRepository.Archivings(); len(archivings) != 0 {
+			t.Errorf("expected no archivings, got %#v", archivings)
+		}
+		job, err := restapi.JobRepository.FindById(started.DBID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if job.MonitoringStatus != schema.MonitoringStatusArchivingFailed {
+			t.Errorf("expected archiving to fail, got monitoring status %d", job.MonitoringStatus)
+		}
+		if recorder := cancel(); recorder.Code != http.StatusNotFound {
+			t.Errorf("expected status 404 after archiving, got %d", recorder.Code)
+		}
+	})
+
+	t.Run("ArchiveJobConcurrently", func(t *testing.T) {
+		cluster, jobId := "testcluster", int64(2201)
+		t.Cleanup(func() {
+			metricdata.TestLoadDataCallback = func(job *schema.Job, metrics []string, scopes []schema.MetricScope, ctx context.Context) (schema.JobData, error) {
+				return testData, nil
+			}
+			if job, err := restapi.JobRepository.Find(context.Background(), &jobId, &cluster, nil); err == nil {
+				restapi.JobRepository.DeleteJobById(job.ID, false)
+			}
+		})
+
+		// The archiving of the stop fails, so no metric data is cached.
+		metricdata.TestLoadDataCallback = func(job *schema.Job, metrics []string, scopes []schema.MetricScope, ctx context.Context) (schema.JobData, error) {
+			return nil, errors.New("metric store not available")
+		}
+		body := strings.Replace(startJobBody, `"jobId":            123,`, fmt.Sprintf(`"jobId": %d,`, jobId), -1)
+		req := httptest.NewRequest(http.MethodPost, "/api/jobs/start_job/", bytes.NewBuffer([]byte(body)))
+		recorder := httptest.NewRecorder()
+		r.ServeHTTP(recorder, req)
+		if recorder.Code != http.StatusCreated {
+			t.Fatal(recorder.Code, recorder.Body.String())
+		}
+		var started api.StartJobApiResponse
+		if err := json.Unmarshal(recorder.Body.Bytes(), &started); err != nil {
+			t.Fatal(err)
+		}
+		body = strings.Replace(stopJobBody, `"jobId":     123,`, fmt.Sprintf(`"jobId": %d,`, jobId), -1)
+		body = strings.Replace(body, `"jobState": "completed",`, `"jobState": "out_of_memory",`, -1)
+		req = httptest.NewRequest(http.MethodPost, "/api/jobs/stop_job/", bytes.NewBuffer([]byte(body)))
+		recorder = httptest.NewRecorder()
+		r.ServeHTTP(recorder, req)
+		if recorder.Code != http.StatusOK {
+			t.Fatal(recorder.Code, recorder.Body.String())
+		}
+		restapi.JobRepository.WaitForArchivingOf(started.DBID)
+
+		// Loading the metric data hangs until both mutations are sent.
+		loading, release := make(chan struct{}), make(chan struct{})
+		var loadingOnce sync.Once
+		metricdata.TestLoadDataCallback = func(job *schema.Job, metrics []string, scopes []schema.MetricScope, ctx context.Context) (schema.JobData, error) {
+			loadingOnce.Do(func() { close(loading) })
+			<-release
+			return testData, nil
+		}
+
+		// Every archiving loads the metric data once, cached or not.
+		hits, misses := `cc_backend_metricdata_cache_requests_total{result="hit"}`, `cc_backend_metricdata_cache_requests_total{result="miss"}`
+		before := scrapeMetrics(t)
+
+		var wg sync.WaitGroup
+		jobs, errs := make([]*schema.Job, 2), make([]error, 2)
+		for i := range jobs {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				jobs[i], errs[i] = restapi.Resolver.Mutation().ArchiveJob(context.Background(), strconv.Itoa(int(started.DBID)), nil)
+			}(i)
+		}
+		select {
+		case <-loading:
+		case <-time.After(5 * time.Second):
+			t.Fatal("archiving did not start")
+		}
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		for i := range jobs {
+			if errs[i] != nil {
+				t.Fatal(errs[i])
+			}
+			if jobs[i].MonitoringStatus != schema.MonitoringStatusArchivingSuccessful {
+				t.Errorf("mutation %d: unexpected monitoring status: %d", i, jobs[i].MonitoringStatus)
+			}
+		}
+		restapi.JobRepository.WaitForArchiving()
+		after := scrapeMetrics(t)
+		if loads := after[hits] + after[misses] - before[hits] - before[misses]; loads != 1 {
+			t.Errorf("expected the job to be archived once, got %v archivings", loads)
+		}
+	})
+
+	t.Run("Roofline", func(t *testing.T) {
+		// Loaded metric data is cached per job, so every case uses a new job.
+		var dbids []int64
+		startJob := func(jobId int) string {
+			body := strings.Replace(startJobBody, `"jobId":            123,`, fmt.Sprintf(`"jobId": %d,`, jobId), -1)
+			req := httptest.NewRequest(http.MethodPost, "/api/jobs/start_job/", bytes.NewBuffer([]byte(body)))
+			recorder := httptest.NewRecorder()
+			r.ServeHTTP(recorder, req)
+			if response := recorder.Result(); response.StatusCode != http.StatusCreated {
+				t.Fatal(response.Status, recorder.Body.String())
+			}
+			var res api.StartJobApiResponse
+			if err := json.Unmarshal(recorder.Body.Bytes(), &res); err != nil {
+				t.Fatal(err)
+			}
+			dbids = append(dbids, res.DBID)
+			return strconv.Itoa(int(res.DBID))
+		}
+
+		t.Cleanup(func() {
+			metricdata.TestLoadDataCallback = func(job *schema.Job, metrics []string, scopes []schema.MetricScope, ctx context.Context) (schema.JobData, error) {
+				return testData, nil
+			}
+			for _, dbid := range dbids {
+				restapi.JobRepository.DeleteJobById(dbid, false)
+			}
+		})
+
+		metricdata.TestLoadDataCallback = func(job *schema.Job, metrics []string, scopes []schema.MetricScope, ctx context.Context) (schema.JobData, error) {
+			return schema.JobData{
+				"flops_any": {schema.MetricScopeNode: &schema.JobMetric{
+					Unit:     schema.Unit{Base: "F/s", Prefix: "G"},
+					Timestep: 60,
+					Series:   []schema.Series{{Hostname: "host123", Data: []schema.Float{10, 20, schema.NaN, 40}}},
+				}},
+				"mem_bw": {schema.MetricScopeNode: &schema.JobMetric{
+					Unit:     schema.Unit{Base: "B/s", Prefix: "G"},
+					Timestep: 60,
+					Series:   []schema.Series{{Hostname: "host123", Data: []schema.Float{5, 0, 10, 8}}},
+				}},
+			}, nil
+		}
+
+		roofline, err := restapi.Resolver.Query().Roofline(context.Background(), startJob(3001))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if roofline.FlopRateSimd.Value != 112 || roofline.MemoryBandwidth.Value != 24 {
+			t.Errorf("wrong peak values: %#v, %#v", roofline.FlopRateSimd, roofline.MemoryBandwidth)
+		}
+		// The timesteps without memory traffic and with a missing value are skipped.
+		expected := []model.RooflinePoint{
+			{Hostname: "host123", Time: 0, Intensity: 2, Performance: 10},
+			{Hostname: "host123", Time: 180, Intensity: 5, Performance: 40},
+		}
+		if len(roofline.Points) != len(expected) {
+			t.Fatalf("expected %d points, got %d", len(expected), len(roofline.Points))
+		}
+		for i, p := range roofline.Points {
+			if *p != expected[i] {
+				t.Errorf("point %d: expected %#v, got %#v", i, expected[i], *p)
+			}
+		}
+
+		// Without mem_bw only the peak values are returned.
+		metricdata.TestLoadDataCallback = func(job *schema.Job, metrics []string, scopes []schema.MetricScope, ctx context.Context) (schema.JobData, error) {
+			return testData, nil
+		}
+		roofline, err = restapi.Resolver.Query().Roofline(context.Background(), startJob(3002))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(roofline.Points) != 0 || roofline.FlopRateScalar == nil {
+			t.Errorf("unexpected roofline data: %#v", roofline)
+		}
+	})
+
+	t.Run("GetJobScriptAndEnv", func(t *testing.T) {
+		var dbids []int64
+		startJob := func(jobId int, metaData string) string {
+			body := strings.Replace(startJobBody, `"jobId":            123,`, fmt.Sprintf(`"jobId": %d,`, jobId), -1)
+			body = strings.Replace(body, `{ "jobScript": "blablabla..." }`, metaData, -1)
+			req := httptest.NewRequest(http.MethodPost, "/api/jobs/start_job/", bytes.NewBuffer([]byte(body)))
+			recorder := httptest.NewRecorder()
+			r.ServeHTTP(recorder, req)
+			if response := recorder.Result(); response.StatusCode != http.StatusCreated {
+				t.Fatal(response.Status, recorder.Body.String())
+			}
+			var res api.StartJobApiResponse
+			if err := json.Unmarshal(recorder.Body.Bytes(), &res); err != nil {
+				t.Fatal(err)
+			}
+			dbids = append(dbids, res.DBID)
+			return strconv.Itoa(int(res.DBID))
+		}
+		t.Cleanup(func() {
+			for _, dbid := range dbids {
+				restapi.JobRepository.DeleteJobById(dbid, false)
+			}
+		})
+
+		get := func(path string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(http.MethodGet, path, nil)
+			recorder := httptest.NewRecorder()
+			r.ServeHTTP(recorder, req)
+			return recorder
+		}
+
+		id := startJob(3101, `{ "jobScript": "#!/bin/bash\nsrun ./a.out", "jobEnv": "HOME=/home/testuser\nOMP_NUM_THREADS=4\nEMPTY=" }`)
+		recorder := get("/api/jobs/" + id + "/script")
+		if recorder.Code != http.StatusOK || recorder.Header().Get("Content-Type") != "text/plain; charset=utf-8" ||
+			recorder.Body.String() != "#!/bin/bash\nsrun ./a.out" {
+			t.Fatalf("unexpected response: %d %s %q", recorder.Code, recorder.Header().Get("Content-Type"), recorder.Body.String())
+		}
+
+		recorder = get("/api/jobs/" + id + "/env")
+		if recorder.Code != http.StatusOK {
+			t.Fatal(recorder.Code, recorder.Body.String())
+		}
+		var env api.GetJobEnvApiResponse
+		if err := json.Unmarshal(recorder.Body.Bytes(), &env); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(env.Env, map[string]string{"HOME": "/home/testuser", "OMP_NUM_THREADS": "4", "EMPTY": ""}) {
+			t.Errorf("unexpected environment: %#v", env.Env)
+		}
+
+		id = startJob(3102, `{ "jobName": "noscript" }`)
+		for _, path := range []string{"/api/jobs/" + id + "/script", "/api/jobs/" + id + "/env"} {
+			if recorder := get(path); recorder.Code != http.StatusNotFound {
+				t.Errorf("%s: expected status 404, got %d", path, recorder.Code)
+			}
+		}
+	})
+
+	t.Run("StartJobPartition", func(t *testing.T) {
+		cc := config.Keys.Clusters[0]
+		t.Cleanup(func() { cc.Partitions, cc.DefaultPartition = nil, "" })
+		cc.Partitions = []*schema.PartitionConfig{
+			{Name: "batch", Nodes: "host[123-124]"},
+			{Name: "large", Nodes: "host200"},
+		}
+
+		var dbids []int64
+		t.Cleanup(func() {
+			for _, dbid := range dbids {
+				restapi.JobRepository.DeleteJobById(dbid, false)
+			}
+		})
+		startJob := func(jobId int, partition string, hostname string) *httptest.ResponseRecorder {
+			body := strings.Replace(startJobBody, `"jobId":            123,`, fmt.Sprintf(`"jobId": %d,`, jobId), -1)
+			body = strings.Replace(body, `"partition":        "default",`, partition, -1)
+			body = strings.Replace(body, `"hostname": "host123"`, fmt.Sprintf(`"hostname": "%s"`, hostname), -1)
+			req := httptest.NewRequest(http.MethodPost, "/api/jobs/start_job/", bytes.NewBuffer([]byte(body)))
+			recorder := httptest.NewRecorder()
+			r.ServeHTTP(recorder, req)
+
+			var res api.StartJobApiResponse
+			if recorder.Code == http.StatusCreated && json.Unmarshal(recorder.Body.Bytes(), &res) == nil {
+				dbids = append(dbids, res.DBID)
+			}
+			return recorder
+		}
+		checkPartition := func(jobId int64, partition string) {
+			cluster := "testcluster"
+			job, err := restapi.JobRepository.Find(context.Background(), &jobId, &cluster, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if job.Partition != partition {
+				t.Errorf("job %d: expected partition '%s', got '%s'", jobId, partition, job.Partition)
+			}
+		}
+
+		if recorder := startJob(3201, `"partition": "default",`, "host123"); recorder.Code != http.StatusCreated {
+			t.Fatal(recorder.Code, recorder.Body.String())
+		}
+		checkPartition(3201, "default")
+
+		if recorder := startJob(3202, "", "host124"); recorder.Code != http.StatusCreated {
+			t.Fatal(recorder.Code, recorder.Body.String())
+		}
+		checkPartition(3202, "batch")
+
+		if recorder := startJob(3203, "", "host125"); recorder.Code != http.StatusBadRequest {
+			t.Fatalf("expected status 400, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+
+		cc.DefaultPartition = "fallback"
+		if recorder := startJob(3204, "", "host125"); recorder.Code != http.StatusCreated {
+			t.Fatal(recorder.Code, recorder.Body.String())
+		}
+		checkPartition(3204, "fallback")
+	})
+
+	t.Run("CancelJob", func(t *testing.T) {
+		var lock sync.Mutex
+		cancelled := []int64{}
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			var job schema.Job
+			if err := json.NewDecoder(req.Body).Decode(&job); err != nil {
+				t.Error(err)
+			}
+			lock.Lock()
+			defer lock.Unlock()
+			cancelled = append(cancelled, job.JobID)
+		}))
+
+		cc := config.Keys.Clusters[0]
+		var dbids []int64
+		t.Cleanup(func() {
+			cc.CancelHook = nil
+			server.Close()
+			for _, dbid := range dbids {
+				restapi.JobRepository.DeleteJobById(dbid, false)
+			}
+		})
+
+		startJob := func(jobId int) int64 {
+			body := strings.Replace(startJobBody, `"jobId":            123,`, fmt.Sprintf(`"jobId": %d,`, jobId), -1)
+			req := httptest.NewRequest(http.MethodPost, "/api/jobs/start_job/", bytes.NewBuffer([]byte(body)))
+			recorder := httptest.NewRecorder()
+			r.ServeHTTP(recorder, req)
+			if recorder.Code != http.StatusCreated {
+				t.Fatal(recorder.Code, recorder.Body.String())
+			}
+			var res api.StartJobApiResponse
+			if err := json.Unmarshal(recorder.Body.Bytes(), &res); err != nil {
+				t.Fatal(err)
+			}
+			dbids = append(dbids, res.DBID)
+			return res.DBID
+		}
+		cancel := func(dbid int64, username string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/jobs/%d/cancel", dbid), nil)
+			req = req.WithContext(context.WithValue(req.Context(), repository.ContextUserKey, &schema.User{
+				Username: username,
+				Roles:    []string{schema.GetRoleString(schema.RoleUser)},
+			}))
+			recorder := httptest.NewRecorder()
+			r.ServeHTTP(recorder, req)
+			return recorder
+		}
+
+		running := startJob(3301)
+		if recorder := cancel(running, "testuser"); recorder.Code != http.StatusNotImplemented {
+			t.Fatalf("expected status 501 without cancel hook, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+
+		cc.CancelHook = &schema.CancelHookConfig{URL: server.URL}
+		if recorder := cancel(running, "otheruser"); recorder.Code != http.StatusForbidden {
+			t.Fatalf("expected status 403 for other user, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		if recorder := cancel(running, "testuser"); recorder.Code != http.StatusAccepted {
+			t.Fatal(recorder.Code, recorder.Body.String())
+		}
+
+		job, err := restapi.JobRepository.FindById(running)
+		if err != nil {
+			t.Fatal(err)
+		}
+		metaData, err := restapi.JobRepository.FetchMetadata(job)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if metaData["cancelRequestedBy"] != "testuser" || metaData["cancelRequestedAt"] == "" {
+			t.Errorf("cancel request not recorded: %#v", metaData)
+		}
+
+		stopped := startJob(3302)
+		if err := restapi.JobRepository.Stop(context.Background(), stopped, 60, schema.JobStateCompleted, schema.MonitoringStatusDisabled); err != nil {
+			t.Fatal(err)
+		}
+		if recorder := cancel(stopped, "testuser"); recorder.Code != http.StatusConflict {
+			t.Fatalf("expected status 409 for stopped job, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+
+		lock.Lock()
+		defer lock.Unlock()
+		if !reflect.DeepEqual(cancelled, []int64{3301}) {
+			t.Errorf("expected cancel hook to be called for job 3301 only, got %v", cancelled)
+		}
+	})
+
+	t.Run("StartJobRedactsMetadata", func(t *testing.T) {
+		body := strings.Replace(startJobBody, `"jobId":            123,`, `"jobId": 3401,`, -1)
+		body = strings.Replace(body, `{ "jobScript": "blablabla..." }`,
+			`{ "jobScript": "#!/bin/bash\nexport MY_API_KEY=sk-test-0123456789abcdef\ncurl -H 'Authorization: Bearer abc.def-ghi' https://example.com\nsrun ./a.out", "jobName": "token-test" }`, -1)
+		req := httptest.NewRequest(http.MethodPost, "/api/jobs/start_job/", bytes.NewBuffer([]byte(body)))
+		recorder := httptest.NewRecorder()
+		r.ServeHTTP(recorder, req)
+		if recorder.Code != http.StatusCreated {
+			t.Fatal(recorder.Code, recorder.Body.String())
+		}
+		var res api.StartJobApiResponse
+		if err := json.Unmarshal(recorder.Body.Bytes(), &res); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { restapi.JobRepository.DeleteJobById(res.DBID, false) })
+
+		// Read the metadata from the database, not from the cache.
+		var raw []byte
+		if err := restapi.JobRepository.DB.QueryRow(`SELECT meta_data FROM job WHERE id = ?`, res.DBID).Scan(&raw); err != nil {
+			t.Fatal(err)
+		}
+		var metaData map[string]string
+		if err := json.Unmarshal(raw, &metaData); err != nil {
+			t.Fatal(err)
+		}
+
+		expected := "#!/bin/bash\nexport MY_API_KEY=***\ncurl -H 'Authorization: Bearer ***' https://example.com\nsrun ./a.out"
+		if metaData["jobScript"] != expected || metaData["jobName"] != "token-test" {
+			t.Errorf("metadata not redacted\ngot: %#v\nwant jobScript: %q", metaData, expected)
+		}
+	})
+
+	t.Run("EditOwnership", func(t *testing.T) {
+		body := strings.Replace(startJobBody, `"jobId":            123,`, `"jobId": 3501,`, -1)
+		req := httptest.NewRequest(http.MethodPost, "/api/jobs/start_job/", bytes.NewBuffer([]byte(body)))
+		recorder := httptest.NewRecorder()
+		r.ServeHTTP(recorder, req)
+		if recorder.Code != http.StatusCreated {
+			t.Fatal(recorder.Code, recorder.Body.String())
+		}
+		var res api.StartJobApiResponse
+		if err := json.Unmarshal(recorder.Body.Bytes(), &res); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { restapi.JobRepository.DeleteJobById(res.DBID, false) })
+
+		edit := func(role schema.Role, body string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/jobs/edit_ownership/%d", res.DBID), strings.NewReader(body))
+			req = req.WithContext(context.WithValue(req.Context(), repository.ContextUserKey, &schema.User{
+				Username: "someone",
+				Roles:    []string{schema.GetRoleString(role)},
+			}))
+			recorder := httptest.NewRecorder()
+			r.ServeHTTP(recorder, req)
+			return recorder
+		}
+
+		if recorder := edit(schema.RoleApi, `{"project": "otherproj"}`); recorder.Code != http.StatusForbidden {
+			t.Fatalf("expected status 403 for non-admin, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		if recorder := edit(schema.RoleAdmin, `{}`); recorder.Code != http.StatusBadRequest {
+			t.Fatalf("expected status 400 without user and project, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		recorder = edit(schema.RoleAdmin, `{"project": "otherproj"}`)
+		if recorder.Code != http.StatusOK {
+			t.Fatal(recorder.Code, recorder.Body.String())
+		}
+		var returned schema.Job
+		if err := json.Unmarshal(recorder.Body.Bytes(), &returned); err != nil {
+			t.Fatal(err)
+		}
+
+		job, err := restapi.JobRepository.FindById(res.DBID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if job.Project != "otherproj" || job.User != "testuser" || returned.Project != "otherproj" {
+			t.Errorf("ownership not updated: user=%s, project=%s (returned: %s)", job.User, job.Project, returned.Project)
+		}
+	})
+
+	t.Run("ClusterAccess", func(t *testing.T) {
+		body := strings.Replace(startJobBody, `"jobId":            123,`, `"jobId": 3601,`, -1)
+		req := httptest.NewRequest(http.MethodPost, "/api/jobs/start_job/", bytes.NewBuffer([]byte(body)))
+		recorder := httptest.NewRecorder()
+		r.ServeHTTP(recorder, req)
+		if recorder.Code != http.StatusCreated {
+			t.Fatal(recorder.Code, recorder.Body.String())
+		}
+		var res api.StartJobApiResponse
+		if err := json.Unmarshal(recorder.Body.Bytes(), &res); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { restapi.JobRepository.DeleteJobById(res.DBID, false) })
+
+		// The user is allowed on testcluster but not on othercluster.
+		clusters := config.Keys.Clusters
+		t.Cleanup(func() { config.Keys.Clusters = clusters })
+		config.Keys.Clusters = append([]*schema.ClusterConfig{}, clusters...)
+		config.Keys.Clusters = append(config.Keys.Clusters, &schema.ClusterConfig{
+			Name:   "othercluster",
+			Access: &schema.ClusterAccessConfig{AllowUsers: []string{"someoneelse"}},
+		})
+
+		get := func(url string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(http.MethodGet, url, nil)
+			req = req.WithContext(context.WithValue(req.Context(), repository.ContextUserKey, &schema.User{
+				Username: "tenant",
+				Roles:    []string{schema.GetRoleString(schema.RoleApi)},
+			}))
+			recorder := httptest.NewRecorder()
+			r.ServeHTTP(recorder, req)
+			return recorder
+		}
+
+		if recorder := get("/api/jobs/?cluster=othercluster"); recorder.Code != http.StatusForbidden {
+			t.Fatalf("expected status 403 for forbidden cluster, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		if recorder := get(fmt.Sprintf("/api/jobs/%d", res.DBID)); recorder.Code != http.StatusOK {
+			t.Fatal(recorder.Code, recorder.Body.String())
+		}
+
+		countJobs := func(url string) int {
+			recorder := get(url)
+			if recorder.Code != http.StatusOK {
+				t.Fatal(recorder.Code, recorder.Body.String())
+			}
+			var jobs api.GetJobsApiResponse
+			if err := json.Unmarshal(recorder.Body.Bytes(), &jobs); err != nil {
+				t.Fatal(err)
+			}
+			return len(jobs.Jobs)
+		}
+		if n := countJobs("/api/jobs/?cluster=testcluster"); n == 0 {
+			t.Fatal("expected jobs on allowed cluster")
+		}
+
+		// Once testcluster is forbidden as well, it is excluded from the lists.
+		testcluster := *config.Keys.Clusters[0]
+		testcluster.Access = &schema.ClusterAccessConfig{DenyUsers: []string{"tenant"}}
+		config.Keys.Clusters[0] = &testcluster
+
+		if recorder := get(fmt.Sprintf("/api/jobs/%d", res.DBID)); recorder.Code != http.StatusForbidden {
+			t.Fatalf("expected status 403 for job on forbidden cluster, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		for url, body := range map[string]string{
+			fmt.Sprintf("/api/jobs/tag_job/%d", res.DBID):   `[{"type": "access", "name": "denied"}]`,
+			fmt.Sprintf("/api/jobs/edit_meta/%d", res.DBID): `{"key": "access", "value": "denied"}`,
+			fmt.Sprintf("/api/jobs/stop_job/%d", res.DBID):  `{"jobState": "completed", "stopTime": 123466789}`,
+		} {
+			req := httptest.NewRequest(http.MethodPost, url, bytes.NewBuffer([]byte(body)))
+			req = req.WithContext(context.WithValue(req.Context(), repository.ContextUserKey, &schema.User{
+				Username: "tenant",
+				Roles:    []string{schema.GetRoleString(schema.RoleApi)},
+			}))
+			recorder := httptest.NewRecorder()
+			r.ServeHTTP(recorder, req)
+			if recorder.Code != http.StatusForbidden {
+				t.Fatalf("%s: expected status 403 for job on forbidden cluster, got %d: %s", url, recorder.Code, recorder.Body.String())
+			}
+		}
+		if job, err := restapi.JobRepository.FindById(res.DBID); err != nil || job.State != schema.JobStateRunning || len(job.MetaData) != 0 {
+			t.Fatalf("job on forbidden cluster modified: %v, %v", job, err)
+		}
+		if n := countJobs("/api/jobs/"); n != 0 {
+			t.Fatalf("expected no jobs of forbidden clusters, got %d", n)
+		}
+		recorder = get("/api/clusters/")
+		var clustersRes api.GetClustersApiResponse
+		if err := json.Unmarshal(recorder.Body.Bytes(), &clustersRes); err != nil {
+			t.Fatal(recorder.Code, err)
+		}
+		for _, c := range clustersRes.Clusters {
+			if c.Name == "testcluster" {
+				t.Fatal("forbidden cluster listed")
+			}
+		}
+	})
+
+	t.Run("MetricAvailability", func(t *testing.T) {
+		metrics, err := restapi.Resolver.Query().MetricAvailability(context.Background(), "testcluster")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(metrics) != 1 {
+			t.Fatalf("expected one metric, got %d", len(metrics))
+		}
+		loadOne := metrics[0]
+		if loadOne.Name != "load_one" || loadOne.Scope != schema.MetricScopeNode ||
+			!reflect.DeepEqual(loadOne.Scopes, []schema.MetricScope{schema.MetricScopeNode}) ||
+			loadOne.Timestep != 60 || loadOne.Peak != 8 {
+			t.Fatalf("unexpected availability of load_one: %#v", loadOne)
+		}
+		if len(loadOne.SubClusters) != 2 || loadOne.SubClusters[0].Name != "sc1" || loadOne.SubClusters[1].Peak != 8 {
+			t.Fatalf("unexpected subclusters of load_one: %#v", loadOne.SubClusters)
+		}
+
+		// Subcluster specific thresholds take precedence, removed subclusters are left out.
+		mc := archive.GetMetricConfig("testcluster", "load_one")
+		t.Cleanup(func() { mc.SubClusters = nil })
+		mc.SubClusters = []*schema.SubClusterConfig{{Name: "sc1", Peak: 4}, {Name: "sc2", Remove: true}}
+		metrics, err = restapi.Resolver.Query().MetricAvailability(context.Background(), "testcluster")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if subClusters := metrics[0].SubClusters; len(subClusters) != 1 || subClusters[0].Name != "sc1" || subClusters[0].Peak != 4 {
+			t.Fatalf("unexpected subclusters of load_one: %#v", subClusters)
+		}
+
+		if _, err := restapi.Resolver.Query().MetricAvailability(context.Background(), "unknown"); err == nil {
+			t.Fatal("expected error for unknown cluster")
+		}
+	})
+
+	t.Run("StartJobTagFailureRollsBack", func(t *testing.T) {
+		// The same tag twice fails on the second insert into jobtag.
+		body := strings.Replace(startJobBody, `"jobId":            123,`, `"jobId": 3901,`, -1)
+		body = strings.Replace(body, `[{ "type": "testTagType", "name": "testTagName" }]`,
+			`[{ "type": "testTagType", "name": "testTagName" }, { "type": "testTagType", "name": "testTagName" }]`, -1)
+		req := httptest.NewRequest(http.MethodPost, "/api/jobs/start_job/", bytes.NewBuffer([]byte(body)))
+		recorder := httptest.NewRecorder()
+		r.ServeHTTP(recorder, req)
+		if recorder.Code != http.StatusConflict {
+			t.Fatal(recorder.Code, recorder.Body.String())
+		}
+
+		jobId, cluster := int64(3901), "testcluster"
+		if job, err := restapi.JobRepository.Find(context.Background(), &jobId, &cluster, nil); err != sql.ErrNoRows {
+			t.Fatalf("job not rolled back: %v, %v", job, err)
 		}
 	})
-	if !ok {
-		t.Fatal("subtest failed")
-	}
 }
@@ -0,0 +1,97 @@
+// Copyright (C) 2023 NHR@FAU, University Erlangen-Nuremberg.
+// All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ClusterCockpit/cc-backend/internal/config"
+	"github.com/ClusterCockpit/cc-backend/internal/repository"
+	"github.com/ClusterCockpit/cc-backend/pkg/schema"
+)
+
+// Context key of the cluster whose API key authenticated the request.
+const apiKeyClusterKey repository.ContextKey = "apiKeyCluster"
+
+// Endpoints that can be used with a cluster API key.
+var apiKeyPaths = []string{"/api/jobs/start_job/", "/api/jobs/stop_job/", "/api/jobs/resume_job/"}
+
+// ApiKeyAuth authenticates requests that carry a `X-API-Key` header. The key
+// is checked against the SHA256 hashes configured per cluster (`apiKeys`).
+// A valid key results in a machine user with the api role which is only
+// allowed to start, stop and resume jobs of that cluster (see
+// checkApiKeyCluster). Requests without a key are passed on to onfailure.
+func (api *RestApi) ApiKeyAuth(
+	onsuccess http.Handler,
+	onfailure func(rw http.ResponseWriter, r *http.Request, authErr error)) http.Handler {
+
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get("X-API-Key")
+		if key == "" {
+			onfailure(rw, r, errors.New("unauthorized (missing api key)"))
+			return
+		}
+
+		cluster, ok := findApiKey(key)
+		if !ok {
+			handleError(errors.New("invalid api key"), http.StatusUnauthorized, rw)
+			return
+		}
+
+		allowed := false
+		for _, path := range apiKeyPaths {
+			if strings.HasPrefix(r.URL.Path, path) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			handleError(fmt.Errorf("api key of cluster %s not allowed for %s", cluster, r.URL.Path), http.StatusForbidden, rw)
+			return
+		}
+
+		user := &schema.User{
+			Username:   fmt.Sprintf("api-key@%s", cluster),
+			Roles:      []string{schema.GetRoleString(schema.RoleApi)},
+			AuthType:   schema.AuthToken,
+			AuthSource: -1,
+		}
+		ctx := context.WithValue(r.Context(), repository.ContextUserKey, user)
+		ctx = context.WithValue(ctx, apiKeyClusterKey, cluster)
+		onsuccess.ServeHTTP(rw, r.WithContext(ctx))
+	})
+}
+
+// findApiKey returns the name of the cluster the key belongs to.
+func findApiKey(key string) (string, bool) {
+	sum := sha256.Sum256([]byte(key))
+	hash := []byte(hex.EncodeToString(sum[:]))
+
+	for _, cluster := range config.Keys.Clusters {
+		for _, apiKey := range cluster.ApiKeys {
+			if subtle.ConstantTimeCompare(hash, []byte(strings.ToLower(apiKey))) == 1 {
+				return cluster.Name, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// checkApiKeyCluster returns an error if the request was authenticated by the
+// API key of another cluster than cluster.
+func checkApiKeyCluster(r *http.Request, cluster string) error {
+	if keyCluster, ok := r.Context().Value(apiKeyClusterKey).(string); ok && keyCluster != cluster {
+		return fmt.Errorf("api key of cluster %s not allowed for jobs of cluster %s", keyCluster, cluster)
+	}
+	return nil
+}
@@ -0,0 +1,319 @@
+// Copyright (C) 2023 NHR@FAU, University Erlangen-Nuremberg.
+// All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+package api
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ClusterCockpit/cc-backend/internal/config"
+	"github.com/ClusterCockpit/cc-backend/internal/repository"
+	"github.com/ClusterCockpit/cc-backend/pkg/log"
+	"github.com/ClusterCockpit/cc-backend/pkg/schema"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Minimum time between two fetches of the JWKS document.
+const jwksMinRefresh = time.Minute
+
+var bearerMethods = []string{
+	"RS256", "RS384", "RS512", "PS256", "PS384", "PS512",
+	"ES256", "ES384", "ES512", "EdDSA",
+}
+
+// BearerAuth authenticates requests that carry a `Authorization: Bearer`
+// JWT signed by an external identity provider (`bearer-auth`). The token is
+// checked against the configured public key or the keys of the JWKS URL and
+// must neither be expired nor issued for another audience. Username and roles
+// are taken from the claims. Requests without a bearer token, or with a token
+// of another issuer (e.g. a JWT of cc-backend itself), are passed on to
+// onfailure.
+func (api *RestApi) BearerAuth(
+	onsuccess http.Handler,
+	onfailure func(rw http.ResponseWriter, r *http.Request, authErr error)) http.Handler {
+
+	bv, err := newBearerVerifier(config.Keys.BearerAuth)
+	if err != nil {
+		log.Errorf("bearer authentication disabled: %s", err.Error())
+	}
+
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rawtoken := r.Header.Get("Authorization")
+		if !strings.HasPrefix(rawtoken, "Bearer ") {
+			rawtoken = ""
+		}
+		rawtoken = strings.TrimPrefix(rawtoken, "Bearer ")
+		if bv == nil || rawtoken == "" || !bv.handles(rawtoken) {
+			onfailure(rw, r, errors.New("unauthorized (missing bearer token)"))
+			return
+		}
+
+		user, err := bv.verify(rawtoken)
+		if err != nil {
+			handleError(fmt.Errorf("invalid bearer token: %w", err), http.StatusUnauthorized, rw)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), repository.ContextUserKey, user)
+		onsuccess.ServeHTTP(rw, r.WithContext(ctx))
+	})
+}
+
+type bearerVerifier struct {
+	config    *schema.BearerAuthConfig
+	publicKey interface{}
+
+	// mutex protects jwks and jwksFetched, fetchMutex serializes the fetches
+	// of the JWKS document without blocking the lookup of known keys.
+	mutex       sync.Mutex
+	fetchMutex  sync.Mutex
+	jwks        map[string]interface{}
+	jwksFetched time.Time
+}
+
+func newBearerVerifier(bc *schema.BearerAuthConfig) (*bearerVerifier, error) {
+	if bc == nil {
+		return nil, errors.New("missing bearer-auth configuration")
+	}
+	if bc.Audience == "" {
+		return nil, errors.New("no audience configured")
+	}
+	if bc.Issuer == "" {
+		return nil, errors.New("no issuer configured")
+	}
+
+	bv := &bearerVerifier{config: bc}
+	switch {
+	case bc.PublicKey != "":
+		block, _ := pem.Decode([]byte(bc.PublicKey))
+		if block == nil {
+			return nil, errors.New("public key is not PEM encoded")
+		}
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parsing public key failed: %w", err)
+		}
+		bv.publicKey = key
+	case bc.JwksUrl == "":
+		return nil, errors.New("neither publicKey nor jwksUrl configured")
+	}
+
+	return bv, nil
+}
+
+// handles reports whether the token is meant for this verifier, i.e. whether
+// its issuer is the configured one.
+func (bv *bearerVerifier) handles(rawtoken string) bool {
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(rawtoken, claims); err != nil {
+		return false
+	}
+	iss, _ := claims.GetIssuer()
+	return iss == bv.config.Issuer
+}
+
+func (bv *bearerVerifier) verify(rawtoken string) (*schema.User, error) {
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods(bearerMethods),
+		jwt.WithAudience(bv.config.Audience),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuer(bv.config.Issuer),
+	}
+
+	claims := jwt.MapClaims{}
+	if _, err := jwt.ParseWithClaims(rawtoken, claims, bv.key, opts...); err != nil {
+		return nil, err
+	}
+
+	usernameClaim, rolesClaim := "sub", "roles"
+	if bv.config.UsernameClaim != "" {
+		usernameClaim = bv.config.UsernameClaim
+	}
+	if bv.config.RolesClaim != "" {
+		rolesClaim = bv.config.RolesClaim
+	}
+
+	username, _ := claims[usernameClaim].(string)
+	if username == "" {
+		return nil, fmt.Errorf("missing claim '%s'", usernameClaim)
+	}
+
+	roles := make([]string, 0)
+	if rawroles, ok := claims[rolesClaim].([]interface{}); ok {
+		for _, rr := range rawroles {
+			if r, ok := rr.(string); ok && schema.IsValidRole(r) {
+				roles = append(roles, r)
+			}
+		}
+	}
+
+	return &schema.User{
+		Username:   username,
+		Roles:      roles,
+		AuthType:   schema.AuthToken,
+		AuthSource: -1,
+	}, nil
+}
+
+// key returns the key to verify the signature of t with.
+func (bv *bearerVerifier) key(t *jwt.Token) (interface{}, error) {
+	if bv.publicKey != nil {
+		return bv.publicKey, nil
+	}
+
+	kid, _ := t.Header["kid"].(string)
+	if key, ok, _ := bv.lookupJwk(kid); ok {
+		return key, nil
+	}
+
+	// Unknown key: The identity provider might have rotated its keys. Only
+	// one request fetches the document, the others wait for it and look the
+	// key up again.
+	bv.fetchMutex.Lock()
+	defer bv.fetchMutex.Unlock()
+
+	key, ok, refresh := bv.lookupJwk(kid)
+	if refresh {
+		keys, err := bv.fetchJwks()
+		bv.mutex.Lock()
+		bv.jwksFetched = time.Now()
+		if err == nil {
+			bv.jwks = keys
+		}
+		bv.mutex.Unlock()
+		if err != nil {
+			log.Warnf("fetching JWKS from %s failed: %s", bv.config.JwksUrl, err.Error())
+			return nil, errors.New("signing keys not available")
+		}
+		key, ok = keys[kid]
+	}
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key '%s'", kid)
+	}
+	return key, nil
+}
+
+// lookupJwk returns the key kid of the last fetched JWKS document and whether
+// the document should be fetched again because the key is unknown.
+func (bv *bearerVerifier) lookupJwk(kid string) (key interface{}, ok, refresh bool) {
+	bv.mutex.Lock()
+	defer bv.mutex.Unlock()
+
+	key, ok = bv.jwks[kid]
+	return key, ok, !ok && time.Since(bv.jwksFetched) > jwksMinRefresh
+}
+
+func (bv *bearerVerifier) fetchJwks() (map[string]interface{}, error) {
+	client := http.Client{Timeout: 10 * time.Second}
+	res, err := client.Get(bv.config.JwksUrl)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status: %s", res.Status)
+	}
+
+	var jwks struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&jwks); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]interface{}, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		key, err := jwk.publicKey()
+		if err != nil {
+			log.Warnf("skipping JWKS key '%s': %s", jwk.Kid, err.Error())
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	return keys, nil
+}
+
+// A public key as JSON Web Key (RFC 7517).
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (jwk *jsonWebKey) publicKey() (interface{}, error) {
+	switch jwk.Kty {
+	case "RSA":
+		n, err := decodeBigInt(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch jwk.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve '%s'", jwk.Crv)
+		}
+		x, err := decodeBigInt(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "OKP":
+		if jwk.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve '%s'", jwk.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 key size")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type '%s'", jwk.Kty)
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,153 @@
+// Copyright (C) 2023 NHR@FAU, University Erlangen-Nuremberg.
+// All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ClusterCockpit/cc-backend/internal/config"
+	"github.com/ClusterCockpit/cc-backend/internal/repository"
+	"github.com/ClusterCockpit/cc-backend/pkg/log"
+	"github.com/ClusterCockpit/cc-backend/pkg/schema"
+	"github.com/gorilla/mux"
+)
+
+// Maximum time a cancel hook may take before the request fails.
+const cancelHookTimeout = 30 * time.Second
+
+// CancelJobApiResponse model
+type CancelJobApiResponse struct {
+	Message string `json:"msg"`
+}
+
+// runCancelHook forwards the request to cancel job to the scheduler. It is a
+// variable so that tests can replace it.
+var runCancelHook = func(ctx context.Context, hook *schema.CancelHookConfig, job *schema.Job) error {
+	ctx, cancel := context.WithTimeout(ctx, cancelHookTimeout)
+	defer cancel()
+
+	if len(hook.Command) != 0 {
+		replacer := strings.NewReplacer(
+			"{jobId}", strconv.FormatInt(job.JobID, 10),
+			"{cluster}", job.Cluster,
+			"{user}", job.User)
+		args := make([]string, 0, len(hook.Command))
+		for _, arg := range hook.Command {
+			args = append(args, replacer.Replace(arg))
+		}
+
+		out, err := exec.CommandContext(ctx, args[0], args[1:]...).CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("%s: %w: %s", args[0], err, strings.TrimSpace(string(out)))
+		}
+		return nil
+	}
+
+	body, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("POST %s: %s", hook.URL, res.Status)
+	}
+	return nil
+}
+
+// cancelJob godoc
+// @summary     Cancel a running job
+// @tags Job add and modify
+// @description Job is specified by database ID. The request is forwarded to the scheduler by the cancel hook
+// @description configured for the cluster of the job, e.g. a command running scancel. Only the owner of the job
+// @description and admins may cancel it. The request is recorded in the metadata keys 'cancelRequestedBy'
+// @description and 'cancelRequestedAt' of the job, the job itself is stopped by the scheduler as usual.
+// @produce     json
+// @param       id  path     int                      true "Database ID of Job"
+// @success     202 {object} api.CancelJobApiResponse "Cancel request forwarded"
+// @failure     400 {object} api.ErrorResponse        "Bad Request"
+// @failure     401 {object} api.ErrorResponse        "Unauthorized"
+// @failure     403 {object} api.ErrorResponse        "Forbidden: not the owner of the job"
+// @failure     409 {object} api.ErrorResponse        "Conflict: job is not running"
+// @failure     422 {object} api.ErrorResponse        "Unprocessable Entity: finding job failed: sql: no rows in result set"
+// @failure     500 {object} api.ErrorResponse        "Internal Server Error"
+// @failure     501 {object} api.ErrorResponse        "Not Implemented: no cancel hook for the cluster"
+// @failure     502 {object} api.ErrorResponse        "Bad Gateway: cancel hook failed"
+// @security    ApiKeyAuth
+// @router      /jobs/{id}/cancel [post]
+func (api *RestApi) cancelJob(rw http.ResponseWriter, r *http.Request) {
+	user := repository.GetUserFromContext(r.Context())
+	if user == nil {
+		handleError(errors.New("cancelling jobs requires a user"), http.StatusForbidden, rw)
+		return
+	}
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		handleError(fmt.Errorf("integer expected in path for id: %w", err), http.StatusBadRequest, rw)
+		return
+	}
+
+	job, err := api.JobRepository.FindById(id)
+	if err != nil {
+		handleError(fmt.Errorf("finding job failed: %w", err), http.StatusUnprocessableEntity, rw)
+		return
+	}
+
+	if job.User != user.Username && !user.HasRole(schema.RoleAdmin) {
+		handleError(fmt.Errorf("user %s may not cancel job (dbid: %d) of user %s", user.Username, job.ID, job.User),
+			http.StatusForbidden, rw)
+		return
+	}
+
+	if job.State != schema.JobStateRunning {
+		handleError(fmt.Errorf("job (dbid: %d) is not running but %s", job.ID, job.State), http.StatusConflict, rw)
+		return
+	}
+
+	hook := config.GetCancelHook(job.Cluster)
+	if hook == nil || (len(hook.Command) == 0 && hook.URL == "") {
+		handleError(fmt.Errorf("no cancel hook configured for cluster %s", job.Cluster), http.StatusNotImplemented, rw)
+		return
+	}
+
+	if err := runCancelHook(r.Context(), hook, job); err != nil {
+		handleError(fmt.Errorf("cancelling job (dbid: %d) failed: %w", job.ID, err), http.StatusBadGateway, rw)
+		return
+	}
+	log.Infof("job (dbid: %d) on %s cancelled by %s", job.ID, job.Cluster, user.Username)
+
+	if err := api.JobRepository.MergeMetadata(job, map[string]string{
+		"cancelRequestedBy": user.Username,
+		"cancelRequestedAt": strconv.FormatInt(time.Now().Unix(), 10),
+	}); err != nil {
+		handleError(fmt.Errorf("recording cancel request failed: %w", err), http.StatusInternalServerError, rw)
+		return
+	}
+
+	rw.Header().Add("Content-Type", "application/json")
+	rw.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(rw).Encode(CancelJobApiResponse{
+		Message: fmt.Sprintf("Cancel request for job %d forwarded", job.ID),
+	})
+}
@@ -23,40 +23,29 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/clusters/": {
+        "/admin/archiving": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get a list of all cluster configs. Specific cluster can be requested using query parameter.",
+                "description": "Lists the jobs queued for archiving or being archived, ordered by the time archiving was requested.\nOnly allowed for admins.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Cluster query"
-                ],
-                "summary": "Lists all cluster configs",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Job Cluster",
-                        "name": "cluster",
-                        "in": "query"
-                    }
+                    "Admin"
                 ],
+                "summary": "Lists ongoing archiving operations",
                 "responses": {
                     "200": {
-                        "description": "Array of clusters",
-                        "schema": {
-                            "$ref": "#/definitions/api.GetClustersApiResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                        "description": "Ongoing archiving operations",
                         "schema": {
-                            "$ref": "#/definitions/api.ErrorResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/api.ArchivingApiResponse"
+                            }
                         }
                     },
                     "401": {
@@ -70,82 +59,39 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/api.ErrorResponse"
-                        }
                     }
                 }
             }
         },
-        "/jobs/": {
-            "get": {
+        "/admin/archiving/{id}": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get a list of all jobs. Filters can be applied using query parameters.\nNumber of results can be limited by page. Results are sorted by descending startTime.",
+                "description": "Job is specified by database ID. A queued job is skipped, loading the metric data of a job being archived\nis cancelled. Archiving the job fails in both cases. Only allowed for admins.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Job query"
+                    "Admin"
                 ],
-                "summary": "Lists all jobs",
+                "summary": "Cancels an ongoing archiving operation",
                 "parameters": [
-                    {
-                        "enum": [
-                            "running",
-                            "completed",
-                            "failed",
-                            "cancelled",
-                            "stopped",
-                            "timeout"
-                        ],
-                        "type": "string",
-                        "description": "Job State",
-                        "name": "state",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Job Cluster",
-                        "name": "cluster",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Syntax: '$from-$to', as unix epoch timestamps in seconds",
-                        "name": "start-time",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Items per page (Default: 25)",
-                        "name": "items-per-page",
-                        "in": "query"
-                    },
                     {
                         "type": "integer",
-                        "description": "Page Number (Default: 1)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Include metadata (e.g. jobScript) in response",
-                        "name": "with-metadata",
-                        "in": "query"
+                        "description": "Database ID of Job",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Job array and page info",
+                        "description": "Success message",
                         "schema": {
-                            "$ref": "#/definitions/api.GetJobsApiResponse"
+                            "$ref": "#/definitions/api.CancelArchivingApiResponse"
                         }
                     },
                     "400": {
@@ -166,8 +112,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Resource not found: job is not being archived",
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
@@ -175,46 +121,34 @@ const docTemplate = `{
                 }
             }
         },
-        "/jobs/delete_job/": {
-            "delete": {
+        "/admin/reindex": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Job to delete is specified by request body. All fields are required in this case.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Runs REINDEX and ANALYZE on the database (OPTIMIZE TABLE and ANALYZE TABLE for MySQL).\nWith recreate, the indexes of the job table are dropped and created again first. Only allowed for admins.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Job remove"
+                    "Admin"
                 ],
-                "summary": "Remove a job from the sql database",
+                "summary": "Reindexes the database",
                 "parameters": [
                     {
-                        "description": "All fields required",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/api.DeleteJobApiRequest"
-                        }
+                        "type": "boolean",
+                        "description": "Drop and recreate the job indexes",
+                        "name": "recreate",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success message",
-                        "schema": {
-                            "$ref": "#/definitions/api.DeleteJobApiResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                        "description": "Durations of the steps",
                         "schema": {
-                            "$ref": "#/definitions/api.ErrorResponse"
+                            "$ref": "#/definitions/api.ReindexApiResponse"
                         }
                     },
                     "401": {
@@ -229,18 +163,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Resource not found",
-                        "schema": {
-                            "$ref": "#/definitions/api.ErrorResponse"
-                        }
-                    },
-                    "422": {
-                        "description": "Unprocessable Entity: finding job failed: sql: no rows in result set",
-                        "schema": {
-                            "$ref": "#/definitions/api.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -250,35 +172,34 @@ const docTemplate = `{
                 }
             }
         },
-        "/jobs/delete_job/{id}": {
-            "delete": {
+        "/clusters/": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Job to remove is specified by database ID. This will not remove the job from the job archive.",
+                "description": "Get a list of all cluster configs. Specific cluster can be requested using query parameter.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Job remove"
+                    "Cluster query"
                 ],
-                "summary": "Remove a job from the sql database",
+                "summary": "Lists all cluster configs",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Database ID of Job",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "description": "Job Cluster",
+                        "name": "cluster",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success message",
+                        "description": "Array of clusters",
                         "schema": {
-                            "$ref": "#/definitions/api.DeleteJobApiResponse"
+                            "$ref": "#/definitions/api.GetClustersApiResponse"
                         }
                     },
                     "400": {
@@ -299,18 +220,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Resource not found",
-                        "schema": {
-                            "$ref": "#/definitions/api.ErrorResponse"
-                        }
-                    },
-                    "422": {
-                        "description": "Unprocessable Entity: finding job failed: sql: no rows in result set",
-                        "schema": {
-                            "$ref": "#/definitions/api.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -320,41 +229,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/jobs/delete_job_before/{ts}": {
-            "delete": {
+        "/clusters/{name}": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Remove all jobs with start time before timestamp. The jobs will not be removed from the job archive.",
+                "description": "Get the config of a cluster including subclusters, topology, metrics and filter ranges.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Job remove"
+                    "Cluster query"
                 ],
-                "summary": "Remove a job from the sql database",
+                "summary": "Get a cluster config",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Unix epoch timestamp",
-                        "name": "ts",
+                        "type": "string",
+                        "description": "Cluster name",
+                        "name": "name",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success message",
-                        "schema": {
-                            "$ref": "#/definitions/api.DeleteJobApiResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                        "description": "Cluster config",
                         "schema": {
-                            "$ref": "#/definitions/api.ErrorResponse"
+                            "$ref": "#/definitions/api.ClusterApiResponse"
                         }
                     },
                     "401": {
@@ -370,13 +273,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Resource not found",
-                        "schema": {
-                            "$ref": "#/definitions/api.ErrorResponse"
-                        }
-                    },
-                    "422": {
-                        "description": "Unprocessable Entity: finding job failed: sql: no rows in result set",
+                        "description": "Cluster not found",
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
@@ -390,14 +287,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/jobs/edit_meta/{id}": {
+        "/grafana/": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Always returns 200 OK, used by Grafana to test the datasource.",
+                "tags": [
+                    "Grafana"
+                ],
+                "summary": "Test the Grafana datasource connection",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/grafana/annotations": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Edit key value pairs in job metadata json\nIf a key already exists its content will be overwritten",
+                "description": "Returns an annotation for every job started or stopped in the requested time window.\nIf the annotation query is set, only jobs of the cluster with that name are considered.",
                 "consumes": [
                     "application/json"
                 ],
@@ -405,32 +321,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Job add and modify"
+                    "Grafana"
                 ],
-                "summary": "Edit meta-data json",
+                "summary": "Returns job start and stop events as Grafana annotations",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Job Database ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Kay value pair to add",
+                        "description": "Annotation and time window",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.EditMetaRequest"
+                            "$ref": "#/definitions/api.GrafanaAnnotationRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Updated job resource",
+                        "description": "Job events",
                         "schema": {
-                            "$ref": "#/definitions/schema.Job"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/api.GrafanaAnnotationResponse"
+                            }
                         }
                     },
                     "400": {
@@ -445,8 +357,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Job does not exist",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
@@ -460,14 +372,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/jobs/start_job/": {
+        "/grafana/query": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Job specified in request body will be saved to database as \"running\" with new DB ID.\nJob specifications follow the 'JobMeta' scheme, API will fail to execute if requirements are not met.",
+                "description": "Loads the metric data of all requested targets in the requested time window.\nJob targets always return the complete job.",
                 "consumes": [
                     "application/json"
                 ],
@@ -475,25 +387,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Job add and modify"
+                    "Grafana"
                 ],
-                "summary": "Adds a new job as \"running\"",
+                "summary": "Returns timeseries for Grafana targets",
                 "parameters": [
                     {
-                        "description": "Job to add",
+                        "description": "Targets and time window",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.JobMeta"
+                            "$ref": "#/definitions/api.GrafanaQueryRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Job added successfully",
+                    "200": {
+                        "description": "Timeseries",
                         "schema": {
-                            "$ref": "#/definitions/api.StartJobApiResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/api.GrafanaTimeSeries"
+                            }
                         }
                     },
                     "400": {
@@ -514,12 +429,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity: The combination of jobId, clusterId and startTime does already exist",
-                        "schema": {
-                            "$ref": "#/definitions/api.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -529,37 +438,43 @@ const docTemplate = `{
                 }
             }
         },
-        "/jobs/stop_job/": {
+        "/grafana/search": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Job to stop is specified by request body. All fields are required in this case.\nReturns full job resource information according to 'JobMeta' scheme.",
+                "description": "Returns all node targets ('node:\u003ccluster\u003e:\u003cmetric\u003e') containing the requested string.\nJob targets ('job:\u003cid\u003e:\u003cmetric\u003e') are not listed but can be used in queries.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Job add and modify"
+                    "Grafana"
                 ],
-                "summary": "Marks job as completed and triggers archiving",
+                "summary": "Lists available Grafana targets",
                 "parameters": [
                     {
-                        "description": "All fields required",
+                        "description": "Search string",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.StopJobApiRequest"
+                            "$ref": "#/definitions/api.GrafanaSearchRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success message",
+                        "description": "Targets",
                         "schema": {
-                            "$ref": "#/definitions/schema.JobMeta"
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
@@ -579,15 +494,139 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
-                    },
-                    "404": {
-                        "description": "Resource not found",
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Pings the database and checks the metric data repositories of all clusters.\nReturns status 503 if any of them is down.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Checks the health of the dependencies",
+                "responses": {
+                    "200": {
+                        "description": "All dependencies healthy",
+                        "schema": {
+                            "$ref": "#/definitions/api.HealthApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity: finding job failed: sql: no rows in result set",
+                    "503": {
+                        "description": "Some dependency is down",
+                        "schema": {
+                            "$ref": "#/definitions/api.HealthApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get a list of all jobs. Filters can be applied using query parameters.\nNumber of results can be limited by page. Results are sorted by descending startTime.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Job query"
+                ],
+                "summary": "Lists all jobs",
+                "parameters": [
+                    {
+                        "enum": [
+                            "running",
+                            "completed",
+                            "failed",
+                            "cancelled",
+                            "stopped",
+                            "timeout"
+                        ],
+                        "type": "string",
+                        "description": "Job State",
+                        "name": "state",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Job Cluster",
+                        "name": "cluster",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Syntax: '$from-$to', as unix epoch timestamps in seconds",
+                        "name": "start-time",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            0,
+                            1
+                        ],
+                        "type": "integer",
+                        "description": "SMT setting of job",
+                        "name": "smt",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (Default: 25)",
+                        "name": "items-per-page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page Number (Default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include metadata (e.g. jobScript) in response",
+                        "name": "with-metadata",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Job array and page info",
+                        "schema": {
+                            "$ref": "#/definitions/api.GetJobsApiResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
@@ -601,14 +640,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/jobs/stop_job/{id}": {
-            "post": {
+        "/jobs/delete_job/": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Job to stop is specified by database ID. Only stopTime and final state are required in request body.\nReturns full job resource information according to 'JobMeta' scheme.",
+                "description": "Job to delete is specified by request body. All fields are required in this case.",
                 "consumes": [
                     "application/json"
                 ],
@@ -616,32 +655,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Job add and modify"
+                    "Job remove"
                 ],
-                "summary": "Marks job as completed and triggers archiving",
+                "summary": "Remove a job from the sql database",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Database ID of Job",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "stopTime and final state in request body",
+                        "description": "All fields required",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.StopJobApiRequest"
+                            "$ref": "#/definitions/api.DeleteJobApiRequest"
                         }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only report what would be deleted (also: header X-Dry-Run)",
+                        "name": "dryRun",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Job resource",
+                        "description": "Success message",
                         "schema": {
-                            "$ref": "#/definitions/schema.JobMeta"
+                            "$ref": "#/definitions/api.DeleteJobApiResponse"
                         }
                     },
                     "400": {
@@ -683,50 +721,41 @@ const docTemplate = `{
                 }
             }
         },
-        "/jobs/tag_job/{id}": {
-            "post": {
+        "/jobs/delete_job/{id}": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Adds tag(s) to a job specified by DB ID. Name and Type of Tag(s) can be chosen freely.\nIf tagged job is already finished: Tag will be written directly to respective archive files.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Job to remove is specified by database ID. This will not remove the job from the job archive.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Job add and modify"
+                    "Job remove"
                 ],
-                "summary": "Adds one or more tags to a job",
+                "summary": "Remove a job from the sql database",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Job Database ID",
+                        "description": "Database ID of Job",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Array of tag-objects to add",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/api.ApiTag"
-                            }
-                        }
+                        "type": "boolean",
+                        "description": "Only report what would be deleted (also: header X-Dry-Run)",
+                        "name": "dryRun",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Updated job resource",
+                        "description": "Success message",
                         "schema": {
-                            "$ref": "#/definitions/schema.Job"
+                            "$ref": "#/definitions/api.DeleteJobApiResponse"
                         }
                     },
                     "400": {
@@ -741,8 +770,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Job or tag does not exist",
+                        "description": "Resource not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity: finding job failed: sql: no rows in result set",
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
@@ -756,41 +797,41 @@ const docTemplate = `{
                 }
             }
         },
-        "/jobs/{id}": {
-            "get": {
+        "/jobs/delete_job_before/{ts}": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Job to get is specified by database ID\nReturns full job resource information according to 'JobMeta' scheme and all metrics according to 'JobData'.",
+                "description": "Remove all jobs with start time before timestamp. The jobs will not be removed from the job archive.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Job query"
+                    "Job remove"
                 ],
-                "summary": "Get job meta and optional all metric data",
+                "summary": "Remove a job from the sql database",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Database ID of Job",
-                        "name": "id",
+                        "description": "Unix epoch timestamp",
+                        "name": "ts",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "boolean",
-                        "description": "Include all available metrics",
-                        "name": "all-metrics",
+                        "description": "Only report what would be deleted (also: header X-Dry-Run)",
+                        "name": "dryRun",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Job resource",
+                        "description": "Success message",
                         "schema": {
-                            "$ref": "#/definitions/api.GetJobApiResponse"
+                            "$ref": "#/definitions/api.DeleteJobApiResponse"
                         }
                     },
                     "400": {
@@ -830,14 +871,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/jobs/edit_meta/{id}": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Job to get is specified by database ID\nReturns full job resource information according to 'JobMeta' scheme and all metrics according to 'JobData'.",
+                "description": "Edit key value pairs in job metadata json\nIf a key already exists its content will be overwritten",
                 "consumes": [
                     "application/json"
                 ],
@@ -845,35 +888,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Job query"
+                    "Job add and modify"
                 ],
-                "summary": "Get job meta and configurable metric data",
+                "summary": "Edit meta-data json",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Database ID of Job",
+                        "description": "Job Database ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Array of metric names",
+                        "description": "Kay value pair to add",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/api.EditMetaRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Job resource",
+                        "description": "Updated job resource",
                         "schema": {
-                            "$ref": "#/definitions/api.GetJobApiResponse"
+                            "$ref": "#/definitions/schema.Job"
                         }
                     },
                     "400": {
@@ -895,13 +935,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Resource not found",
-                        "schema": {
-                            "$ref": "#/definitions/api.ErrorResponse"
-                        }
-                    },
-                    "422": {
-                        "description": "Unprocessable Entity: finding job failed: sql: no rows in result set",
+                        "description": "Job does not exist",
                         "schema": {
                             "$ref": "#/definitions/api.ErrorResponse"
                         }
@@ -915,465 +949,2726 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/{id}": {
+        "/jobs/edit_ownership/{id}": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Modifies user defined by username (id) in one of four possible ways.\nIf more than one formValue is set then only the highest priority field is used.\nOnly accessible from IPs registered with apiAllowedIPs configuration option.",
+                "description": "Changes the user and/or project of a job, e.g. if the scheduler reported the wrong ones.\nEmpty fields are kept. The meta.json of archived jobs is updated as well. Only allowed for admins.",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Job add and modify"
                 ],
-                "summary": "Updates an existing user",
+                "summary": "Correct the user and project of a job",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Database ID of User",
-                        "name": "id",
+                        "type": "integer",
+                        "description": "Job Database ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "enum": [
-                            "admin",
-                            "support",
-                            "manager",
-                            "user",
-                            "api"
-                        ],
-                        "type": "string",
-                        "description": "Priority 1: Role to add",
-                        "name": "add-role",
-                        "in": "formData"
-                    },
-                    {
-                        "enum": [
-                            "admin",
-                            "support",
-                            "manager",
-                            "user",
-                            "api"
-                        ],
-                        "type": "string",
-                        "description": "Priority 2: Role to remove",
-                        "name": "remove-role",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Priority 3: Project to add",
-                        "name": "add-project",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Priority 4: Project to remove",
-                        "name": "remove-project",
-                        "in": "formData"
+                        "description": "New user and/or project",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.EditOwnershipRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success Response Message",
+                        "description": "Updated job resource",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/schema.Job"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity: The user could not be updated",
+                    "404": {
+                        "description": "Job does not exist",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/api.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/users/": {
+        "/jobs/export": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Returns a JSON-encoded list of users.\nRequired query-parameter defines if all users or only users with additional special roles are returned.\nOnly accessible from IPs registered with apiAllowedIPs configuration option.",
+                "description": "Get all jobs matching the filters as CSV file, sorted by descending startTime.\nAccepts the same filters as the job list. Columns: jobId, user, project, cluster, state,\nstartTime, duration, numNodes and the footprint averages. Users only get their own jobs.",
                 "produces": [
-                    "application/json"
+                    "text/csv"
                 ],
                 "tags": [
-                    "User"
+                    "Job query"
                 ],
-                "summary": "Returns a list of users",
+                "summary": "Exports jobs as CSV",
                 "parameters": [
                     {
-                        "type": "boolean",
-                        "description": "If returned list should contain all users or only users with additional special roles",
-                        "name": "not-just-user",
-                        "in": "query",
-                        "required": true
+                        "enum": [
+                            "csv"
+                        ],
+                        "type": "string",
+                        "description": "Export format (Default: csv)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "running",
+                            "completed",
+                            "failed",
+                            "cancelled",
+                            "stopped",
+                            "timeout"
+                        ],
+                        "type": "string",
+                        "description": "Job State",
+                        "name": "state",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Job Cluster",
+                        "name": "cluster",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Syntax: '$from-$to', as unix epoch timestamps in seconds",
+                        "name": "start-time",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            0,
+                            1
+                        ],
+                        "type": "integer",
+                        "description": "SMT setting of job",
+                        "name": "smt",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of users returned successfully",
+                        "description": "CSV file",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/api.ApiReturnedUser"
-                            }
+                            "type": "string"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/api.ErrorResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/jobs/import": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "User specified in form data will be saved to database.\nOnly accessible from IPs registered with apiAllowedIPs configuration option.",
+                "description": "The request body is a tar file containing the 'meta.json' and 'data.json' of a job, as exported\nby '/jobs/{id}/archive.tar'. The job is written to the job archive and added to the database.",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/x-tar"
                 ],
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
-                    "User"
-                ],
-                "summary": "Adds a new user",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Unique user ID",
-                        "name": "username",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "User password",
-                        "name": "password",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "enum": [
-                            "admin",
-                            "support",
-                            "manager",
-                            "user",
-                            "api"
-                        ],
-                        "type": "string",
-                        "description": "User role",
-                        "name": "role",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Managed project, required for new manager role user",
-                        "name": "project",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Users name",
-                        "name": "name",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Users email",
-                        "name": "email",
-                        "in": "formData"
-                    }
+                    "Job add and modify"
                 ],
+                "summary": "Imports a job from a tar file",
                 "responses": {
-                    "200": {
-                        "description": "Success Response",
+                    "201": {
+                        "description": "Database ID of the imported job",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/api.StartJobApiResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity: creating user failed",
+                    "413": {
+                        "description": "Request Entity Too Large: The request body exceeds max-request-body-size",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "422": {
+                        "description": "Unprocessable Entity: The combination of jobId, clusterId and startTime does already exist",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/api.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/jobs/metrics/{id}/stream": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "User defined by username in form data will be deleted from database.\nOnly accessible from IPs registered with apiAllowedIPs configuration option.",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+                "description": "Job is specified by database ID. The response contains one 'JobMetricSeries' object per line (NDJSON)\nand is written while the data is still being loaded, so that large jobs do not have to be kept in memory.\nErrors that occur after the first line has been written are reported as a final line with an 'error' field.",
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Job query"
                 ],
-                "summary": "Deletes a user",
+                "summary": "Streams the metric data of a job",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "User ID to delete",
-                        "name": "username",
-                        "in": "formData",
+                        "type": "integer",
+                        "description": "Database ID of Job",
+                        "name": "id",
+                        "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Metrics to stream (default: all)",
+                        "name": "metric",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Scopes to stream (default: node)",
+                        "name": "scope",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User deleted successfully"
+                        "description": "One series per line",
+                        "schema": {
+                            "$ref": "#/definitions/api.JobMetricSeries"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
                     "422": {
-                        "description": "Unprocessable Entity: deleting user failed",
+                        "description": "Unprocessable Entity: finding job failed: sql: no rows in result set",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/api.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable: The rate limit of the metric data repository was exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
                         }
                     }
                 }
             }
-        }
+        },
+        "/jobs/monitoring": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get all jobs with the given monitoring status, e.g. jobs stuck in 'running or archiving' (1)\nafter a crash during archiving. Results are sorted by ascending startTime.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Job query"
+                ],
+                "summary": "Lists jobs by monitoring status",
+                "parameters": [
+                    {
+                        "enum": [
+                            0,
+                            1,
+                            2,
+                            3,
+                            4,
+                            5
+                        ],
+                        "type": "integer",
+                        "description": "Monitoring status",
+                        "name": "status",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Job Cluster",
+                        "name": "cluster",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Job array",
+                        "schema": {
+                            "$ref": "#/definitions/api.GetMonitoringStatusJobsApiResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/resume_job/": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Job to resume is specified by request body. Use this if a job was stopped in error.\nThe job state is set back to running and an already written job archive is removed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Job add and modify"
+                ],
+                "summary": "Marks a stopped job as running again",
+                "parameters": [
+                    {
+                        "description": "jobId, cluster and startTime of job",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.ResumeJobApiRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Job resource",
+                        "schema": {
+                            "$ref": "#/definitions/schema.JobMeta"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Resource not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large: The request body exceeds max-request-body-size",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity: finding job failed: sql: no rows in result set",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/running": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get all running jobs of a cluster together with the current averages of their metrics,\nloaded from the metric data repository. Footprints are cached for one minute.\nResults are sorted by ascending startTime.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Job query"
+                ],
+                "summary": "Lists running jobs with live footprints",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job Cluster",
+                        "name": "cluster",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Metrics of the footprint (Default: all metrics of the cluster)",
+                        "name": "metric",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Running jobs",
+                        "schema": {
+                            "$ref": "#/definitions/api.GetRunningJobsApiResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable: The rate limit of the metric data repository was exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/search": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get all jobs whose metadata (e.g. the jobScript) contains the given term, ignoring case.\nResults are sorted by descending startTime. Users that are not admin, support or api\nonly find their own jobs. Every search scans all jobs, as the metadata is not indexed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Job query"
+                ],
+                "summary": "Searches the metadata of jobs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search term",
+                        "name": "meta",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Job array",
+                        "schema": {
+                            "$ref": "#/definitions/api.SearchJobsApiResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/start_job/": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Job specified in request body will be saved to database as \"running\" with new DB ID.\nJob specifications follow the 'JobMeta' scheme, API will fail to execute if requirements are not met.\nIf 'subCluster' is given it is used as is, otherwise it is inferred from the hostname of the first resource.\nIf 'partition' is missing and the cluster config declares partitions, it is inferred from the hostnames.\nIf the job was already started, the config option 'duplicate-start-job-mode' decides whether 422 is returned (reject)\nor the metadata and tags of the request are added to the existing job (merge).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Job add and modify"
+                ],
+                "summary": "Adds a new job as \"running\"",
+                "parameters": [
+                    {
+                        "description": "Job to add",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.JobMeta"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Request merged into the already started job (merge mode)",
+                        "schema": {
+                            "$ref": "#/definitions/api.StartJobApiResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Job added successfully",
+                        "schema": {
+                            "$ref": "#/definitions/api.StartJobApiResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict: The tags of the request conflict with each other",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large: The request body exceeds max-request-body-size",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity: The combination of jobId, clusterId and startTime does already exist (code duplicate_job) or resources do not match the cluster topology",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests: The user already has the maximum number of running jobs",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable: The ingest-timeout expired while waiting for other requests",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/stop_job/": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Job to stop is specified by request body. All fields are required in this case.\nIf the job is unknown, the config option 'stop-job-mode' decides whether 404 is returned (strict)\nor a minimal job is created from the request and stopped (lenient).\nJob states that are neither builtin nor configured in 'job-states' are stored as \"unknown\".\nReturns full job resource information according to 'JobMeta' scheme.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Job add and modify"
+                ],
+                "summary": "Marks job as completed and triggers archiving",
+                "parameters": [
+                    {
+                        "description": "All fields required",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.StopJobApiRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success message",
+                        "schema": {
+                            "$ref": "#/definitions/schema.JobMeta"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Resource not found: job unknown (strict mode)",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large: The request body exceeds max-request-body-size",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity: finding job failed or job is not running",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/stop_job/{id}": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Job to stop is specified by database ID. Only stopTime and final state are required in request body.\nReturns full job resource information according to 'JobMeta' scheme.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Job add and modify"
+                ],
+                "summary": "Marks job as completed and triggers archiving",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Database ID of Job",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "stopTime and final state in request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.StopJobApiRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Job resource",
+                        "schema": {
+                            "$ref": "#/definitions/schema.JobMeta"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Resource not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large: The request body exceeds max-request-body-size",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity: finding job failed: sql: no rows in result set or job is not running",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/tag_job/{id}": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Adds tag(s) to a job specified by DB ID. Name and Type of Tag(s) can be chosen freely.\nIf tagged job is already finished: Tag will be written directly to respective archive files.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Job add and modify"
+                ],
+                "summary": "Adds one or more tags to a job",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Job Database ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Array of tag-objects to add",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/api.ApiTag"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated job resource",
+                        "schema": {
+                            "$ref": "#/definitions/schema.Job"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Job or tag does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Job to get is specified by database ID\nReturns full job resource information according to 'JobMeta' scheme and all metrics according to 'JobData'.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Job query"
+                ],
+                "summary": "Get job meta and optional all metric data",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Database ID of Job",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include all available metrics",
+                        "name": "all-metrics",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Job resource",
+                        "schema": {
+                            "$ref": "#/definitions/api.GetJobApiResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Resource not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity: finding job failed: sql: no rows in result set",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Job to get is specified by database ID\nReturns full job resource information according to 'JobMeta' scheme and all metrics according to 'JobData'.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Job query"
+                ],
+                "summary": "Get job meta and configurable metric data",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Database ID of Job",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Array of metric names",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Job resource",
+                        "schema": {
+                            "$ref": "#/definitions/api.GetJobApiResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Resource not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity: finding job failed: sql: no rows in result set",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}/archive.tar": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Job is specified by database ID. The response is a tar file containing the 'meta.json' and 'data.json'\nof the job as stored in the job archive, for example to reproduce a problem offline.",
+                "produces": [
+                    "application/x-tar"
+                ],
+                "tags": [
+                    "Job query"
+                ],
+                "summary": "Exports the archive of a job",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Database ID of Job",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tar file with meta.json and data.json",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict: Job is not archived yet",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity: finding job failed: sql: no rows in result set",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Job is specified by database ID. The request is forwarded to the scheduler by the cancel hook\nconfigured for the cluster of the job, e.g. a command running scancel. Only the owner of the job\nand admins may cancel it. The request is recorded in the metadata keys 'cancelRequestedBy'\nand 'cancelRequestedAt' of the job, the job itself is stopped by the scheduler as usual.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Job add and modify"
+                ],
+                "summary": "Cancel a running job",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Database ID of Job",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Cancel request forwarded",
+                        "schema": {
+                            "$ref": "#/definitions/api.CancelJobApiResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden: not the owner of the job",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict: job is not running",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity: finding job failed: sql: no rows in result set",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented: no cancel hook for the cluster",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway: cancel hook failed",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}/env": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Job is specified by database ID. Returns the environment variables stored in the metadata\nkey 'jobEnv' as NAME=VALUE pairs separated by newlines or NUL characters.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Job query"
+                ],
+                "summary": "Get the environment of a job",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Database ID of Job",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Job environment",
+                        "schema": {
+                            "$ref": "#/definitions/api.GetJobEnvApiResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Resource not found: job has no environment",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity: finding job failed: sql: no rows in result set",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}/monitoring_status": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Force-sets the monitoring status of the job with the given database id, e.g. to\nrecover a job stuck in 'running or archiving' (1). Only allowed for admins.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Job add and modify"
+                ],
+                "summary": "Sets the monitoring status of a job",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Database ID of Job",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New monitoring status",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.UpdateMonitoringStatusApiRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated job",
+                        "schema": {
+                            "$ref": "#/definitions/schema.JobMeta"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Job not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}/script": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Job is specified by database ID. Returns the job script stored in the metadata key 'jobScript'\nas plain text, without loading the rest of the metadata.",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Job query"
+                ],
+                "summary": "Get the job script of a job",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Database ID of Job",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Job script",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Resource not found: job has no script",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity: finding job failed: sql: no rows in result set",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}/stats": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Job is specified by database ID. Returns min/avg/max of every node for the requested metrics\nwithout loading the timeseries. Statistics of archived jobs are read from the job archive.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Job query"
+                ],
+                "summary": "Get the metric statistics of a job",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Database ID of Job",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated list of metrics (default: all)",
+                        "name": "metrics",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Job statistics",
+                        "schema": {
+                            "$ref": "#/definitions/api.GetJobStatsApiResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity: finding job failed: sql: no rows in result set",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns all tags visible to the user together with the number of jobs they are attached to,\nsorted by count descending. Users that are not admin or support only get counts over the jobs\nthey are allowed to see.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tag"
+                ],
+                "summary": "Lists tags with their usage counts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only return tags of this type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only return tags attached to at least this many jobs",
+                        "name": "minCount",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tags with counts",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/api.TagCountApiResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/{id}": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Modifies user defined by username (id) in one of four possible ways.\nIf more than one formValue is set then only the highest priority field is used.\nOnly accessible from IPs registered with apiAllowedIPs configuration option.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Updates an existing user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Database ID of User",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "admin",
+                            "support",
+                            "manager",
+                            "user",
+                            "api"
+                        ],
+                        "type": "string",
+                        "description": "Priority 1: Role to add",
+                        "name": "add-role",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "admin",
+                            "support",
+                            "manager",
+                            "user",
+                            "api"
+                        ],
+                        "type": "string",
+                        "description": "Priority 2: Role to remove",
+                        "name": "remove-role",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Priority 3: Project to add",
+                        "name": "add-project",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Priority 4: Project to remove",
+                        "name": "remove-project",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Response Message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity: The user could not be updated",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns a JSON-encoded list of users.\nRequired query-parameter defines if all users or only users with additional special roles are returned.\nOnly accessible from IPs registered with apiAllowedIPs configuration option.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Returns a list of users",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "If returned list should contain all users or only users with additional special roles",
+                        "name": "not-just-user",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of users returned successfully",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/api.ApiReturnedUser"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "User specified in form data will be saved to database.\nOnly accessible from IPs registered with apiAllowedIPs configuration option.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Adds a new user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique user ID",
+                        "name": "username",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User password",
+                        "name": "password",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "admin",
+                            "support",
+                            "manager",
+                            "user",
+                            "api"
+                        ],
+                        "type": "string",
+                        "description": "User role",
+                        "name": "role",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Managed project, required for new manager role user",
+                        "name": "project",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Users name",
+                        "name": "name",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Users email",
+                        "name": "email",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success Response",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity: creating user failed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "User defined by username in form data will be deleted from database.\nOnly accessible from IPs registered with apiAllowedIPs configuration option.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Deletes a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID to delete",
+                        "name": "username",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User deleted successfully"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity: deleting user failed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        }
     },
     "definitions": {
         "api.ApiReturnedUser": {
             "type": "object",
             "properties": {
-                "email": {
-                    "type": "string"
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "projects": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.ApiTag": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "description": "Tag Name",
+                    "type": "string",
+                    "example": "Testjob"
+                },
+                "scope": {
+                    "description": "Tag Scope: 'global' (default) or username of owner",
+                    "type": "string",
+                    "example": "global"
+                },
+                "type": {
+                    "description": "Tag Type",
+                    "type": "string",
+                    "example": "Debug"
+                }
+            }
+        },
+        "api.ArchivingApiResponse": {
+            "type": "object",
+            "properties": {
+                "cancelled": {
+                    "description": "True if cancelled, but not finished yet",
+                    "type": "boolean"
+                },
+                "cluster": {
+                    "description": "Cluster of the job",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Database ID of the job",
+                    "type": "integer"
+                },
+                "jobId": {
+                    "description": "Job ID of the scheduler",
+                    "type": "integer"
+                },
+                "phase": {
+                    "description": "One of queued, loading (metric data) or updating (database)",
+                    "type": "string",
+                    "example": "loading"
+                },
+                "queuedAt": {
+                    "description": "Unix timestamp of the archiving request",
+                    "type": "integer"
+                },
+                "startedAt": {
+                    "description": "Unix timestamp of the start of archiving, missing while queued",
+                    "type": "integer"
+                }
+            }
+        },
+        "api.CancelArchivingApiResponse": {
+            "type": "object",
+            "properties": {
+                "msg": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.CancelJobApiResponse": {
+            "type": "object",
+            "properties": {
+                "msg": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.ClusterApiResponse": {
+            "type": "object",
+            "properties": {
+                "filterRanges": {
+                    "description": "Filter ranges from the program config",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schema.FilterRanges"
+                        }
+                    ]
+                },
+                "metricConfig": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.MetricConfig"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "subClusters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.SubCluster"
+                    }
+                }
+            }
+        },
+        "api.DeleteJobApiRequest": {
+            "type": "object",
+            "required": [
+                "jobId"
+            ],
+            "properties": {
+                "cluster": {
+                    "description": "Cluster of job",
+                    "type": "string",
+                    "example": "fritz"
+                },
+                "jobId": {
+                    "description": "Cluster Job ID of job",
+                    "type": "integer",
+                    "example": 123000
+                },
+                "startTime": {
+                    "description": "Start Time of job as epoch",
+                    "type": "integer",
+                    "example": 1649723812
+                }
+            }
+        },
+        "api.DeleteJobApiResponse": {
+            "type": "object",
+            "properties": {
+                "dryRun": {
+                    "description": "True if nothing was actually deleted",
+                    "type": "boolean"
+                },
+                "jobIds": {
+                    "description": "Database IDs of the (would-be) deleted jobs",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "msg": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.EditMetaRequest": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "type": "string",
+                    "example": "jobScript"
+                },
+                "value": {
+                    "type": "string",
+                    "example": "bash script"
+                }
+            }
+        },
+        "api.EditOwnershipRequest": {
+            "type": "object",
+            "properties": {
+                "project": {
+                    "description": "New project of the job, unchanged if empty",
+                    "type": "string",
+                    "example": "abcd"
+                },
+                "user": {
+                    "description": "New user of the job, unchanged if empty",
+                    "type": "string",
+                    "example": "abcd100h"
+                }
+            }
+        },
+        "api.ErrorDetails": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Machine-readable error code, derived from the HTTP status if not more specific",
+                    "type": "string",
+                    "example": "duplicate_job"
+                },
+                "message": {
+                    "description": "Error message",
+                    "type": "string"
+                }
+            }
+        },
+        "api.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/api.ErrorDetails"
+                }
+            }
+        },
+        "api.GetClustersApiResponse": {
+            "type": "object",
+            "properties": {
+                "clusters": {
+                    "description": "Array of clusters",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.ClusterApiResponse"
+                    }
+                }
+            }
+        },
+        "api.GetJobApiResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.JobMetricWithName"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/schema.Job"
+                }
+            }
+        },
+        "api.GetJobEnvApiResponse": {
+            "type": "object",
+            "properties": {
+                "env": {
+                    "description": "Environment variables of the job",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "description": "Database ID of the job",
+                    "type": "integer"
+                }
+            }
+        },
+        "api.GetJobStatsApiResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "Database ID of the job",
+                    "type": "integer"
+                },
+                "stats": {
+                    "description": "Statistics as map metric -\u003e hostname -\u003e min/avg/max",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "object",
+                        "additionalProperties": {
+                            "$ref": "#/definitions/schema.MetricStatistics"
+                        }
+                    }
+                }
+            }
+        },
+        "api.GetJobsApiResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "description": "Number of jobs returned",
+                    "type": "integer"
+                },
+                "jobs": {
+                    "description": "Array of jobs",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.JobMeta"
+                    }
+                },
+                "page": {
+                    "description": "Page id returned",
+                    "type": "integer"
+                }
+            }
+        },
+        "api.GetMonitoringStatusJobsApiResponse": {
+            "type": "object",
+            "properties": {
+                "jobs": {
+                    "description": "Array of jobs",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.JobMeta"
+                    }
+                },
+                "monitoringStatus": {
+                    "description": "Requested monitoring status",
+                    "type": "integer"
+                }
+            }
+        },
+        "api.GetRunningJobsApiResponse": {
+            "type": "object",
+            "properties": {
+                "cluster": {
+                    "description": "Requested cluster",
+                    "type": "string",
+                    "example": "fritz"
+                },
+                "jobs": {
+                    "description": "Array of running jobs",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.RunningJobApiResponse"
+                    }
+                }
+            }
+        },
+        "api.GrafanaAnnotation": {
+            "type": "object",
+            "properties": {
+                "datasource": {
+                    "description": "Name of the datasource",
+                    "type": "string",
+                    "example": "cc"
+                },
+                "enable": {
+                    "description": "Annotation enabled",
+                    "type": "boolean",
+                    "example": true
+                },
+                "iconColor": {
+                    "description": "Color of the annotation",
+                    "type": "string",
+                    "example": "green"
                 },
                 "name": {
-                    "type": "string"
+                    "description": "Name of the annotation",
+                    "type": "string",
+                    "example": "Jobs"
                 },
-                "projects": {
+                "query": {
+                    "description": "Cluster to show job events for (optional)",
+                    "type": "string",
+                    "example": "fritz"
+                }
+            }
+        },
+        "api.GrafanaAnnotationRequest": {
+            "type": "object",
+            "properties": {
+                "annotation": {
+                    "description": "Requested annotation",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/api.GrafanaAnnotation"
+                        }
+                    ]
+                },
+                "range": {
+                    "description": "Requested time window",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/api.GrafanaTimeRange"
+                        }
+                    ]
+                }
+            }
+        },
+        "api.GrafanaAnnotationResponse": {
+            "type": "object",
+            "properties": {
+                "annotation": {
+                    "description": "Requested annotation",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/api.GrafanaAnnotation"
+                        }
+                    ]
+                },
+                "tags": {
+                    "description": "Tags of the event",
                     "type": "array",
                     "items": {
                         "type": "string"
+                    },
+                    "example": [
+                        "fritz",
+                        "started"
+                    ]
+                },
+                "text": {
+                    "description": "Description of the event",
+                    "type": "string",
+                    "example": "user abcd100h"
+                },
+                "time": {
+                    "description": "Time of the event in ms",
+                    "type": "integer",
+                    "example": 1675957496000
+                },
+                "title": {
+                    "description": "Title of the event",
+                    "type": "string",
+                    "example": "Job 123 started"
+                }
+            }
+        },
+        "api.GrafanaQueryRequest": {
+            "type": "object",
+            "properties": {
+                "range": {
+                    "description": "Requested time window",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/api.GrafanaTimeRange"
+                        }
+                    ]
+                },
+                "targets": {
+                    "description": "Requested targets",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.GrafanaTarget"
+                    }
+                }
+            }
+        },
+        "api.GrafanaSearchRequest": {
+            "type": "object",
+            "properties": {
+                "target": {
+                    "description": "Part of a target name",
+                    "type": "string",
+                    "example": "node:fritz"
+                }
+            }
+        },
+        "api.GrafanaTarget": {
+            "type": "object",
+            "properties": {
+                "refId": {
+                    "description": "Grafana query reference",
+                    "type": "string",
+                    "example": "A"
+                },
+                "target": {
+                    "description": "Target as returned by search",
+                    "type": "string",
+                    "example": "job:123:flops_any"
+                },
+                "type": {
+                    "description": "Only 'timeserie' is supported",
+                    "type": "string",
+                    "example": "timeserie"
+                }
+            }
+        },
+        "api.GrafanaTimeRange": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "description": "Start of the requested time window",
+                    "type": "string",
+                    "example": "2023-02-09T15:44:56Z"
+                },
+                "to": {
+                    "description": "End of the requested time window",
+                    "type": "string",
+                    "example": "2023-02-09T21:44:56Z"
+                }
+            }
+        },
+        "api.GrafanaTimeSeries": {
+            "type": "object",
+            "properties": {
+                "datapoints": {
+                    "description": "Array of [value, timestamp in ms]",
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "number"
+                        }
+                    }
+                },
+                "target": {
+                    "description": "Name of the series",
+                    "type": "string",
+                    "example": "f0101:flops_any"
+                }
+            }
+        },
+        "api.HealthApiResponse": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "description": "Results of the single checks",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.HealthCheckResult"
+                    }
+                },
+                "healthy": {
+                    "description": "False if any of the checked dependencies is down",
+                    "type": "boolean"
+                }
+            }
+        },
+        "api.HealthCheckResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "description": "Reason if not healthy",
+                    "type": "string"
+                },
+                "healthy": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "description": "Checked dependency: 'database' or 'metricdata/\u003ccluster\u003e'",
+                    "type": "string",
+                    "example": "metricdata/fritz"
+                }
+            }
+        },
+        "api.JobMetricSeries": {
+            "type": "object",
+            "properties": {
+                "metric": {
+                    "description": "Metric name",
+                    "type": "string",
+                    "example": "flops_any"
+                },
+                "scope": {
+                    "description": "Metric scope of the series",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schema.MetricScope"
+                        }
+                    ],
+                    "example": "node"
+                },
+                "series": {
+                    "description": "Series data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schema.Series"
+                        }
+                    ]
+                }
+            }
+        },
+        "api.JobMetricWithName": {
+            "type": "object",
+            "properties": {
+                "metric": {
+                    "$ref": "#/definitions/schema.JobMetric"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "scope": {
+                    "$ref": "#/definitions/schema.MetricScope"
+                }
+            }
+        },
+        "api.ReindexApiResponse": {
+            "type": "object",
+            "properties": {
+                "analyzeMs": {
+                    "description": "Duration of ANALYZE in milliseconds",
+                    "type": "integer",
+                    "example": 40
+                },
+                "recreateMs": {
+                    "description": "Duration of recreating the indexes in milliseconds",
+                    "type": "integer",
+                    "example": 120
+                },
+                "recreated": {
+                    "description": "True if the job indexes were recreated",
+                    "type": "boolean"
+                },
+                "reindexMs": {
+                    "description": "Duration of REINDEX in milliseconds",
+                    "type": "integer",
+                    "example": 80
+                },
+                "totalMs": {
+                    "description": "Total duration in milliseconds",
+                    "type": "integer",
+                    "example": 240
+                }
+            }
+        },
+        "api.ResumeJobApiRequest": {
+            "type": "object",
+            "required": [
+                "jobId"
+            ],
+            "properties": {
+                "cluster": {
+                    "description": "Cluster of job",
+                    "type": "string",
+                    "example": "fritz"
+                },
+                "jobId": {
+                    "description": "Cluster Job ID of job",
+                    "type": "integer",
+                    "example": 123000
+                },
+                "startTime": {
+                    "description": "Start Time of job as epoch",
+                    "type": "integer",
+                    "example": 1649723812
+                }
+            }
+        },
+        "api.RunningJobApiResponse": {
+            "type": "object",
+            "properties": {
+                "arrayJobId": {
+                    "description": "The unique identifier of an array job",
+                    "type": "integer",
+                    "example": 123000
+                },
+                "cluster": {
+                    "description": "The unique identifier of a cluster",
+                    "type": "string",
+                    "example": "fritz"
+                },
+                "concurrentJobs": {
+                    "$ref": "#/definitions/schema.JobLinkResultList"
+                },
+                "duration": {
+                    "description": "Duration of job in seconds (Min \u003e 0)",
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 43200
+                },
+                "exclusive": {
+                    "description": "Specifies how nodes are shared: 0 - Shared among multiple jobs of multiple users, 1 - Job exclusive (Default), 2 - Shared among multiple jobs of same user",
+                    "type": "integer",
+                    "maximum": 2,
+                    "minimum": 0,
+                    "example": 1
+                },
+                "footprint": {
+                    "description": "Live average of each metric, summed over the nodes of the job",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
                     }
                 },
-                "roles": {
-                    "type": "array",
-                    "items": {
+                "id": {
+                    "description": "The unique identifier of a job in the database",
+                    "type": "integer"
+                },
+                "jobId": {
+                    "description": "The unique identifier of a job",
+                    "type": "integer",
+                    "example": 123000
+                },
+                "jobState": {
+                    "description": "Final state of job",
+                    "enum": [
+                        "completed",
+                        "failed",
+                        "cancelled",
+                        "stopped",
+                        "timeout",
+                        "out_of_memory"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schema.JobState"
+                        }
+                    ],
+                    "example": "completed"
+                },
+                "metaData": {
+                    "description": "Additional information about the job",
+                    "type": "object",
+                    "additionalProperties": {
                         "type": "string"
                     }
                 },
-                "username": {
-                    "type": "string"
-                }
-            }
-        },
-        "api.ApiTag": {
-            "type": "object",
-            "properties": {
-                "name": {
-                    "description": "Tag Name",
-                    "type": "string",
-                    "example": "Testjob"
+                "monitoringStatus": {
+                    "description": "State of monitoring system during job run: 0 - Disabled, 1 - Running or Archiving (Default), 2 - Archiving Failed, 3 - Archiving Successfull, 4 - Archived and downsampled, 5 - Archived with statistics only",
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 0,
+                    "example": 1
                 },
-                "type": {
-                    "description": "Tag Type",
-                    "type": "string",
-                    "example": "Debug"
-                }
-            }
-        },
-        "api.DeleteJobApiRequest": {
-            "type": "object",
-            "required": [
-                "jobId"
-            ],
-            "properties": {
-                "cluster": {
-                    "description": "Cluster of job",
-                    "type": "string",
-                    "example": "fritz"
+                "numAcc": {
+                    "description": "Number of accelerators used (Min \u003e 0)",
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 2
                 },
-                "jobId": {
-                    "description": "Cluster Job ID of job",
+                "numHwthreads": {
+                    "description": "NumCores         int32             ` + "`" + `json:\"numCores\" db:\"num_cores\" example:\"20\" minimum:\"1\"` + "`" + `                                                             // Number of HWThreads used (Min \u003e 0)",
                     "type": "integer",
-                    "example": 123000
+                    "minimum": 1,
+                    "example": 20
                 },
-                "startTime": {
-                    "description": "Start Time of job as epoch",
+                "numNodes": {
+                    "description": "Number of nodes used (Min \u003e 0)",
                     "type": "integer",
-                    "example": 1649723812
-                }
-            }
-        },
-        "api.DeleteJobApiResponse": {
-            "type": "object",
-            "properties": {
-                "msg": {
-                    "type": "string"
-                }
-            }
-        },
-        "api.EditMetaRequest": {
-            "type": "object",
-            "properties": {
-                "key": {
+                    "minimum": 1,
+                    "example": 2
+                },
+                "partition": {
+                    "description": "The Slurm partition to which the job was submitted",
                     "type": "string",
-                    "example": "jobScript"
+                    "example": "main"
                 },
-                "value": {
+                "project": {
+                    "description": "The unique identifier of a project",
                     "type": "string",
-                    "example": "bash script"
-                }
-            }
-        },
-        "api.ErrorResponse": {
-            "type": "object",
-            "properties": {
-                "error": {
-                    "description": "Error Message",
-                    "type": "string"
+                    "example": "abcd200"
                 },
-                "status": {
-                    "description": "Statustext of Errorcode",
-                    "type": "string"
-                }
-            }
-        },
-        "api.GetClustersApiResponse": {
-            "type": "object",
-            "properties": {
-                "clusters": {
-                    "description": "Array of clusters",
+                "resources": {
+                    "description": "Resources used by job",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/schema.Cluster"
+                        "$ref": "#/definitions/schema.Resource"
                     }
-                }
-            }
-        },
-        "api.GetJobApiResponse": {
-            "type": "object",
-            "properties": {
-                "data": {
+                },
+                "smt": {
+                    "description": "SMT threads used by job",
+                    "type": "integer",
+                    "example": 4
+                },
+                "startTime": {
+                    "description": "Start epoch time stamp in seconds (Min \u003e 0)",
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1649723812
+                },
+                "statistics": {
+                    "description": "Metric statistics of job",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/schema.JobStatistics"
+                    }
+                },
+                "subCluster": {
+                    "description": "The unique identifier of a sub cluster",
+                    "type": "string",
+                    "example": "main"
+                },
+                "tags": {
+                    "description": "List of tags",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/api.JobMetricWithName"
+                        "$ref": "#/definitions/schema.Tag"
                     }
                 },
-                "meta": {
-                    "$ref": "#/definitions/schema.Job"
+                "user": {
+                    "description": "The unique identifier of a user",
+                    "type": "string",
+                    "example": "abcd100h"
+                },
+                "walltime": {
+                    "description": "Requested walltime of job in seconds (Min \u003e 0)",
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 86400
                 }
             }
         },
-        "api.GetJobsApiResponse": {
+        "api.SearchJobsApiResponse": {
             "type": "object",
             "properties": {
                 "items": {
@@ -1387,23 +3682,10 @@ const docTemplate = `{
                         "$ref": "#/definitions/schema.JobMeta"
                     }
                 },
-                "page": {
-                    "description": "Page id returned",
-                    "type": "integer"
-                }
-            }
-        },
-        "api.JobMetricWithName": {
-            "type": "object",
-            "properties": {
-                "metric": {
-                    "$ref": "#/definitions/schema.JobMetric"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "scope": {
-                    "$ref": "#/definitions/schema.MetricScope"
+                "term": {
+                    "description": "Searched term",
+                    "type": "string",
+                    "example": "module load"
                 }
             }
         },
@@ -1448,12 +3730,56 @@ const docTemplate = `{
                     "example": 1649723812
                 },
                 "stopTime": {
-                    "description": "Stop Time of job as epoch",
+                    "description": "Stop Time of job as epoch, if not after the start time the duration is set to 0",
                     "type": "integer",
                     "example": 1649763839
                 }
             }
         },
+        "api.TagCountApiResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "Number of jobs with this tag",
+                    "type": "integer",
+                    "example": 42
+                },
+                "id": {
+                    "description": "The unique DB identifier of a tag",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "Tag Name",
+                    "type": "string",
+                    "example": "Testjob"
+                },
+                "scope": {
+                    "description": "Tag Scope: 'global' or username of owner",
+                    "type": "string",
+                    "example": "global"
+                },
+                "type": {
+                    "description": "Tag Type",
+                    "type": "string",
+                    "example": "Debug"
+                }
+            }
+        },
+        "api.UpdateMonitoringStatusApiRequest": {
+            "type": "object",
+            "required": [
+                "monitoringStatus"
+            ],
+            "properties": {
+                "monitoringStatus": {
+                    "description": "New monitoring status",
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 0,
+                    "example": 2
+                }
+            }
+        },
         "schema.Accelerator": {
             "type": "object",
             "properties": {
@@ -1468,23 +3794,28 @@ const docTemplate = `{
                 }
             }
         },
-        "schema.Cluster": {
+        "schema.FilterRanges": {
             "type": "object",
             "properties": {
-                "metricConfig": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/schema.MetricConfig"
-                    }
+                "duration": {
+                    "$ref": "#/definitions/schema.IntRange"
                 },
-                "name": {
-                    "type": "string"
+                "numNodes": {
+                    "$ref": "#/definitions/schema.IntRange"
                 },
-                "subClusters": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/schema.SubCluster"
-                    }
+                "startTime": {
+                    "$ref": "#/definitions/schema.TimeRange"
+                }
+            }
+        },
+        "schema.IntRange": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "integer"
+                },
+                "to": {
+                    "type": "integer"
                 }
             }
         },
@@ -1505,6 +3836,11 @@ const docTemplate = `{
                 "concurrentJobs": {
                     "$ref": "#/definitions/schema.JobLinkResultList"
                 },
+                "dataCompleteness": {
+                    "description": "Fraction of the expected metric values of the job that are not missing (NaN), set on archiving",
+                    "type": "number",
+                    "example": 0.98
+                },
                 "duration": {
                     "description": "Duration of job in seconds (Min \u003e 0)",
                     "type": "integer",
@@ -1568,9 +3904,9 @@ const docTemplate = `{
                     }
                 },
                 "monitoringStatus": {
-                    "description": "State of monitoring system during job run: 0 - Disabled, 1 - Running or Archiving (Default), 2 - Archiving Failed, 3 - Archiving Successfull",
+                    "description": "State of monitoring system during job run: 0 - Disabled, 1 - Running or Archiving (Default), 2 - Archiving Failed, 3 - Archiving Successfull, 4 - Archived and downsampled, 5 - Archived with statistics only",
                     "type": "integer",
-                    "maximum": 3,
+                    "maximum": 5,
                     "minimum": 0,
                     "example": 1
                 },
@@ -1732,9 +4068,9 @@ const docTemplate = `{
                     }
                 },
                 "monitoringStatus": {
-                    "description": "State of monitoring system during job run: 0 - Disabled, 1 - Running or Archiving (Default), 2 - Archiving Failed, 3 - Archiving Successfull",
+                    "description": "State of monitoring system during job run: 0 - Disabled, 1 - Running or Archiving (Default), 2 - Archiving Failed, 3 - Archiving Successfull, 4 - Archived and downsampled, 5 - Archived with statistics only",
                     "type": "integer",
-                    "maximum": 3,
+                    "maximum": 5,
                     "minimum": 0,
                     "example": 1
                 },
@@ -1846,7 +4182,12 @@ const docTemplate = `{
                 "stopped",
                 "timeout",
                 "preempted",
-                "out_of_memory"
+                "out_of_memory",
+                "suspended",
+                "node_fail",
+                "boot_fail",
+                "deadline",
+                "unknown"
             ],
             "x-enum-varnames": [
                 "JobStateRunning",
@@ -1856,7 +4197,12 @@ const docTemplate = `{
                 "JobStateStopped",
                 "JobStateTimeout",
                 "JobStatePreempted",
-                "JobStateOutOfMemory"
+                "JobStateOutOfMemory",
+                "JobStateSuspended",
+                "JobStateNodeFail",
+                "JobStateBootFail",
+                "JobStateDeadline",
+                "JobStateUnknown"
             ]
         },
         "schema.JobStatistics": {
@@ -2013,6 +4359,19 @@ const docTemplate = `{
                 "id": {
                     "type": "string"
                 },
+                "max": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "min": {
+                    "description": "Minimum and maximum of the data points averaged into each value of\nData by downsampling, nil if the series is not downsampled.",
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
                 "statistics": {
                     "$ref": "#/definitions/schema.MetricStatistics"
                 }
@@ -2121,6 +4480,11 @@ const docTemplate = `{
                     "type": "string",
                     "example": "Testjob"
                 },
+                "scope": {
+                    "description": "Tag Scope: 'global' or username of owner",
+                    "type": "string",
+                    "example": "global"
+                },
                 "type": {
                     "description": "Tag Type",
                     "type": "string",
@@ -2128,6 +4492,17 @@ const docTemplate = `{
                 }
             }
         },
+        "schema.TimeRange": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
         "schema.Topology": {
             "type": "object",
             "properties": {
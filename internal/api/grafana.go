@@ -0,0 +1,315 @@
+// Copyright (C) 2023 NHR@FAU, University Erlangen-Nuremberg.
+// All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ClusterCockpit/cc-backend/internal/metricdata"
+	"github.com/ClusterCockpit/cc-backend/internal/repository"
+	"github.com/ClusterCockpit/cc-backend/pkg/archive"
+	"github.com/ClusterCockpit/cc-backend/pkg/schema"
+)
+
+// Endpoints for the Grafana SimpleJson datasource. Supported targets are
+//
+//	node:<cluster>:<metric>[:<hostname>]   node data of all (or one) nodes of a cluster
+//	job:<id>:<metric>                      data of a job specified by its database ID
+//
+// Datapoints are returned as [value, unix timestamp in milliseconds].
+
+// GrafanaTimeRange model
+type GrafanaTimeRange struct {
+	From time.Time `json:"from" example:"2023-02-09T15:44:56Z"` // Start of the requested time window
+	To   time.Time `json:"to" example:"2023-02-09T21:44:56Z"`   // End of the requested time window
+}
+
+// GrafanaSearchRequest model
+type GrafanaSearchRequest struct {
+	Target string `json:"target" example:"node:fritz"` // Part of a target name
+}
+
+// GrafanaTarget model
+type GrafanaTarget struct {
+	Target string `json:"target" example:"job:123:flops_any"` // Target as returned by search
+	RefId  string `json:"refId" example:"A"`                  // Grafana query reference
+	Type   string `json:"type" example:"timeserie"`           // Only 'timeserie' is supported
+}
+
+// GrafanaQueryRequest model
+type GrafanaQueryRequest struct {
+	Range   GrafanaTimeRange `json:"range"`   // Requested time window
+	Targets []GrafanaTarget  `json:"targets"` // Requested targets
+}
+
+// GrafanaTimeSeries model
+type GrafanaTimeSeries struct {
+	Target     string           `json:"target" example:"f0101:flops_any"` // Name of the series
+	Datapoints [][]schema.Float `json:"datapoints"`                       // Array of [value, timestamp in ms]
+}
+
+// GrafanaAnnotation model
+type GrafanaAnnotation struct {
+	Name       string `json:"name" example:"Jobs"`       // Name of the annotation
+	Datasource string `json:"datasource" example:"cc"`   // Name of the datasource
+	Enable     bool   `json:"enable" example:"true"`     // Annotation enabled
+	Query      string `json:"query" example:"fritz"`     // Cluster to show job events for (optional)
+	IconColor  string `json:"iconColor" example:"green"` // Color of the annotation
+}
+
+// GrafanaAnnotationRequest model
+type GrafanaAnnotationRequest struct {
+	Range      GrafanaTimeRange  `json:"range"`      // Requested time window
+	Annotation GrafanaAnnotation `json:"annotation"` // Requested annotation
+}
+
+// GrafanaAnnotationResponse model
+type GrafanaAnnotationResponse struct {
+	Annotation GrafanaAnnotation `json:"annotation"`                      // Requested annotation
+	Time       int64             `json:"time" example:"1675957496000"`    // Time of the event in ms
+	Title      string            `json:"title" example:"Job 123 started"` // Title of the event
+	Text       string            `json:"text" example:"user abcd100h"`    // Description of the event
+	Tags       []string          `json:"tags" example:"fritz,started"`    // Tags of the event
+}
+
+// grafanaTestConnection godoc
+// @summary     Test the Grafana datasource connection
+// @tags Grafana
+// @description Always returns 200 OK, used by Grafana to test the datasource.
+// @success     200
+// @security    ApiKeyAuth
+// @router      /grafana/ [get]
+func (api *RestApi) grafanaTestConnection(rw http.ResponseWriter, r *http.Request) {
+	rw.WriteHeader(http.StatusOK)
+}
+
+// grafanaSearch godoc
+// @summary     Lists available Grafana targets
+// @tags Grafana
+// @description Returns all node targets ('node:<cluster>:<metric>') containing the requested string.
+// @description Job targets ('job:<id>:<metric>') are not listed but can be used in queries.
+// @accept      json
+// @produce     json
+// @param       request body     api.GrafanaSearchRequest true "Search string"
+// @success     200     {array}  string                       "Targets"
+// @failure     400     {object} api.ErrorResponse            "Bad Request"
+// @failure     401     {object} api.ErrorResponse            "Unauthorized"
+// @failure     403     {object} api.ErrorResponse            "Forbidden"
+// @security    ApiKeyAuth
+// @router      /grafana/search [post]
+func (api *RestApi) grafanaSearch(rw http.ResponseWriter, r *http.Request) {
+	if user := repository.GetUserFromContext(r.Context()); user != nil &&
+		!user.HasRole(schema.RoleApi) {
+
+		handleError(fmt.Errorf("missing role: %v", schema.GetRoleString(schema.RoleApi)), http.StatusForbidden, rw)
+		return
+	}
+
+	req := GrafanaSearchRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleError(fmt.Errorf("parsing request body failed: %w", err), http.StatusBadRequest, rw)
+		return
+	}
+
+	targets := make([]string, 0)
+	for _, cluster := range archive.GetClusters() {
+		for _, mc := range cluster.MetricConfig {
+			target := fmt.Sprintf("node:%s:%s", cluster.Name, mc.Name)
+			if strings.Contains(target, req.Target) {
+				targets = append(targets, target)
+			}
+		}
+	}
+
+	rw.Header().Add("Content-Type", "application/json")
+	json.NewEncoder(rw).Encode(targets)
+}
+
+// grafanaQuery godoc
+// @summary     Returns timeseries for Grafana targets
+// @tags Grafana
+// @description Loads the metric data of all requested targets in the requested time window.
+// @description Job targets always return the complete job.
+// @accept      json
+// @produce     json
+// @param       request body     api.GrafanaQueryRequest true "Targets and time window"
+// @success     200     {array}  api.GrafanaTimeSeries         "Timeseries"
+// @failure     400     {object} api.ErrorResponse             "Bad Request"
+// @failure     401     {object} api.ErrorResponse             "Unauthorized"
+// @failure     403     {object} api.ErrorResponse             "Forbidden"
+// @failure     500     {object} api.ErrorResponse             "Internal Server Error"
+// @security    ApiKeyAuth
+// @router      /grafana/query [post]
+func (api *RestApi) grafanaQuery(rw http.ResponseWriter, r *http.Request) {
+	if user := repository.GetUserFromContext(r.Context()); user != nil &&
+		!user.HasRole(schema.RoleApi) {
+
+		handleError(fmt.Errorf("missing role: %v", schema.GetRoleString(schema.RoleApi)), http.StatusForbidden, rw)
+		return
+	}
+
+	req := GrafanaQueryRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleError(fmt.Errorf("parsing request body failed: %w", err), http.StatusBadRequest, rw)
+		return
+	}
+
+	result := make([]GrafanaTimeSeries, 0, len(req.Targets))
+	for _, target := range req.Targets {
+		parts := strings.Split(target.Target, ":")
+		var series []GrafanaTimeSeries
+		var err error
+
+		switch {
+		case len(parts) >= 3 && len(parts) <= 4 && parts[0] == "node":
+			var nodes []string
+			if len(parts) == 4 {
+				nodes = []string{parts[3]}
+			}
+			series, err = grafanaNodeSeries(r, parts[1], parts[2], nodes, req.Range)
+		case len(parts) == 3 && parts[0] == "job":
+			series, err = api.grafanaJobSeries(r, parts[1], parts[2])
+		default:
+			handleError(fmt.Errorf("invalid target: %#v", target.Target), http.StatusBadRequest, rw)
+			return
+		}
+
+		if err != nil {
+			handleError(fmt.Errorf("loading data for target %#v failed: %w", target.Target, err), http.StatusInternalServerError, rw)
+			return
+		}
+		result = append(result, series...)
+	}
+
+	rw.Header().Add("Content-Type", "application/json")
+	json.NewEncoder(rw).Encode(result)
+}
+
+func grafanaNodeSeries(r *http.Request, cluster, metric string, nodes []string, tr GrafanaTimeRange) ([]GrafanaTimeSeries, error) {
+	data, err := metricdata.LoadNodeData(repository.GetUserFromContext(r.Context()), cluster, []string{metric}, nodes,
+		[]schema.MetricScope{schema.MetricScopeNode}, tr.From, tr.To, r.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	series := make([]GrafanaTimeSeries, 0, len(data))
+	for host, metrics := range data {
+		for _, jm := range metrics[metric] {
+			for _, s := range jm.Series {
+				series = append(series, GrafanaTimeSeries{
+					Target:     fmt.Sprintf("%s:%s", host, metric),
+					Datapoints: grafanaDatapoints(s.Data, tr.From.Unix(), jm.Timestep),
+				})
+			}
+		}
+	}
+
+	return series, nil
+}
+
+func (api *RestApi) grafanaJobSeries(r *http.Request, id, metric string) ([]GrafanaTimeSeries, error) {
+	job, err := api.Resolver.Query().Job(r.Context(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := metricdata.LoadData(job, []string{metric}, []schema.MetricScope{schema.MetricScopeNode}, r.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	series := make([]GrafanaTimeSeries, 0)
+	if jm, ok := data[metric][schema.MetricScopeNode]; ok {
+		for _, s := range jm.Series {
+			series = append(series, GrafanaTimeSeries{
+				Target:     fmt.Sprintf("%s:%s", s.Hostname, metric),
+				Datapoints: grafanaDatapoints(s.Data, job.StartTime.Unix(), jm.Timestep),
+			})
+		}
+	}
+
+	return series, nil
+}
+
+// grafanaDatapoints converts a series starting at start (seconds) into
+// Grafana datapoints. NaN values are encoded as null.
+func grafanaDatapoints(data []schema.Float, start int64, timestep int) [][]schema.Float {
+	datapoints := make([][]schema.Float, 0, len(data))
+	for i, v := range data {
+		ts := (start + int64(i*timestep)) * 1000
+		datapoints = append(datapoints, []schema.Float{v, schema.Float(ts)})
+	}
+	return datapoints
+}
+
+// grafanaAnnotations godoc
+// @summary     Returns job start and stop events as Grafana annotations
+// @tags Grafana
+// @description Returns an annotation for every job started or stopped in the requested time window.
+// @description If the annotation query is set, only jobs of the cluster with that name are considered.
+// @accept      json
+// @produce     json
+// @param       request body     api.GrafanaAnnotationRequest true "Annotation and time window"
+// @success     200     {array}  api.GrafanaAnnotationResponse     "Job events"
+// @failure     400     {object} api.ErrorResponse                 "Bad Request"
+// @failure     401     {object} api.ErrorResponse                 "Unauthorized"
+// @failure     403     {object} api.ErrorResponse                 "Forbidden"
+// @failure     500     {object} api.ErrorResponse                 "Internal Server Error"
+// @security    ApiKeyAuth
+// @router      /grafana/annotations [post]
+func (api *RestApi) grafanaAnnotations(rw http.ResponseWriter, r *http.Request) {
+	if user := repository.GetUserFromContext(r.Context()); user != nil &&
+		!user.HasRole(schema.RoleApi) {
+
+		handleError(fmt.Errorf("missing role: %v", schema.GetRoleString(schema.RoleApi)), http.StatusForbidden, rw)
+		return
+	}
+
+	req := GrafanaAnnotationRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleError(fmt.Errorf("parsing request body failed: %w", err), http.StatusBadRequest, rw)
+		return
+	}
+
+	from, to := req.Range.From.Unix(), req.Range.To.Unix()
+	jobs, err := api.JobRepository.FindJobsStartedOrStoppedBetween(r.Context(), from, to, req.Annotation.Query)
+	if err != nil {
+		handleError(err, http.StatusInternalServerError, rw)
+		return
+	}
+
+	annotations := make([]GrafanaAnnotationResponse, 0, len(jobs))
+	for _, job := range jobs {
+		text := fmt.Sprintf("user %s, project %s, %d nodes", job.User, job.Project, job.NumNodes)
+		start := job.StartTime.Unix()
+		if start >= from && start <= to {
+			annotations = append(annotations, GrafanaAnnotationResponse{
+				Annotation: req.Annotation,
+				Time:       start * 1000,
+				Title:      fmt.Sprintf("Job %d started", job.JobID),
+				Text:       text,
+				Tags:       []string{job.Cluster, "started"},
+			})
+		}
+
+		stop := start + int64(job.Duration)
+		if job.State != schema.JobStateRunning && stop >= from && stop <= to {
+			annotations = append(annotations, GrafanaAnnotationResponse{
+				Annotation: req.Annotation,
+				Time:       stop * 1000,
+				Title:      fmt.Sprintf("Job %d %s", job.JobID, job.State),
+				Text:       text,
+				Tags:       []string{job.Cluster, string(job.State)},
+			})
+		}
+	}
+
+	rw.Header().Add("Content-Type", "application/json")
+	json.NewEncoder(rw).Encode(annotations)
+}
@@ -5,8 +5,11 @@
 package api
 
 import (
+	"archive/tar"
 	"bufio"
+	"context"
 	"database/sql"
+	"encoding/csv"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -14,6 +17,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -25,10 +29,13 @@ import (
 	"github.com/ClusterCockpit/cc-backend/internal/graph/model"
 	"github.com/ClusterCockpit/cc-backend/internal/importer"
 	"github.com/ClusterCockpit/cc-backend/internal/metricdata"
+	"github.com/ClusterCockpit/cc-backend/internal/metrics"
 	"github.com/ClusterCockpit/cc-backend/internal/repository"
+	"github.com/ClusterCockpit/cc-backend/internal/tracing"
 	"github.com/ClusterCockpit/cc-backend/internal/util"
 	"github.com/ClusterCockpit/cc-backend/pkg/archive"
 	"github.com/ClusterCockpit/cc-backend/pkg/log"
+	"github.com/ClusterCockpit/cc-backend/pkg/lrucache"
 	"github.com/ClusterCockpit/cc-backend/pkg/schema"
 	"github.com/gorilla/mux"
 )
@@ -62,23 +69,49 @@ type RestApi struct {
 func (api *RestApi) MountRoutes(r *mux.Router) {
 	r = r.PathPrefix("/api").Subrouter()
 	r.StrictSlash(true)
+	r.Use(tracing.Middleware)
+	r.Use(metrics.Middleware)
 
-	r.HandleFunc("/jobs/start_job/", api.startJob).Methods(http.MethodPost, http.MethodPut)
-	r.HandleFunc("/jobs/stop_job/", api.stopJobByRequest).Methods(http.MethodPost, http.MethodPut)
-	r.HandleFunc("/jobs/stop_job/{id}", api.stopJobById).Methods(http.MethodPost, http.MethodPut)
-	// r.HandleFunc("/jobs/import/", api.importJob).Methods(http.MethodPost, http.MethodPut)
+	r.HandleFunc("/jobs/start_job/", limitIngest(api.startJob)).Methods(http.MethodPost, http.MethodPut)
+	r.HandleFunc("/jobs/stop_job/", limitIngest(api.stopJobByRequest)).Methods(http.MethodPost, http.MethodPut)
+	r.HandleFunc("/jobs/stop_job/{id}", limitIngest(api.stopJobById)).Methods(http.MethodPost, http.MethodPut)
+	r.HandleFunc("/jobs/resume_job/", limitIngest(api.resumeJob)).Methods(http.MethodPost, http.MethodPut)
+	r.HandleFunc("/jobs/import", limitIngest(api.importJob)).Methods(http.MethodPost)
 
 	r.HandleFunc("/jobs/", api.getJobs).Methods(http.MethodGet)
+	r.HandleFunc("/jobs/export", api.exportJobs).Methods(http.MethodGet)
+	r.HandleFunc("/jobs/monitoring", api.getJobsByMonitoringStatus).Methods(http.MethodGet)
+	r.HandleFunc("/jobs/running", api.getRunningJobs).Methods(http.MethodGet)
+	r.HandleFunc("/jobs/search", api.searchJobs).Methods(http.MethodGet)
+	r.HandleFunc("/jobs/{id}/monitoring_status", api.updateMonitoringStatus).Methods(http.MethodPost)
+	r.HandleFunc("/jobs/{id}/cancel", api.cancelJob).Methods(http.MethodPost)
 	r.HandleFunc("/jobs/{id}", api.getJobById).Methods(http.MethodPost)
 	r.HandleFunc("/jobs/{id}", api.getCompleteJobById).Methods(http.MethodGet)
 	r.HandleFunc("/jobs/tag_job/{id}", api.tagJob).Methods(http.MethodPost, http.MethodPatch)
 	r.HandleFunc("/jobs/edit_meta/{id}", api.editMeta).Methods(http.MethodPost, http.MethodPatch)
+	r.HandleFunc("/jobs/edit_ownership/{id}", api.editOwnership).Methods(http.MethodPost, http.MethodPatch)
 	r.HandleFunc("/jobs/metrics/{id}", api.getJobMetrics).Methods(http.MethodGet)
+	r.HandleFunc("/jobs/metrics/{id}/stream", api.streamJobMetrics).Methods(http.MethodGet)
+	r.HandleFunc("/jobs/{id}/stats", api.getJobStats).Methods(http.MethodGet)
+	r.HandleFunc("/jobs/{id}/archive.tar", api.getJobArchive).Methods(http.MethodGet)
+	r.HandleFunc("/jobs/{id}/script", api.getJobScript).Methods(http.MethodGet)
+	r.HandleFunc("/jobs/{id}/env", api.getJobEnv).Methods(http.MethodGet)
 	r.HandleFunc("/jobs/delete_job/", api.deleteJobByRequest).Methods(http.MethodDelete)
 	r.HandleFunc("/jobs/delete_job/{id}", api.deleteJobById).Methods(http.MethodDelete)
 	r.HandleFunc("/jobs/delete_job_before/{ts}", api.deleteJobBefore).Methods(http.MethodDelete)
 
 	r.HandleFunc("/clusters/", api.getClusters).Methods(http.MethodGet)
+	r.HandleFunc("/clusters/{name}", api.getCluster).Methods(http.MethodGet)
+	r.HandleFunc("/tags/", api.getTags).Methods(http.MethodGet)
+	r.HandleFunc("/health", api.getHealth).Methods(http.MethodGet)
+	r.HandleFunc("/admin/reindex", api.reindex).Methods(http.MethodPost)
+	r.HandleFunc("/admin/archiving", api.getArchivings).Methods(http.MethodGet)
+	r.HandleFunc("/admin/archiving/{id}", api.cancelArchiving).Methods(http.MethodDelete)
+
+	r.HandleFunc("/grafana/", api.grafanaTestConnection).Methods(http.MethodGet)
+	r.HandleFunc("/grafana/search", api.grafanaSearch).Methods(http.MethodPost)
+	r.HandleFunc("/grafana/query", api.grafanaQuery).Methods(http.MethodPost)
+	r.HandleFunc("/grafana/annotations", api.grafanaAnnotations).Methods(http.MethodPost)
 
 	if api.MachineStateDir != "" {
 		r.HandleFunc("/machine_state/{cluster}/{host}", api.getMachineState).Methods(http.MethodGet)
@@ -93,6 +126,10 @@ func (api *RestApi) MountRoutes(r *mux.Router) {
 		r.HandleFunc("/users/", api.deleteUser).Methods(http.MethodDelete)
 		r.HandleFunc("/user/{id}", api.updateUser).Methods(http.MethodPost)
 		r.HandleFunc("/configuration/", api.updateConfiguration).Methods(http.MethodPost)
+		r.HandleFunc("/filters/", api.getFilters).Methods(http.MethodGet)
+		r.HandleFunc("/filters/{name}", api.getFilter).Methods(http.MethodGet)
+		r.HandleFunc("/filters/{name}", api.saveFilter).Methods(http.MethodPut, http.MethodPost)
+		r.HandleFunc("/filters/{name}", api.deleteFilter).Methods(http.MethodDelete)
 	}
 }
 
@@ -104,7 +141,9 @@ type StartJobApiResponse struct {
 
 // DeleteJobApiResponse model
 type DeleteJobApiResponse struct {
-	Message string `json:"msg"`
+	Message string  `json:"msg"`
+	DryRun  bool    `json:"dryRun,omitempty"` // True if nothing was actually deleted
+	JobIds  []int64 `json:"jobIds,omitempty"` // Database IDs of the (would-be) deleted jobs
 }
 
 // UpdateUserApiResponse model
@@ -114,11 +153,18 @@ type UpdateUserApiResponse struct {
 
 // StopJobApiRequest model
 type StopJobApiRequest struct {
-	JobId     *int64          `json:"jobId" example:"123000"`
-	Cluster   *string         `json:"cluster" example:"fritz"`
-	StartTime *int64          `json:"startTime" example:"1649723812"`
-	State     schema.JobState `json:"jobState" validate:"required" example:"completed"`
-	StopTime  int64           `json:"stopTime" validate:"required" example:"1649763839"`
+	JobId     *int64          `json:"jobId" example:"123000"`                            // Cluster Job ID of job
+	Cluster   *string         `json:"cluster" example:"fritz"`                           // Cluster of job
+	StartTime *int64          `json:"startTime" example:"1649723812"`                    // Start Time of job as epoch
+	State     schema.JobState `json:"jobState" validate:"required" example:"completed"`  // Final job state
+	StopTime  int64           `json:"stopTime" validate:"required" example:"1649763839"` // Stop Time of job as epoch, if not after the start time the duration is set to 0
+}
+
+// ResumeJobApiRequest model
+type ResumeJobApiRequest struct {
+	JobId     *int64  `json:"jobId" validate:"required" example:"123000"` // Cluster Job ID of job
+	Cluster   *string `json:"cluster" example:"fritz"`                    // Cluster of job
+	StartTime *int64  `json:"startTime" example:"1649723812"`             // Start Time of job as epoch
 }
 
 // DeleteJobApiRequest model
@@ -135,23 +181,109 @@ type GetJobsApiResponse struct {
 	Page  int               `json:"page"`  // Page id returned
 }
 
+// GetMonitoringStatusJobsApiResponse model
+type GetMonitoringStatusJobsApiResponse struct {
+	MonitoringStatus int32             `json:"monitoringStatus"` // Requested monitoring status
+	Jobs             []*schema.JobMeta `json:"jobs"`             // Array of jobs
+}
+
+// RunningJobApiResponse model
+type RunningJobApiResponse struct {
+	*schema.JobMeta
+	Footprint map[string]schema.Float `json:"footprint,omitempty"` // Live average of each metric, summed over the nodes of the job
+}
+
+// GetRunningJobsApiResponse model
+type GetRunningJobsApiResponse struct {
+	Cluster string                   `json:"cluster" example:"fritz"` // Requested cluster
+	Jobs    []*RunningJobApiResponse `json:"jobs"`                    // Array of running jobs
+}
+
+// SearchJobsApiResponse model
+type SearchJobsApiResponse struct {
+	Term  string            `json:"term" example:"module load"` // Searched term
+	Jobs  []*schema.JobMeta `json:"jobs"`                       // Array of jobs
+	Items int               `json:"items"`                      // Number of jobs returned
+}
+
+// UpdateMonitoringStatusApiRequest model
+type UpdateMonitoringStatusApiRequest struct {
+	MonitoringStatus *int32 `json:"monitoringStatus" validate:"required" example:"2" minimum:"0" maximum:"5"` // New monitoring status
+}
+
 // GetClustersApiResponse model
 type GetClustersApiResponse struct {
-	Clusters []*schema.Cluster `json:"clusters"` // Array of clusters
+	Clusters []*ClusterApiResponse `json:"clusters"` // Array of clusters
+}
+
+// ClusterApiResponse model
+type ClusterApiResponse struct {
+	*schema.Cluster
+	FilterRanges *schema.FilterRanges `json:"filterRanges,omitempty"` // Filter ranges from the program config
+}
+
+// HealthApiResponse model
+type HealthApiResponse struct {
+	Healthy bool                `json:"healthy"` // False if any of the checked dependencies is down
+	Checks  []HealthCheckResult `json:"checks"`  // Results of the single checks
+}
+
+// HealthCheckResult model
+type HealthCheckResult struct {
+	Name    string `json:"name" example:"metricdata/fritz"` // Checked dependency: 'database' or 'metricdata/<cluster>'
+	Healthy bool   `json:"healthy"`
+	Error   string `json:"error,omitempty"` // Reason if not healthy
+}
+
+// ReindexApiResponse model
+type ReindexApiResponse struct {
+	Recreated  bool  `json:"recreated"`                // True if the job indexes were recreated
+	RecreateMs int64 `json:"recreateMs" example:"120"` // Duration of recreating the indexes in milliseconds
+	ReindexMs  int64 `json:"reindexMs" example:"80"`   // Duration of REINDEX in milliseconds
+	AnalyzeMs  int64 `json:"analyzeMs" example:"40"`   // Duration of ANALYZE in milliseconds
+	TotalMs    int64 `json:"totalMs" example:"240"`    // Total duration in milliseconds
+}
+
+// ArchivingApiResponse model
+type ArchivingApiResponse struct {
+	ID        int64  `json:"id"`                      // Database ID of the job
+	JobID     int64  `json:"jobId"`                   // Job ID of the scheduler
+	Cluster   string `json:"cluster"`                 // Cluster of the job
+	QueuedAt  int64  `json:"queuedAt"`                // Unix timestamp of the archiving request
+	StartedAt int64  `json:"startedAt,omitempty"`     // Unix timestamp of the start of archiving, missing while queued
+	Phase     string `json:"phase" example:"loading"` // One of queued, loading (metric data) or updating (database)
+	Cancelled bool   `json:"cancelled"`               // True if cancelled, but not finished yet
+}
+
+// CancelArchivingApiResponse model
+type CancelArchivingApiResponse struct {
+	Message string `json:"msg"`
 }
 
 // ErrorResponse model
 type ErrorResponse struct {
-	// Statustext of Errorcode
-	Status string `json:"status"`
-	Error  string `json:"error"` // Error Message
+	Error ErrorDetails `json:"error"`
+}
+
+// ErrorDetails model
+type ErrorDetails struct {
+	// Machine-readable error code, derived from the HTTP status if not more specific
+	Code    string `json:"code" example:"duplicate_job"`
+	Message string `json:"message"` // Error message
 }
 
 // ApiTag model
 type ApiTag struct {
 	// Tag Type
-	Type string `json:"type" example:"Debug"`
-	Name string `json:"name" example:"Testjob"` // Tag Name
+	Type  string `json:"type" example:"Debug"`
+	Name  string `json:"name" example:"Testjob"` // Tag Name
+	Scope string `json:"scope" example:"global"` // Tag Scope: 'global' (default) or username of owner
+}
+
+// TagCountApiResponse model
+type TagCountApiResponse struct {
+	schema.Tag
+	Count int `json:"count" example:"42"` // Number of jobs with this tag
 }
 
 // ApiMeta model
@@ -160,6 +292,11 @@ type EditMetaRequest struct {
 	Value string `json:"value" example:"bash script"`
 }
 
+type EditOwnershipRequest struct {
+	User    string `json:"user" example:"abcd100h"` // New user of the job, unchanged if empty
+	Project string `json:"project" example:"abcd"`  // New project of the job, unchanged if empty
+}
+
 type TagJobApiRequest []*ApiTag
 
 type GetJobApiRequest []string
@@ -188,16 +325,92 @@ type ApiReturnedUser struct {
 	Projects []string `json:"projects"`
 }
 
+// limitIngest restricts the size of the request body to max-request-body-size
+// and cancels the context of the request after ingest-timeout.
+func limitIngest(next http.HandlerFunc) http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		if limit := config.Keys.MaxRequestBodySize; limit > 0 {
+			r.Body = http.MaxBytesReader(rw, r.Body, limit)
+		}
+
+		if config.Keys.IngestTimeout != "" {
+			timeout, err := time.ParseDuration(config.Keys.IngestTimeout)
+			if err != nil {
+				handleError(fmt.Errorf("invalid ingest-timeout: %w", err), http.StatusInternalServerError, rw)
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+			r = r.WithContext(ctx)
+		}
+
+		next(rw, r)
+	}
+}
+
+// bodyTooLarge reports whether err was caused by a request body exceeding
+// the limit set by limitIngest.
+func bodyTooLarge(err error) bool {
+	return strings.Contains(err.Error(), "http: request body too large")
+}
+
+// codedError attaches a machine-readable code for the error response to err.
+type codedError struct {
+	code string
+	err  error
+}
+
+func (e *codedError) Error() string { return e.err.Error() }
+func (e *codedError) Unwrap() error { return e.err }
+
+// withCode sets the code of the error response for err, e.g. "duplicate_job".
+func withCode(code string, err error) error {
+	return &codedError{code: code, err: err}
+}
+
+// handleError responds with an ErrorResponse. Its code is taken from err (see
+// withCode), otherwise from the status text, e.g. "unprocessable_entity".
 func handleError(err error, statusCode int, rw http.ResponseWriter) {
 	log.Warnf("REST ERROR : %s", err.Error())
+	if bodyTooLarge(err) {
+		statusCode = http.StatusRequestEntityTooLarge
+	} else if errors.Is(err, metricdata.ErrRateLimited) {
+		statusCode = http.StatusServiceUnavailable
+	} else if errors.Is(err, metricdata.ErrTimeout) {
+		statusCode = http.StatusGatewayTimeout
+	} else if errors.Is(err, config.ErrClusterForbidden) {
+		statusCode = http.StatusForbidden
+	}
+
+	code := strings.ToLower(strings.ReplaceAll(http.StatusText(statusCode), " ", "_"))
+	var ce *codedError
+	if errors.As(err, &ce) {
+		code = ce.code
+	}
+
 	rw.Header().Add("Content-Type", "application/json")
 	rw.WriteHeader(statusCode)
 	json.NewEncoder(rw).Encode(ErrorResponse{
-		Status: http.StatusText(statusCode),
-		Error:  err.Error(),
+		Error: ErrorDetails{
+			Code:    code,
+			Message: err.Error(),
+		},
 	})
 }
 
+// dryRun reports whether a destructive request should only report what it
+// would change, requested by either the 'dryRun' query parameter or the
+// 'X-Dry-Run' header.
+func dryRun(r *http.Request) bool {
+	val := r.URL.Query().Get("dryRun")
+	if val == "" {
+		val = r.Header.Get("X-Dry-Run")
+	}
+	b, err := strconv.ParseBool(val)
+	return err == nil && b
+}
+
 func decode(r io.Reader, val interface{}) error {
 	dec := json.NewDecoder(r)
 	dec.DisallowUnknownFields()
@@ -267,7 +480,7 @@ func (api *RestApi) getClusters(rw http.ResponseWriter, r *http.Request) {
 	bw := bufio.NewWriter(rw)
 	defer bw.Flush()
 
-	var clusters []*schema.Cluster
+	clusters := make([]*ClusterApiResponse, 0)
 
 	if r.URL.Query().Has("cluster") {
 		name := r.URL.Query().Get("cluster")
@@ -276,9 +489,18 @@ func (api *RestApi) getClusters(rw http.ResponseWriter, r *http.Request) {
 			handleError(fmt.Errorf("unknown cluster: %s", name), http.StatusBadRequest, rw)
 			return
 		}
-		clusters = append(clusters, cluster)
+		if err := config.CheckClusterAccess(repository.GetUserFromContext(r.Context()), name); err != nil {
+			handleError(err, http.StatusForbidden, rw)
+			return
+		}
+		clusters = append(clusters, newClusterApiResponse(cluster))
 	} else {
-		clusters = archive.Clusters
+		user := repository.GetUserFromContext(r.Context())
+		for _, cluster := range archive.GetClusters() {
+			if config.HasClusterAccess(user, cluster.Name) {
+				clusters = append(clusters, newClusterApiResponse(cluster))
+			}
+		}
 	}
 
 	payload := GetClustersApiResponse{
@@ -291,6 +513,54 @@ func (api *RestApi) getClusters(rw http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// getCluster godoc
+// @summary     Get a cluster config
+// @tags Cluster query
+// @description Get the config of a cluster including subclusters, topology, metrics and filter ranges.
+// @produce     json
+// @param       name           path     string            true "Cluster name"
+// @success     200            {object} api.ClusterApiResponse  "Cluster config"
+// @failure     401            {object} api.ErrorResponse       "Unauthorized"
+// @failure     403            {object} api.ErrorResponse       "Forbidden"
+// @failure     404            {object} api.ErrorResponse       "Cluster not found"
+// @failure     500            {object} api.ErrorResponse       "Internal Server Error"
+// @security    ApiKeyAuth
+// @router      /clusters/{name} [get]
+func (api *RestApi) getCluster(rw http.ResponseWriter, r *http.Request) {
+	if user := repository.GetUserFromContext(r.Context()); user != nil &&
+		!user.HasRole(schema.RoleApi) {
+
+		handleError(fmt.Errorf("missing role: %v", schema.GetRoleString(schema.RoleApi)), http.StatusForbidden, rw)
+		return
+	}
+
+	name := mux.Vars(r)["name"]
+	cluster := archive.GetCluster(name)
+	if cluster == nil {
+		handleError(fmt.Errorf("unknown cluster: %s", name), http.StatusNotFound, rw)
+		return
+	}
+	if err := config.CheckClusterAccess(repository.GetUserFromContext(r.Context()), name); err != nil {
+		handleError(err, http.StatusForbidden, rw)
+		return
+	}
+
+	rw.Header().Add("Content-Type", "application/json")
+	if err := json.NewEncoder(rw).Encode(newClusterApiResponse(cluster)); err != nil {
+		log.Warnf("Error while encoding cluster %s: %v", name, err)
+	}
+}
+
+// newClusterApiResponse adds the filter ranges of the program config to the
+// cluster config. Other parts of the program config, like the metric data
+// repository credentials, are not exposed.
+func newClusterApiResponse(cluster *schema.Cluster) *ClusterApiResponse {
+	return &ClusterApiResponse{
+		Cluster:      cluster,
+		FilterRanges: config.GetFilterRanges(cluster.Name),
+	}
+}
+
 // getJobs godoc
 // @summary     Lists all jobs
 // @tags Job query
@@ -300,6 +570,7 @@ func (api *RestApi) getClusters(rw http.ResponseWriter, r *http.Request) {
 // @param       state          query    string            false "Job State" Enums(running, completed, failed, cancelled, stopped, timeout)
 // @param       cluster        query    string            false "Job Cluster"
 // @param       start-time     query    string            false "Syntax: '$from-$to', as unix epoch timestamps in seconds"
+// @param       smt            query    int               false "SMT setting of job" Enums(0, 1)
 // @param       items-per-page query    int               false "Items per page (Default: 25)"
 // @param       page           query    int               false "Page Number (Default: 1)"
 // @param       with-metadata  query    bool              false "Include metadata (e.g. jobScript) in response"
@@ -324,38 +595,14 @@ func (api *RestApi) getJobs(rw http.ResponseWriter, r *http.Request) {
 	order := &model.OrderByInput{Field: "startTime", Order: model.SortDirectionEnumDesc}
 
 	for key, vals := range r.URL.Query() {
+		if ok, err := parseJobFilter(filter, key, vals); err != nil {
+			handleError(err, http.StatusBadRequest, rw)
+			return
+		} else if ok {
+			continue
+		}
+
 		switch key {
-		case "state":
-			for _, s := range vals {
-				state := schema.JobState(s)
-				if !state.Valid() {
-					handleError(fmt.Errorf("invalid query parameter value: state"),
-						http.StatusBadRequest, rw)
-					return
-				}
-				filter.State = append(filter.State, state)
-			}
-		case "cluster":
-			filter.Cluster = &model.StringInput{Eq: &vals[0]}
-		case "start-time":
-			st := strings.Split(vals[0], "-")
-			if len(st) != 2 {
-				handleError(fmt.Errorf("invalid query parameter value: startTime"),
-					http.StatusBadRequest, rw)
-				return
-			}
-			from, err := strconv.ParseInt(st[0], 10, 64)
-			if err != nil {
-				handleError(err, http.StatusBadRequest, rw)
-				return
-			}
-			to, err := strconv.ParseInt(st[1], 10, 64)
-			if err != nil {
-				handleError(err, http.StatusBadRequest, rw)
-				return
-			}
-			ufrom, uto := time.Unix(from, 0), time.Unix(to, 0)
-			filter.StartTime = &schema.TimeRange{From: &ufrom, To: &uto}
 		case "page":
 			x, err := strconv.Atoi(vals[0])
 			if err != nil {
@@ -379,6 +626,15 @@ func (api *RestApi) getJobs(rw http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if err := config.ValidateFilter(filter); err != nil {
+		handleError(err, http.StatusBadRequest, rw)
+		return
+	}
+	if err := graph.CheckClusterFilters(repository.GetUserFromContext(r.Context()), []*model.JobFilter{filter}); err != nil {
+		handleError(err, http.StatusForbidden, rw)
+		return
+	}
+
 	jobs, err := api.JobRepository.QueryJobs(r.Context(), []*model.JobFilter{filter}, page, order)
 	if err != nil {
 		handleError(err, http.StatusInternalServerError, rw)
@@ -400,13 +656,13 @@ func (api *RestApi) getJobs(rw http.ResponseWriter, r *http.Request) {
 			StartTime: job.StartTime.Unix(),
 		}
 
-		res.Tags, err = api.JobRepository.GetTags(&job.ID)
+		res.Tags, err = api.JobRepository.GetTags(repository.GetUserFromContext(r.Context()), &job.ID)
 		if err != nil {
 			handleError(err, http.StatusInternalServerError, rw)
 			return
 		}
 
-		if res.MonitoringStatus == schema.MonitoringStatusArchivingSuccessful {
+		if schema.IsArchived(res.MonitoringStatus) {
 			res.Statistics, err = archive.GetStatistics(job)
 			if err != nil {
 				handleError(err, http.StatusInternalServerError, rw)
@@ -434,104 +690,541 @@ func (api *RestApi) getJobs(rw http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// getJobById godoc
-// @summary   Get job meta and optional all metric data
+// parseJobFilter applies the query parameter key to filter. It returns false
+// if key is no filter parameter.
+func parseJobFilter(filter *model.JobFilter, key string, vals []string) (bool, error) {
+	switch key {
+	case "state":
+		for _, s := range vals {
+			state := schema.JobState(s)
+			if !state.Valid() {
+				return true, fmt.Errorf("invalid query parameter value: state")
+			}
+			filter.State = append(filter.State, state)
+		}
+	case "cluster":
+		filter.Cluster = &model.StringInput{Eq: &vals[0]}
+	case "start-time":
+		st := strings.Split(vals[0], "-")
+		if len(st) != 2 {
+			return true, fmt.Errorf("invalid query parameter value: startTime")
+		}
+		from, err := strconv.ParseInt(st[0], 10, 64)
+		if err != nil {
+			return true, err
+		}
+		to, err := strconv.ParseInt(st[1], 10, 64)
+		if err != nil {
+			return true, err
+		}
+		ufrom, uto := time.Unix(from, 0), time.Unix(to, 0)
+		filter.StartTime = &schema.TimeRange{From: &ufrom, To: &uto}
+	case "smt":
+		x, err := strconv.Atoi(vals[0])
+		if err != nil || (x != 0 && x != 1) {
+			return true, fmt.Errorf("invalid query parameter value: smt")
+		}
+		filter.Smt = &x
+	default:
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// Number of rows after which exportJobs flushes the CSV writer.
+const exportFlushRows = 100
+
+// countingWriter counts the bytes written to w.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
+
+// exportJobs godoc
+// @summary     Exports jobs as CSV
 // @tags Job query
-// @description Job to get is specified by database ID
-// @description Returns full job resource information according to 'JobMeta' scheme and all metrics according to 'JobData'.
-// @produce     json
-// @param       id          path     int                  true "Database ID of Job"
-// @param       all-metrics query    bool                 false "Include all available metrics"
-// @success     200     {object} api.GetJobApiResponse      "Job resource"
-// @failure     400     {object} api.ErrorResponse          "Bad Request"
-// @failure     401     {object} api.ErrorResponse          "Unauthorized"
-// @failure     403     {object} api.ErrorResponse          "Forbidden"
-// @failure     404     {object} api.ErrorResponse          "Resource not found"
-// @failure     422     {object} api.ErrorResponse          "Unprocessable Entity: finding job failed: sql: no rows in result set"
-// @failure     500     {object} api.ErrorResponse          "Internal Server Error"
+// @description Get all jobs matching the filters as CSV file, sorted by descending startTime.
+// @description Accepts the same filters as the job list. Columns: jobId, user, project, cluster, state,
+// @description startTime, duration, numNodes and the footprint averages. Users only get their own jobs.
+// @produce     text/csv
+// @param       format         query    string            false "Export format (Default: csv)" Enums(csv)
+// @param       state          query    string            false "Job State" Enums(running, completed, failed, cancelled, stopped, timeout)
+// @param       cluster        query    string            false "Job Cluster"
+// @param       start-time     query    string            false "Syntax: '$from-$to', as unix epoch timestamps in seconds"
+// @param       smt            query    int               false "SMT setting of job" Enums(0, 1)
+// @success     200            {string} string                  "CSV file"
+// @failure     400            {object} api.ErrorResponse       "Bad Request"
+// @failure     401   		   {object} api.ErrorResponse       "Unauthorized"
+// @failure     403            {object} api.ErrorResponse       "Forbidden"
+// @failure     500            {object} api.ErrorResponse       "Internal Server Error"
 // @security    ApiKeyAuth
-// @router      /jobs/{id} [get]
-func (api *RestApi) getCompleteJobById(rw http.ResponseWriter, r *http.Request) {
-	if user := repository.GetUserFromContext(r.Context()); user != nil &&
-		!user.HasRole(schema.RoleApi) {
+// @router      /jobs/export [get]
+func (api *RestApi) exportJobs(rw http.ResponseWriter, r *http.Request) {
+	filter := &model.JobFilter{}
+	order := &model.OrderByInput{Field: "startTime", Order: model.SortDirectionEnumDesc}
 
-		handleError(fmt.Errorf("missing role: %v",
-			schema.GetRoleString(schema.RoleApi)), http.StatusForbidden, rw)
-		return
-	}
+	for key, vals := range r.URL.Query() {
+		if ok, err := parseJobFilter(filter, key, vals); err != nil {
+			handleError(err, http.StatusBadRequest, rw)
+			return
+		} else if ok {
+			continue
+		}
 
-	// Fetch job from db
-	id, ok := mux.Vars(r)["id"]
-	var job *schema.Job
-	var err error
-	if ok {
-		id, e := strconv.ParseInt(id, 10, 64)
-		if e != nil {
-			handleError(fmt.Errorf("integer expected in path for id: %w", e), http.StatusBadRequest, rw)
+		if key != "format" {
+			handleError(fmt.Errorf("invalid query parameter: %s", key),
+				http.StatusBadRequest, rw)
+			return
+		}
+		if vals[0] != "csv" {
+			handleError(fmt.Errorf("unsupported export format: %s", vals[0]),
+				http.StatusBadRequest, rw)
 			return
 		}
+	}
 
-		job, err = api.JobRepository.FindById(id)
-	} else {
-		handleError(errors.New("the parameter 'id' is required"), http.StatusBadRequest, rw)
+	if err := config.ValidateFilter(filter); err != nil {
+		handleError(err, http.StatusBadRequest, rw)
 		return
 	}
+	if err := graph.CheckClusterFilters(repository.GetUserFromContext(r.Context()), []*model.JobFilter{filter}); err != nil {
+		handleError(err, http.StatusForbidden, rw)
+		return
+	}
+
+	rw.Header().Add("Content-Type", "text/csv")
+	rw.Header().Add("Content-Disposition", `attachment; filename="jobs.csv"`)
+	out := &countingWriter{w: rw}
+	cw := csv.NewWriter(out)
+	cw.Write([]string{"jobId", "user", "project", "cluster", "state", "startTime", "duration", "numNodes",
+		"flopsAnyAvg", "memBwAvg", "loadAvg", "netBwAvg", "fileBwAvg"})
+
+	// The rows are written to the client while iterating the jobs. The csv
+	// writer buffers them, so they are flushed every exportFlushRows rows.
+	count := 0
+	err := api.JobRepository.IterJobs(r.Context(), []*model.JobFilter{filter}, order, func(job *schema.Job) error {
+		count++
+		if err := cw.Write([]string{
+			strconv.FormatInt(job.JobID, 10),
+			job.User,
+			job.Project,
+			job.Cluster,
+			string(job.State),
+			job.StartTime.UTC().Format(time.RFC3339),
+			strconv.Itoa(int(job.Duration)),
+			strconv.Itoa(int(job.NumNodes)),
+			strconv.FormatFloat(job.FlopsAnyAvg, 'f', -1, 64),
+			strconv.FormatFloat(job.MemBwAvg, 'f', -1, 64),
+			strconv.FormatFloat(job.LoadAvg, 'f', -1, 64),
+			strconv.FormatFloat(job.NetBwAvg, 'f', -1, 64),
+			strconv.FormatFloat(job.FileBwAvg, 'f', -1, 64),
+		}); err != nil {
+			return err
+		}
+		if count%exportFlushRows == 0 {
+			cw.Flush()
+			return cw.Error()
+		}
+		return nil
+	})
 	if err != nil {
-		handleError(fmt.Errorf("finding job failed: %w", err), http.StatusUnprocessableEntity, rw)
+		if out.n == 0 {
+			// Nothing was sent yet, the rows are still buffered.
+			rw.Header().Del("Content-Type")
+			rw.Header().Del("Content-Disposition")
+			handleError(err, http.StatusInternalServerError, rw)
+			return
+		}
+		log.Errorf("/api/jobs/export: aborted after %d jobs: %v", count, err)
 		return
 	}
 
-	job.Tags, err = api.JobRepository.GetTags(&job.ID)
-	if err != nil {
-		handleError(err, http.StatusInternalServerError, rw)
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		log.Warnf("/api/jobs/export: writing response failed: %v", err)
 		return
+	}
+	log.Debugf("/api/jobs/export: %d jobs exported", count)
+}
 
+// searchJobs godoc
+// @summary     Searches the metadata of jobs
+// @tags Job query
+// @description Get all jobs whose metadata (e.g. the jobScript) contains the given term, ignoring case.
+// @description Results are sorted by descending startTime. Users that are not admin, support or api
+// @description only find their own jobs. Every search scans all jobs, as the metadata is not indexed.
+// @produce     json
+// @param       meta           query    string            true  "Search term"
+// @success     200            {object} api.SearchJobsApiResponse "Job array"
+// @failure     400            {object} api.ErrorResponse       "Bad Request"
+// @failure     401            {object} api.ErrorResponse       "Unauthorized"
+// @failure     500            {object} api.ErrorResponse       "Internal Server Error"
+// @security    ApiKeyAuth
+// @router      /jobs/search [get]
+func (api *RestApi) searchJobs(rw http.ResponseWriter, r *http.Request) {
+	term := r.URL.Query().Get("meta")
+	if term == "" {
+		handleError(errors.New("missing query parameter: meta"), http.StatusBadRequest, rw)
+		return
 	}
-	if _, err = api.JobRepository.FetchMetadata(job); err != nil {
 
+	jobs, err := api.JobRepository.SearchMetadata(r.Context(), term)
+	if err != nil {
 		handleError(err, http.StatusInternalServerError, rw)
 		return
 	}
 
-	var scopes []schema.MetricScope
-
-	if job.NumNodes == 1 {
-		scopes = []schema.MetricScope{"core"}
-	} else {
-		scopes = []schema.MetricScope{"node"}
+	results := make([]*schema.JobMeta, 0, len(jobs))
+	for _, job := range jobs {
+		results = append(results, &schema.JobMeta{
+			ID:        &job.ID,
+			BaseJob:   job.BaseJob,
+			StartTime: job.StartTime.Unix(),
+		})
 	}
 
-	var data schema.JobData
+	rw.Header().Add("Content-Type", "application/json")
+	json.NewEncoder(rw).Encode(SearchJobsApiResponse{
+		Term:  term,
+		Jobs:  results,
+		Items: len(results),
+	})
+}
 
-	if r.URL.Query().Get("all-metrics") == "true" {
-		data, err = metricdata.LoadData(job, nil, scopes, r.Context())
-		if err != nil {
-			log.Warn("Error while loading job data")
-			return
-		}
-	}
+// getJobsByMonitoringStatus godoc
+// @summary     Lists jobs by monitoring status
+// @tags Job query
+// @description Get all jobs with the given monitoring status, e.g. jobs stuck in 'running or archiving' (1)
+// @description after a crash during archiving. Results are sorted by ascending startTime.
+// @produce     json
+// @param       status         query    int               true  "Monitoring status" Enums(0, 1, 2, 3, 4, 5)
+// @param       cluster        query    string            false "Job Cluster"
+// @success     200            {object} api.GetMonitoringStatusJobsApiResponse "Job array"
+// @failure     400            {object} api.ErrorResponse       "Bad Request"
+// @failure     401            {object} api.ErrorResponse       "Unauthorized"
+// @failure     403            {object} api.ErrorResponse       "Forbidden"
+// @failure     500            {object} api.ErrorResponse       "Internal Server Error"
+// @security    ApiKeyAuth
+// @router      /jobs/monitoring [get]
+func (api *RestApi) getJobsByMonitoringStatus(rw http.ResponseWriter, r *http.Request) {
+	if user := repository.GetUserFromContext(r.Context()); user != nil &&
+		!user.HasRole(schema.RoleApi) {
 
-	log.Debugf("/api/job/%s: get job %d", id, job.JobID)
-	rw.Header().Add("Content-Type", "application/json")
-	bw := bufio.NewWriter(rw)
-	defer bw.Flush()
+		handleError(fmt.Errorf("missing role: %v", schema.GetRoleString(schema.RoleApi)), http.StatusForbidden, rw)
+		return
+	}
 
-	payload := GetCompleteJobApiResponse{
-		Meta: job,
-		Data: data,
+	status, err := parseMonitoringStatus(r.URL.Query().Get("status"))
+	if err != nil {
+		handleError(err, http.StatusBadRequest, rw)
+		return
 	}
 
-	if err := json.NewEncoder(bw).Encode(payload); err != nil {
+	jobs, err := api.JobRepository.FindByMonitoringStatus(r.Context(), status, r.URL.Query().Get("cluster"))
+	if err != nil {
 		handleError(err, http.StatusInternalServerError, rw)
 		return
 	}
-}
 
-// getJobById godoc
-// @summary   Get job meta and configurable metric data
-// @tags Job query
-// @description Job to get is specified by database ID
-// @description Returns full job resource information according to 'JobMeta' scheme and all metrics according to 'JobData'.
+	results := make([]*schema.JobMeta, 0, len(jobs))
+	for _, job := range jobs {
+		results = append(results, &schema.JobMeta{
+			ID:        &job.ID,
+			BaseJob:   job.BaseJob,
+			StartTime: job.StartTime.Unix(),
+		})
+	}
+
+	rw.Header().Add("Content-Type", "application/json")
+	json.NewEncoder(rw).Encode(GetMonitoringStatusJobsApiResponse{
+		MonitoringStatus: status,
+		Jobs:             results,
+	})
+}
+
+// Live footprints of running jobs, kept briefly so that an operations
+// overview polling /jobs/running does not hit the metric store every time.
+var runningFootprints = lrucache.New(1024 * 1024)
+
+// getRunningJobs godoc
+// @summary     Lists running jobs with live footprints
+// @tags Job query
+// @description Get all running jobs of a cluster together with the current averages of their metrics,
+// @description loaded from the metric data repository. Footprints are cached for one minute.
+// @description Results are sorted by ascending startTime.
+// @produce     json
+// @param       cluster        query    string            true  "Job Cluster"
+// @param       metric         query    []string          false "Metrics of the footprint (Default: all metrics of the cluster)" collectionFormat(multi)
+// @success     200            {object} api.GetRunningJobsApiResponse "Running jobs"
+// @failure     400            {object} api.ErrorResponse       "Bad Request"
+// @failure     401            {object} api.ErrorResponse       "Unauthorized"
+// @failure     403            {object} api.ErrorResponse       "Forbidden"
+// @failure     500            {object} api.ErrorResponse       "Internal Server Error"
+// @failure     503            {object} api.ErrorResponse       "Service Unavailable: The rate limit of the metric data repository was exceeded"
+// @security    ApiKeyAuth
+// @router      /jobs/running [get]
+func (api *RestApi) getRunningJobs(rw http.ResponseWriter, r *http.Request) {
+	if user := repository.GetUserFromContext(r.Context()); user != nil &&
+		!user.HasRole(schema.RoleApi) {
+
+		handleError(fmt.Errorf("missing role: %v", schema.GetRoleString(schema.RoleApi)), http.StatusForbidden, rw)
+		return
+	}
+
+	clusterName := r.URL.Query().Get("cluster")
+	cluster := archive.GetCluster(clusterName)
+	if cluster == nil {
+		handleError(fmt.Errorf("unknown cluster: '%s'", clusterName), http.StatusBadRequest, rw)
+		return
+	}
+
+	metrics := r.URL.Query()["metric"]
+	if len(metrics) == 0 {
+		for _, mc := range cluster.MetricConfig {
+			metrics = append(metrics, mc.Name)
+		}
+	}
+
+	filter := &model.JobFilter{
+		Cluster: &model.StringInput{Eq: &clusterName},
+		State:   []schema.JobState{schema.JobStateRunning},
+	}
+	order := &model.OrderByInput{Field: "startTime", Order: model.SortDirectionEnumAsc}
+	jobs, err := api.JobRepository.QueryJobs(r.Context(), []*model.JobFilter{filter}, nil, order)
+	if err != nil {
+		handleError(err, http.StatusInternalServerError, rw)
+		return
+	}
+
+	results := make([]*RunningJobApiResponse, 0, len(jobs))
+	for _, job := range jobs {
+		res := &RunningJobApiResponse{
+			JobMeta: &schema.JobMeta{
+				ID:        &job.ID,
+				BaseJob:   job.BaseJob,
+				StartTime: job.StartTime.Unix(),
+			},
+		}
+
+		if job.MonitoringStatus != schema.MonitoringStatusDisabled {
+			key := fmt.Sprintf("%d:%s", job.ID, strings.Join(metrics, ","))
+			footprint := runningFootprints.Get(key, func() (interface{}, time.Duration, int) {
+				avgs := make([][]schema.Float, len(metrics))
+				if err := metricdata.LoadAverages(job, metrics, avgs, r.Context()); err != nil {
+					log.Warnf("loading footprint of running job (dbid: %d) failed: %s", job.ID, err.Error())
+					return nil, 0, 0
+				}
+
+				footprint := make(map[string]schema.Float, len(metrics))
+				for i, metric := range metrics {
+					footprint[metric] = avgs[i][0]
+				}
+				return footprint, time.Minute, len(metrics) * 16
+			})
+			if footprint != nil {
+				res.Footprint = footprint.(map[string]schema.Float)
+			}
+		}
+
+		results = append(results, res)
+	}
+
+	rw.Header().Add("Content-Type", "application/json")
+	json.NewEncoder(rw).Encode(GetRunningJobsApiResponse{
+		Cluster: clusterName,
+		Jobs:    results,
+	})
+}
+
+// updateMonitoringStatus godoc
+// @summary     Sets the monitoring status of a job
+// @tags Job add and modify
+// @description Force-sets the monitoring status of the job with the given database id, e.g. to
+// @description recover a job stuck in 'running or archiving' (1). Only allowed for admins.
+// @accept      json
+// @produce     json
+// @param       id      path     int                                  true "Database ID of Job"
+// @param       request body     api.UpdateMonitoringStatusApiRequest true "New monitoring status"
+// @success     200     {object} schema.JobMeta                       "Updated job"
+// @failure     400     {object} api.ErrorResponse                    "Bad Request"
+// @failure     401     {object} api.ErrorResponse                    "Unauthorized"
+// @failure     403     {object} api.ErrorResponse                    "Forbidden"
+// @failure     404     {object} api.ErrorResponse                    "Job not found"
+// @failure     500     {object} api.ErrorResponse                    "Internal Server Error"
+// @security    ApiKeyAuth
+// @router      /jobs/{id}/monitoring_status [post]
+func (api *RestApi) updateMonitoringStatus(rw http.ResponseWriter, r *http.Request) {
+	if user := repository.GetUserFromContext(r.Context()); user != nil &&
+		!user.HasRole(schema.RoleAdmin) {
+
+		handleError(fmt.Errorf("missing role: %v", schema.GetRoleString(schema.RoleAdmin)), http.StatusForbidden, rw)
+		return
+	}
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		handleError(fmt.Errorf("parsing job id failed: %w", err), http.StatusBadRequest, rw)
+		return
+	}
+
+	var req UpdateMonitoringStatusApiRequest
+	if err := decode(r.Body, &req); err != nil {
+		handleError(fmt.Errorf("parsing request body failed: %w", err), http.StatusBadRequest, rw)
+		return
+	}
+	if req.MonitoringStatus == nil {
+		handleError(errors.New("the field 'monitoringStatus' is required"), http.StatusBadRequest, rw)
+		return
+	}
+	if s := *req.MonitoringStatus; s < schema.MonitoringStatusDisabled || s > schema.MonitoringStatusArchiveStatsOnly {
+		handleError(fmt.Errorf("invalid monitoring status: %d", s), http.StatusBadRequest, rw)
+		return
+	}
+
+	job, err := api.JobRepository.FindById(id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			handleError(fmt.Errorf("job %d not found", id), http.StatusNotFound, rw)
+			return
+		}
+		handleError(err, http.StatusInternalServerError, rw)
+		return
+	}
+
+	if err := api.JobRepository.UpdateMonitoringStatus(id, *req.MonitoringStatus); err != nil {
+		handleError(err, http.StatusInternalServerError, rw)
+		return
+	}
+	log.Infof("monitoring status of job %d (dbid: %d) changed from %d to %d",
+		job.JobID, job.ID, job.MonitoringStatus, *req.MonitoringStatus)
+	job.MonitoringStatus = *req.MonitoringStatus
+
+	rw.Header().Add("Content-Type", "application/json")
+	json.NewEncoder(rw).Encode(&schema.JobMeta{
+		ID:        &job.ID,
+		BaseJob:   job.BaseJob,
+		StartTime: job.StartTime.Unix(),
+	})
+}
+
+func parseMonitoringStatus(s string) (int32, error) {
+	status, err := strconv.ParseInt(s, 10, 32)
+	if err != nil || status < int64(schema.MonitoringStatusDisabled) ||
+		status > int64(schema.MonitoringStatusArchiveStatsOnly) {
+		return 0, fmt.Errorf("invalid monitoring status: '%s'", s)
+	}
+	return int32(status), nil
+}
+
+// getJobById godoc
+// @summary   Get job meta and optional all metric data
+// @tags Job query
+// @description Job to get is specified by database ID
+// @description Returns full job resource information according to 'JobMeta' scheme and all metrics according to 'JobData'.
+// @produce     json
+// @param       id          path     int                  true "Database ID of Job"
+// @param       all-metrics query    bool                 false "Include all available metrics"
+// @success     200     {object} api.GetJobApiResponse      "Job resource"
+// @failure     400     {object} api.ErrorResponse          "Bad Request"
+// @failure     401     {object} api.ErrorResponse          "Unauthorized"
+// @failure     403     {object} api.ErrorResponse          "Forbidden"
+// @failure     404     {object} api.ErrorResponse          "Resource not found"
+// @failure     422     {object} api.ErrorResponse          "Unprocessable Entity: finding job failed: sql: no rows in result set"
+// @failure     500     {object} api.ErrorResponse          "Internal Server Error"
+// @security    ApiKeyAuth
+// @router      /jobs/{id} [get]
+func (api *RestApi) getCompleteJobById(rw http.ResponseWriter, r *http.Request) {
+	if user := repository.GetUserFromContext(r.Context()); user != nil &&
+		!user.HasRole(schema.RoleApi) {
+
+		handleError(fmt.Errorf("missing role: %v",
+			schema.GetRoleString(schema.RoleApi)), http.StatusForbidden, rw)
+		return
+	}
+
+	// Fetch job from db
+	id, ok := mux.Vars(r)["id"]
+	var job *schema.Job
+	var err error
+	if ok {
+		id, e := strconv.ParseInt(id, 10, 64)
+		if e != nil {
+			handleError(fmt.Errorf("integer expected in path for id: %w", e), http.StatusBadRequest, rw)
+			return
+		}
+
+		job, err = api.JobRepository.FindById(id)
+	} else {
+		handleError(errors.New("the parameter 'id' is required"), http.StatusBadRequest, rw)
+		return
+	}
+	if err != nil {
+		handleError(fmt.Errorf("finding job failed: %w", err), http.StatusUnprocessableEntity, rw)
+		return
+	}
+	if err := config.CheckClusterAccess(repository.GetUserFromContext(r.Context()), job.Cluster); err != nil {
+		handleError(err, http.StatusForbidden, rw)
+		return
+	}
+
+	job.Tags, err = api.JobRepository.GetTags(repository.GetUserFromContext(r.Context()), &job.ID)
+	if err != nil {
+		handleError(err, http.StatusInternalServerError, rw)
+		return
+
+	}
+	if _, err = api.JobRepository.FetchMetadata(job); err != nil {
+
+		handleError(err, http.StatusInternalServerError, rw)
+		return
+	}
+
+	var scopes []schema.MetricScope
+
+	if job.NumNodes == 1 {
+		scopes = []schema.MetricScope{"core"}
+	} else {
+		scopes = []schema.MetricScope{"node"}
+	}
+
+	var data schema.JobData
+
+	if r.URL.Query().Get("all-metrics") == "true" {
+		data, err = metricdata.LoadData(job, nil, scopes, r.Context())
+		if err != nil {
+			log.Warn("Error while loading job data")
+			return
+		}
+	}
+
+	log.Debugf("/api/job/%s: get job %d", id, job.JobID)
+	rw.Header().Add("Content-Type", "application/json")
+	bw := bufio.NewWriter(rw)
+	defer bw.Flush()
+
+	payload := GetCompleteJobApiResponse{
+		Meta: job,
+		Data: data,
+	}
+
+	if err := json.NewEncoder(bw).Encode(payload); err != nil {
+		handleError(err, http.StatusInternalServerError, rw)
+		return
+	}
+}
+
+// getJobById godoc
+// @summary   Get job meta and configurable metric data
+// @tags Job query
+// @description Job to get is specified by database ID
+// @description Returns full job resource information according to 'JobMeta' scheme and all metrics according to 'JobData'.
 // @accept      json
 // @produce     json
 // @param       id          path     int                  true "Database ID of Job"
@@ -574,8 +1267,12 @@ func (api *RestApi) getJobById(rw http.ResponseWriter, r *http.Request) {
 		handleError(fmt.Errorf("finding job failed: %w", err), http.StatusUnprocessableEntity, rw)
 		return
 	}
+	if err := config.CheckClusterAccess(repository.GetUserFromContext(r.Context()), job.Cluster); err != nil {
+		handleError(err, http.StatusForbidden, rw)
+		return
+	}
 
-	job.Tags, err = api.JobRepository.GetTags(&job.ID)
+	job.Tags, err = api.JobRepository.GetTags(repository.GetUserFromContext(r.Context()), &job.ID)
 	if err != nil {
 		handleError(err, http.StatusInternalServerError, rw)
 		return
@@ -589,7 +1286,7 @@ func (api *RestApi) getJobById(rw http.ResponseWriter, r *http.Request) {
 
 	var metrics GetJobApiRequest
 	if err = decode(r.Body, &metrics); err != nil {
-		http.Error(rw, err.Error(), http.StatusBadRequest)
+		handleError(err, http.StatusBadRequest, rw)
 		return
 	}
 
@@ -646,6 +1343,7 @@ func (api *RestApi) getJobById(rw http.ResponseWriter, r *http.Request) {
 // @success     200     {object} schema.Job                "Updated job resource"
 // @failure     400     {object} api.ErrorResponse         "Bad Request"
 // @failure     401     {object} api.ErrorResponse         "Unauthorized"
+// @failure     403     {object} api.ErrorResponse         "Forbidden"
 // @failure     404     {object} api.ErrorResponse         "Job does not exist"
 // @failure     500     {object} api.ErrorResponse         "Internal Server Error"
 // @security    ApiKeyAuth
@@ -659,24 +1357,28 @@ func (api *RestApi) editMeta(rw http.ResponseWriter, r *http.Request) {
 
 	iid, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
 	if err != nil {
-		http.Error(rw, err.Error(), http.StatusBadRequest)
+		handleError(err, http.StatusBadRequest, rw)
 		return
 	}
 
 	job, err := api.JobRepository.FindById(iid)
 	if err != nil {
-		http.Error(rw, err.Error(), http.StatusNotFound)
+		handleError(err, http.StatusNotFound, rw)
+		return
+	}
+	if err := config.CheckClusterAccess(repository.GetUserFromContext(r.Context()), job.Cluster); err != nil {
+		handleError(err, http.StatusForbidden, rw)
 		return
 	}
 
 	var req EditMetaRequest
 	if err := decode(r.Body, &req); err != nil {
-		http.Error(rw, err.Error(), http.StatusBadRequest)
+		handleError(err, http.StatusBadRequest, rw)
 		return
 	}
 
 	if err := api.JobRepository.UpdateMetadata(job, req.Key, req.Value); err != nil {
-		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		handleError(err, http.StatusInternalServerError, rw)
 		return
 	}
 
@@ -685,87 +1387,381 @@ func (api *RestApi) editMeta(rw http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(rw).Encode(job)
 }
 
-// tagJob godoc
-// @summary     Adds one or more tags to a job
+// editOwnership godoc
+// @summary     Correct the user and project of a job
 // @tags Job add and modify
-// @description Adds tag(s) to a job specified by DB ID. Name and Type of Tag(s) can be chosen freely.
-// @description If tagged job is already finished: Tag will be written directly to respective archive files.
+// @description Changes the user and/or project of a job, e.g. if the scheduler reported the wrong ones.
+// @description Empty fields are kept. The meta.json of archived jobs is updated as well. Only allowed for admins.
 // @accept      json
 // @produce     json
-// @param       id      path     int                  true "Job Database ID"
-// @param       request body     api.TagJobApiRequest true "Array of tag-objects to add"
-// @success     200     {object} schema.Job                "Updated job resource"
-// @failure     400     {object} api.ErrorResponse         "Bad Request"
-// @failure     401     {object} api.ErrorResponse         "Unauthorized"
-// @failure     404     {object} api.ErrorResponse         "Job or tag does not exist"
-// @failure     500     {object} api.ErrorResponse         "Internal Server Error"
+// @param       id      path     int                      true "Job Database ID"
+// @param       request body     api.EditOwnershipRequest true "New user and/or project"
+// @success     200     {object} schema.Job               "Updated job resource"
+// @failure     400     {object} api.ErrorResponse        "Bad Request"
+// @failure     401     {object} api.ErrorResponse        "Unauthorized"
+// @failure     403     {object} api.ErrorResponse        "Forbidden"
+// @failure     404     {object} api.ErrorResponse        "Job does not exist"
+// @failure     500     {object} api.ErrorResponse        "Internal Server Error"
 // @security    ApiKeyAuth
-// @router      /jobs/tag_job/{id} [post]
-func (api *RestApi) tagJob(rw http.ResponseWriter, r *http.Request) {
+// @router      /jobs/edit_ownership/{id} [post]
+func (api *RestApi) editOwnership(rw http.ResponseWriter, r *http.Request) {
 	if user := repository.GetUserFromContext(r.Context()); user != nil &&
-		!user.HasRole(schema.RoleApi) {
-
-		handleError(fmt.Errorf("missing role: %v", schema.GetRoleString(schema.RoleApi)), http.StatusForbidden, rw)
+		!user.HasRole(schema.RoleAdmin) {
+		handleError(fmt.Errorf("missing role: %v", schema.GetRoleString(schema.RoleAdmin)), http.StatusForbidden, rw)
 		return
 	}
 
 	iid, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
 	if err != nil {
-		http.Error(rw, err.Error(), http.StatusBadRequest)
+		handleError(err, http.StatusBadRequest, rw)
 		return
 	}
 
-	job, err := api.JobRepository.FindById(iid)
-	if err != nil {
-		http.Error(rw, err.Error(), http.StatusNotFound)
+	var req EditOwnershipRequest
+	if err := decode(r.Body, &req); err != nil {
+		handleError(err, http.StatusBadRequest, rw)
+		return
+	}
+	if req.User == "" && req.Project == "" {
+		handleError(errors.New("user or project required"), http.StatusBadRequest, rw)
 		return
 	}
 
-	job.Tags, err = api.JobRepository.GetTags(&job.ID)
-	if err != nil {
-		http.Error(rw, err.Error(), http.StatusInternalServerError)
+	if err := api.JobRepository.UpdateOwnership(iid, req.User, req.Project); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			handleError(err, http.StatusNotFound, rw)
+		} else {
+			handleError(err, http.StatusInternalServerError, rw)
+		}
 		return
 	}
 
-	var req TagJobApiRequest
-	if err := decode(r.Body, &req); err != nil {
-		http.Error(rw, err.Error(), http.StatusBadRequest)
+	job, err := api.JobRepository.FindById(iid)
+	if err != nil {
+		handleError(err, http.StatusInternalServerError, rw)
 		return
 	}
 
-	for _, tag := range req {
-		tagId, err := api.JobRepository.AddTagOrCreate(job.ID, tag.Type, tag.Name)
-		if err != nil {
-			http.Error(rw, err.Error(), http.StatusInternalServerError)
+	rw.Header().Add("Content-Type", "application/json")
+	rw.WriteHeader(http.StatusOK)
+	json.NewEncoder(rw).Encode(job)
+}
+
+// getTags godoc
+// @summary     Lists tags with their usage counts
+// @tags Tag
+// @description Returns all tags visible to the user together with the number of jobs they are attached to,
+// @description sorted by count descending. Users that are not admin or support only get counts over the jobs
+// @description they are allowed to see.
+// @produce     json
+// @param       type     query    string false "Only return tags of this type"
+// @param       minCount query    int    false "Only return tags attached to at least this many jobs"
+// @success     200      {array}  api.TagCountApiResponse "Tags with counts"
+// @failure     400      {object} api.ErrorResponse       "Bad Request"
+// @failure     401      {object} api.ErrorResponse       "Unauthorized"
+// @failure     500      {object} api.ErrorResponse       "Internal Server Error"
+// @security    ApiKeyAuth
+// @router      /tags/ [get]
+func (api *RestApi) getTags(rw http.ResponseWriter, r *http.Request) {
+	minCount := 0
+	if val := r.URL.Query().Get("minCount"); val != "" {
+		n, err := strconv.Atoi(val)
+		if err != nil || n < 0 {
+			handleError(fmt.Errorf("invalid query parameter value: minCount"), http.StatusBadRequest, rw)
 			return
 		}
+		minCount = n
+	}
 
-		job.Tags = append(job.Tags, &schema.Tag{
-			ID:   tagId,
-			Type: tag.Type,
-			Name: tag.Name,
-		})
+	counts, err := api.JobRepository.TagCounts(repository.GetUserFromContext(r.Context()),
+		r.URL.Query().Get("type"), minCount)
+	if err != nil {
+		handleError(err, http.StatusInternalServerError, rw)
+		return
+	}
+
+	res := make([]TagCountApiResponse, 0, len(counts))
+	for _, tc := range counts {
+		res = append(res, TagCountApiResponse{Tag: tc.Tag, Count: tc.Count})
 	}
 
 	rw.Header().Add("Content-Type", "application/json")
 	rw.WriteHeader(http.StatusOK)
-	json.NewEncoder(rw).Encode(job)
+	json.NewEncoder(rw).Encode(res)
 }
 
-// startJob godoc
-// @summary     Adds a new job as "running"
-// @tags Job add and modify
+// getHealth godoc
+// @summary     Checks the health of the dependencies
+// @tags Health
+// @description Pings the database and checks the metric data repositories of all clusters.
+// @description Returns status 503 if any of them is down.
+// @produce     json
+// @success     200 {object} api.HealthApiResponse "All dependencies healthy"
+// @failure     401 {object} api.ErrorResponse     "Unauthorized"
+// @failure     503 {object} api.HealthApiResponse "Some dependency is down"
+// @security    ApiKeyAuth
+// @router      /health [get]
+func (api *RestApi) getHealth(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	res := HealthApiResponse{Healthy: true}
+	check := func(name string, err error) {
+		result := HealthCheckResult{Name: name, Healthy: err == nil}
+		if err != nil {
+			result.Error = err.Error()
+			res.Healthy = false
+		}
+		res.Checks = append(res.Checks, result)
+	}
+
+	err := api.JobRepository.DB.PingContext(ctx)
+	if err != nil {
+		log.Warnf("database is unhealthy: %s", err.Error())
+	}
+	check("database", err)
+
+	results := metricdata.HealthCheck(ctx)
+	clusters := make([]string, 0, len(results))
+	for cluster := range results {
+		clusters = append(clusters, cluster)
+	}
+	sort.Strings(clusters)
+	for _, cluster := range clusters {
+		check("metricdata/"+cluster, results[cluster])
+	}
+
+	status := http.StatusOK
+	if !res.Healthy {
+		status = http.StatusServiceUnavailable
+	}
+
+	rw.Header().Add("Content-Type", "application/json")
+	rw.WriteHeader(status)
+	json.NewEncoder(rw).Encode(res)
+}
+
+// reindex godoc
+// @summary     Reindexes the database
+// @tags Admin
+// @description Runs REINDEX and ANALYZE on the database (OPTIMIZE TABLE and ANALYZE TABLE for MySQL).
+// @description With recreate, the indexes of the job table are dropped and created again first. Only allowed for admins.
+// @produce     json
+// @param       recreate query    bool                   false "Drop and recreate the job indexes"
+// @success     200      {object} api.ReindexApiResponse "Durations of the steps"
+// @failure     401      {object} api.ErrorResponse      "Unauthorized"
+// @failure     403      {object} api.ErrorResponse      "Forbidden"
+// @failure     500      {object} api.ErrorResponse      "Internal Server Error"
+// @security    ApiKeyAuth
+// @router      /admin/reindex [post]
+func (api *RestApi) reindex(rw http.ResponseWriter, r *http.Request) {
+	if user := repository.GetUserFromContext(r.Context()); user != nil &&
+		!user.HasRole(schema.RoleAdmin) {
+
+		handleError(fmt.Errorf("missing role: %v", schema.GetRoleString(schema.RoleAdmin)), http.StatusForbidden, rw)
+		return
+	}
+
+	recreate := r.URL.Query().Get("recreate") == "true"
+	start := time.Now()
+	timings, err := api.JobRepository.Reindex(recreate)
+	if err != nil {
+		handleError(fmt.Errorf("reindexing failed: %w", err), http.StatusInternalServerError, rw)
+		return
+	}
+
+	res := ReindexApiResponse{
+		Recreated:  recreate,
+		RecreateMs: timings.Recreate.Milliseconds(),
+		ReindexMs:  timings.Reindex.Milliseconds(),
+		AnalyzeMs:  timings.Analyze.Milliseconds(),
+		TotalMs:    time.Since(start).Milliseconds(),
+	}
+	log.Infof("database reindexed in %d ms (recreate: %v)", res.TotalMs, recreate)
+
+	rw.Header().Add("Content-Type", "application/json")
+	rw.WriteHeader(http.StatusOK)
+	json.NewEncoder(rw).Encode(res)
+}
+
+// getArchivings godoc
+// @summary     Lists ongoing archiving operations
+// @tags Admin
+// @description Lists the jobs queued for archiving or being archived, ordered by the time archiving was requested.
+// @description Only allowed for admins.
+// @produce     json
+// @success     200 {array}  api.ArchivingApiResponse "Ongoing archiving operations"
+// @failure     401 {object} api.ErrorResponse        "Unauthorized"
+// @failure     403 {object} api.ErrorResponse        "Forbidden"
+// @security    ApiKeyAuth
+// @router      /admin/archiving [get]
+func (api *RestApi) getArchivings(rw http.ResponseWriter, r *http.Request) {
+	if user := repository.GetUserFromContext(r.Context()); user != nil &&
+		!user.HasRole(schema.RoleAdmin) {
+
+		handleError(fmt.Errorf("missing role: %v", schema.GetRoleString(schema.RoleAdmin)), http.StatusForbidden, rw)
+		return
+	}
+
+	tasks := api.JobRepository.Archivings()
+	res := make([]ArchivingApiResponse, 0, len(tasks))
+	for _, task := range tasks {
+		archiving := ArchivingApiResponse{
+			ID:        task.ID,
+			JobID:     task.JobID,
+			Cluster:   task.Cluster,
+			QueuedAt:  task.Queued.Unix(),
+			Phase:     task.Phase,
+			Cancelled: task.Cancelled,
+		}
+		if !task.Started.IsZero() {
+			archiving.StartedAt = task.Started.Unix()
+		}
+		res = append(res, archiving)
+	}
+
+	rw.Header().Add("Content-Type", "application/json")
+	rw.WriteHeader(http.StatusOK)
+	json.NewEncoder(rw).Encode(res)
+}
+
+// cancelArchiving godoc
+// @summary     Cancels an ongoing archiving operation
+// @tags Admin
+// @description Job is specified by database ID. A queued job is skipped, loading the metric data of a job being archived
+// @description is cancelled. Archiving the job fails in both cases. Only allowed for admins.
+// @produce     json
+// @param       id  path     int                            true "Database ID of Job"
+// @success     200 {object} api.CancelArchivingApiResponse "Success message"
+// @failure     400 {object} api.ErrorResponse              "Bad Request"
+// @failure     401 {object} api.ErrorResponse              "Unauthorized"
+// @failure     403 {object} api.ErrorResponse              "Forbidden"
+// @failure     404 {object} api.ErrorResponse              "Resource not found: job is not being archived"
+// @security    ApiKeyAuth
+// @router      /admin/archiving/{id} [delete]
+func (api *RestApi) cancelArchiving(rw http.ResponseWriter, r *http.Request) {
+	if user := repository.GetUserFromContext(r.Context()); user != nil &&
+		!user.HasRole(schema.RoleAdmin) {
+
+		handleError(fmt.Errorf("missing role: %v", schema.GetRoleString(schema.RoleAdmin)), http.StatusForbidden, rw)
+		return
+	}
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		handleError(fmt.Errorf("integer expected in path for id: %w", err), http.StatusBadRequest, rw)
+		return
+	}
+
+	if err := api.JobRepository.CancelArchiving(id); err != nil {
+		handleError(fmt.Errorf("job (dbid: %d) is not being archived", id), http.StatusNotFound, rw)
+		return
+	}
+	log.Infof("archiving job (dbid: %d) cancelled", id)
+
+	rw.Header().Add("Content-Type", "application/json")
+	rw.WriteHeader(http.StatusOK)
+	json.NewEncoder(rw).Encode(CancelArchivingApiResponse{
+		Message: fmt.Sprintf("Archiving of job %d cancelled", id),
+	})
+}
+
+// tagJob godoc
+// @summary     Adds one or more tags to a job
+// @tags Job add and modify
+// @description Adds tag(s) to a job specified by DB ID. Name and Type of Tag(s) can be chosen freely.
+// @description If tagged job is already finished: Tag will be written directly to respective archive files.
+// @accept      json
+// @produce     json
+// @param       id      path     int                  true "Job Database ID"
+// @param       request body     api.TagJobApiRequest true "Array of tag-objects to add"
+// @success     200     {object} schema.Job                "Updated job resource"
+// @failure     400     {object} api.ErrorResponse         "Bad Request"
+// @failure     401     {object} api.ErrorResponse         "Unauthorized"
+// @failure     403     {object} api.ErrorResponse         "Forbidden"
+// @failure     404     {object} api.ErrorResponse         "Job or tag does not exist"
+// @failure     500     {object} api.ErrorResponse         "Internal Server Error"
+// @security    ApiKeyAuth
+// @router      /jobs/tag_job/{id} [post]
+func (api *RestApi) tagJob(rw http.ResponseWriter, r *http.Request) {
+	if user := repository.GetUserFromContext(r.Context()); user != nil &&
+		!user.HasRole(schema.RoleApi) {
+
+		handleError(fmt.Errorf("missing role: %v", schema.GetRoleString(schema.RoleApi)), http.StatusForbidden, rw)
+		return
+	}
+
+	iid, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		handleError(err, http.StatusBadRequest, rw)
+		return
+	}
+
+	job, err := api.JobRepository.FindById(iid)
+	if err != nil {
+		handleError(err, http.StatusNotFound, rw)
+		return
+	}
+	if err := config.CheckClusterAccess(repository.GetUserFromContext(r.Context()), job.Cluster); err != nil {
+		handleError(err, http.StatusForbidden, rw)
+		return
+	}
+
+	job.Tags, err = api.JobRepository.GetTags(repository.GetUserFromContext(r.Context()), &job.ID)
+	if err != nil {
+		handleError(err, http.StatusInternalServerError, rw)
+		return
+	}
+
+	var req TagJobApiRequest
+	if err := decode(r.Body, &req); err != nil {
+		handleError(err, http.StatusBadRequest, rw)
+		return
+	}
+
+	for _, tag := range req {
+		tagId, err := api.JobRepository.AddTagOrCreate(repository.GetUserFromContext(r.Context()), job.ID, tag.Type, tag.Name, tag.Scope)
+		if err != nil {
+			handleError(err, http.StatusInternalServerError, rw)
+			return
+		}
+
+		job.Tags = append(job.Tags, &schema.Tag{
+			ID:    tagId,
+			Type:  tag.Type,
+			Name:  tag.Name,
+			Scope: tag.Scope,
+		})
+	}
+
+	rw.Header().Add("Content-Type", "application/json")
+	rw.WriteHeader(http.StatusOK)
+	json.NewEncoder(rw).Encode(job)
+}
+
+// startJob godoc
+// @summary     Adds a new job as "running"
+// @tags Job add and modify
 // @description Job specified in request body will be saved to database as "running" with new DB ID.
 // @description Job specifications follow the 'JobMeta' scheme, API will fail to execute if requirements are not met.
+// @description If 'subCluster' is given it is used as is, otherwise it is inferred from the hostname of the first resource.
+// @description If 'partition' is missing and the cluster config declares partitions, it is inferred from the hostnames.
+// @description If the job was already started, the config option 'duplicate-start-job-mode' decides whether 422 is returned (reject)
+// @description or the metadata and tags of the request are added to the existing job (merge).
 // @accept      json
 // @produce     json
 // @param       request body     schema.JobMeta          true "Job to add"
+// @success     200     {object} api.StartJobApiResponse      "Request merged into the already started job (merge mode)"
 // @success     201     {object} api.StartJobApiResponse      "Job added successfully"
 // @failure     400     {object} api.ErrorResponse            "Bad Request"
 // @failure     401     {object} api.ErrorResponse            "Unauthorized"
 // @failure     403     {object} api.ErrorResponse            "Forbidden"
-// @failure     422     {object} api.ErrorResponse            "Unprocessable Entity: The combination of jobId, clusterId and startTime does already exist"
+// @failure     409     {object} api.ErrorResponse            "Conflict: The tags of the request conflict with each other"
+// @failure     413     {object} api.ErrorResponse            "Request Entity Too Large: The request body exceeds max-request-body-size"
+// @failure     422     {object} api.ErrorResponse            "Unprocessable Entity: The combination of jobId, clusterId and startTime does already exist (code duplicate_job) or resources do not match the cluster topology"
+// @failure     429     {object} api.ErrorResponse            "Too Many Requests: The user already has the maximum number of running jobs"
 // @failure     500     {object} api.ErrorResponse            "Internal Server Error"
+// @failure     503     {object} api.ErrorResponse            "Service Unavailable: The ingest-timeout expired while waiting for other requests"
 // @security    ApiKeyAuth
 // @router      /jobs/start_job/ [post]
 func (api *RestApi) startJob(rw http.ResponseWriter, r *http.Request) {
@@ -782,19 +1778,72 @@ func (api *RestApi) startJob(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := checkApiKeyCluster(r, req.Cluster); err != nil {
+		handleError(err, http.StatusForbidden, rw)
+		return
+	}
+
 	if req.State == "" {
 		req.State = schema.JobStateRunning
 	}
+	// Secrets must not end up in the database or the job-archive.
+	req.MetaData = repository.RedactMetadata(req.MetaData)
+	// An explicitly given subcluster takes precedence over the one inferred from the hostname.
+	if req.SubCluster != "" {
+		if _, err := archive.GetSubCluster(req.Cluster, req.SubCluster); err != nil {
+			handleError(fmt.Errorf("invalid subCluster: %w", err), http.StatusBadRequest, rw)
+			return
+		}
+	} else if err := archive.AssignSubCluster(&req.BaseJob); err != nil {
+		handleError(fmt.Errorf("inferring subCluster failed, set 'subCluster' explicitly: %w", err), http.StatusBadRequest, rw)
+		return
+	}
+	if err := archive.AssignPartition(&req.BaseJob); err != nil {
+		handleError(fmt.Errorf("inferring partition failed, set 'partition' explicitly: %w", err), http.StatusBadRequest, rw)
+		return
+	}
 	if err := importer.SanityChecks(&req.BaseJob); err != nil {
 		handleError(err, http.StatusBadRequest, rw)
 		return
 	}
+	if err := archive.CheckResources(&req.BaseJob); err != nil {
+		handleError(err, http.StatusUnprocessableEntity, rw)
+		return
+	}
+	if fr := config.GetFilterRanges(req.Cluster); fr != nil && fr.NumNodes != nil &&
+		(int(req.NumNodes) < fr.NumNodes.From || int(req.NumNodes) > fr.NumNodes.To) {
+		log.Warnf("start_job: numNodes %d of job %d is outside of the filterRanges [%d, %d] of cluster '%s'",
+			req.NumNodes, req.JobID, fr.NumNodes.From, fr.NumNodes.To, req.Cluster)
+	}
 
 	// aquire lock to avoid race condition between API calls
 	var unlockOnce sync.Once
 	api.RepositoryMutex.Lock()
 	defer unlockOnce.Do(api.RepositoryMutex.Unlock)
 
+	// Do not insert the job if the scheduler already gave up waiting for the lock
+	if err := r.Context().Err(); err != nil {
+		handleError(fmt.Errorf("waiting for the job repository failed: %w", err), http.StatusServiceUnavailable, rw)
+		return
+	}
+
+	// The exemption applies to the authenticated user submitting the job
+	// (e.g. a machine account), not to the owner of the job.
+	submitter := repository.GetUserFromContext(r.Context())
+	if limit := config.Keys.MaxRunningJobsPerUser; limit > 0 && req.State == schema.JobStateRunning &&
+		(submitter == nil || !util.Contains(config.Keys.RunningJobsLimitExempt, submitter.Username)) {
+		count, err := api.JobRepository.CountRunningJobs(req.User)
+		if err != nil {
+			handleError(fmt.Errorf("counting running jobs failed: %w", err), http.StatusInternalServerError, rw)
+			return
+		}
+		if count >= limit {
+			handleError(fmt.Errorf("user '%s' already has %d running jobs (limit: %d)", req.User, count, limit),
+				http.StatusTooManyRequests, rw)
+			return
+		}
+	}
+
 	// Check if combination of (job_id, cluster_id, start_time) already exists:
 	jobs, err := api.JobRepository.FindAll(&req.JobID, &req.Cluster, nil)
 	if err != nil && err != sql.ErrNoRows {
@@ -803,27 +1852,56 @@ func (api *RestApi) startJob(rw http.ResponseWriter, r *http.Request) {
 	} else if err == nil {
 		for _, job := range jobs {
 			if (req.StartTime - job.StartTimeUnix) < 86400 {
-				handleError(fmt.Errorf("a job with that jobId, cluster and startTime already exists: dbid: %d, jobid: %d", job.ID, job.JobID), http.StatusUnprocessableEntity, rw)
+				if config.Keys.DuplicateStartJobMode != "merge" {
+					handleError(withCode("duplicate_job", fmt.Errorf("a job with that jobId, cluster and startTime already exists: dbid: %d, jobid: %d", job.ID, job.JobID)), http.StatusUnprocessableEntity, rw)
+					return
+				}
+
+				unlockOnce.Do(api.RepositoryMutex.Unlock)
+				if err := api.mergeStartJob(r, job, &req); err != nil {
+					handleError(fmt.Errorf("merging into job %d failed: %w", job.ID, err), http.StatusInternalServerError, rw)
+					return
+				}
+
+				log.Printf("duplicate start of job (id: %d) merged: cluster=%s, jobId=%d", job.ID, job.Cluster, job.JobID)
+				rw.Header().Add("Content-Type", "application/json")
+				rw.WriteHeader(http.StatusOK)
+				json.NewEncoder(rw).Encode(StartJobApiResponse{
+					DBID: job.ID,
+				})
 				return
 			}
 		}
 	}
 
-	id, err := api.JobRepository.Start(&req)
-	if err != nil {
-		handleError(fmt.Errorf("insert into database failed: %w", err), http.StatusInternalServerError, rw)
-		return
-	}
-	// unlock here, adding Tags can be async
-	unlockOnce.Do(api.RepositoryMutex.Unlock)
+	// The job and its tags are inserted together, a failing tag leaves no job behind.
+	var id int64
+	if err := api.JobRepository.WithTx(func(tx *repository.JobRepository) error {
+		if id, err = tx.Start(r.Context(), &req); err != nil {
+			return fmt.Errorf("insert into database failed: %w", err)
+		}
 
-	for _, tag := range req.Tags {
-		if _, err := api.JobRepository.AddTagOrCreate(id, tag.Type, tag.Name); err != nil {
-			http.Error(rw, err.Error(), http.StatusInternalServerError)
-			handleError(fmt.Errorf("adding tag to new job %d failed: %w", id, err), http.StatusInternalServerError, rw)
-			return
+		for _, tag := range req.Tags {
+			if _, err := tx.AddTagOrCreate(repository.GetUserFromContext(r.Context()), id, tag.Type, tag.Name, tag.Scope); err != nil {
+				return fmt.Errorf("adding tag to new job failed: %w", err)
+			}
+		}
+
+		if config.Keys.AutoTagHardware {
+			if err := tx.AddHardwareTag(id, req.Cluster, req.SubCluster); err != nil {
+				log.Warnf("adding hardware tag to new job %d failed: %s", id, err.Error())
+			}
+		}
+		return nil
+	}); err != nil {
+		if errors.Is(err, repository.ErrConflict) {
+			handleError(err, http.StatusConflict, rw)
+		} else {
+			handleError(err, http.StatusInternalServerError, rw)
 		}
+		return
 	}
+	unlockOnce.Do(api.RepositoryMutex.Unlock)
 
 	log.Printf("new job (id: %d): cluster=%s, jobId=%d, user=%s, startTime=%d", id, req.Cluster, req.JobID, req.User, req.StartTime)
 	rw.Header().Add("Content-Type", "application/json")
@@ -833,6 +1911,41 @@ func (api *RestApi) startJob(rw http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// mergeStartJob adds the metadata and tags of a repeated start request to the
+// already started job. Tags the job already has are skipped.
+func (api *RestApi) mergeStartJob(r *http.Request, job *schema.Job, req *schema.JobMeta) error {
+	if len(req.MetaData) != 0 {
+		if err := api.JobRepository.MergeMetadata(job, req.MetaData); err != nil {
+			return err
+		}
+	}
+
+	tags, err := api.JobRepository.GetTags(nil, &job.ID)
+	if err != nil {
+		return err
+	}
+	for _, tag := range req.Tags {
+		scope := tag.Scope
+		if scope == "" {
+			scope = repository.TagScopeGlobal
+		}
+
+		exists := false
+		for _, t := range tags {
+			exists = exists || (t.Type == tag.Type && t.Name == tag.Name && t.Scope == scope)
+		}
+		if exists {
+			continue
+		}
+
+		if _, err := api.JobRepository.AddTagOrCreate(repository.GetUserFromContext(r.Context()), job.ID, tag.Type, tag.Name, scope); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // stopJobById godoc
 // @summary     Marks job as completed and triggers archiving
 // @tags Job add and modify
@@ -847,7 +1960,8 @@ func (api *RestApi) startJob(rw http.ResponseWriter, r *http.Request) {
 // @failure     401     {object} api.ErrorResponse          "Unauthorized"
 // @failure     403     {object} api.ErrorResponse          "Forbidden"
 // @failure     404     {object} api.ErrorResponse          "Resource not found"
-// @failure     422     {object} api.ErrorResponse          "Unprocessable Entity: finding job failed: sql: no rows in result set"
+// @failure     413     {object} api.ErrorResponse          "Request Entity Too Large: The request body exceeds max-request-body-size"
+// @failure     422     {object} api.ErrorResponse          "Unprocessable Entity: finding job failed: sql: no rows in result set or job is not running"
 // @failure     500     {object} api.ErrorResponse          "Internal Server Error"
 // @security    ApiKeyAuth
 // @router      /jobs/stop_job/{id} [post]
@@ -886,14 +2000,25 @@ func (api *RestApi) stopJobById(rw http.ResponseWriter, r *http.Request) {
 		handleError(fmt.Errorf("finding job failed: %w", err), http.StatusUnprocessableEntity, rw)
 		return
 	}
+	if err := checkApiKeyCluster(r, job.Cluster); err != nil {
+		handleError(err, http.StatusForbidden, rw)
+		return
+	}
+	if err := config.CheckClusterAccess(repository.GetUserFromContext(r.Context()), job.Cluster); err != nil {
+		handleError(err, http.StatusForbidden, rw)
+		return
+	}
 
-	api.checkAndHandleStopJob(rw, job, req)
+	api.checkAndHandleStopJob(r.Context(), rw, job, req)
 }
 
 // stopJobByRequest godoc
 // @summary     Marks job as completed and triggers archiving
 // @tags Job add and modify
 // @description Job to stop is specified by request body. All fields are required in this case.
+// @description If the job is unknown, the config option 'stop-job-mode' decides whether 404 is returned (strict)
+// @description or a minimal job is created from the request and stopped (lenient).
+// @description Job states that are neither builtin nor configured in 'job-states' are stored as "unknown".
 // @description Returns full job resource information according to 'JobMeta' scheme.
 // @produce     json
 // @param       request body     api.StopJobApiRequest true "All fields required"
@@ -901,8 +2026,9 @@ func (api *RestApi) stopJobById(rw http.ResponseWriter, r *http.Request) {
 // @failure     400     {object} api.ErrorResponse          "Bad Request"
 // @failure     401     {object} api.ErrorResponse          "Unauthorized"
 // @failure     403     {object} api.ErrorResponse          "Forbidden"
-// @failure     404     {object} api.ErrorResponse          "Resource not found"
-// @failure     422     {object} api.ErrorResponse          "Unprocessable Entity: finding job failed: sql: no rows in result set"
+// @failure     404     {object} api.ErrorResponse          "Resource not found: job unknown (strict mode)"
+// @failure     413     {object} api.ErrorResponse          "Request Entity Too Large: The request body exceeds max-request-body-size"
+// @failure     422     {object} api.ErrorResponse          "Unprocessable Entity: finding job failed or job is not running"
 // @failure     500     {object} api.ErrorResponse          "Internal Server Error"
 // @security    ApiKeyAuth
 // @router      /jobs/stop_job/ [post]
@@ -929,250 +2055,891 @@ func (api *RestApi) stopJobByRequest(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	job, err = api.JobRepository.Find(req.JobId, req.Cluster, req.StartTime)
+	job, err = api.JobRepository.Find(r.Context(), req.JobId, req.Cluster, req.StartTime)
 
-	if err != nil {
+	if err == sql.ErrNoRows {
+		if config.Keys.StopJobMode != "lenient" {
+			log.Infof("stop_job: unknown job (jobId: %d), responding with not found (strict mode)", *req.JobId)
+			handleError(fmt.Errorf("finding job failed: %w", err), http.StatusNotFound, rw)
+			return
+		}
+
+		if req.Cluster != nil {
+			if err := checkApiKeyCluster(r, *req.Cluster); err != nil {
+				handleError(err, http.StatusForbidden, rw)
+				return
+			}
+		}
+		log.Infof("stop_job: unknown job (jobId: %d), creating job from stop request (lenient mode)", *req.JobId)
+		job, err = api.createJobFromStopRequest(r.Context(), req)
+		if err != nil {
+			handleError(fmt.Errorf("creating unknown job failed: %w", err), http.StatusBadRequest, rw)
+			return
+		}
+	} else if err != nil {
 		handleError(fmt.Errorf("finding job failed: %w", err), http.StatusUnprocessableEntity, rw)
 		return
 	}
+	if err := checkApiKeyCluster(r, job.Cluster); err != nil {
+		handleError(err, http.StatusForbidden, rw)
+		return
+	}
 
-	api.checkAndHandleStopJob(rw, job, req)
+	api.checkAndHandleStopJob(r.Context(), rw, job, req)
 }
 
-// deleteJobById godoc
-// @summary     Remove a job from the sql database
-// @tags Job remove
-// @description Job to remove is specified by database ID. This will not remove the job from the job archive.
+// createJobFromStopRequest inserts a minimal running job for a stop request
+// of a job that was never started. As the resources of the job are unknown,
+// monitoring is disabled for it.
+func (api *RestApi) createJobFromStopRequest(ctx context.Context, req StopJobApiRequest) (*schema.Job, error) {
+	if req.Cluster == nil || req.StartTime == nil {
+		return nil, errors.New("the fields 'cluster' and 'startTime' are required for unknown jobs")
+	}
+	job := &schema.JobMeta{
+		BaseJob: schema.BaseJob{
+			JobID:            *req.JobId,
+			Cluster:          *req.Cluster,
+			State:            schema.JobStateRunning,
+			MonitoringStatus: schema.MonitoringStatusDisabled,
+			Exclusive:        1,
+			Resources:        []*schema.Resource{},
+		},
+		StartTime: *req.StartTime,
+	}
+
+	api.RepositoryMutex.Lock()
+	id, err := api.JobRepository.Start(ctx, job)
+	api.RepositoryMutex.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	return api.JobRepository.FindById(id)
+}
+
+// resumeJob godoc
+// @summary     Marks a stopped job as running again
+// @tags Job add and modify
+// @description Job to resume is specified by request body. Use this if a job was stopped in error.
+// @description The job state is set back to running and an already written job archive is removed.
+// @accept      json
 // @produce     json
-// @param       id      path     int                   true "Database ID of Job"
-// @success     200     {object} api.DeleteJobApiResponse     "Success message"
+// @param       request body     api.ResumeJobApiRequest true "jobId, cluster and startTime of job"
+// @success     200     {object} schema.JobMeta             "Job resource"
 // @failure     400     {object} api.ErrorResponse          "Bad Request"
 // @failure     401     {object} api.ErrorResponse          "Unauthorized"
 // @failure     403     {object} api.ErrorResponse          "Forbidden"
 // @failure     404     {object} api.ErrorResponse          "Resource not found"
+// @failure     413     {object} api.ErrorResponse          "Request Entity Too Large: The request body exceeds max-request-body-size"
 // @failure     422     {object} api.ErrorResponse          "Unprocessable Entity: finding job failed: sql: no rows in result set"
 // @failure     500     {object} api.ErrorResponse          "Internal Server Error"
 // @security    ApiKeyAuth
-// @router      /jobs/delete_job/{id} [delete]
-func (api *RestApi) deleteJobById(rw http.ResponseWriter, r *http.Request) {
-	if user := repository.GetUserFromContext(r.Context()); user != nil && !user.HasRole(schema.RoleApi) {
-		handleError(fmt.Errorf("missing role: %v", schema.GetRoleString(schema.RoleApi)), http.StatusForbidden, rw)
+// @router      /jobs/resume_job/ [post]
+func (api *RestApi) resumeJob(rw http.ResponseWriter, r *http.Request) {
+	if user := repository.GetUserFromContext(r.Context()); user != nil &&
+		!user.HasRole(schema.RoleApi) {
+		handleError(fmt.Errorf("missing role: %v", schema.GetRoleString(schema.RoleApi)), http.StatusForbidden, rw)
+		return
+	}
+
+	// Parse request body
+	req := ResumeJobApiRequest{}
+	if err := decode(r.Body, &req); err != nil {
+		handleError(fmt.Errorf("parsing request body failed: %w", err), http.StatusBadRequest, rw)
+		return
+	}
+
+	if req.JobId == nil {
+		handleError(errors.New("the field 'jobId' is required"), http.StatusBadRequest, rw)
+		return
+	}
+
+	job, err := api.JobRepository.Find(r.Context(), req.JobId, req.Cluster, req.StartTime)
+	if err != nil {
+		handleError(fmt.Errorf("finding job failed: %w", err), http.StatusUnprocessableEntity, rw)
+		return
+	}
+	if err := checkApiKeyCluster(r, job.Cluster); err != nil {
+		handleError(err, http.StatusForbidden, rw)
+		return
+	}
+
+	if job.State == schema.JobStateRunning {
+		handleError(errors.New("only stopped jobs can be resumed"), http.StatusBadRequest, rw)
+		return
+	}
+
+	// Archiving might still be in progress, wait for it before removing the archive
+	if job.MonitoringStatus == schema.MonitoringStatusRunningOrArchiving {
+		api.JobRepository.WaitForArchivingOf(job.ID)
+	}
+
+	if err := api.JobRepository.Resume(job.ID); err != nil {
+		handleError(fmt.Errorf("resuming job failed: %w", err), http.StatusInternalServerError, rw)
+		return
+	}
+
+	if ar := archive.GetHandle(); ar.Exists(job) {
+		ar.CleanUp([]*schema.Job{job})
+	}
+
+	job.State = schema.JobStateRunning
+	job.Duration = 0
+	job.MonitoringStatus = schema.MonitoringStatusRunningOrArchiving
+
+	log.Printf("resumed job (dbid: %d): cluster=%s, jobId=%d, user=%s, startTime=%s", job.ID, job.Cluster, job.JobID, job.User, job.StartTime)
+	rw.Header().Add("Content-Type", "application/json")
+	rw.WriteHeader(http.StatusOK)
+	json.NewEncoder(rw).Encode(job)
+}
+
+// deleteJobById godoc
+// @summary     Remove a job from the sql database
+// @tags Job remove
+// @description Job to remove is specified by database ID. This will not remove the job from the job archive.
+// @produce     json
+// @param       id      path     int                   true "Database ID of Job"
+// @param       dryRun  query    bool                  false "Only report what would be deleted (also: header X-Dry-Run)"
+// @success     200     {object} api.DeleteJobApiResponse     "Success message"
+// @failure     400     {object} api.ErrorResponse          "Bad Request"
+// @failure     401     {object} api.ErrorResponse          "Unauthorized"
+// @failure     403     {object} api.ErrorResponse          "Forbidden"
+// @failure     404     {object} api.ErrorResponse          "Resource not found"
+// @failure     422     {object} api.ErrorResponse          "Unprocessable Entity: finding job failed: sql: no rows in result set"
+// @failure     500     {object} api.ErrorResponse          "Internal Server Error"
+// @security    ApiKeyAuth
+// @router      /jobs/delete_job/{id} [delete]
+func (api *RestApi) deleteJobById(rw http.ResponseWriter, r *http.Request) {
+	if user := repository.GetUserFromContext(r.Context()); user != nil && !user.HasRole(schema.RoleApi) {
+		handleError(fmt.Errorf("missing role: %v", schema.GetRoleString(schema.RoleApi)), http.StatusForbidden, rw)
+		return
+	}
+
+	// Fetch job (that will be stopped) from db
+	id, ok := mux.Vars(r)["id"]
+	if !ok {
+		handleError(errors.New("the parameter 'id' is required"), http.StatusBadRequest, rw)
+		return
+	}
+	dbid, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		handleError(fmt.Errorf("integer expected in path for id: %w", err), http.StatusBadRequest, rw)
+		return
+	}
+
+	dry := dryRun(r)
+	if err := api.JobRepository.DeleteJobById(dbid, dry); err != nil {
+		handleError(fmt.Errorf("deleting job failed: %w", err), http.StatusUnprocessableEntity, rw)
+		return
+	}
+
+	res := DeleteJobApiResponse{
+		Message: fmt.Sprintf("Successfully deleted job %d", dbid),
+		DryRun:  dry,
+		JobIds:  []int64{dbid},
+	}
+	if dry {
+		res.Message = fmt.Sprintf("Dry run: would delete job %d", dbid)
+	}
+	rw.Header().Add("Content-Type", "application/json")
+	rw.WriteHeader(http.StatusOK)
+	json.NewEncoder(rw).Encode(res)
+}
+
+// deleteJobByRequest godoc
+// @summary     Remove a job from the sql database
+// @tags Job remove
+// @description Job to delete is specified by request body. All fields are required in this case.
+// @accept      json
+// @produce     json
+// @param       request body     api.DeleteJobApiRequest true "All fields required"
+// @param       dryRun  query    bool                  false "Only report what would be deleted (also: header X-Dry-Run)"
+// @success     200     {object} api.DeleteJobApiResponse     "Success message"
+// @failure     400     {object} api.ErrorResponse          "Bad Request"
+// @failure     401     {object} api.ErrorResponse          "Unauthorized"
+// @failure     403     {object} api.ErrorResponse          "Forbidden"
+// @failure     404     {object} api.ErrorResponse          "Resource not found"
+// @failure     422     {object} api.ErrorResponse          "Unprocessable Entity: finding job failed: sql: no rows in result set"
+// @failure     500     {object} api.ErrorResponse          "Internal Server Error"
+// @security    ApiKeyAuth
+// @router      /jobs/delete_job/ [delete]
+func (api *RestApi) deleteJobByRequest(rw http.ResponseWriter, r *http.Request) {
+	if user := repository.GetUserFromContext(r.Context()); user != nil &&
+		!user.HasRole(schema.RoleApi) {
+		handleError(fmt.Errorf("missing role: %v", schema.GetRoleString(schema.RoleApi)), http.StatusForbidden, rw)
+		return
+	}
+
+	// Parse request body
+	req := DeleteJobApiRequest{}
+	if err := decode(r.Body, &req); err != nil {
+		handleError(fmt.Errorf("parsing request body failed: %w", err), http.StatusBadRequest, rw)
+		return
+	}
+
+	// Fetch job (that will be deleted) from db
+	var job *schema.Job
+	var err error
+	if req.JobId == nil {
+		handleError(errors.New("the field 'jobId' is required"), http.StatusBadRequest, rw)
+		return
+	}
+
+	job, err = api.JobRepository.Find(r.Context(), req.JobId, req.Cluster, req.StartTime)
+
+	if err != nil {
+		handleError(fmt.Errorf("finding job failed: %w", err), http.StatusUnprocessableEntity, rw)
+		return
+	}
+
+	dry := dryRun(r)
+	err = api.JobRepository.DeleteJobById(job.ID, dry)
+	if err != nil {
+		handleError(fmt.Errorf("deleting job failed: %w", err), http.StatusUnprocessableEntity, rw)
+		return
+	}
+
+	res := DeleteJobApiResponse{
+		Message: fmt.Sprintf("Successfully deleted job %d", job.ID),
+		DryRun:  dry,
+		JobIds:  []int64{job.ID},
+	}
+	if dry {
+		res.Message = fmt.Sprintf("Dry run: would delete job %d", job.ID)
+	}
+	rw.Header().Add("Content-Type", "application/json")
+	rw.WriteHeader(http.StatusOK)
+	json.NewEncoder(rw).Encode(res)
+}
+
+// deleteJobBefore godoc
+// @summary     Remove a job from the sql database
+// @tags Job remove
+// @description Remove all jobs with start time before timestamp. The jobs will not be removed from the job archive.
+// @produce     json
+// @param       ts      path     int                   true "Unix epoch timestamp"
+// @param       dryRun  query    bool                  false "Only report what would be deleted (also: header X-Dry-Run)"
+// @success     200     {object} api.DeleteJobApiResponse     "Success message"
+// @failure     400     {object} api.ErrorResponse          "Bad Request"
+// @failure     401     {object} api.ErrorResponse          "Unauthorized"
+// @failure     403     {object} api.ErrorResponse          "Forbidden"
+// @failure     404     {object} api.ErrorResponse          "Resource not found"
+// @failure     422     {object} api.ErrorResponse          "Unprocessable Entity: finding job failed: sql: no rows in result set"
+// @failure     500     {object} api.ErrorResponse          "Internal Server Error"
+// @security    ApiKeyAuth
+// @router      /jobs/delete_job_before/{ts} [delete]
+func (api *RestApi) deleteJobBefore(rw http.ResponseWriter, r *http.Request) {
+	if user := repository.GetUserFromContext(r.Context()); user != nil && !user.HasRole(schema.RoleApi) {
+		handleError(fmt.Errorf("missing role: %v", schema.GetRoleString(schema.RoleApi)), http.StatusForbidden, rw)
+		return
+	}
+
+	var ids []int64
+	dry := dryRun(r)
+	// Fetch job (that will be stopped) from db
+	id, ok := mux.Vars(r)["ts"]
+	var err error
+	if ok {
+		ts, e := strconv.ParseInt(id, 10, 64)
+		if e != nil {
+			handleError(fmt.Errorf("integer expected in path for ts: %w", e), http.StatusBadRequest, rw)
+			return
+		}
+
+		ids, err = api.JobRepository.DeleteJobsBefore(ts, dry)
+	} else {
+		handleError(errors.New("the parameter 'ts' is required"), http.StatusBadRequest, rw)
+		return
+	}
+	if err != nil {
+		handleError(fmt.Errorf("deleting jobs failed: %w", err), http.StatusUnprocessableEntity, rw)
+		return
+	}
+
+	res := DeleteJobApiResponse{
+		Message: fmt.Sprintf("Successfully deleted %d jobs", len(ids)),
+		DryRun:  dry,
+		JobIds:  ids,
+	}
+	if dry {
+		res.Message = fmt.Sprintf("Dry run: would delete %d jobs", len(ids))
+	}
+	rw.Header().Add("Content-Type", "application/json")
+	rw.WriteHeader(http.StatusOK)
+	json.NewEncoder(rw).Encode(res)
+}
+
+func (api *RestApi) checkAndHandleStopJob(ctx context.Context, rw http.ResponseWriter, job *schema.Job, req StopJobApiRequest) {
+	// Sanity checks, the state transition is checked by JobRepository.Stop
+	if job == nil {
+		handleError(errors.New("no job to stop"), http.StatusBadRequest, rw)
+		return
+	}
+
+	if req.State != "" && !req.State.Valid() {
+		log.Warnf("unknown job state %#v of job (dbid: %d) stored as %#v", req.State, job.ID, schema.JobStateUnknown)
+		req.State = schema.JobStateUnknown
+	} else if req.State == "" {
+		req.State = schema.JobStateCompleted
+	}
+
+	// A stop time not after the start time (e.g. due to clock skew between the
+	// scheduler and cc-backend) is corrected to a duration of 0. There is no
+	// metric data to archive for such a job.
+	if duration := req.StopTime - job.StartTime.Unix(); duration > 0 {
+		job.Duration = int32(duration)
+	} else {
+		log.Warnf("stop time %d of job (dbid: %d) is not after its start time %d, setting duration to 0",
+			req.StopTime, job.ID, job.StartTime.Unix())
+		job.Duration = 0
+		if job.MonitoringStatus != schema.MonitoringStatusDisabled {
+			job.MonitoringStatus = schema.MonitoringStatusArchivingFailed
+		}
+	}
+
+	// Mark job as stopped in the database (update state and duration)
+	job.State = req.State
+	if err := api.JobRepository.Stop(ctx, job.ID, job.Duration, job.State, job.MonitoringStatus); err != nil {
+		var transitionErr *repository.StateTransitionError
+		if errors.As(err, &transitionErr) {
+			handleError(err, http.StatusUnprocessableEntity, rw)
+		} else {
+			handleError(fmt.Errorf("marking job as stopped failed: %w", err), http.StatusInternalServerError, rw)
+		}
+		return
+	}
+
+	log.Printf("archiving job... (dbid: %d): cluster=%s, jobId=%d, user=%s, startTime=%s", job.ID, job.Cluster, job.JobID, job.User, job.StartTime)
+
+	// Send a response (with status OK). This means that erros that happen from here on forward
+	// can *NOT* be communicated to the client. If reading from a MetricDataRepository or
+	// writing to the filesystem fails, the client will not know.
+	rw.Header().Add("Content-Type", "application/json")
+	rw.WriteHeader(http.StatusOK)
+	json.NewEncoder(rw).Encode(job)
+
+	// Monitoring is disabled or there is nothing to archive...
+	if job.MonitoringStatus == schema.MonitoringStatusDisabled ||
+		job.MonitoringStatus == schema.MonitoringStatusArchivingFailed {
+		return
+	}
+
+	// Trigger async archiving
+	api.JobRepository.TriggerArchiving(job)
+}
+
+func (api *RestApi) getJobMetrics(rw http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	metrics := r.URL.Query()["metric"]
+	var scopes []schema.MetricScope
+	for _, scope := range r.URL.Query()["scope"] {
+		var s schema.MetricScope
+		if err := s.UnmarshalGQL(scope); err != nil {
+			handleError(err, http.StatusBadRequest, rw)
+			return
+		}
+		scopes = append(scopes, s)
+	}
+	// Units are given as <metric>:<unit>, for example mem_bw:GB/s.
+	var units []*model.MetricUnitInput
+	for _, param := range r.URL.Query()["unit"] {
+		metric, unit, ok := strings.Cut(param, ":")
+		if !ok {
+			handleError(fmt.Errorf("invalid unit parameter '%s', expected <metric>:<unit>", param), http.StatusBadRequest, rw)
+			return
+		}
+		units = append(units, &model.MetricUnitInput{Metric: metric, Unit: unit})
+	}
+	var maxPoints *int
+	if param := r.URL.Query().Get("maxPoints"); param != "" {
+		n, err := strconv.Atoi(param)
+		if err != nil {
+			handleError(fmt.Errorf("integer expected for maxPoints: %w", err), http.StatusBadRequest, rw)
+			return
+		}
+		maxPoints = &n
+	}
+
+	rw.Header().Add("Content-Type", "application/json")
+	rw.WriteHeader(http.StatusOK)
+
+	type Respone struct {
+		Data *struct {
+			JobMetrics []*model.JobMetricWithName `json:"jobMetrics"`
+		} `json:"data"`
+		Error *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+
+	data, err := api.Resolver.Query().JobMetrics(r.Context(), id, metrics, scopes, units, maxPoints)
+	if err != nil {
+		json.NewEncoder(rw).Encode(Respone{
+			Error: &struct {
+				Message string "json:\"message\""
+			}{Message: err.Error()},
+		})
+		return
+	}
+
+	json.NewEncoder(rw).Encode(Respone{
+		Data: &struct {
+			JobMetrics []*model.JobMetricWithName "json:\"jobMetrics\""
+		}{JobMetrics: data},
+	})
+}
+
+// GetJobStatsApiResponse model
+type GetJobStatsApiResponse struct {
+	ID    int64                                         `json:"id"`    // Database ID of the job
+	Stats map[string]map[string]schema.MetricStatistics `json:"stats"` // Statistics as map metric -> hostname -> min/avg/max
+}
+
+// getJobStats godoc
+// @summary     Get the metric statistics of a job
+// @tags Job query
+// @description Job is specified by database ID. Returns min/avg/max of every node for the requested metrics
+// @description without loading the timeseries. Statistics of archived jobs are read from the job archive.
+// @produce     json
+// @param       id      path     int    true  "Database ID of Job"
+// @param       metrics query    string false "Comma separated list of metrics (default: all)"
+// @success     200     {object} api.GetJobStatsApiResponse "Job statistics"
+// @failure     400     {object} api.ErrorResponse          "Bad Request"
+// @failure     401     {object} api.ErrorResponse          "Unauthorized"
+// @failure     403     {object} api.ErrorResponse          "Forbidden"
+// @failure     422     {object} api.ErrorResponse          "Unprocessable Entity: finding job failed: sql: no rows in result set"
+// @failure     500     {object} api.ErrorResponse          "Internal Server Error"
+// @security    ApiKeyAuth
+// @router      /jobs/{id}/stats [get]
+func (api *RestApi) getJobStats(rw http.ResponseWriter, r *http.Request) {
+	if user := repository.GetUserFromContext(r.Context()); user != nil &&
+		!user.HasRole(schema.RoleApi) {
+
+		handleError(fmt.Errorf("missing role: %v",
+			schema.GetRoleString(schema.RoleApi)), http.StatusForbidden, rw)
+		return
+	}
+
+	id := mux.Vars(r)["id"]
+	if _, err := strconv.ParseInt(id, 10, 64); err != nil {
+		handleError(fmt.Errorf("integer expected in path for id: %w", err), http.StatusBadRequest, rw)
+		return
+	}
+
+	var metrics []string
+	if param := r.URL.Query().Get("metrics"); param != "" {
+		metrics = strings.Split(param, ",")
+	}
+
+	// The resolver only returns jobs the user is allowed to see.
+	job, err := api.Resolver.Query().Job(r.Context(), id)
+	if err != nil {
+		handleJobLookupError(err, rw)
+		return
+	}
+
+	stats, err := metricdata.LoadStats(job, metrics, r.Context())
+	if err != nil {
+		handleError(fmt.Errorf("loading job statistics failed: %w", err), http.StatusInternalServerError, rw)
+		return
+	}
+
+	rw.Header().Add("Content-Type", "application/json")
+	rw.WriteHeader(http.StatusOK)
+	json.NewEncoder(rw).Encode(GetJobStatsApiResponse{ID: job.ID, Stats: stats})
+}
+
+// handleJobLookupError writes the error of looking up a job through the job
+// resolver: 422 for unknown jobs, 403 for jobs the user may not see and 500
+// for all other errors.
+func handleJobLookupError(err error, rw http.ResponseWriter) {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		handleError(fmt.Errorf("finding job failed: %w", err), http.StatusUnprocessableEntity, rw)
+	case errors.Is(err, graph.ErrJobNotVisible), errors.Is(err, config.ErrClusterForbidden):
+		handleError(err, http.StatusForbidden, rw)
+	default:
+		handleError(err, http.StatusInternalServerError, rw)
+	}
+}
+
+// Metadata keys of the job script and the environment of a job.
+const (
+	jobScriptKey = "jobScript"
+	jobEnvKey    = "jobEnv"
+)
+
+// GetJobEnvApiResponse model
+type GetJobEnvApiResponse struct {
+	ID  int64             `json:"id"`  // Database ID of the job
+	Env map[string]string `json:"env"` // Environment variables of the job
+}
+
+// getJobScript godoc
+// @summary     Get the job script of a job
+// @tags Job query
+// @description Job is specified by database ID. Returns the job script stored in the metadata key 'jobScript'
+// @description as plain text, without loading the rest of the metadata.
+// @produce     plain
+// @param       id  path     int               true "Database ID of Job"
+// @success     200 {string} string            "Job script"
+// @failure     400 {object} api.ErrorResponse "Bad Request"
+// @failure     401 {object} api.ErrorResponse "Unauthorized"
+// @failure     403 {object} api.ErrorResponse "Forbidden"
+// @failure     404 {object} api.ErrorResponse "Resource not found: job has no script"
+// @failure     422 {object} api.ErrorResponse "Unprocessable Entity: finding job failed: sql: no rows in result set"
+// @failure     500 {object} api.ErrorResponse "Internal Server Error"
+// @security    ApiKeyAuth
+// @router      /jobs/{id}/script [get]
+func (api *RestApi) getJobScript(rw http.ResponseWriter, r *http.Request) {
+	job, metaData, ok := api.getJobMetadata(rw, r)
+	if !ok {
 		return
 	}
 
-	// Fetch job (that will be stopped) from db
-	id, ok := mux.Vars(r)["id"]
-	var err error
-	if ok {
-		id, e := strconv.ParseInt(id, 10, 64)
-		if e != nil {
-			handleError(fmt.Errorf("integer expected in path for id: %w", e), http.StatusBadRequest, rw)
-			return
-		}
+	script, ok := metaData[jobScriptKey]
+	if !ok || script == "" {
+		handleError(fmt.Errorf("job (dbid: %d) has no script", job.ID), http.StatusNotFound, rw)
+		return
+	}
 
-		err = api.JobRepository.DeleteJobById(id)
-	} else {
-		handleError(errors.New("the parameter 'id' is required"), http.StatusBadRequest, rw)
+	rw.Header().Add("Content-Type", "text/plain; charset=utf-8")
+	rw.WriteHeader(http.StatusOK)
+	io.WriteString(rw, script)
+}
+
+// getJobEnv godoc
+// @summary     Get the environment of a job
+// @tags Job query
+// @description Job is specified by database ID. Returns the environment variables stored in the metadata
+// @description key 'jobEnv' as NAME=VALUE pairs separated by newlines or NUL characters.
+// @produce     json
+// @param       id  path     int                      true "Database ID of Job"
+// @success     200 {object} api.GetJobEnvApiResponse "Job environment"
+// @failure     400 {object} api.ErrorResponse        "Bad Request"
+// @failure     401 {object} api.ErrorResponse        "Unauthorized"
+// @failure     403 {object} api.ErrorResponse        "Forbidden"
+// @failure     404 {object} api.ErrorResponse        "Resource not found: job has no environment"
+// @failure     422 {object} api.ErrorResponse        "Unprocessable Entity: finding job failed: sql: no rows in result set"
+// @failure     500 {object} api.ErrorResponse        "Internal Server Error"
+// @security    ApiKeyAuth
+// @router      /jobs/{id}/env [get]
+func (api *RestApi) getJobEnv(rw http.ResponseWriter, r *http.Request) {
+	job, metaData, ok := api.getJobMetadata(rw, r)
+	if !ok {
 		return
 	}
-	if err != nil {
-		handleError(fmt.Errorf("deleting job failed: %w", err), http.StatusUnprocessableEntity, rw)
+
+	raw, ok := metaData[jobEnvKey]
+	if !ok || raw == "" {
+		handleError(fmt.Errorf("job (dbid: %d) has no environment", job.ID), http.StatusNotFound, rw)
 		return
 	}
+
+	env := make(map[string]string)
+	for _, line := range strings.FieldsFunc(raw, func(c rune) bool { return c == '\n' || c == 0 }) {
+		if name, value, ok := strings.Cut(strings.TrimSuffix(line, "\r"), "="); ok && name != "" {
+			env[name] = value
+		}
+	}
+
 	rw.Header().Add("Content-Type", "application/json")
 	rw.WriteHeader(http.StatusOK)
-	json.NewEncoder(rw).Encode(DeleteJobApiResponse{
-		Message: fmt.Sprintf("Successfully deleted job %s", id),
-	})
+	json.NewEncoder(rw).Encode(GetJobEnvApiResponse{ID: job.ID, Env: env})
 }
 
-// deleteJobByRequest godoc
-// @summary     Remove a job from the sql database
-// @tags Job remove
-// @description Job to delete is specified by request body. All fields are required in this case.
-// @accept      json
-// @produce     json
-// @param       request body     api.DeleteJobApiRequest true "All fields required"
-// @success     200     {object} api.DeleteJobApiResponse     "Success message"
-// @failure     400     {object} api.ErrorResponse          "Bad Request"
-// @failure     401     {object} api.ErrorResponse          "Unauthorized"
-// @failure     403     {object} api.ErrorResponse          "Forbidden"
-// @failure     404     {object} api.ErrorResponse          "Resource not found"
-// @failure     422     {object} api.ErrorResponse          "Unprocessable Entity: finding job failed: sql: no rows in result set"
-// @failure     500     {object} api.ErrorResponse          "Internal Server Error"
+// getJobMetadata loads the job given by the id in the path of r and its
+// metadata. If that fails, an error is written to rw and false returned.
+func (api *RestApi) getJobMetadata(rw http.ResponseWriter, r *http.Request) (*schema.Job, map[string]string, bool) {
+	if user := repository.GetUserFromContext(r.Context()); user != nil &&
+		!user.HasRole(schema.RoleApi) {
+
+		handleError(fmt.Errorf("missing role: %v",
+			schema.GetRoleString(schema.RoleApi)), http.StatusForbidden, rw)
+		return nil, nil, false
+	}
+
+	id := mux.Vars(r)["id"]
+	if _, err := strconv.ParseInt(id, 10, 64); err != nil {
+		handleError(fmt.Errorf("integer expected in path for id: %w", err), http.StatusBadRequest, rw)
+		return nil, nil, false
+	}
+
+	// The resolver only returns jobs the user is allowed to see.
+	job, err := api.Resolver.Query().Job(r.Context(), id)
+	if err != nil {
+		handleJobLookupError(err, rw)
+		return nil, nil, false
+	}
+
+	metaData, err := api.JobRepository.FetchMetadata(job)
+	if err != nil {
+		handleError(fmt.Errorf("fetching metadata failed: %w", err), http.StatusInternalServerError, rw)
+		return nil, nil, false
+	}
+
+	return job, metaData, true
+}
+
+// getJobArchive godoc
+// @summary     Exports the archive of a job
+// @tags Job query
+// @description Job is specified by database ID. The response is a tar file containing the 'meta.json' and 'data.json'
+// @description of the job as stored in the job archive, for example to reproduce a problem offline.
+// @produce     application/x-tar
+// @param       id  path     int                true "Database ID of Job"
+// @success     200 {file}   file               "Tar file with meta.json and data.json"
+// @failure     400 {object} api.ErrorResponse  "Bad Request"
+// @failure     401 {object} api.ErrorResponse  "Unauthorized"
+// @failure     403 {object} api.ErrorResponse  "Forbidden"
+// @failure     409 {object} api.ErrorResponse  "Conflict: Job is not archived yet"
+// @failure     422 {object} api.ErrorResponse  "Unprocessable Entity: finding job failed: sql: no rows in result set"
+// @failure     500 {object} api.ErrorResponse  "Internal Server Error"
 // @security    ApiKeyAuth
-// @router      /jobs/delete_job/ [delete]
-func (api *RestApi) deleteJobByRequest(rw http.ResponseWriter, r *http.Request) {
+// @router      /jobs/{id}/archive.tar [get]
+func (api *RestApi) getJobArchive(rw http.ResponseWriter, r *http.Request) {
 	if user := repository.GetUserFromContext(r.Context()); user != nil &&
 		!user.HasRole(schema.RoleApi) {
-		handleError(fmt.Errorf("missing role: %v", schema.GetRoleString(schema.RoleApi)), http.StatusForbidden, rw)
+
+		handleError(fmt.Errorf("missing role: %v",
+			schema.GetRoleString(schema.RoleApi)), http.StatusForbidden, rw)
 		return
 	}
 
-	// Parse request body
-	req := DeleteJobApiRequest{}
-	if err := decode(r.Body, &req); err != nil {
-		handleError(fmt.Errorf("parsing request body failed: %w", err), http.StatusBadRequest, rw)
+	id := mux.Vars(r)["id"]
+	if _, err := strconv.ParseInt(id, 10, 64); err != nil {
+		handleError(fmt.Errorf("integer expected in path for id: %w", err), http.StatusBadRequest, rw)
 		return
 	}
 
-	// Fetch job (that will be deleted) from db
-	var job *schema.Job
-	var err error
-	if req.JobId == nil {
-		handleError(errors.New("the field 'jobId' is required"), http.StatusBadRequest, rw)
+	// The resolver only returns jobs the user is allowed to see.
+	job, err := api.Resolver.Query().Job(r.Context(), id)
+	if err != nil {
+		handleJobLookupError(err, rw)
 		return
 	}
 
-	job, err = api.JobRepository.Find(req.JobId, req.Cluster, req.StartTime)
+	if !schema.IsArchived(job.MonitoringStatus) || !archive.GetHandle().Exists(job) {
+		handleError(fmt.Errorf("job %d is not archived", job.ID), http.StatusConflict, rw)
+		return
+	}
 
+	jobMeta, err := archive.GetHandle().LoadJobMeta(job)
 	if err != nil {
-		handleError(fmt.Errorf("finding job failed: %w", err), http.StatusUnprocessableEntity, rw)
+		handleError(fmt.Errorf("loading job meta data failed: %w", err), http.StatusInternalServerError, rw)
 		return
 	}
-
-	err = api.JobRepository.DeleteJobById(job.ID)
+	jobData, err := archive.GetHandle().LoadJobData(job)
 	if err != nil {
-		handleError(fmt.Errorf("deleting job failed: %w", err), http.StatusUnprocessableEntity, rw)
+		handleError(fmt.Errorf("loading job metric data failed: %w", err), http.StatusInternalServerError, rw)
 		return
 	}
 
-	rw.Header().Add("Content-Type", "application/json")
+	// Both files are encoded before anything is written, a tar header
+	// needs the size of the file.
+	files := []struct {
+		name string
+		v    interface{}
+	}{{"meta.json", jobMeta}, {"data.json", jobData}}
+	contents := make([][]byte, len(files))
+	for i, f := range files {
+		if contents[i], err = json.Marshal(f.v); err != nil {
+			handleError(fmt.Errorf("encoding %s failed: %w", f.name, err), http.StatusInternalServerError, rw)
+			return
+		}
+	}
+
+	rw.Header().Add("Content-Type", "application/x-tar")
+	rw.Header().Add("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-%d-%d.tar"`,
+		job.Cluster, job.JobID, job.StartTime.Unix()))
 	rw.WriteHeader(http.StatusOK)
-	json.NewEncoder(rw).Encode(DeleteJobApiResponse{
-		Message: fmt.Sprintf("Successfully deleted job %d", job.ID),
-	})
+
+	tw := tar.NewWriter(rw)
+	for i, f := range files {
+		hdr := &tar.Header{
+			Name:    f.name,
+			Mode:    0644,
+			Size:    int64(len(contents[i])),
+			ModTime: time.Now(),
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			log.Warnf("/api/jobs/%d/archive.tar: writing response failed: %v", job.ID, err)
+			return
+		}
+		if _, err := tw.Write(contents[i]); err != nil {
+			log.Warnf("/api/jobs/%d/archive.tar: writing response failed: %v", job.ID, err)
+			return
+		}
+	}
+	if err := tw.Close(); err != nil {
+		log.Warnf("/api/jobs/%d/archive.tar: writing response failed: %v", job.ID, err)
+	}
 }
 
-// deleteJobBefore godoc
-// @summary     Remove a job from the sql database
-// @tags Job remove
-// @description Remove all jobs with start time before timestamp. The jobs will not be removed from the job archive.
+// importJob godoc
+// @summary     Imports a job from a tar file
+// @tags Job add and modify
+// @description The request body is a tar file containing the 'meta.json' and 'data.json' of a job, as exported
+// @description by '/jobs/{id}/archive.tar'. The job is written to the job archive and added to the database.
+// @accept      application/x-tar
 // @produce     json
-// @param       ts      path     int                   true "Unix epoch timestamp"
-// @success     200     {object} api.DeleteJobApiResponse     "Success message"
-// @failure     400     {object} api.ErrorResponse          "Bad Request"
-// @failure     401     {object} api.ErrorResponse          "Unauthorized"
-// @failure     403     {object} api.ErrorResponse          "Forbidden"
-// @failure     404     {object} api.ErrorResponse          "Resource not found"
-// @failure     422     {object} api.ErrorResponse          "Unprocessable Entity: finding job failed: sql: no rows in result set"
-// @failure     500     {object} api.ErrorResponse          "Internal Server Error"
+// @success     201 {object} api.StartJobApiResponse "Database ID of the imported job"
+// @failure     400 {object} api.ErrorResponse      "Bad Request"
+// @failure     401 {object} api.ErrorResponse      "Unauthorized"
+// @failure     403 {object} api.ErrorResponse      "Forbidden"
+// @failure     413 {object} api.ErrorResponse      "Request Entity Too Large: The request body exceeds max-request-body-size"
+// @failure     422 {object} api.ErrorResponse      "Unprocessable Entity: The combination of jobId, clusterId and startTime does already exist"
 // @security    ApiKeyAuth
-// @router      /jobs/delete_job_before/{ts} [delete]
-func (api *RestApi) deleteJobBefore(rw http.ResponseWriter, r *http.Request) {
-	if user := repository.GetUserFromContext(r.Context()); user != nil && !user.HasRole(schema.RoleApi) {
-		handleError(fmt.Errorf("missing role: %v", schema.GetRoleString(schema.RoleApi)), http.StatusForbidden, rw)
+// @router      /jobs/import [post]
+func (api *RestApi) importJob(rw http.ResponseWriter, r *http.Request) {
+	if user := repository.GetUserFromContext(r.Context()); user != nil &&
+		!user.HasAnyRole([]schema.Role{schema.RoleAdmin, schema.RoleApi}) {
+
+		handleError(fmt.Errorf("missing role: %v or %v",
+			schema.GetRoleString(schema.RoleAdmin), schema.GetRoleString(schema.RoleApi)), http.StatusForbidden, rw)
 		return
 	}
 
-	var cnt int
-	// Fetch job (that will be stopped) from db
-	id, ok := mux.Vars(r)["ts"]
-	var err error
-	if ok {
-		ts, e := strconv.ParseInt(id, 10, 64)
-		if e != nil {
-			handleError(fmt.Errorf("integer expected in path for ts: %w", e), http.StatusBadRequest, rw)
+	files := map[string][]byte{}
+	tr := tar.NewReader(r.Body)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			handleError(fmt.Errorf("reading tar file failed: %w", err), http.StatusBadRequest, rw)
 			return
 		}
 
-		cnt, err = api.JobRepository.DeleteJobsBefore(ts)
-	} else {
-		handleError(errors.New("the parameter 'ts' is required"), http.StatusBadRequest, rw)
+		// Allow the files to be in a directory.
+		name := filepath.Base(hdr.Name)
+		if hdr.Typeflag != tar.TypeReg || (name != "meta.json" && name != "data.json") {
+			continue
+		}
+		if files[name], err = io.ReadAll(tr); err != nil {
+			handleError(fmt.Errorf("reading %s failed: %w", name, err), http.StatusBadRequest, rw)
+			return
+		}
+	}
+	if files["meta.json"] == nil || files["data.json"] == nil {
+		handleError(errors.New("the tar file has to contain meta.json and data.json"), http.StatusBadRequest, rw)
 		return
 	}
+
+	id, err := importer.ImportJob(files["meta.json"], files["data.json"])
 	if err != nil {
-		handleError(fmt.Errorf("deleting jobs failed: %w", err), http.StatusUnprocessableEntity, rw)
+		if errors.Is(err, importer.ErrJobExists) {
+			handleError(withCode("duplicate_job", err), http.StatusUnprocessableEntity, rw)
+		} else {
+			handleError(fmt.Errorf("importing job failed: %w", err), http.StatusBadRequest, rw)
+		}
 		return
 	}
 
 	rw.Header().Add("Content-Type", "application/json")
-	rw.WriteHeader(http.StatusOK)
-	json.NewEncoder(rw).Encode(DeleteJobApiResponse{
-		Message: fmt.Sprintf("Successfully deleted %d jobs", cnt),
+	rw.WriteHeader(http.StatusCreated)
+	json.NewEncoder(rw).Encode(StartJobApiResponse{
+		DBID: id,
 	})
 }
 
-func (api *RestApi) checkAndHandleStopJob(rw http.ResponseWriter, job *schema.Job, req StopJobApiRequest) {
-	// Sanity checks
-	if job == nil || job.StartTime.Unix() >= req.StopTime || job.State != schema.JobStateRunning {
-		handleError(errors.New("stopTime must be larger than startTime and only running jobs can be stopped"), http.StatusBadRequest, rw)
-		return
-	}
+// JobMetricSeries model
+type JobMetricSeries struct {
+	Metric string             `json:"metric" example:"flops_any"` // Metric name
+	Scope  schema.MetricScope `json:"scope" example:"node"`       // Metric scope of the series
+	Series *schema.Series     `json:"series"`                     // Series data
+}
 
-	if req.State != "" && !req.State.Valid() {
-		handleError(fmt.Errorf("invalid job state: %#v", req.State), http.StatusBadRequest, rw)
-		return
-	} else if req.State == "" {
-		req.State = schema.JobStateCompleted
-	}
+// streamJobMetrics godoc
+// @summary     Streams the metric data of a job
+// @tags Job query
+// @description Job is specified by database ID. The response contains one 'JobMetricSeries' object per line (NDJSON)
+// @description and is written while the data is still being loaded, so that large jobs do not have to be kept in memory.
+// @description Errors that occur after the first line has been written are reported as a final line with an 'error' field.
+// @produce     json
+// @param       id      path     int      true  "Database ID of Job"
+// @param       metric  query    []string false "Metrics to stream (default: all)"
+// @param       scope   query    []string false "Scopes to stream (default: node)"
+// @success     200     {object} api.JobMetricSeries        "One series per line"
+// @failure     400     {object} api.ErrorResponse          "Bad Request"
+// @failure     401     {object} api.ErrorResponse          "Unauthorized"
+// @failure     403     {object} api.ErrorResponse          "Forbidden"
+// @failure     422     {object} api.ErrorResponse          "Unprocessable Entity: finding job failed: sql: no rows in result set"
+// @failure     500     {object} api.ErrorResponse          "Internal Server Error"
+// @failure     503     {object} api.ErrorResponse          "Service Unavailable: The rate limit of the metric data repository was exceeded"
+// @security    ApiKeyAuth
+// @router      /jobs/metrics/{id}/stream [get]
+func (api *RestApi) streamJobMetrics(rw http.ResponseWriter, r *http.Request) {
+	if user := repository.GetUserFromContext(r.Context()); user != nil &&
+		!user.HasRole(schema.RoleApi) {
 
-	// Mark job as stopped in the database (update state and duration)
-	job.Duration = int32(req.StopTime - job.StartTime.Unix())
-	job.State = req.State
-	if err := api.JobRepository.Stop(job.ID, job.Duration, job.State, job.MonitoringStatus); err != nil {
-		handleError(fmt.Errorf("marking job as stopped failed: %w", err), http.StatusInternalServerError, rw)
+		handleError(fmt.Errorf("missing role: %v",
+			schema.GetRoleString(schema.RoleApi)), http.StatusForbidden, rw)
 		return
 	}
 
-	log.Printf("archiving job... (dbid: %d): cluster=%s, jobId=%d, user=%s, startTime=%s", job.ID, job.Cluster, job.JobID, job.User, job.StartTime)
-
-	// Send a response (with status OK). This means that erros that happen from here on forward
-	// can *NOT* be communicated to the client. If reading from a MetricDataRepository or
-	// writing to the filesystem fails, the client will not know.
-	rw.Header().Add("Content-Type", "application/json")
-	rw.WriteHeader(http.StatusOK)
-	json.NewEncoder(rw).Encode(job)
-
-	// Monitoring is disabled...
-	if job.MonitoringStatus == schema.MonitoringStatusDisabled {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		handleError(fmt.Errorf("integer expected in path for id: %w", err), http.StatusBadRequest, rw)
 		return
 	}
 
-	// Trigger async archiving
-	api.JobRepository.TriggerArchiving(job)
-}
-
-func (api *RestApi) getJobMetrics(rw http.ResponseWriter, r *http.Request) {
-	id := mux.Vars(r)["id"]
 	metrics := r.URL.Query()["metric"]
 	var scopes []schema.MetricScope
 	for _, scope := range r.URL.Query()["scope"] {
 		var s schema.MetricScope
 		if err := s.UnmarshalGQL(scope); err != nil {
-			http.Error(rw, err.Error(), http.StatusBadRequest)
+			handleError(err, http.StatusBadRequest, rw)
 			return
 		}
 		scopes = append(scopes, s)
 	}
 
-	rw.Header().Add("Content-Type", "application/json")
-	rw.WriteHeader(http.StatusOK)
-
-	type Respone struct {
-		Data *struct {
-			JobMetrics []*model.JobMetricWithName `json:"jobMetrics"`
-		} `json:"data"`
-		Error *struct {
-			Message string `json:"message"`
-		} `json:"error"`
+	job, err := api.JobRepository.FindById(id)
+	if err != nil {
+		handleError(fmt.Errorf("finding job failed: %w", err), http.StatusUnprocessableEntity, rw)
+		return
+	}
+	if err := config.CheckClusterAccess(repository.GetUserFromContext(r.Context()), job.Cluster); err != nil {
+		handleError(err, http.StatusForbidden, rw)
+		return
 	}
 
-	data, err := api.Resolver.Query().JobMetrics(r.Context(), id, metrics, scopes)
-	if err != nil {
-		json.NewEncoder(rw).Encode(Respone{
-			Error: &struct {
-				Message string "json:\"message\""
-			}{Message: err.Error()},
+	flusher, _ := rw.(http.Flusher)
+	enc := json.NewEncoder(rw)
+	started := false
+	err = metricdata.StreamData(job, metrics, scopes, r.Context(),
+		func(metric string, scope schema.MetricScope, series *schema.Series) error {
+			if !started {
+				rw.Header().Add("Content-Type", "application/x-ndjson")
+				rw.WriteHeader(http.StatusOK)
+				started = true
+			}
+			if err := enc.Encode(JobMetricSeries{Metric: metric, Scope: scope, Series: series}); err != nil {
+				return err
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+			return nil
 		})
+
+	if err != nil {
+		if !started {
+			handleError(fmt.Errorf("loading metric data failed: %w", err), http.StatusInternalServerError, rw)
+			return
+		}
+
+		log.Warnf("streaming metric data of job %d failed: %s", job.ID, err.Error())
+		enc.Encode(ErrorResponse{Error: ErrorDetails{Code: "internal_server_error", Message: err.Error()}})
 		return
 	}
 
-	json.NewEncoder(rw).Encode(Respone{
-		Data: &struct {
-			JobMetrics []*model.JobMetricWithName "json:\"jobMetrics\""
-		}{JobMetrics: data},
-	})
+	if !started {
+		rw.Header().Add("Content-Type", "application/x-ndjson")
+		rw.WriteHeader(http.StatusOK)
+	}
 }
 
 // createUser godoc
@@ -1199,14 +2966,14 @@ func (api *RestApi) getJobMetrics(rw http.ResponseWriter, r *http.Request) {
 func (api *RestApi) createUser(rw http.ResponseWriter, r *http.Request) {
 	err := securedCheck(r)
 	if err != nil {
-		http.Error(rw, err.Error(), http.StatusForbidden)
+		handleError(err, http.StatusForbidden, rw)
 		return
 	}
 
 	rw.Header().Set("Content-Type", "text/plain")
 	me := repository.GetUserFromContext(r.Context())
 	if !me.HasRole(schema.RoleAdmin) {
-		http.Error(rw, "Only admins are allowed to create new users", http.StatusForbidden)
+		handleError(errors.New("Only admins are allowed to create new users"), http.StatusForbidden, rw)
 		return
 	}
 
@@ -1215,17 +2982,15 @@ func (api *RestApi) createUser(rw http.ResponseWriter, r *http.Request) {
 		r.FormValue("email"), r.FormValue("project")
 
 	if len(password) == 0 && role != schema.GetRoleString(schema.RoleApi) {
-		http.Error(rw, "Only API users are allowed to have a blank password (login will be impossible)", http.StatusBadRequest)
+		handleError(errors.New("Only API users are allowed to have a blank password (login will be impossible)"), http.StatusBadRequest, rw)
 		return
 	}
 
 	if len(project) != 0 && role != schema.GetRoleString(schema.RoleManager) {
-		http.Error(rw, "only managers require a project (can be changed later)",
-			http.StatusBadRequest)
+		handleError(errors.New("only managers require a project (can be changed later)"), http.StatusBadRequest, rw)
 		return
 	} else if len(project) == 0 && role == schema.GetRoleString(schema.RoleManager) {
-		http.Error(rw, "managers require a project to manage (can be changed later)",
-			http.StatusBadRequest)
+		handleError(errors.New("managers require a project to manage (can be changed later)"), http.StatusBadRequest, rw)
 		return
 	}
 
@@ -1237,7 +3002,7 @@ func (api *RestApi) createUser(rw http.ResponseWriter, r *http.Request) {
 		Projects: []string{project},
 		Roles:    []string{role},
 	}); err != nil {
-		http.Error(rw, err.Error(), http.StatusUnprocessableEntity)
+		handleError(err, http.StatusUnprocessableEntity, rw)
 		return
 	}
 
@@ -1263,18 +3028,18 @@ func (api *RestApi) createUser(rw http.ResponseWriter, r *http.Request) {
 func (api *RestApi) deleteUser(rw http.ResponseWriter, r *http.Request) {
 	err := securedCheck(r)
 	if err != nil {
-		http.Error(rw, err.Error(), http.StatusForbidden)
+		handleError(err, http.StatusForbidden, rw)
 		return
 	}
 
 	if user := repository.GetUserFromContext(r.Context()); !user.HasRole(schema.RoleAdmin) {
-		http.Error(rw, "Only admins are allowed to delete a user", http.StatusForbidden)
+		handleError(errors.New("Only admins are allowed to delete a user"), http.StatusForbidden, rw)
 		return
 	}
 
 	username := r.FormValue("username")
 	if err := repository.GetUserRepository().DelUser(username); err != nil {
-		http.Error(rw, err.Error(), http.StatusUnprocessableEntity)
+		handleError(err, http.StatusUnprocessableEntity, rw)
 		return
 	}
 
@@ -1299,18 +3064,18 @@ func (api *RestApi) deleteUser(rw http.ResponseWriter, r *http.Request) {
 func (api *RestApi) getUsers(rw http.ResponseWriter, r *http.Request) {
 	err := securedCheck(r)
 	if err != nil {
-		http.Error(rw, err.Error(), http.StatusForbidden)
+		handleError(err, http.StatusForbidden, rw)
 		return
 	}
 
 	if user := repository.GetUserFromContext(r.Context()); !user.HasRole(schema.RoleAdmin) {
-		http.Error(rw, "Only admins are allowed to fetch a list of users", http.StatusForbidden)
+		handleError(errors.New("Only admins are allowed to fetch a list of users"), http.StatusForbidden, rw)
 		return
 	}
 
 	users, err := repository.GetUserRepository().ListUsers(r.URL.Query().Get("not-just-user") == "true")
 	if err != nil {
-		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		handleError(err, http.StatusInternalServerError, rw)
 		return
 	}
 
@@ -1341,12 +3106,12 @@ func (api *RestApi) getUsers(rw http.ResponseWriter, r *http.Request) {
 func (api *RestApi) updateUser(rw http.ResponseWriter, r *http.Request) {
 	err := securedCheck(r)
 	if err != nil {
-		http.Error(rw, err.Error(), http.StatusForbidden)
+		handleError(err, http.StatusForbidden, rw)
 		return
 	}
 
 	if user := repository.GetUserFromContext(r.Context()); !user.HasRole(schema.RoleAdmin) {
-		http.Error(rw, "Only admins are allowed to update a user", http.StatusForbidden)
+		handleError(errors.New("Only admins are allowed to update a user"), http.StatusForbidden, rw)
 		return
 	}
 
@@ -1359,37 +3124,37 @@ func (api *RestApi) updateUser(rw http.ResponseWriter, r *http.Request) {
 	// TODO: Handle anything but roles...
 	if newrole != "" {
 		if err := repository.GetUserRepository().AddRole(r.Context(), mux.Vars(r)["id"], newrole); err != nil {
-			http.Error(rw, err.Error(), http.StatusUnprocessableEntity)
+			handleError(err, http.StatusUnprocessableEntity, rw)
 			return
 		}
 		rw.Write([]byte("Add Role Success"))
 	} else if delrole != "" {
 		if err := repository.GetUserRepository().RemoveRole(r.Context(), mux.Vars(r)["id"], delrole); err != nil {
-			http.Error(rw, err.Error(), http.StatusUnprocessableEntity)
+			handleError(err, http.StatusUnprocessableEntity, rw)
 			return
 		}
 		rw.Write([]byte("Remove Role Success"))
 	} else if newproj != "" {
 		if err := repository.GetUserRepository().AddProject(r.Context(), mux.Vars(r)["id"], newproj); err != nil {
-			http.Error(rw, err.Error(), http.StatusUnprocessableEntity)
+			handleError(err, http.StatusUnprocessableEntity, rw)
 			return
 		}
 		rw.Write([]byte("Add Project Success"))
 	} else if delproj != "" {
 		if err := repository.GetUserRepository().RemoveProject(r.Context(), mux.Vars(r)["id"], delproj); err != nil {
-			http.Error(rw, err.Error(), http.StatusUnprocessableEntity)
+			handleError(err, http.StatusUnprocessableEntity, rw)
 			return
 		}
 		rw.Write([]byte("Remove Project Success"))
 	} else {
-		http.Error(rw, "Not Add or Del [role|project]?", http.StatusInternalServerError)
+		handleError(errors.New("Not Add or Del [role|project]?"), http.StatusInternalServerError, rw)
 	}
 }
 
 func (api *RestApi) getJWT(rw http.ResponseWriter, r *http.Request) {
 	err := securedCheck(r)
 	if err != nil {
-		http.Error(rw, err.Error(), http.StatusForbidden)
+		handleError(err, http.StatusForbidden, rw)
 		return
 	}
 
@@ -1398,21 +3163,20 @@ func (api *RestApi) getJWT(rw http.ResponseWriter, r *http.Request) {
 	me := repository.GetUserFromContext(r.Context())
 	if !me.HasRole(schema.RoleAdmin) {
 		if username != me.Username {
-			http.Error(rw, "Only admins are allowed to sign JWTs not for themselves",
-				http.StatusForbidden)
+			handleError(errors.New("Only admins are allowed to sign JWTs not for themselves"), http.StatusForbidden, rw)
 			return
 		}
 	}
 
 	user, err := repository.GetUserRepository().GetUser(username)
 	if err != nil {
-		http.Error(rw, err.Error(), http.StatusUnprocessableEntity)
+		handleError(err, http.StatusUnprocessableEntity, rw)
 		return
 	}
 
 	jwt, err := api.Authentication.JwtAuth.ProvideJWT(user)
 	if err != nil {
-		http.Error(rw, err.Error(), http.StatusUnprocessableEntity)
+		handleError(err, http.StatusUnprocessableEntity, rw)
 		return
 	}
 
@@ -1423,19 +3187,19 @@ func (api *RestApi) getJWT(rw http.ResponseWriter, r *http.Request) {
 func (api *RestApi) getRoles(rw http.ResponseWriter, r *http.Request) {
 	err := securedCheck(r)
 	if err != nil {
-		http.Error(rw, err.Error(), http.StatusForbidden)
+		handleError(err, http.StatusForbidden, rw)
 		return
 	}
 
 	user := repository.GetUserFromContext(r.Context())
 	if !user.HasRole(schema.RoleAdmin) {
-		http.Error(rw, "only admins are allowed to fetch a list of roles", http.StatusForbidden)
+		handleError(errors.New("only admins are allowed to fetch a list of roles"), http.StatusForbidden, rw)
 		return
 	}
 
 	roles, err := schema.GetValidRoles(user)
 	if err != nil {
-		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		handleError(err, http.StatusInternalServerError, rw)
 		return
 	}
 
@@ -1449,16 +3213,80 @@ func (api *RestApi) updateConfiguration(rw http.ResponseWriter, r *http.Request)
 	fmt.Printf("REST > KEY: %#v\nVALUE: %#v\n", key, value)
 
 	if err := repository.GetUserCfgRepo().UpdateConfig(key, value, repository.GetUserFromContext(r.Context())); err != nil {
-		http.Error(rw, err.Error(), http.StatusUnprocessableEntity)
+		handleError(err, http.StatusUnprocessableEntity, rw)
 		return
 	}
 
 	rw.Write([]byte("success"))
 }
 
+// filterError writes err of the saved filter helpers with the matching status.
+func filterError(err error, rw http.ResponseWriter) {
+	if errors.Is(err, repository.ErrForbidden) {
+		handleError(err, http.StatusForbidden, rw)
+		return
+	}
+	handleError(err, http.StatusUnprocessableEntity, rw)
+}
+
+// getFilters returns the saved job filters of the user by name.
+func (api *RestApi) getFilters(rw http.ResponseWriter, r *http.Request) {
+	filters, err := repository.GetUserCfgRepo().ListFilters(r.Context())
+	if err != nil {
+		filterError(err, rw)
+		return
+	}
+
+	rw.Header().Add("Content-Type", "application/json")
+	rw.WriteHeader(http.StatusOK)
+	json.NewEncoder(rw).Encode(filters)
+}
+
+// getFilter returns the saved job filter {name} of the user.
+func (api *RestApi) getFilter(rw http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["name"]
+	filter, err := repository.GetUserCfgRepo().GetFilter(name, r.Context())
+	if err != nil {
+		filterError(err, rw)
+		return
+	}
+	if filter == nil {
+		handleError(fmt.Errorf("no saved filter '%s'", name), http.StatusNotFound, rw)
+		return
+	}
+
+	rw.Header().Add("Content-Type", "application/json")
+	rw.WriteHeader(http.StatusOK)
+	rw.Write(filter)
+}
+
+// saveFilter saves the JSON request body as job filter {name} of the user.
+func (api *RestApi) saveFilter(rw http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		handleError(err, http.StatusBadRequest, rw)
+		return
+	}
+
+	if err := repository.GetUserCfgRepo().SaveFilter(mux.Vars(r)["name"], string(body), r.Context()); err != nil {
+		filterError(err, rw)
+		return
+	}
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// deleteFilter removes the saved job filter {name} of the user.
+func (api *RestApi) deleteFilter(rw http.ResponseWriter, r *http.Request) {
+	if err := repository.GetUserCfgRepo().DeleteFilter(mux.Vars(r)["name"], r.Context()); err != nil {
+		filterError(err, rw)
+		return
+	}
+	rw.WriteHeader(http.StatusNoContent)
+}
+
 func (api *RestApi) putMachineState(rw http.ResponseWriter, r *http.Request) {
 	if api.MachineStateDir == "" {
-		http.Error(rw, "REST > machine state not enabled", http.StatusNotFound)
+		handleError(errors.New("REST > machine state not enabled"), http.StatusNotFound, rw)
 		return
 	}
 
@@ -1467,20 +3295,20 @@ func (api *RestApi) putMachineState(rw http.ResponseWriter, r *http.Request) {
 	host := vars["host"]
 	dir := filepath.Join(api.MachineStateDir, cluster)
 	if err := os.MkdirAll(dir, 0755); err != nil {
-		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		handleError(err, http.StatusInternalServerError, rw)
 		return
 	}
 
 	filename := filepath.Join(dir, fmt.Sprintf("%s.json", host))
 	f, err := os.Create(filename)
 	if err != nil {
-		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		handleError(err, http.StatusInternalServerError, rw)
 		return
 	}
 	defer f.Close()
 
 	if _, err := io.Copy(f, r.Body); err != nil {
-		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		handleError(err, http.StatusInternalServerError, rw)
 		return
 	}
 
@@ -1489,7 +3317,7 @@ func (api *RestApi) putMachineState(rw http.ResponseWriter, r *http.Request) {
 
 func (api *RestApi) getMachineState(rw http.ResponseWriter, r *http.Request) {
 	if api.MachineStateDir == "" {
-		http.Error(rw, "REST > machine state not enabled", http.StatusNotFound)
+		handleError(errors.New("REST > machine state not enabled"), http.StatusNotFound, rw)
 		return
 	}
 
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
@@ -67,9 +68,74 @@ func (la *LdapAuthenticator) Init() error {
 		la.UserAttr = "gecos"
 	}
 
+	for group, role := range lc.GroupRoles {
+		if !schema.IsValidRole(role) {
+			return fmt.Errorf("invalid role '%s' for LDAP group '%s'", role, group)
+		}
+	}
+	if lc.DefaultRole != "" && !schema.IsValidRole(lc.DefaultRole) {
+		return fmt.Errorf("invalid LDAP default role '%s'", lc.DefaultRole)
+	}
+
 	return nil
 }
 
+// groupAttr returns the attribute listing the group DNs of an LDAP user.
+func groupAttr() string {
+	if attr := config.Keys.LdapConfig.GroupAttr; attr != "" {
+		return attr
+	}
+	return "memberOf"
+}
+
+// searchAttrs returns the attributes to request for LDAP users.
+func (la *LdapAuthenticator) searchAttrs() []string {
+	attrs := []string{"dn", "uid", la.UserAttr}
+	if len(config.Keys.LdapConfig.GroupRoles) != 0 {
+		attrs = append(attrs, groupAttr())
+	}
+	return attrs
+}
+
+// rolesFromEntry derives the roles of an LDAP user from its group
+// memberships using the group_roles mapping. Users without a mapped group
+// get the default role. Without a mapping, every user gets the role user.
+func rolesFromEntry(entry *ldap.Entry) []string {
+	lc := config.Keys.LdapConfig
+	defaultRole := schema.GetRoleString(schema.RoleUser)
+	if lc.DefaultRole != "" {
+		defaultRole = lc.DefaultRole
+	}
+	if len(lc.GroupRoles) == 0 {
+		return []string{defaultRole}
+	}
+
+	roles := make([]string, 0)
+	for _, group := range entry.GetAttributeValues(groupAttr()) {
+		for dn, role := range lc.GroupRoles {
+			// DNs are case insensitive
+			if strings.EqualFold(dn, group) && !contains(roles, role) {
+				roles = append(roles, role)
+			}
+		}
+	}
+
+	if len(roles) == 0 {
+		return []string{defaultRole}
+	}
+	sort.Strings(roles)
+	return roles
+}
+
+func contains(list []string, s string) bool {
+	for _, e := range list {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
+
 func (la *LdapAuthenticator) CanLogin(
 	user *schema.User,
 	username string,
@@ -95,7 +161,7 @@ func (la *LdapAuthenticator) CanLogin(
 				lc.UserBase,
 				ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
 				fmt.Sprintf("(&%s(uid=%s))", lc.UserFilter, username),
-				[]string{"dn", "uid", la.UserAttr}, nil)
+				la.searchAttrs(), nil)
 
 			sr, err := l.Search(searchRequest)
 			if err != nil {
@@ -110,8 +176,7 @@ func (la *LdapAuthenticator) CanLogin(
 
 			entry := sr.Entries[0]
 			name := entry.GetAttributeValue(la.UserAttr)
-			var roles []string
-			roles = append(roles, schema.GetRoleString(schema.RoleUser))
+			roles := rolesFromEntry(entry)
 			projects := make([]string, 0)
 
 			user = &schema.User{
@@ -154,9 +219,45 @@ func (la *LdapAuthenticator) Login(
 		return nil, fmt.Errorf("Authentication failed")
 	}
 
+	if len(config.Keys.LdapConfig.GroupRoles) != 0 {
+		la.updateRoles(user)
+	}
+
 	return user, nil
 }
 
+// updateRoles sets the roles of user to the roles derived from its current
+// LDAP groups. The groups are searched with the sync bind DN, as users may
+// not be allowed to read their group memberships. The user keeps its roles
+// if the groups can not be read.
+func (la *LdapAuthenticator) updateRoles(user *schema.User) {
+	lc := config.Keys.LdapConfig
+	l, err := la.getLdapConnection(true)
+	if err != nil {
+		log.Warnf("AUTH/LDAP > Could not read groups of user %s, keeping roles %v", user.Username, user.Roles)
+		return
+	}
+	defer l.Close()
+
+	sr, err := l.Search(ldap.NewSearchRequest(
+		lc.UserBase,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		fmt.Sprintf("(&%s(uid=%s))", lc.UserFilter, ldap.EscapeFilter(user.Username)),
+		la.searchAttrs(), nil))
+	if err != nil || len(sr.Entries) != 1 {
+		log.Warnf("AUTH/LDAP > Could not read groups of user %s, keeping roles %v", user.Username, user.Roles)
+		return
+	}
+
+	roles := rolesFromEntry(sr.Entries[0])
+	if err := repository.GetUserRepository().SetRoles(user.Username, roles); err != nil {
+		log.Errorf("AUTH/LDAP > Updating roles of user %s failed: %v", user.Username, err)
+		return
+	}
+	log.Debugf("AUTH/LDAP > Roles of user %s from LDAP groups: %v", user.Username, roles)
+	user.Roles = roles
+}
+
 func (la *LdapAuthenticator) Sync() error {
 	const IN_DB int = 1
 	const IN_LDAP int = 2
@@ -185,19 +286,21 @@ func (la *LdapAuthenticator) Sync() error {
 		lc.UserBase,
 		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
 		lc.UserFilter,
-		[]string{"dn", "uid", la.UserAttr}, nil))
+		la.searchAttrs(), nil))
 	if err != nil {
 		log.Warn("LDAP search error")
 		return err
 	}
 
 	newnames := map[string]string{}
+	newroles := map[string][]string{}
 	for _, entry := range ldapResults.Entries {
 		username := entry.GetAttributeValue("uid")
 		if username == "" {
 			return errors.New("no attribute 'uid'")
 		}
 
+		newroles[username] = rolesFromEntry(entry)
 		_, ok := users[username]
 		if !ok {
 			users[username] = IN_LDAP
@@ -213,9 +316,7 @@ func (la *LdapAuthenticator) Sync() error {
 			log.Debugf("sync: remove %v (does not show up in LDAP anymore)", username)
 		} else if where == IN_LDAP {
 			name := newnames[username]
-
-			var roles []string
-			roles = append(roles, schema.GetRoleString(schema.RoleUser))
+			roles := newroles[username]
 			projects := make([]string, 0)
 
 			user := &schema.User{
@@ -226,17 +327,46 @@ func (la *LdapAuthenticator) Sync() error {
 				AuthSource: schema.AuthViaLDAP,
 			}
 
-			log.Debugf("sync: add %v (name: %v, roles: [user], ldap: true)", username, name)
+			log.Debugf("sync: add %v (name: %v, roles: %v, ldap: true)", username, name, roles)
 			if err := ur.AddUser(user); err != nil {
 				log.Errorf("User '%s' LDAP: Insert into DB failed", username)
 				return err
 			}
+		} else if where == IN_BOTH && len(lc.GroupRoles) != 0 {
+			// Group memberships of existing users may have changed as well.
+			user, err := ur.GetUser(username)
+			if err != nil {
+				log.Errorf("sync: reading user %v failed: %v", username, err)
+				continue
+			}
+			roles := newroles[username]
+			if equalRoles(user.Roles, roles) {
+				continue
+			}
+			log.Debugf("sync: update roles of %v (%v -> %v)", username, user.Roles, roles)
+			if err := ur.SetRoles(username, roles); err != nil {
+				log.Errorf("User '%s' LDAP: Updating roles failed", username)
+				return err
+			}
 		}
 	}
 
 	return nil
 }
 
+// equalRoles reports whether a and b contain the same roles in any order.
+func equalRoles(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, role := range a {
+		if !contains(b, role) {
+			return false
+		}
+	}
+	return true
+}
+
 func (la *LdapAuthenticator) getLdapConnection(admin bool) (*ldap.Conn, error) {
 
 	lc := config.Keys.LdapConfig
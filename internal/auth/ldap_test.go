@@ -0,0 +1,90 @@
+// Copyright (C) 2023 NHR@FAU, University Erlangen-Nuremberg.
+// All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+package auth
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ClusterCockpit/cc-backend/internal/config"
+	"github.com/ClusterCockpit/cc-backend/pkg/schema"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	adminGroup = "cn=hpc-admins,ou=groups,dc=example,dc=com"
+	userGroup  = "cn=hpc-users,ou=groups,dc=example,dc=com"
+)
+
+func setupLdapConfig(t *testing.T, lc *schema.LdapConfig) {
+	old := config.Keys.LdapConfig
+	config.Keys.LdapConfig = lc
+	t.Cleanup(func() { config.Keys.LdapConfig = old })
+}
+
+func TestRolesFromEntry(t *testing.T) {
+	setupLdapConfig(t, &schema.LdapConfig{
+		GroupRoles: map[string]string{
+			adminGroup: "admin",
+			userGroup:  "user",
+		},
+		DefaultRole: "api",
+	})
+
+	entry := ldap.NewEntry("uid=alice,ou=people,dc=example,dc=com", map[string][]string{
+		"uid": {"alice"},
+		"memberOf": {
+			"CN=hpc-admins,OU=groups,DC=example,DC=com",
+			userGroup,
+			"cn=staff,ou=groups,dc=example,dc=com",
+		},
+	})
+	if roles := rolesFromEntry(entry); !reflect.DeepEqual(roles, []string{"admin", "user"}) {
+		t.Errorf("wrong roles for alice: %v", roles)
+	}
+
+	entry = ldap.NewEntry("uid=bob,ou=people,dc=example,dc=com", map[string][]string{
+		"uid":      {"bob"},
+		"memberOf": {"cn=staff,ou=groups,dc=example,dc=com"},
+	})
+	if roles := rolesFromEntry(entry); !reflect.DeepEqual(roles, []string{"api"}) {
+		t.Errorf("expected default role for bob, got %v", roles)
+	}
+}
+
+func TestRolesFromEntryGroupAttr(t *testing.T) {
+	setupLdapConfig(t, &schema.LdapConfig{
+		GroupAttr:  "isMemberOf",
+		GroupRoles: map[string]string{adminGroup: "admin"},
+	})
+
+	entry := ldap.NewEntry("uid=alice,ou=people,dc=example,dc=com", map[string][]string{
+		"memberOf":   {adminGroup},
+		"isMemberOf": {userGroup},
+	})
+	if roles := rolesFromEntry(entry); !reflect.DeepEqual(roles, []string{"user"}) {
+		t.Errorf("expected role user, got %v", roles)
+	}
+}
+
+func TestRolesFromEntryNoMapping(t *testing.T) {
+	setupLdapConfig(t, &schema.LdapConfig{})
+
+	entry := ldap.NewEntry("uid=alice,ou=people,dc=example,dc=com", map[string][]string{
+		"memberOf": {adminGroup},
+	})
+	if roles := rolesFromEntry(entry); !reflect.DeepEqual(roles, []string{"user"}) {
+		t.Errorf("expected role user, got %v", roles)
+	}
+}
+
+func TestEqualRoles(t *testing.T) {
+	if !equalRoles([]string{"user", "admin"}, []string{"admin", "user"}) {
+		t.Error("expected roles in another order to be equal")
+	}
+	if equalRoles([]string{"user"}, []string{"user", "admin"}) || equalRoles([]string{"user", "api"}, []string{"user", "admin"}) {
+		t.Error("expected different roles not to be equal")
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (C) 2023 NHR@FAU, University Erlangen-Nuremberg.
+// All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ClusterCockpit/cc-backend/pkg/schema"
+)
+
+// ErrClusterForbidden is returned if a user may not access a cluster because
+// of its access configuration.
+var ErrClusterForbidden = errors.New("access to cluster forbidden")
+
+// HasClusterAccess reports whether user may see the jobs and node data of
+// cluster. Without user (authentication disabled or internal calls), admins
+// and for clusters without access configuration this is always true.
+func HasClusterAccess(user *schema.User, cluster string) bool {
+	if user == nil || user.HasRole(schema.RoleAdmin) {
+		return true
+	}
+
+	for _, c := range Keys.Clusters {
+		if c.Name == cluster {
+			return c.Access == nil || permits(c.Access, user)
+		}
+	}
+	return true
+}
+
+// CheckClusterAccess returns an error wrapping ErrClusterForbidden if user
+// may not access cluster.
+func CheckClusterAccess(user *schema.User, cluster string) error {
+	if !HasClusterAccess(user, cluster) {
+		return fmt.Errorf("%w: user %s may not access cluster %s", ErrClusterForbidden, user.Username, cluster)
+	}
+	return nil
+}
+
+// ForbiddenClusters returns the names of the configured clusters user may not
+// access, nil if there are none.
+func ForbiddenClusters(user *schema.User) []string {
+	var forbidden []string
+	for _, c := range Keys.Clusters {
+		if !HasClusterAccess(user, c.Name) {
+			forbidden = append(forbidden, c.Name)
+		}
+	}
+	return forbidden
+}
+
+// permits reports whether user is allowed and not denied by the access
+// configuration a. Denials take precedence.
+func permits(a *schema.ClusterAccessConfig, user *schema.User) bool {
+	if matchesUser(user, a.DenyRoles, a.DenyUsers) {
+		return false
+	}
+	if len(a.AllowRoles) == 0 && len(a.AllowUsers) == 0 {
+		return true
+	}
+	return matchesUser(user, a.AllowRoles, a.AllowUsers)
+}
+
+func matchesUser(user *schema.User, roles, usernames []string) bool {
+	for _, username := range usernames {
+		if username == user.Username {
+			return true
+		}
+	}
+	for _, role := range roles {
+		if hasRole, _ := user.HasValidRole(role); hasRole {
+			return true
+		}
+	}
+	return false
+}
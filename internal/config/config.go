@@ -25,6 +25,16 @@ var Keys schema.ProgramConfig = schema.ProgramConfig{
 	SessionMaxAge:             "168h",
 	StopJobsExceedingWalltime: 0,
 	ShortRunningJobsDuration:  5 * 60,
+	StopJobMode:               "strict",
+	DuplicateStartJobMode:     "reject",
+	MaxRequestBodySize:        64 << 20,
+	IngestTimeout:             "10s",
+	ArchiveWorkers:            1,
+	ArchiveQueueSize:          128,
+	NodeDataTimeout:           "30s",
+	SimilarJobsNumNodesFactor: 2,
+	SimilarJobsFlopsBand:      0.5,
+	WebhookWorkers:            2,
 	UiDefaults: map[string]interface{}{
 		"analysis_view_histogramMetrics":         []string{"flops_any", "mem_bw", "mem_used"},
 		"analysis_view_scatterPlotMetrics":       [][]string{{"flops_any", "mem_bw"}, {"flops_any", "cpu_load"}, {"cpu_load", "mem_bw"}},
@@ -5,7 +5,11 @@
 package config
 
 import (
+	"reflect"
 	"testing"
+
+	"github.com/ClusterCockpit/cc-backend/internal/graph/model"
+	"github.com/ClusterCockpit/cc-backend/pkg/schema"
 )
 
 func TestInit(t *testing.T) {
@@ -23,3 +27,91 @@ func TestInitMinimal(t *testing.T) {
 		t.Errorf("wrong addr\ngot: %s \nwant: 127.0.0.1:8080", Keys.Addr)
 	}
 }
+
+func TestValidateFilter(t *testing.T) {
+	clusters := Keys.Clusters
+	t.Cleanup(func() { Keys.Clusters = clusters })
+
+	Keys.Clusters = []*schema.ClusterConfig{
+		{Name: "small", FilterRanges: &schema.FilterRanges{
+			NumNodes: &schema.IntRange{From: 1, To: 64},
+			Duration: &schema.IntRange{From: 0, To: 86400},
+		}},
+		{Name: "large", FilterRanges: &schema.FilterRanges{
+			NumNodes: &schema.IntRange{From: 1, To: 1024},
+		}},
+	}
+
+	small := "small"
+	tests := []struct {
+		name   string
+		filter *model.JobFilter
+		valid  bool
+	}{
+		{"in range", &model.JobFilter{NumNodes: &schema.IntRange{From: 1, To: 32}}, true},
+		{"in range of other cluster", &model.JobFilter{NumNodes: &schema.IntRange{From: 100, To: 200}}, true},
+		{"above to of selected cluster", &model.JobFilter{
+			Cluster:  &model.StringInput{Eq: &small},
+			NumNodes: &schema.IntRange{From: 100, To: 200},
+		}, false},
+		{"above to of all clusters", &model.JobFilter{NumNodes: &schema.IntRange{From: 2000, To: 4000}}, false},
+		{"from larger than to", &model.JobFilter{NumNodes: &schema.IntRange{From: 8, To: 4}}, false},
+		{"duration above to", &model.JobFilter{
+			Cluster:  &model.StringInput{Eq: &small},
+			Duration: &schema.IntRange{From: 90000, To: 100000},
+		}, false},
+	}
+
+	for _, tt := range tests {
+		if err := ValidateFilter(tt.filter); (err == nil) != tt.valid {
+			t.Errorf("%s: expected valid=%v, got error %v", tt.name, tt.valid, err)
+		}
+	}
+}
+
+func TestClusterAccess(t *testing.T) {
+	clusters := Keys.Clusters
+	t.Cleanup(func() { Keys.Clusters = clusters })
+
+	Keys.Clusters = []*schema.ClusterConfig{
+		{Name: "open"},
+		{Name: "staff", Access: &schema.ClusterAccessConfig{
+			AllowRoles: []string{"support"},
+			AllowUsers: []string{"alice"},
+			DenyUsers:  []string{"mallory"},
+		}},
+		{Name: "noapi", Access: &schema.ClusterAccessConfig{DenyRoles: []string{"api"}}},
+	}
+
+	alice := &schema.User{Username: "alice", Roles: []string{"user"}}
+	bob := &schema.User{Username: "bob", Roles: []string{"user"}}
+	mallory := &schema.User{Username: "mallory", Roles: []string{"support"}}
+	admin := &schema.User{Username: "root", Roles: []string{"admin", "api"}}
+	tests := []struct {
+		user    *schema.User
+		cluster string
+		allowed bool
+	}{
+		{alice, "open", true},
+		{alice, "staff", true},
+		{bob, "open", true},
+		{bob, "staff", false},
+		{mallory, "staff", false}, // Denials take precedence
+		{&schema.User{Username: "carol", Roles: []string{"support"}}, "staff", true},
+		{&schema.User{Username: "svc", Roles: []string{"api"}}, "noapi", false},
+		{admin, "staff", true},
+		{admin, "noapi", true},
+		{nil, "staff", true},
+		{bob, "unknown", true},
+	}
+	for _, tt := range tests {
+		if allowed := HasClusterAccess(tt.user, tt.cluster); allowed != tt.allowed {
+			t.Errorf("%v on %s: expected allowed=%v", tt.user, tt.cluster, tt.allowed)
+		}
+	}
+
+	if forbidden := ForbiddenClusters(bob); !reflect.DeepEqual(forbidden, []string{"staff"}) {
+		t.Errorf("unexpected forbidden clusters for bob: %v", forbidden)
+	}
+
+}
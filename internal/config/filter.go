@@ -0,0 +1,124 @@
+// Copyright (C) 2023 NHR@FAU, University Erlangen-Nuremberg.
+// All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+package config
+
+import (
+	"fmt"
+
+	"github.com/ClusterCockpit/cc-backend/internal/graph/model"
+	"github.com/ClusterCockpit/cc-backend/pkg/schema"
+)
+
+// GetFilterRanges returns the filterRanges declared for cluster, or nil if
+// the cluster is not configured or declares none.
+func GetFilterRanges(cluster string) *schema.FilterRanges {
+	for _, c := range Keys.Clusters {
+		if c.Name == cluster {
+			return c.FilterRanges
+		}
+	}
+	return nil
+}
+
+// GetDefaultMetrics returns the defaultMetrics and defaultScopes declared for
+// cluster. Both are nil if the cluster is not configured or declares none.
+func GetDefaultMetrics(cluster string) ([]string, []schema.MetricScope) {
+	for _, c := range Keys.Clusters {
+		if c.Name == cluster {
+			return c.DefaultMetrics, c.DefaultScopes
+		}
+	}
+	return nil, nil
+}
+
+// GetPartitions returns the partitions and the default partition declared for
+// cluster. Both are empty if the cluster is not configured or declares none.
+func GetPartitions(cluster string) ([]*schema.PartitionConfig, string) {
+	for _, c := range Keys.Clusters {
+		if c.Name == cluster {
+			return c.Partitions, c.DefaultPartition
+		}
+	}
+	return nil, ""
+}
+
+// GetCancelHook returns the hook forwarding cancel requests for jobs of
+// cluster to the scheduler, nil if there is none.
+func GetCancelHook(cluster string) *schema.CancelHookConfig {
+	for _, c := range Keys.Clusters {
+		if c.Name == cluster {
+			return c.CancelHook
+		}
+	}
+	return nil
+}
+
+// ValidateFilter checks the numNodes, duration and startTime ranges of
+// filter against the filterRanges of the configured clusters. If the filter
+// selects a cluster, only the ranges of this cluster are considered,
+// otherwise the filter has to overlap with the ranges of at least one
+// cluster. Clusters without filterRanges accept every filter.
+func ValidateFilter(filter *model.JobFilter) error {
+	if filter == nil {
+		return nil
+	}
+
+	if filter.NumNodes != nil && filter.NumNodes.From > filter.NumNodes.To {
+		return fmt.Errorf("invalid numNodes filter: from (%d) is larger than to (%d)",
+			filter.NumNodes.From, filter.NumNodes.To)
+	}
+	if filter.Duration != nil && filter.Duration.From > filter.Duration.To {
+		return fmt.Errorf("invalid duration filter: from (%d) is larger than to (%d)",
+			filter.Duration.From, filter.Duration.To)
+	}
+	if st := filter.StartTime; st != nil && st.From != nil && st.To != nil && st.From.After(*st.To) {
+		return fmt.Errorf("invalid startTime filter: from (%s) is after to (%s)", st.From, st.To)
+	}
+
+	var err error
+	for _, c := range Keys.Clusters {
+		if filter.Cluster != nil && filter.Cluster.Eq != nil && *filter.Cluster.Eq != c.Name {
+			continue
+		}
+
+		if err = checkFilterRanges(filter, c); err == nil {
+			return nil
+		}
+	}
+
+	return err
+}
+
+func checkFilterRanges(filter *model.JobFilter, cluster *schema.ClusterConfig) error {
+	fr := cluster.FilterRanges
+	if fr == nil {
+		return nil
+	}
+
+	if filter.NumNodes != nil && fr.NumNodes != nil &&
+		(filter.NumNodes.From > fr.NumNodes.To || filter.NumNodes.To < fr.NumNodes.From) {
+		return fmt.Errorf("numNodes filter [%d, %d] is outside of the range [%d, %d] of cluster '%s'",
+			filter.NumNodes.From, filter.NumNodes.To, fr.NumNodes.From, fr.NumNodes.To, cluster.Name)
+	}
+
+	if filter.Duration != nil && fr.Duration != nil &&
+		(filter.Duration.From > fr.Duration.To || filter.Duration.To < fr.Duration.From) {
+		return fmt.Errorf("duration filter [%d, %d] is outside of the range [%d, %d] of cluster '%s'",
+			filter.Duration.From, filter.Duration.To, fr.Duration.From, fr.Duration.To, cluster.Name)
+	}
+
+	if st := filter.StartTime; st != nil && fr.StartTime != nil {
+		if st.From != nil && fr.StartTime.To != nil && st.From.After(*fr.StartTime.To) {
+			return fmt.Errorf("startTime filter begins after the end (%s) of the range of cluster '%s'",
+				fr.StartTime.To, cluster.Name)
+		}
+		if st.To != nil && fr.StartTime.From != nil && st.To.Before(*fr.StartTime.From) {
+			return fmt.Errorf("startTime filter ends before the begin (%s) of the range of cluster '%s'",
+				fr.StartTime.From, cluster.Name)
+		}
+	}
+
+	return nil
+}
@@ -37,27 +37,30 @@ type IntRangeOutput struct {
 }
 
 type JobFilter struct {
-	Tags            []string          `json:"tags,omitempty"`
-	JobID           *StringInput      `json:"jobId,omitempty"`
-	ArrayJobID      *int              `json:"arrayJobId,omitempty"`
-	User            *StringInput      `json:"user,omitempty"`
-	Project         *StringInput      `json:"project,omitempty"`
-	JobName         *StringInput      `json:"jobName,omitempty"`
-	Cluster         *StringInput      `json:"cluster,omitempty"`
-	Partition       *StringInput      `json:"partition,omitempty"`
-	Duration        *schema.IntRange  `json:"duration,omitempty"`
-	MinRunningFor   *int              `json:"minRunningFor,omitempty"`
-	NumNodes        *schema.IntRange  `json:"numNodes,omitempty"`
-	NumAccelerators *schema.IntRange  `json:"numAccelerators,omitempty"`
-	NumHWThreads    *schema.IntRange  `json:"numHWThreads,omitempty"`
-	StartTime       *schema.TimeRange `json:"startTime,omitempty"`
-	State           []schema.JobState `json:"state,omitempty"`
-	FlopsAnyAvg     *FloatRange       `json:"flopsAnyAvg,omitempty"`
-	MemBwAvg        *FloatRange       `json:"memBwAvg,omitempty"`
-	LoadAvg         *FloatRange       `json:"loadAvg,omitempty"`
-	MemUsedMax      *FloatRange       `json:"memUsedMax,omitempty"`
-	Exclusive       *int              `json:"exclusive,omitempty"`
-	Node            *StringInput      `json:"node,omitempty"`
+	Tags             []string          `json:"tags,omitempty"`
+	AllTags          []string          `json:"allTags,omitempty"`
+	JobID            *StringInput      `json:"jobId,omitempty"`
+	ArrayJobID       *int              `json:"arrayJobId,omitempty"`
+	User             *StringInput      `json:"user,omitempty"`
+	Project          *StringInput      `json:"project,omitempty"`
+	JobName          *StringInput      `json:"jobName,omitempty"`
+	Cluster          *StringInput      `json:"cluster,omitempty"`
+	Partition        *StringInput      `json:"partition,omitempty"`
+	Duration         *schema.IntRange  `json:"duration,omitempty"`
+	MinRunningFor    *int              `json:"minRunningFor,omitempty"`
+	NumNodes         *schema.IntRange  `json:"numNodes,omitempty"`
+	NumAccelerators  *schema.IntRange  `json:"numAccelerators,omitempty"`
+	NumHWThreads     *schema.IntRange  `json:"numHWThreads,omitempty"`
+	StartTime        *schema.TimeRange `json:"startTime,omitempty"`
+	State            []schema.JobState `json:"state,omitempty"`
+	FlopsAnyAvg      *FloatRange       `json:"flopsAnyAvg,omitempty"`
+	MemBwAvg         *FloatRange       `json:"memBwAvg,omitempty"`
+	LoadAvg          *FloatRange       `json:"loadAvg,omitempty"`
+	MemUsedMax       *FloatRange       `json:"memUsedMax,omitempty"`
+	DataCompleteness *FloatRange       `json:"dataCompleteness,omitempty"`
+	Exclusive        *int              `json:"exclusive,omitempty"`
+	Smt              *int              `json:"smt,omitempty"`
+	Node             *StringInput      `json:"node,omitempty"`
 }
 
 type JobLink struct {
@@ -78,10 +81,12 @@ type JobMetricWithName struct {
 }
 
 type JobResultList struct {
-	Items  []*schema.Job `json:"items"`
-	Offset *int          `json:"offset,omitempty"`
-	Limit  *int          `json:"limit,omitempty"`
-	Count  *int          `json:"count,omitempty"`
+	Items       []*schema.Job `json:"items"`
+	Offset      *int          `json:"offset,omitempty"`
+	Limit       *int          `json:"limit,omitempty"`
+	Count       *int          `json:"count,omitempty"`
+	EndCursor   *string       `json:"endCursor,omitempty"`
+	HasNextPage *bool         `json:"hasNextPage,omitempty"`
 }
 
 type JobsStatistics struct {
@@ -104,6 +109,19 @@ type JobsStatistics struct {
 	HistMetrics    []*MetricHistoPoints `json:"histMetrics"`
 }
 
+type MetricAvailability struct {
+	Name        string                          `json:"name"`
+	Unit        *schema.Unit                    `json:"unit"`
+	Scope       schema.MetricScope              `json:"scope"`
+	Scopes      []schema.MetricScope            `json:"scopes"`
+	Timestep    int                             `json:"timestep"`
+	Peak        float64                         `json:"peak"`
+	Normal      float64                         `json:"normal"`
+	Caution     float64                         `json:"caution"`
+	Alert       float64                         `json:"alert"`
+	SubClusters []*SubClusterMetricAvailability `json:"subClusters"`
+}
+
 type MetricFootprints struct {
 	Metric string         `json:"metric"`
 	Data   []schema.Float `json:"data"`
@@ -122,6 +140,11 @@ type MetricHistoPoints struct {
 	Data   []*MetricHistoPoint `json:"data,omitempty"`
 }
 
+type MetricUnitInput struct {
+	Metric string `json:"metric"`
+	Unit   string `json:"unit"`
+}
+
 type NodeMetrics struct {
 	Host       string               `json:"host"`
 	SubCluster string               `json:"subCluster"`
@@ -138,6 +161,25 @@ type PageRequest struct {
 	Page         int `json:"page"`
 }
 
+type PercentileSeries struct {
+	Percentile int            `json:"percentile"`
+	Data       []schema.Float `json:"data"`
+}
+
+type RooflineData struct {
+	FlopRateScalar  *schema.MetricValue `json:"flopRateScalar"`
+	FlopRateSimd    *schema.MetricValue `json:"flopRateSimd"`
+	MemoryBandwidth *schema.MetricValue `json:"memoryBandwidth"`
+	Points          []*RooflinePoint    `json:"points"`
+}
+
+type RooflinePoint struct {
+	Hostname    string  `json:"hostname"`
+	Time        int     `json:"time"`
+	Intensity   float64 `json:"intensity"`
+	Performance float64 `json:"performance"`
+}
+
 type StringInput struct {
 	Eq         *string  `json:"eq,omitempty"`
 	Neq        *string  `json:"neq,omitempty"`
@@ -147,6 +189,14 @@ type StringInput struct {
 	In         []string `json:"in,omitempty"`
 }
 
+type SubClusterMetricAvailability struct {
+	Name    string  `json:"name"`
+	Peak    float64 `json:"peak"`
+	Normal  float64 `json:"normal"`
+	Caution float64 `json:"caution"`
+	Alert   float64 `json:"alert"`
+}
+
 type TimeRangeOutput struct {
 	From time.Time `json:"from"`
 	To   time.Time `json:"to"`
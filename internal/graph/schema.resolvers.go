@@ -8,9 +8,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"time"
 
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/ClusterCockpit/cc-backend/internal/config"
 	"github.com/ClusterCockpit/cc-backend/internal/graph/generated"
 	"github.com/ClusterCockpit/cc-backend/internal/graph/model"
 	"github.com/ClusterCockpit/cc-backend/internal/metricdata"
@@ -27,7 +30,16 @@ func (r *clusterResolver) Partitions(ctx context.Context, obj *schema.Cluster) (
 
 // Tags is the resolver for the tags field.
 func (r *jobResolver) Tags(ctx context.Context, obj *schema.Job) ([]*schema.Tag, error) {
-	return r.Repo.GetTags(&obj.ID)
+	// Prefetched for all items by the jobs query
+	if obj.Tags != nil {
+		return obj.Tags, nil
+	}
+	return r.Repo.GetTags(repository.GetUserFromContext(ctx), &obj.ID)
+}
+
+// Comments is the resolver for the comments field.
+func (r *jobResolver) Comments(ctx context.Context, obj *schema.Job) ([]*schema.JobComment, error) {
+	return r.Repo.GetComments(obj.ID)
 }
 
 // ConcurrentJobs is the resolver for the concurrentJobs field.
@@ -43,6 +55,35 @@ func (r *jobResolver) ConcurrentJobs(ctx context.Context, obj *schema.Job) (*mod
 	return nil, nil
 }
 
+// SimilarJobs is the resolver for the similarJobs field.
+func (r *jobResolver) SimilarJobs(ctx context.Context, obj *schema.Job, limit *int) ([]*schema.Job, error) {
+	l := defaultSimilarJobsLimit
+	if limit != nil {
+		if *limit <= 0 {
+			return nil, fmt.Errorf("invalid limit %d", *limit)
+		}
+		l = *limit
+	}
+	if l > maxSimilarJobsLimit {
+		l = maxSimilarJobsLimit
+	}
+	return r.Repo.FindSimilar(ctx, obj, l)
+}
+
+// FootprintPercentiles is the resolver for the footprintPercentiles field.
+func (r *jobResolver) FootprintPercentiles(ctx context.Context, obj *schema.Job, windowDays *int) ([]*repository.FootprintPercentile, error) {
+	days := defaultFootprintPercentilesWindow
+	if windowDays != nil {
+		days = *windowDays
+	}
+	return r.Repo.FootprintPercentiles(ctx, obj, time.Duration(days)*24*time.Hour)
+}
+
+// Energy is the resolver for the energy field.
+func (r *jobResolver) Energy(ctx context.Context, obj *schema.Job) (*float64, error) {
+	return metricdata.LoadEnergy(obj, ctx)
+}
+
 // MetaData is the resolver for the metaData field.
 func (r *jobResolver) MetaData(ctx context.Context, obj *schema.Job) (interface{}, error) {
 	return r.Repo.FetchMetadata(obj)
@@ -54,14 +95,24 @@ func (r *jobResolver) UserData(ctx context.Context, obj *schema.Job) (*model.Use
 }
 
 // CreateTag is the resolver for the createTag field.
-func (r *mutationResolver) CreateTag(ctx context.Context, typeArg string, name string) (*schema.Tag, error) {
-	id, err := r.Repo.CreateTag(typeArg, name)
+func (r *mutationResolver) CreateTag(ctx context.Context, typeArg string, name string, scope *string) (*schema.Tag, error) {
+	user := repository.GetUserFromContext(ctx)
+
+	// Without explicit scope admins create global tags, everybody else private ones
+	tagScope := repository.TagScopeGlobal
+	if scope != nil {
+		tagScope = *scope
+	} else if user != nil && !user.HasRole(schema.RoleAdmin) {
+		tagScope = user.Username
+	}
+
+	id, err := r.Repo.CreateTag(user, typeArg, name, tagScope)
 	if err != nil {
 		log.Warn("Error while creating tag")
 		return nil, err
 	}
 
-	return &schema.Tag{ID: id, Type: typeArg, Name: name}, nil
+	return &schema.Tag{ID: id, Type: typeArg, Name: name, Scope: tagScope}, nil
 }
 
 // DeleteTag is the resolver for the deleteTag field.
@@ -85,7 +136,7 @@ func (r *mutationResolver) AddTagsToJob(ctx context.Context, job string, tagIds
 			return nil, err
 		}
 
-		if tags, err = r.Repo.AddTag(jid, tid); err != nil {
+		if tags, err = r.Repo.AddTag(repository.GetUserFromContext(ctx), jid, tid); err != nil {
 			log.Warn("Error while adding tag")
 			return nil, err
 		}
@@ -110,7 +161,7 @@ func (r *mutationResolver) RemoveTagsFromJob(ctx context.Context, job string, ta
 			return nil, err
 		}
 
-		if tags, err = r.Repo.RemoveTag(jid, tid); err != nil {
+		if tags, err = r.Repo.RemoveTag(repository.GetUserFromContext(ctx), jid, tid); err != nil {
 			log.Warn("Error while removing tag")
 			return nil, err
 		}
@@ -119,6 +170,109 @@ func (r *mutationResolver) RemoveTagsFromJob(ctx context.Context, job string, ta
 	return tags, nil
 }
 
+// AddComment is the resolver for the addComment field.
+func (r *mutationResolver) AddComment(ctx context.Context, job string, text string) (*schema.JobComment, error) {
+	// Only users allowed to see the job can comment on it.
+	j, err := r.Query().Job(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Repo.AddComment(repository.GetUserFromContext(ctx), j.ID, text)
+}
+
+// DeleteComment is the resolver for the deleteComment field.
+func (r *mutationResolver) DeleteComment(ctx context.Context, id string) (string, error) {
+	cid, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		log.Warn("Error while parsing comment id")
+		return "", err
+	}
+
+	if err := r.Repo.DeleteComment(repository.GetUserFromContext(ctx), cid); err != nil {
+		log.Warn("Error while deleting comment")
+		return "", err
+	}
+
+	return id, nil
+}
+
+// ArchiveJob is the resolver for the archiveJob field.
+func (r *mutationResolver) ArchiveJob(ctx context.Context, id string, dryRun *bool) (*schema.Job, error) {
+	numericId, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		log.Warn("Error while parsing job id")
+		return nil, err
+	}
+
+	if user := repository.GetUserFromContext(ctx); user != nil && !user.HasRole(schema.RoleAdmin) {
+		return nil, errors.New("you are not allowed to archive this job")
+	}
+
+	job, err := r.Repo.FindById(numericId)
+	if err != nil {
+		log.Warn("Error while finding job by id")
+		return nil, err
+	}
+
+	if job.State == schema.JobStateRunning {
+		return nil, fmt.Errorf("job (dbid: %d) is still running", job.ID)
+	}
+
+	// In a dry run the job is only validated and returned unchanged.
+	if dryRun != nil && *dryRun {
+		log.Infof("archiveJob: dry run, would re-archive job (dbid: %d)", job.ID)
+		return job, nil
+	}
+
+	// If the job is already being archived, its result is returned instead of
+	// archiving it a second time.
+	if r.Repo.WaitForArchivingOf(job.ID) {
+		return r.Repo.FindById(numericId)
+	}
+
+	if err := r.Repo.UpdateMonitoringStatus(job.ID, schema.MonitoringStatusRunningOrArchiving); err != nil {
+		log.Warn("Error while updating monitoring status")
+		return nil, err
+	}
+	// The metric data is loaded from the metric data repositories again, not
+	// from the (possibly missing) job-archive.
+	job.MonitoringStatus = schema.MonitoringStatusRunningOrArchiving
+
+	// Waiting for this run (not the whole queue) makes sure the returned job
+	// reflects its result.
+	<-r.Repo.TriggerArchiving(job)
+
+	return r.Repo.FindById(numericId)
+}
+
+// UpdateJobOwnership is the resolver for the updateJobOwnership field.
+func (r *mutationResolver) UpdateJobOwnership(ctx context.Context, id string, user *string, project *string) (*schema.Job, error) {
+	numericId, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		log.Warn("Error while parsing job id")
+		return nil, err
+	}
+
+	if u := repository.GetUserFromContext(ctx); u != nil && !u.HasRole(schema.RoleAdmin) {
+		return nil, errors.New("you are not allowed to change the ownership of this job")
+	}
+
+	var newUser, newProject string
+	if user != nil {
+		newUser = *user
+	}
+	if project != nil {
+		newProject = *project
+	}
+	if err := r.Repo.UpdateOwnership(numericId, newUser, newProject); err != nil {
+		log.Warn("Error while updating job ownership")
+		return nil, err
+	}
+
+	return r.Repo.FindById(numericId)
+}
+
 // UpdateConfiguration is the resolver for the updateConfiguration field.
 func (r *mutationResolver) UpdateConfiguration(ctx context.Context, name string, value string) (*string, error) {
 	if err := repository.GetUserCfgRepo().UpdateConfig(name, value, repository.GetUserFromContext(ctx)); err != nil {
@@ -131,12 +285,24 @@ func (r *mutationResolver) UpdateConfiguration(ctx context.Context, name string,
 
 // Clusters is the resolver for the clusters field.
 func (r *queryResolver) Clusters(ctx context.Context) ([]*schema.Cluster, error) {
-	return archive.Clusters, nil
+	user := repository.GetUserFromContext(ctx)
+	clusters := make([]*schema.Cluster, 0, len(archive.GetClusters()))
+	for _, c := range archive.GetClusters() {
+		if config.HasClusterAccess(user, c.Name) {
+			clusters = append(clusters, c)
+		}
+	}
+	return clusters, nil
 }
 
 // Tags is the resolver for the tags field.
 func (r *queryResolver) Tags(ctx context.Context) ([]*schema.Tag, error) {
-	return r.Repo.GetTags(nil)
+	return r.Repo.GetTags(repository.GetUserFromContext(ctx), nil)
+}
+
+// Projects is the resolver for the projects field.
+func (r *queryResolver) Projects(ctx context.Context) ([]string, error) {
+	return r.Repo.Projects(ctx)
 }
 
 // User is the resolver for the user field.
@@ -146,6 +312,10 @@ func (r *queryResolver) User(ctx context.Context, username string) (*model.User,
 
 // AllocatedNodes is the resolver for the allocatedNodes field.
 func (r *queryResolver) AllocatedNodes(ctx context.Context, cluster string) ([]*model.Count, error) {
+	if err := config.CheckClusterAccess(repository.GetUserFromContext(ctx), cluster); err != nil {
+		return nil, err
+	}
+
 	data, err := r.Repo.AllocatedNodes(cluster)
 	if err != nil {
 		log.Warn("Error while fetching allocated nodes")
@@ -177,24 +347,65 @@ func (r *queryResolver) Job(ctx context.Context, id string) (*schema.Job, error)
 		return nil, err
 	}
 
-	if user := repository.GetUserFromContext(ctx); user != nil &&
-		job.User != user.Username &&
-		user.HasNotRoles([]schema.Role{schema.RoleAdmin, schema.RoleSupport, schema.RoleManager}) {
-		return nil, errors.New("you are not allowed to see this job")
+	user := repository.GetUserFromContext(ctx)
+	if err := config.CheckClusterAccess(user, job.Cluster); err != nil {
+		return nil, err
+	}
+	if !jobVisible(user, job) {
+		return nil, ErrJobNotVisible
 	}
 
 	return job, nil
 }
 
+// JobsByArrayID is the resolver for the jobsByArrayId field.
+func (r *queryResolver) JobsByArrayID(ctx context.Context, arrayJobID string, cluster string) ([]*schema.Job, error) {
+	if err := config.CheckClusterAccess(repository.GetUserFromContext(ctx), cluster); err != nil {
+		return nil, err
+	}
+
+	numericId, err := strconv.ParseInt(arrayJobID, 10, 64)
+	if err != nil {
+		log.Warn("Error while parsing array job id")
+		return nil, err
+	}
+
+	// Only the tasks the user would be allowed to see using the job query
+	jobs, err := r.Repo.FindByArrayJobId(ctx, numericId, cluster)
+	if err != nil {
+		log.Warn("Error while finding jobs by array job id")
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
 // JobMetrics is the resolver for the jobMetrics field.
-func (r *queryResolver) JobMetrics(ctx context.Context, id string, metrics []string, scopes []schema.MetricScope) ([]*model.JobMetricWithName, error) {
+func (r *queryResolver) JobMetrics(ctx context.Context, id string, metrics []string, scopes []schema.MetricScope, units []*model.MetricUnitInput, maxPoints *int) ([]*model.JobMetricWithName, error) {
 	job, err := r.Query().Job(ctx, id)
 	if err != nil {
 		log.Warn("Error while querying job for metrics")
 		return nil, err
 	}
 
-	data, err := metricdata.LoadData(job, metrics, scopes, ctx)
+	var convert map[string]schema.Unit
+	if len(units) != 0 {
+		convert = make(map[string]schema.Unit, len(units))
+		for _, u := range units {
+			unit, err := schema.ParseUnit(u.Unit)
+			if err != nil {
+				return nil, err
+			}
+			convert[u.Metric] = unit
+		}
+	}
+
+	var data schema.JobData
+	if maxPoints != nil {
+		data, err = metricdata.LoadDataDownsampled(job, metrics, scopes, convert, *maxPoints, ctx)
+	} else {
+		data, err = metricdata.LoadDataWithUnits(job, metrics, scopes, convert, ctx)
+	}
 	if err != nil {
 		log.Warn("Error while loading job data")
 		return nil, err
@@ -216,11 +427,14 @@ func (r *queryResolver) JobMetrics(ctx context.Context, id string, metrics []str
 
 // JobsFootprints is the resolver for the jobsFootprints field.
 func (r *queryResolver) JobsFootprints(ctx context.Context, filter []*model.JobFilter, metrics []string) (*model.Footprints, error) {
+	if err := CheckClusterFilters(repository.GetUserFromContext(ctx), filter); err != nil {
+		return nil, err
+	}
 	return r.jobsFootprints(ctx, filter, metrics)
 }
 
 // Jobs is the resolver for the jobs field.
-func (r *queryResolver) Jobs(ctx context.Context, filter []*model.JobFilter, page *model.PageRequest, order *model.OrderByInput) (*model.JobResultList, error) {
+func (r *queryResolver) Jobs(ctx context.Context, filter []*model.JobFilter, page *model.PageRequest, order *model.OrderByInput, first *int, after *string) (*model.JobResultList, error) {
 	if page == nil {
 		page = &model.PageRequest{
 			ItemsPerPage: 50,
@@ -228,19 +442,59 @@ func (r *queryResolver) Jobs(ctx context.Context, filter []*model.JobFilter, pag
 		}
 	}
 
-	jobs, err := r.Repo.QueryJobs(ctx, filter, page, order)
+	for _, f := range filter {
+		if err := config.ValidateFilter(f); err != nil {
+			log.Warnf("Invalid job filter: %s", err.Error())
+			return nil, err
+		}
+	}
+	if err := CheckClusterFilters(repository.GetUserFromContext(ctx), filter); err != nil {
+		return nil, err
+	}
+
+	var jobs []*schema.Job
+	var endCursor *string
+	var hasNextPage bool
+	var err error
+	cursorPaging := first != nil || after != nil
+	if cursorPaging {
+		limit, cursor := page.ItemsPerPage, ""
+		if first != nil {
+			limit = *first
+		}
+		if after != nil {
+			cursor = *after
+		}
+		jobs, hasNextPage, err = r.Repo.QueryJobsAfter(ctx, filter, order, cursor, limit)
+		if err == nil && len(jobs) != 0 {
+			c := repository.EncodeJobCursor(jobs[len(jobs)-1])
+			endCursor = &c
+		}
+	} else {
+		jobs, err = r.Repo.QueryJobs(ctx, filter, page, order)
+	}
 	if err != nil {
 		log.Warn("Error while querying jobs")
 		return nil, err
 	}
 
+	if requireSubField(ctx, "items", "tags") {
+		if err := r.prefetchTags(ctx, jobs); err != nil {
+			log.Warn("Error while fetching tags of jobs")
+			return nil, err
+		}
+	}
+
 	count, err := r.Repo.CountJobs(ctx, filter)
 	if err != nil {
 		log.Warn("Error while counting jobs")
 		return nil, err
 	}
+	if !cursorPaging && page.ItemsPerPage != -1 {
+		hasNextPage = page.Page*page.ItemsPerPage < count
+	}
 
-	return &model.JobResultList{Items: jobs, Count: &count}, nil
+	return &model.JobResultList{Items: jobs, Count: &count, EndCursor: endCursor, HasNextPage: &hasNextPage}, nil
 }
 
 // JobsStatistics is the resolver for the jobsStatistics field.
@@ -248,6 +502,10 @@ func (r *queryResolver) JobsStatistics(ctx context.Context, filter []*model.JobF
 	var err error
 	var stats []*model.JobsStatistics
 
+	if err := CheckClusterFilters(repository.GetUserFromContext(ctx), filter); err != nil {
+		return nil, err
+	}
+
 	if requireField(ctx, "totalJobs") || requireField(ctx, "totalWalltime") || requireField(ctx, "totalNodes") || requireField(ctx, "totalCores") ||
 		requireField(ctx, "totalAccs") || requireField(ctx, "totalNodeHours") || requireField(ctx, "totalCoreHours") || requireField(ctx, "totalAccHours") {
 		if groupBy == nil {
@@ -305,8 +563,48 @@ func (r *queryResolver) JobsStatistics(ctx context.Context, filter []*model.JobF
 	return stats, nil
 }
 
+// TopUsers is the resolver for the topUsers field.
+func (r *queryResolver) TopUsers(ctx context.Context, cluster *string, from time.Time, to time.Time, limit *int) ([]*repository.UserStat, error) {
+	if cluster != nil {
+		if err := config.CheckClusterAccess(repository.GetUserFromContext(ctx), *cluster); err != nil {
+			return nil, err
+		}
+	}
+	c, l := topUsageArgs(cluster, limit)
+	stats, err := r.Repo.TopUsers(ctx, c, from.Unix(), to.Unix(), l)
+	if err != nil {
+		return nil, err
+	}
+
+	return userStatPointers(stats), nil
+}
+
+// TopProjects is the resolver for the topProjects field.
+func (r *queryResolver) TopProjects(ctx context.Context, cluster *string, from time.Time, to time.Time, limit *int) ([]*repository.UserStat, error) {
+	if cluster != nil {
+		if err := config.CheckClusterAccess(repository.GetUserFromContext(ctx), *cluster); err != nil {
+			return nil, err
+		}
+	}
+	c, l := topUsageArgs(cluster, limit)
+	stats, err := r.Repo.TopProjects(ctx, c, from.Unix(), to.Unix(), l)
+	if err != nil {
+		return nil, err
+	}
+
+	return userStatPointers(stats), nil
+}
+
+// Roofline is the resolver for the roofline field.
+func (r *queryResolver) Roofline(ctx context.Context, id string) (*model.RooflineData, error) {
+	return r.roofline(ctx, id)
+}
+
 // RooflineHeatmap is the resolver for the rooflineHeatmap field.
 func (r *queryResolver) RooflineHeatmap(ctx context.Context, filter []*model.JobFilter, rows int, cols int, minX float64, minY float64, maxX float64, maxY float64) ([][]float64, error) {
+	if err := CheckClusterFilters(repository.GetUserFromContext(ctx), filter); err != nil {
+		return nil, err
+	}
 	return r.rooflineHeatmap(ctx, filter, rows, cols, minX, minY, maxX, maxY)
 }
 
@@ -317,14 +615,33 @@ func (r *queryResolver) NodeMetrics(ctx context.Context, cluster string, nodes [
 		return nil, errors.New("you need to be an administrator for this query")
 	}
 
+	c := archive.GetCluster(cluster)
+	if c == nil {
+		return nil, fmt.Errorf("unknown cluster: %s", cluster)
+	}
+
 	if metrics == nil {
-		for _, mc := range archive.GetCluster(cluster).MetricConfig {
+		for _, mc := range c.MetricConfig {
 			metrics = append(metrics, mc.Name)
 		}
 	}
 
-	data, err := metricdata.LoadNodeData(cluster, metrics, nodes, scopes, from, to, ctx)
-	if err != nil {
+	if nodes == nil {
+		var err error
+		if nodes, err = archive.GetClusterNodes(cluster); err != nil {
+			return nil, err
+		}
+	}
+
+	if scopes == nil {
+		scopes = []schema.MetricScope{schema.MetricScopeNode}
+	}
+
+	data, err := metricdata.LoadNodeData(user, cluster, metrics, nodes, scopes, from, to, ctx)
+	if errors.Is(err, metricdata.ErrTimeout) && data != nil {
+		// Return the data loaded before the timeout together with the error.
+		graphql.AddError(ctx, err)
+	} else if err != nil {
 		log.Warn("Error while loading node data")
 		return nil, err
 	}
@@ -346,13 +663,61 @@ func (r *queryResolver) NodeMetrics(ctx context.Context, cluster string, nodes [
 				})
 			}
 		}
+		sort.Slice(host.Metrics, func(i, j int) bool { return host.Metrics[i].Name < host.Metrics[j].Name })
 
 		nodeMetrics = append(nodeMetrics, host)
 	}
+	sort.Slice(nodeMetrics, func(i, j int) bool { return nodeMetrics[i].Host < nodeMetrics[j].Host })
 
 	return nodeMetrics, nil
 }
 
+// Timeline is the resolver for the timeline field.
+func (r *queryResolver) Timeline(ctx context.Context, cluster string, from time.Time, to time.Time) ([]*repository.JobSpan, error) {
+	if err := config.CheckClusterAccess(repository.GetUserFromContext(ctx), cluster); err != nil {
+		return nil, err
+	}
+
+	spans, err := r.Repo.Timeline(ctx, cluster, from.Unix(), to.Unix())
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*repository.JobSpan, 0, len(spans))
+	for i := range spans {
+		res = append(res, &spans[i])
+	}
+	return res, nil
+}
+
+// MetricAvailability is the resolver for the metricAvailability field.
+func (r *queryResolver) MetricAvailability(ctx context.Context, cluster string) ([]*model.MetricAvailability, error) {
+	if err := config.CheckClusterAccess(repository.GetUserFromContext(ctx), cluster); err != nil {
+		return nil, err
+	}
+
+	c := archive.GetCluster(cluster)
+	if c == nil {
+		return nil, fmt.Errorf("unknown cluster: %s", cluster)
+	}
+
+	res := make([]*model.MetricAvailability, 0, len(c.MetricConfig))
+	for _, mc := range c.MetricConfig {
+		res = append(res, metricAvailability(c, mc))
+	}
+	return res, nil
+}
+
+// Percentiles is the resolver for the percentiles field.
+func (r *statsSeriesResolver) Percentiles(ctx context.Context, obj *schema.StatsSeries) ([]*model.PercentileSeries, error) {
+	res := make([]*model.PercentileSeries, 0, len(obj.Percentiles))
+	for p, data := range obj.Percentiles {
+		res = append(res, &model.PercentileSeries{Percentile: p, Data: data})
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Percentile < res[j].Percentile })
+	return res, nil
+}
+
 // NumberOfNodes is the resolver for the numberOfNodes field.
 func (r *subClusterResolver) NumberOfNodes(ctx context.Context, obj *schema.SubCluster) (int, error) {
 	nodeList, err := archive.ParseNodeList(obj.Nodes)
@@ -362,6 +727,36 @@ func (r *subClusterResolver) NumberOfNodes(ctx context.Context, obj *schema.SubC
 	return nodeList.NodeCount(), nil
 }
 
+// JobStateChanged is the resolver for the jobStateChanged field.
+func (r *subscriptionResolver) JobStateChanged(ctx context.Context, cluster *string) (<-chan *schema.Job, error) {
+	user := repository.GetUserFromContext(ctx)
+	jobs, cancel := r.Repo.SubscribeJobStates()
+
+	res := make(chan *schema.Job)
+	go func() {
+		defer close(res)
+		defer cancel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case job := <-jobs:
+				if (cluster != nil && job.Cluster != *cluster) || !jobVisible(user, job) {
+					continue
+				}
+
+				select {
+				case res <- job:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return res, nil
+}
+
 // Cluster returns generated.ClusterResolver implementation.
 func (r *Resolver) Cluster() generated.ClusterResolver { return &clusterResolver{r} }
 
@@ -374,11 +769,19 @@ func (r *Resolver) Mutation() generated.MutationResolver { return &mutationResol
 // Query returns generated.QueryResolver implementation.
 func (r *Resolver) Query() generated.QueryResolver { return &queryResolver{r} }
 
+// StatsSeries returns generated.StatsSeriesResolver implementation.
+func (r *Resolver) StatsSeries() generated.StatsSeriesResolver { return &statsSeriesResolver{r} }
+
 // SubCluster returns generated.SubClusterResolver implementation.
 func (r *Resolver) SubCluster() generated.SubClusterResolver { return &subClusterResolver{r} }
 
+// Subscription returns generated.SubscriptionResolver implementation.
+func (r *Resolver) Subscription() generated.SubscriptionResolver { return &subscriptionResolver{r} }
+
 type clusterResolver struct{ *Resolver }
 type jobResolver struct{ *Resolver }
 type mutationResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
+type statsSeriesResolver struct{ *Resolver }
 type subClusterResolver struct{ *Resolver }
+type subscriptionResolver struct{ *Resolver }
@@ -6,19 +6,36 @@ package graph
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 
 	"github.com/99designs/gqlgen/graphql"
+	"github.com/ClusterCockpit/cc-backend/internal/config"
 	"github.com/ClusterCockpit/cc-backend/internal/graph/model"
 	"github.com/ClusterCockpit/cc-backend/internal/metricdata"
+	"github.com/ClusterCockpit/cc-backend/internal/repository"
+	"github.com/ClusterCockpit/cc-backend/pkg/archive"
 	"github.com/ClusterCockpit/cc-backend/pkg/log"
 	"github.com/ClusterCockpit/cc-backend/pkg/schema"
-	// "github.com/ClusterCockpit/cc-backend/pkg/archive"
 )
 
 const MAX_JOBS_FOR_ANALYSIS = 500
 
+// CheckClusterFilters returns an error wrapping config.ErrClusterForbidden if
+// one of filters explicitly selects a cluster user may not access. Clusters
+// selected otherwise are not checked, the job queries exclude them silently.
+func CheckClusterFilters(user *schema.User, filters []*model.JobFilter) error {
+	for _, f := range filters {
+		if f != nil && f.Cluster != nil && f.Cluster.Eq != nil {
+			if err := config.CheckClusterAccess(user, *f.Cluster.Eq); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // Helper function for the rooflineHeatmap GraphQL query placed here so that schema.resolvers.go is not too full.
 func (r *queryResolver) rooflineHeatmap(
 	ctx context.Context,
@@ -94,6 +111,82 @@ func (r *queryResolver) rooflineHeatmap(
 	return tiles, nil
 }
 
+// Helper function for the roofline GraphQL query placed here so that schema.resolvers.go is not too full.
+// If flops_any or mem_bw are not available at node scope, only the peak values of the subcluster are returned.
+func (r *queryResolver) roofline(ctx context.Context, id string) (*model.RooflineData, error) {
+	job, err := r.Query().Job(ctx, id)
+	if err != nil {
+		log.Warn("Error while querying job for roofline")
+		return nil, err
+	}
+
+	subCluster, err := archive.GetSubCluster(job.Cluster, job.SubCluster)
+	if err != nil {
+		log.Warnf("Error while getting subcluster of job %d for roofline", job.ID)
+		return nil, err
+	}
+
+	res := &model.RooflineData{
+		FlopRateScalar:  &subCluster.FlopRateScalar,
+		FlopRateSimd:    &subCluster.FlopRateSimd,
+		MemoryBandwidth: &subCluster.MemoryBandwidth,
+		Points:          []*model.RooflinePoint{},
+	}
+	if job.MonitoringStatus == schema.MonitoringStatusDisabled || job.MonitoringStatus == schema.MonitoringStatusArchivingFailed {
+		return res, nil
+	}
+
+	jobdata, err := metricdata.LoadData(job, []string{"flops_any", "mem_bw"}, []schema.MetricScope{schema.MetricScopeNode}, ctx)
+	if err != nil {
+		log.Warnf("Error while loading roofline metrics for job %d", job.ID)
+		return nil, err
+	}
+
+	flops, ok1 := jobdata["flops_any"][schema.MetricScopeNode]
+	membw, ok2 := jobdata["mem_bw"][schema.MetricScopeNode]
+	if !ok1 || !ok2 {
+		log.Infof("roofline(): 'flops_any' or 'mem_bw' not available at 'node' level for job %d", job.ID)
+		return res, nil
+	}
+
+	res.Points = rooflinePoints(flops, membw)
+	return res, nil
+}
+
+// rooflinePoints pairs the flops_any and mem_bw values of every node and
+// timestep. Timesteps with a missing value or without memory traffic are skipped.
+func rooflinePoints(flops, membw *schema.JobMetric) []*model.RooflinePoint {
+	points := []*model.RooflinePoint{}
+	for _, flopsSeries := range flops.Series {
+		var membwSeries *schema.Series
+		for i := range membw.Series {
+			if membw.Series[i].Hostname == flopsSeries.Hostname {
+				membwSeries = &membw.Series[i]
+				break
+			}
+		}
+		if membwSeries == nil {
+			continue
+		}
+
+		for i := 0; i < len(flopsSeries.Data) && i < len(membwSeries.Data); i++ {
+			f, m := flopsSeries.Data[i], membwSeries.Data[i]
+			if f.IsNaN() || m.IsNaN() || m <= 0 {
+				continue
+			}
+
+			points = append(points, &model.RooflinePoint{
+				Hostname:    flopsSeries.Hostname,
+				Time:        i * flops.Timestep,
+				Intensity:   float64(f / m),
+				Performance: float64(f),
+			})
+		}
+	}
+
+	return points
+}
+
 // Helper function for the jobsFootprints GraphQL query placed here so that schema.resolvers.go is not too full.
 func (r *queryResolver) jobsFootprints(ctx context.Context, filter []*model.JobFilter, metrics []string) (*model.Footprints, error) {
 	jobs, err := r.Repo.QueryJobs(ctx, filter, &model.PageRequest{Page: 1, ItemsPerPage: MAX_JOBS_FOR_ANALYSIS + 1}, nil)
@@ -176,6 +269,116 @@ func (r *queryResolver) jobsFootprints(ctx context.Context, filter []*model.JobF
 // 	return totalJobCores
 // }
 
+// prefetchTags loads the tags of all jobs with one query, so that the job
+// resolver does not query them job by job.
+func (r *queryResolver) prefetchTags(ctx context.Context, jobs []*schema.Job) error {
+	ids := make([]int64, 0, len(jobs))
+	for _, job := range jobs {
+		ids = append(ids, job.ID)
+	}
+
+	tags, err := r.Repo.GetTagsForJobs(repository.GetUserFromContext(ctx), ids)
+	if err != nil {
+		return err
+	}
+
+	for _, job := range jobs {
+		job.Tags = tags[job.ID]
+		if job.Tags == nil {
+			job.Tags = make([]*schema.Tag, 0)
+		}
+	}
+	return nil
+}
+
+// metricAvailability returns the scopes and thresholds of the metric mc of
+// cluster. Thresholds set for a subcluster take precedence over the cluster
+// wide ones, subclusters the metric is removed from are left out.
+func metricAvailability(cluster *schema.Cluster, mc *schema.MetricConfig) *model.MetricAvailability {
+	res := &model.MetricAvailability{
+		Name:        mc.Name,
+		Unit:        &mc.Unit,
+		Scope:       mc.Scope,
+		Scopes:      availableScopes(mc.Scope),
+		Timestep:    mc.Timestep,
+		Peak:        mc.Peak,
+		Normal:      mc.Normal,
+		Caution:     mc.Caution,
+		Alert:       mc.Alert,
+		SubClusters: make([]*model.SubClusterMetricAvailability, 0, len(cluster.SubClusters)),
+	}
+
+	for _, sc := range cluster.SubClusters {
+		avail := &model.SubClusterMetricAvailability{
+			Name:    sc.Name,
+			Peak:    mc.Peak,
+			Normal:  mc.Normal,
+			Caution: mc.Caution,
+			Alert:   mc.Alert,
+		}
+
+		removed := false
+		for _, scc := range mc.SubClusters {
+			if scc.Name != sc.Name {
+				continue
+			}
+			removed = scc.Remove
+			if scc.Peak != 0 {
+				avail.Peak = scc.Peak
+			}
+			if scc.Normal != 0 {
+				avail.Normal = scc.Normal
+			}
+			if scc.Caution != 0 {
+				avail.Caution = scc.Caution
+			}
+			if scc.Alert != 0 {
+				avail.Alert = scc.Alert
+			}
+		}
+
+		if !removed {
+			res.SubClusters = append(res.SubClusters, avail)
+		}
+	}
+
+	return res
+}
+
+// availableScopes returns the scopes data of a metric with the native scope
+// can be loaded at, from the native scope up to node. Accelerator metrics
+// can only be aggregated to node.
+func availableScopes(native schema.MetricScope) []schema.MetricScope {
+	if native == schema.MetricScopeAccelerator {
+		return []schema.MetricScope{schema.MetricScopeAccelerator, schema.MetricScopeNode}
+	}
+
+	scopes := make([]schema.MetricScope, 0, 5)
+	for _, scope := range []schema.MetricScope{
+		schema.MetricScopeHWThread, schema.MetricScopeCore, schema.MetricScopeMemoryDomain,
+		schema.MetricScopeSocket, schema.MetricScopeNode,
+	} {
+		if native.LTE(scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
+
+// ErrJobNotVisible is returned by the job resolver if the user may not see
+// the job.
+var ErrJobNotVisible = errors.New("you are not allowed to see this job")
+
+// jobVisible reports whether user may see job. Without user (authentication
+// disabled), all jobs are visible.
+func jobVisible(user *schema.User, job *schema.Job) bool {
+	if !config.HasClusterAccess(user, job.Cluster) {
+		return false
+	}
+	return user == nil || job.User == user.Username ||
+		!user.HasNotRoles([]schema.Role{schema.RoleAdmin, schema.RoleSupport, schema.RoleManager})
+}
+
 func requireField(ctx context.Context, name string) bool {
 	fields := graphql.CollectAllFields(ctx)
 
@@ -187,3 +390,55 @@ func requireField(ctx context.Context, name string) bool {
 
 	return false
 }
+
+// requireSubField reports whether name is selected below the field parent of
+// the current field, e.g. the tags of the items of a job list.
+func requireSubField(ctx context.Context, parent, name string) bool {
+	opCtx := graphql.GetOperationContext(ctx)
+	for _, f := range graphql.CollectFieldsCtx(ctx, nil) {
+		if f.Name != parent {
+			continue
+		}
+		for _, sf := range graphql.CollectFields(opCtx, f.Selections, nil) {
+			if sf.Name == name {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Number of users or projects returned by topUsers and topProjects if no
+// limit is given.
+const defaultTopUsageLimit = 10
+
+// Number of jobs returned by similarJobs if no limit is given, and the
+// maximum limit.
+const (
+	defaultSimilarJobsLimit = 10
+	maxSimilarJobsLimit     = 100
+)
+
+// Days before and after the start of a job within which the jobs compared by
+// footprintPercentiles were started, if no window is given.
+const defaultFootprintPercentilesWindow = 30
+
+func topUsageArgs(cluster *string, limit *int) (string, int) {
+	c, l := "", defaultTopUsageLimit
+	if cluster != nil {
+		c = *cluster
+	}
+	if limit != nil {
+		l = *limit
+	}
+	return c, l
+}
+
+func userStatPointers(stats []repository.UserStat) []*repository.UserStat {
+	res := make([]*repository.UserStat, 0, len(stats))
+	for i := range stats {
+		res = append(res, &stats[i])
+	}
+	return res
+}
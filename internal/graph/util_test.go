@@ -0,0 +1,34 @@
+// Copyright (C) 2023 NHR@FAU, University Erlangen-Nuremberg.
+// All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+package graph
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ClusterCockpit/cc-backend/internal/config"
+	"github.com/ClusterCockpit/cc-backend/internal/graph/model"
+	"github.com/ClusterCockpit/cc-backend/pkg/schema"
+)
+
+func TestCheckClusterFilters(t *testing.T) {
+	clusters := config.Keys.Clusters
+	t.Cleanup(func() { config.Keys.Clusters = clusters })
+
+	config.Keys.Clusters = []*schema.ClusterConfig{
+		{Name: "open"},
+		{Name: "staff", Access: &schema.ClusterAccessConfig{AllowRoles: []string{"support"}}},
+	}
+
+	bob := &schema.User{Username: "bob", Roles: []string{"user"}}
+	staff, open := "staff", "open"
+	err := CheckClusterFilters(bob, []*model.JobFilter{{Cluster: &model.StringInput{Eq: &open}}, {Cluster: &model.StringInput{Eq: &staff}}})
+	if !errors.Is(err, config.ErrClusterForbidden) {
+		t.Errorf("expected ErrClusterForbidden, got %v", err)
+	}
+	if err := CheckClusterFilters(bob, []*model.JobFilter{{Cluster: &model.StringInput{StartsWith: &staff}}}); err != nil {
+		t.Errorf("unexpected error for filter not selecting a cluster: %v", err)
+	}
+}
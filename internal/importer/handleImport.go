@@ -6,7 +6,10 @@ package importer
 
 import (
 	"bytes"
+	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -19,116 +22,132 @@ import (
 	"github.com/ClusterCockpit/cc-backend/pkg/schema"
 )
 
+// ErrJobExists is returned by ImportJob if the combination of jobId, cluster
+// and startTime is already in the database.
+var ErrJobExists = errors.New("a job with that jobId, cluster and startTime does already exist")
+
 // Import all jobs specified as `<path-to-meta.json>:<path-to-data.json>,...`
 func HandleImportFlag(flag string) error {
-	r := repository.GetJobRepository()
-
 	for _, pair := range strings.Split(flag, ",") {
 		files := strings.Split(pair, ":")
 		if len(files) != 2 {
 			return fmt.Errorf("REPOSITORY/INIT > invalid import flag format")
 		}
 
-		raw, err := os.ReadFile(files[0])
+		rawMeta, err := os.ReadFile(files[0])
 		if err != nil {
 			log.Warn("Error while reading metadata file for import")
 			return err
 		}
 
-		if config.Keys.Validate {
-			if err = schema.Validate(schema.Meta, bytes.NewReader(raw)); err != nil {
-				return fmt.Errorf("REPOSITORY/INIT > validate job meta: %v", err)
-			}
-		}
-		dec := json.NewDecoder(bytes.NewReader(raw))
-		dec.DisallowUnknownFields()
-		jobMeta := schema.JobMeta{BaseJob: schema.JobDefaults}
-		if err = dec.Decode(&jobMeta); err != nil {
-			log.Warn("Error while decoding raw json metadata for import")
-			return err
-		}
-
-		raw, err = os.ReadFile(files[1])
+		rawData, err := os.ReadFile(files[1])
 		if err != nil {
 			log.Warn("Error while reading jobdata file for import")
 			return err
 		}
 
-		if config.Keys.Validate {
-			if err = schema.Validate(schema.Data, bytes.NewReader(raw)); err != nil {
-				return fmt.Errorf("REPOSITORY/INIT > validate job data: %v", err)
-			}
-		}
-		dec = json.NewDecoder(bytes.NewReader(raw))
-		dec.DisallowUnknownFields()
-		jobData := schema.JobData{}
-		if err = dec.Decode(&jobData); err != nil {
-			log.Warn("Error while decoding raw json jobdata for import")
+		if _, err := ImportJob(rawMeta, rawData); err != nil {
 			return err
 		}
+	}
+	return nil
+}
 
-		// checkJobData(&jobData)
-
-		jobMeta.MonitoringStatus = schema.MonitoringStatusArchivingSuccessful
-
-		// if _, err = r.Find(&jobMeta.JobID, &jobMeta.Cluster, &jobMeta.StartTime); err != sql.ErrNoRows {
-		// 	if err != nil {
-		// 		log.Warn("Error while finding job in jobRepository")
-		// 		return err
-		// 	}
-		//
-		// 	return fmt.Errorf("REPOSITORY/INIT > a job with that jobId, cluster and startTime does already exist")
-		// }
-		//
-		job := schema.Job{
-			BaseJob:       jobMeta.BaseJob,
-			StartTime:     time.Unix(jobMeta.StartTime, 0),
-			StartTimeUnix: jobMeta.StartTime,
-		}
-
-		// TODO: Other metrics...
-		job.LoadAvg = loadJobStat(&jobMeta, "cpu_load")
-		job.FlopsAnyAvg = loadJobStat(&jobMeta, "flops_any")
-		job.MemUsedMax = loadJobStat(&jobMeta, "mem_used")
-		job.MemBwAvg = loadJobStat(&jobMeta, "mem_bw")
-		job.NetBwAvg = loadJobStat(&jobMeta, "net_bw")
-		job.FileBwAvg = loadJobStat(&jobMeta, "file_bw")
+// Import the job given by the contents of its meta.json and data.json into
+// the job-archive and the database. Returns the database id of the new job.
+func ImportJob(rawMeta []byte, rawData []byte) (int64, error) {
+	r := repository.GetJobRepository()
 
-		job.RawResources, err = json.Marshal(job.Resources)
-		if err != nil {
-			log.Warn("Error while marshaling job resources")
-			return err
-		}
-		job.RawMetaData, err = json.Marshal(job.MetaData)
-		if err != nil {
-			log.Warn("Error while marshaling job metadata")
-			return err
+	if config.Keys.Validate {
+		if err := schema.Validate(schema.Meta, bytes.NewReader(rawMeta)); err != nil {
+			return 0, fmt.Errorf("REPOSITORY/INIT > validate job meta: %v", err)
 		}
+	}
+	dec := json.NewDecoder(bytes.NewReader(rawMeta))
+	dec.DisallowUnknownFields()
+	jobMeta := schema.JobMeta{BaseJob: schema.JobDefaults}
+	if err := dec.Decode(&jobMeta); err != nil {
+		log.Warn("Error while decoding raw json metadata for import")
+		return 0, err
+	}
 
-		if err = SanityChecks(&job.BaseJob); err != nil {
-			log.Warn("BaseJob SanityChecks failed")
-			return err
+	if config.Keys.Validate {
+		if err := schema.Validate(schema.Data, bytes.NewReader(rawData)); err != nil {
+			return 0, fmt.Errorf("REPOSITORY/INIT > validate job data: %v", err)
 		}
+	}
+	dec = json.NewDecoder(bytes.NewReader(rawData))
+	dec.DisallowUnknownFields()
+	jobData := schema.JobData{}
+	if err := dec.Decode(&jobData); err != nil {
+		log.Warn("Error while decoding raw json jobdata for import")
+		return 0, err
+	}
 
-		if err = archive.GetHandle().ImportJob(&jobMeta, &jobData); err != nil {
-			log.Error("Error while importing job")
-			return err
-		}
+	// checkJobData(&jobData)
+
+	jobMeta.MonitoringStatus = schema.MonitoringStatusArchivingSuccessful
 
-		id, err := r.InsertJob(&job)
+	// Checked before writing to the job-archive, which would overwrite the
+	// files of the existing job.
+	if _, err := r.Find(context.Background(), &jobMeta.JobID, &jobMeta.Cluster, &jobMeta.StartTime); err != sql.ErrNoRows {
 		if err != nil {
-			log.Warn("Error while job db insert")
-			return err
+			log.Warn("Error while finding job in jobRepository")
+			return 0, err
 		}
 
-		for _, tag := range job.Tags {
-			if _, err := r.AddTagOrCreate(id, tag.Type, tag.Name); err != nil {
-				log.Error("Error while adding or creating tag")
-				return err
-			}
-		}
+		return 0, ErrJobExists
+	}
 
-		log.Infof("successfully imported a new job (jobId: %d, cluster: %s, dbid: %d)", job.JobID, job.Cluster, id)
+	job := schema.Job{
+		BaseJob:       jobMeta.BaseJob,
+		StartTime:     time.Unix(jobMeta.StartTime, 0),
+		StartTimeUnix: jobMeta.StartTime,
 	}
-	return nil
+
+	// TODO: Other metrics...
+	job.LoadAvg = loadJobStat(&jobMeta, "cpu_load")
+	job.FlopsAnyAvg = loadJobStat(&jobMeta, "flops_any")
+	job.MemUsedMax = loadJobStat(&jobMeta, "mem_used")
+	job.MemBwAvg = loadJobStat(&jobMeta, "mem_bw")
+	job.NetBwAvg = loadJobStat(&jobMeta, "net_bw")
+	job.FileBwAvg = loadJobStat(&jobMeta, "file_bw")
+
+	var err error
+	job.RawResources, err = json.Marshal(job.Resources)
+	if err != nil {
+		log.Warn("Error while marshaling job resources")
+		return 0, err
+	}
+	job.RawMetaData, err = json.Marshal(job.MetaData)
+	if err != nil {
+		log.Warn("Error while marshaling job metadata")
+		return 0, err
+	}
+
+	if err = SanityChecks(&job.BaseJob); err != nil {
+		log.Warn("BaseJob SanityChecks failed")
+		return 0, err
+	}
+
+	if err = archive.GetHandle().ImportJob(&jobMeta, &jobData); err != nil {
+		log.Error("Error while importing job")
+		return 0, err
+	}
+
+	id, err := r.InsertJob(&job)
+	if err != nil {
+		log.Warn("Error while job db insert")
+		return 0, err
+	}
+
+	for _, tag := range job.Tags {
+		if _, err := r.AddTagOrCreate(nil, id, tag.Type, tag.Name, tag.Scope); err != nil {
+			log.Error("Error while adding or creating tag")
+			return 0, err
+		}
+	}
+
+	log.Infof("successfully imported a new job (jobId: %d, cluster: %s, dbid: %d)", job.JobID, job.Cluster, id)
+	return id, nil
 }
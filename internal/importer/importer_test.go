@@ -5,18 +5,21 @@
 package importer_test
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ClusterCockpit/cc-backend/internal/config"
 	"github.com/ClusterCockpit/cc-backend/internal/importer"
 	"github.com/ClusterCockpit/cc-backend/internal/repository"
 	"github.com/ClusterCockpit/cc-backend/pkg/archive"
 	"github.com/ClusterCockpit/cc-backend/pkg/log"
+	"github.com/ClusterCockpit/cc-backend/pkg/schema"
 )
 
 func copyFile(s string, d string) error {
@@ -163,7 +166,7 @@ func TestHandleImportFlag(t *testing.T) {
 			}
 
 			result := readResult(t, testname)
-			job, err := r.Find(&result.JobId, &result.Cluster, &result.StartTime)
+			job, err := r.Find(context.Background(), &result.JobId, &result.Cluster, &result.StartTime)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -173,3 +176,187 @@ func TestHandleImportFlag(t *testing.T) {
 		})
 	}
 }
+
+func TestSanityChecks(t *testing.T) {
+	setup(t)
+
+	tests := map[string]struct {
+		meta string
+		err  string
+	}{
+		"Valid": {
+			meta: `"numNodes": 2, "numHwthreads": 4, "resources": [{"hostname": "f0101", "hwthreads": [0, 1]}, {"hostname": "f0102", "hwthreads": [0, 1]}]`,
+		},
+		"ValidWithoutHWThreads": {
+			meta: `"numNodes": 1, "numHwthreads": 72, "resources": [{"hostname": "f0101"}]`,
+		},
+		"NumNodesMismatch": {
+			meta: `"numNodes": 3, "resources": [{"hostname": "f0101"}, {"hostname": "f0102"}]`,
+			err:  "len(resources) does not equal numNodes (2 vs 3)",
+		},
+		"NumHWThreadsMismatch": {
+			meta: `"numNodes": 2, "numHwthreads": 8, "resources": [{"hostname": "f0101", "hwthreads": [0, 1]}, {"hostname": "f0102", "hwthreads": [0, 1]}]`,
+			err:  "number of hwthreads in resources does not equal numHWThreads (4 vs 8)",
+		},
+		"NumAccMismatch": {
+			meta: `"numNodes": 1, "numAcc": 1, "resources": [{"hostname": "f0101", "accelerators": ["00000000:01:00.0", "00000000:41:00.0"]}]`,
+			err:  "number of accelerators in resources does not equal numAcc (2 vs 1)",
+		},
+		"NumAccMissing": {
+			meta: `"numNodes": 1, "resources": [{"hostname": "f0101", "accelerators": ["00000000:01:00.0"]}]`,
+			err:  "number of accelerators in resources does not equal numAcc (1 vs 0)",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var job schema.BaseJob
+			input := `{"jobId": 1, "user": "testuser", "cluster": "fritz", "subCluster": "main", "jobState": "completed", ` + tc.meta + `}`
+			if err := json.Unmarshal([]byte(input), &job); err != nil {
+				t.Fatal(err)
+			}
+
+			err := importer.SanityChecks(&job)
+			if tc.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			} else if err == nil || err.Error() != tc.err {
+				t.Fatalf("expected error %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestImportNewJobs(t *testing.T) {
+	r := setup(t)
+
+	raw, err := os.ReadFile(filepath.Join("testdata", "meta-fritzMinimal.input"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var jobMeta schema.JobMeta
+	if err := json.Unmarshal(raw, &jobMeta); err != nil {
+		t.Fatal(err)
+	}
+	jobMeta.Tags = []*schema.Tag{{Type: "testTagType", Name: "testTagName"}}
+
+	raw, err = os.ReadFile(filepath.Join("testdata", "data-fritzMinimal.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var jobData schema.JobData
+	if err := json.Unmarshal(raw, &jobData); err != nil {
+		t.Fatal(err)
+	}
+
+	ar := archive.GetHandle()
+	if err := ar.ImportJob(&jobMeta, &jobData); err != nil {
+		t.Fatal(err)
+	}
+	if err := importer.InitDB(); err != nil {
+		t.Fatal(err)
+	}
+
+	// A second job with the same tag arrives in the archive.
+	jobMeta.JobID += 1
+	jobMeta.StartTime += 3600
+	if err := ar.ImportJob(&jobMeta, &jobData); err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := importer.ImportNewJobs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 {
+		t.Errorf("expected 1 new job, got %d", n)
+	}
+
+	for _, jobId := range []int64{jobMeta.JobID - 1, jobMeta.JobID} {
+		job, err := r.Find(context.Background(), &jobId, &jobMeta.Cluster, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tags, err := r.GetTags(nil, &job.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(tags) != 1 || tags[0].Name != "testTagName" {
+			t.Errorf("unexpected tags of job %d: %v", jobId, tags)
+		}
+	}
+
+	tags, err := r.GetTags(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tags) != 1 {
+		t.Errorf("expected the existing tag to be reused, got %d tags", len(tags))
+	}
+
+	if n, err := importer.ImportNewJobs(); err != nil || n != 0 {
+		t.Errorf("expected no new jobs, got %d (error: %v)", n, err)
+	}
+}
+
+func TestCleanupOrphanedArchives(t *testing.T) {
+	r := setup(t)
+
+	raw, err := os.ReadFile(filepath.Join("testdata", "meta-fritzMinimal.input"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var jobMeta schema.JobMeta
+	if err := json.Unmarshal(raw, &jobMeta); err != nil {
+		t.Fatal(err)
+	}
+	raw, err = os.ReadFile(filepath.Join("testdata", "data-fritzMinimal.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var jobData schema.JobData
+	if err := json.Unmarshal(raw, &jobData); err != nil {
+		t.Fatal(err)
+	}
+
+	ar := archive.GetHandle()
+	jobs := make([]*schema.Job, 0, 2)
+	for i := 0; i < 2; i++ {
+		if err := ar.ImportJob(&jobMeta, &jobData); err != nil {
+			t.Fatal(err)
+		}
+		jobs = append(jobs, &schema.Job{BaseJob: jobMeta.BaseJob, StartTime: time.Unix(jobMeta.StartTime, 0)})
+		jobMeta.JobID += 1
+		jobMeta.StartTime += 3600
+	}
+	if err := importer.InitDB(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The first job is deleted from the database, its directory is orphaned.
+	orphan, err := r.Find(context.Background(), &jobs[0].JobID, &jobs[0].Cluster, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.DeleteJobById(orphan.ID, false); err != nil {
+		t.Fatal(err)
+	}
+
+	if n, err := importer.CleanupOrphanedArchives(true); err != nil || n != 1 {
+		t.Fatalf("dry run: expected 1 orphaned directory, got %d (error: %v)", n, err)
+	}
+	if !ar.Exists(jobs[0]) {
+		t.Fatal("dry run removed the orphaned directory")
+	}
+
+	if n, err := importer.CleanupOrphanedArchives(false); err != nil || n != 1 {
+		t.Fatalf("expected 1 removed directory, got %d (error: %v)", n, err)
+	}
+	if ar.Exists(jobs[0]) {
+		t.Error("orphaned directory not removed")
+	}
+	if !ar.Exists(jobs[1]) {
+		t.Error("directory of a job in the database removed")
+	}
+}
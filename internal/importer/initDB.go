@@ -5,6 +5,8 @@
 package importer
 
 import (
+	"context"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -27,12 +29,98 @@ func InitDB() error {
 	starttime := time.Now()
 	log.Print("Building job table...")
 
+	i, err := importArchive(r, make(map[string]int64), nil)
+	if err != nil {
+		return err
+	}
+
+	log.WithFields(log.Fields{"jobs": i, "duration": time.Since(starttime)}).
+		Printf("A total of %d jobs have been registered in %.3f seconds.", i, time.Since(starttime).Seconds())
+	return nil
+}
+
+// Add the jobs found in `archive` that are not yet in the database, matched
+// by cluster, job id and start time. In contrast to InitDB, existing jobs and
+// tags are kept. Returns the number of inserted jobs.
+func ImportNewJobs() (int, error) {
+	r := repository.GetJobRepository()
+	starttime := time.Now()
+	log.Print("Adding new jobs to job table...")
+
+	existing, err := r.GetTags(nil, nil)
+	if err != nil {
+		log.Errorf("repository importNewJobs(): %v", err)
+		return 0, err
+	}
+	tags := make(map[string]int64, len(existing))
+	for _, tag := range existing {
+		tags[tag.Name+":"+tag.Type+":"+tag.Scope] = tag.ID
+	}
+
+	i, err := importArchive(r, tags, func(jobMeta *schema.JobMeta) bool {
+		_, err := r.Find(context.Background(), &jobMeta.JobID, &jobMeta.Cluster, &jobMeta.StartTime)
+		if err != nil && err != sql.ErrNoRows {
+			log.Warnf("repository importNewJobs(): %v", err)
+		}
+		return err == nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	log.WithFields(log.Fields{"jobs": i, "duration": time.Since(starttime)}).
+		Printf("A total of %d new jobs have been registered in %.3f seconds.", i, time.Since(starttime).Seconds())
+	return i, nil
+}
+
+// Remove the job directories in `archive` of the jobs that are not in the
+// database (anymore), matched by cluster, job id and start time. With dryRun
+// set, the orphaned directories are only logged. Returns the number of
+// removed (or, with dryRun, orphaned) job directories.
+func CleanupOrphanedArchives(dryRun bool) (int, error) {
+	r := repository.GetJobRepository()
+	starttime := time.Now()
+	log.Print("Looking for orphaned job directories in job-archive...")
+
+	orphans := make([]*schema.Job, 0)
+	for jobContainer := range archive.GetHandle().Iter(false) {
+		jobMeta := jobContainer.Meta
+		_, err := r.Find(context.Background(), &jobMeta.JobID, &jobMeta.Cluster, &jobMeta.StartTime)
+		if err == nil {
+			continue
+		}
+		if err != sql.ErrNoRows {
+			log.Errorf("repository cleanupOrphanedArchives(): %v", err)
+			return 0, err
+		}
+
+		log.Infof("orphaned job directory: cluster=%s, jobId=%d, startTime=%d", jobMeta.Cluster, jobMeta.JobID, jobMeta.StartTime)
+		orphans = append(orphans, &schema.Job{
+			BaseJob:       jobMeta.BaseJob,
+			StartTime:     time.Unix(jobMeta.StartTime, 0),
+			StartTimeUnix: jobMeta.StartTime,
+		})
+	}
+
+	if dryRun {
+		log.Printf("Dry run: would remove %d orphaned job directories.\n", len(orphans))
+		return len(orphans), nil
+	}
+
+	archive.GetHandle().CleanUp(orphans)
+	log.Printf("A total of %d orphaned job directories have been removed in %.3f seconds.\n", len(orphans), time.Since(starttime).Seconds())
+	return len(orphans), nil
+}
+
+// Insert the jobs found in `archive` and their tags, skipping the jobs for
+// which skip returns true. tags maps "name:type:scope" to the ids of the tags
+// already in the database and is extended by new tags.
+func importArchive(r *repository.JobRepository, tags map[string]int64, skip func(*schema.JobMeta) bool) (int, error) {
 	t, err := r.TransactionInit()
 	if err != nil {
 		log.Warn("Error while initializing SQL transactions")
-		return err
+		return 0, err
 	}
-	tags := make(map[string]int64)
 
 	// Not using log.Print because we want the line to end with `\r` and
 	// this function is only ever called when a special command line flag
@@ -46,11 +134,15 @@ func InitDB() error {
 	for jobContainer := range ar.Iter(false) {
 
 		jobMeta := jobContainer.Meta
+		if skip != nil && skip(jobMeta) {
+			continue
+		}
 
 		// Bundle 100 inserts into one transaction for better performance
 		if i%100 == 0 {
 			r.TransactionCommit(t)
 			fmt.Printf("%d jobs inserted...\r", i)
+			log.WithFields(log.Fields{"jobs": i, "errors": errorOccured}).Debug("importing job-archive")
 		}
 
 		jobMeta.MonitoringStatus = schema.MonitoringStatusArchivingSuccessful
@@ -83,7 +175,9 @@ func InitDB() error {
 		}
 
 		if err := SanityChecks(&job.BaseJob); err != nil {
-			log.Errorf("repository initDB(): %v", err)
+			log.WithFields(log.Fields{"job_id": job.JobID, "cluster": job.Cluster, "start_time": job.StartTimeUnix}).
+				Errorf("repository initDB(): skipping job %d (cluster: %s, startTime: %d): %v",
+					job.JobID, job.Cluster, job.StartTimeUnix, err)
 			errorOccured++
 			continue
 		}
@@ -96,7 +190,10 @@ func InitDB() error {
 		}
 
 		for _, tag := range job.Tags {
-			tagstr := tag.Name + ":" + tag.Type
+			if tag.Scope == "" {
+				tag.Scope = repository.TagScopeGlobal
+			}
+			tagstr := tag.Name + ":" + tag.Type + ":" + tag.Scope
 			tagId, ok := tags[tagstr]
 			if !ok {
 				tagId, err = r.TransactionAddTag(t, tag)
@@ -117,12 +214,10 @@ func InitDB() error {
 	}
 
 	if errorOccured > 0 {
-		log.Warnf("Error in import of %d jobs!", errorOccured)
+		log.WithFields(log.Fields{"errors": errorOccured}).Warnf("Error in import of %d jobs!", errorOccured)
 	}
 
-	r.TransactionEnd(t)
-	log.Printf("A total of %d jobs have been registered in %.3f seconds.\n", i, time.Since(starttime).Seconds())
-	return nil
+	return i, r.TransactionEnd(t)
 }
 
 // This function also sets the subcluster if necessary!
@@ -147,6 +242,21 @@ func SanityChecks(job *schema.BaseJob) error {
 		return fmt.Errorf("len(resources) does not equal numNodes (%d vs %d)", len(job.Resources), job.NumNodes)
 	}
 
+	// Listing the hwthreads and accelerators of a resource is optional, and a
+	// hwthread count of 0 means it was not given. If both are there, they have
+	// to agree. Listed accelerators always have to match numAcc.
+	numHWThreads, numAcc := 0, 0
+	for _, res := range job.Resources {
+		numHWThreads += len(res.HWThreads)
+		numAcc += len(res.Accelerators)
+	}
+	if numHWThreads != 0 && job.NumHWThreads != 0 && numHWThreads != int(job.NumHWThreads) {
+		return fmt.Errorf("number of hwthreads in resources does not equal numHWThreads (%d vs %d)", numHWThreads, job.NumHWThreads)
+	}
+	if numAcc != 0 && numAcc != int(job.NumAcc) {
+		return fmt.Errorf("number of accelerators in resources does not equal numAcc (%d vs %d)", numAcc, job.NumAcc)
+	}
+
 	return nil
 }
 
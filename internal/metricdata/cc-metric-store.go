@@ -9,6 +9,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -123,6 +124,27 @@ func (ccms *CCMetricStore) doRequest(
 	ctx context.Context,
 	body *ApiQueryRequest,
 ) (*ApiQueryResponse, error) {
+	res, err := ccms.sendRequest(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var resBody ApiQueryResponse
+	if err := json.NewDecoder(bufio.NewReader(res.Body)).Decode(&resBody); err != nil {
+		log.Warn("Error while decoding result body")
+		return nil, err
+	}
+
+	return &resBody, nil
+}
+
+// sendRequest posts the query to the metric store. The caller has to close
+// the body of the returned response.
+func (ccms *CCMetricStore) sendRequest(
+	ctx context.Context,
+	body *ApiQueryRequest,
+) (*http.Response, error) {
 	buf := &bytes.Buffer{}
 	if err := json.NewEncoder(buf).Encode(body); err != nil {
 		log.Warn("Error while encoding request body")
@@ -145,16 +167,27 @@ func (ccms *CCMetricStore) doRequest(
 	}
 
 	if res.StatusCode != http.StatusOK {
+		res.Body.Close()
 		return nil, fmt.Errorf("'%s': HTTP Status: %s", ccms.queryEndpoint, res.Status)
 	}
 
-	var resBody ApiQueryResponse
-	if err := json.NewDecoder(bufio.NewReader(res.Body)).Decode(&resBody); err != nil {
-		log.Warn("Error while decoding result body")
-		return nil, err
+	return res, nil
+}
+
+// HealthCheck sends a query without any metrics, which also verifies that
+// the metric store accepts the configured token.
+func (ccms *CCMetricStore) HealthCheck(ctx context.Context) error {
+	now := time.Now().Unix()
+	res, err := ccms.sendRequest(ctx, &ApiQueryRequest{
+		Queries: []ApiQuery{},
+		From:    now,
+		To:      now,
+	})
+	if err != nil {
+		return err
 	}
 
-	return &resBody, nil
+	return res.Body.Close()
 }
 
 func (ccms *CCMetricStore) LoadData(
@@ -184,10 +217,24 @@ func (ccms *CCMetricStore) LoadData(
 		return nil, err
 	}
 
+	return ccms.collectJobData(job, metrics, req.Queries, assignedScope, resBody.Results)
+}
+
+// collectJobData converts the results of the queries built for job into
+// schema.JobData. Metrics without any data are reported as
+// *MissingMetricsError, errors for other metrics as partial errors.
+func (ccms *CCMetricStore) collectJobData(
+	job *schema.Job,
+	metrics []string,
+	queries []ApiQuery,
+	assignedScope []schema.MetricScope,
+	results [][]ApiMetricData,
+) (schema.JobData, error) {
 	var errors []string
+	failed := make(map[string]bool)
 	jobData := make(schema.JobData)
-	for i, row := range resBody.Results {
-		query := req.Queries[i]
+	for i, row := range results {
+		query := queries[i]
 		metric := ccms.toLocalName(query.Metric)
 		scope := assignedScope[i]
 		mc := archive.GetMetricConfig(job.Cluster, metric)
@@ -209,33 +256,11 @@ func (ccms *CCMetricStore) LoadData(
 			if res.Error != nil {
 				/* Build list for "partial errors", if any */
 				errors = append(errors, fmt.Sprintf("failed to fetch '%s' from host '%s': %s", query.Metric, query.Hostname, *res.Error))
+				failed[metric] = true
 				continue
 			}
 
-			id := (*string)(nil)
-			if query.Type != nil {
-				id = new(string)
-				*id = query.TypeIds[ndx]
-			}
-
-			if res.Avg.IsNaN() || res.Min.IsNaN() || res.Max.IsNaN() {
-				// TODO: use schema.Float instead of float64?
-				// This is done because regular float64 can not be JSONed when NaN.
-				res.Avg = schema.Float(0)
-				res.Min = schema.Float(0)
-				res.Max = schema.Float(0)
-			}
-
-			jobMetric.Series = append(jobMetric.Series, schema.Series{
-				Hostname: query.Hostname,
-				Id:       id,
-				Statistics: schema.MetricStatistics{
-					Avg: float64(res.Avg),
-					Min: float64(res.Min),
-					Max: float64(res.Max),
-				},
-				Data: res.Data,
-			})
+			jobMetric.Series = append(jobMetric.Series, toSeries(&query, ndx, &res))
 		}
 
 		// So that one can later check len(jobData):
@@ -247,14 +272,281 @@ func (ccms *CCMetricStore) LoadData(
 		}
 	}
 
-	if len(errors) != 0 {
+	// Errors only concerning metrics the metric store has no data for at all
+	// are reported as missing metrics, so that the metrics it returned can
+	// still be used.
+	partial := false
+	for metric := range failed {
+		if _, ok := jobData[metric]; ok {
+			partial = true
+		}
+	}
+
+	if partial {
 		/* Returns list for "partial errors" */
 		return jobData, fmt.Errorf("METRICDATA/CCMS > Errors: %s", strings.Join(errors, ", "))
 	}
 
+	if noData, notConfigured := MissingMetrics(job, metrics, jobData); len(noData) != 0 || len(notConfigured) != 0 {
+		if len(errors) != 0 {
+			log.Infof("METRICDATA/CCMS > Errors: %s", strings.Join(errors, ", "))
+		}
+		return jobData, &MissingMetricsError{NoData: noData, NotConfigured: notConfigured}
+	}
+
 	return jobData, nil
 }
 
+// LoadDataForJobs loads the node data of all jobs with one request. The jobs
+// have to be jobs of the cluster of this repository. The metric store is
+// queried for the timespan covering all jobs, the series are then cut to the
+// timespan of their job and their statistics recomputed. Errors concerning
+// single jobs are logged and their jobs left out of the result.
+func (ccms *CCMetricStore) LoadDataForJobs(
+	jobs []*schema.Job,
+	metrics []string,
+	ctx context.Context,
+) (map[int64]schema.JobData, error) {
+	if len(jobs) == 0 {
+		return map[int64]schema.JobData{}, nil
+	}
+
+	scopes := []schema.MetricScope{schema.MetricScopeNode}
+	req := ApiQueryRequest{
+		WithStats: true,
+		WithData:  true,
+	}
+
+	// The queries of jobs[i] are req.Queries[offsets[i]:offsets[i+1]].
+	offsets := make([]int, 0, len(jobs)+1)
+	assignedScope := []schema.MetricScope{}
+	for _, job := range jobs {
+		queries, jobScopes, err := ccms.buildQueries(job, metrics, scopes)
+		if err != nil {
+			log.Warn("Error while building queries")
+			return nil, err
+		}
+
+		from, to := job.StartTime.Unix(), job.StartTime.Add(time.Duration(job.Duration)*time.Second).Unix()
+		if len(offsets) == 0 || from < req.From {
+			req.From = from
+		}
+		if len(offsets) == 0 || to > req.To {
+			req.To = to
+		}
+		req.Cluster = job.Cluster
+		offsets = append(offsets, len(req.Queries))
+		req.Queries = append(req.Queries, queries...)
+		assignedScope = append(assignedScope, jobScopes...)
+	}
+	offsets = append(offsets, len(req.Queries))
+
+	resBody, err := ccms.doRequest(ctx, &req)
+	if err != nil {
+		log.Error("Error while performing request")
+		return nil, err
+	}
+	if len(resBody.Results) != len(req.Queries) {
+		return nil, fmt.Errorf("METRICDATA/CCMS > expected %d results, got %d", len(req.Queries), len(resBody.Results))
+	}
+
+	res := make(map[int64]schema.JobData, len(jobs))
+	for i, job := range jobs {
+		first, last := offsets[i], offsets[i+1]
+		results := resBody.Results[first:last]
+		for j, row := range results {
+			mc := archive.GetMetricConfig(job.Cluster, ccms.toLocalName(req.Queries[first+j].Metric))
+			if mc == nil {
+				continue
+			}
+			results[j] = jobResults(job, row, req.From, int64(mc.Timestep))
+		}
+
+		jd, err := ccms.collectJobData(job, metrics, req.Queries[first:last], assignedScope[first:last], results)
+		var missing *MissingMetricsError
+		if errors.As(err, &missing) {
+			log.Infof("job %d: %s", job.JobID, missing.Error())
+			err = nil
+		}
+		if err != nil {
+			log.Errorf("loading data of job %d failed: %s", job.JobID, err.Error())
+			continue
+		}
+		res[job.ID] = jd
+	}
+
+	return res, nil
+}
+
+// jobResults cuts the data of row, queried from the timestamp from, to the
+// timespan of job and recomputes the statistics for it.
+func jobResults(job *schema.Job, row []ApiMetricData, from, timestep int64) []ApiMetricData {
+	if timestep <= 0 {
+		return row
+	}
+
+	start := job.StartTime.Unix()
+	end := start + int64(job.Duration)
+	cut := make([]ApiMetricData, len(row))
+	for i, res := range row {
+		if res.Error != nil {
+			cut[i] = res
+			continue
+		}
+		if res.From == 0 {
+			res.From = from
+		}
+
+		// Data point k was measured at res.From + k * timestep.
+		res.Data = sliceData(res.Data, (start-res.From+timestep-1)/timestep, (end-res.From)/timestep)
+		stats := seriesStatistics(res.Data)
+		res.Min, res.Avg, res.Max = schema.Float(stats.Min), schema.Float(stats.Avg), schema.Float(stats.Max)
+		res.From, res.To = start, end
+		cut[i] = res
+	}
+
+	return cut
+}
+
+// StreamData works like LoadData, but the response of the metric store is
+// decoded incrementally and every series is passed to handler as soon as it
+// has been read instead of collecting all of them in a schema.JobData.
+func (ccms *CCMetricStore) StreamData(
+	job *schema.Job,
+	metrics []string,
+	scopes []schema.MetricScope,
+	ctx context.Context,
+	handler SeriesHandler,
+) error {
+	queries, assignedScope, err := ccms.buildQueries(job, metrics, scopes)
+	if err != nil {
+		log.Warn("Error while building queries")
+		return err
+	}
+
+	req := ApiQueryRequest{
+		Cluster:   job.Cluster,
+		From:      job.StartTime.Unix(),
+		To:        job.StartTime.Add(time.Duration(job.Duration) * time.Second).Unix(),
+		Queries:   queries,
+		WithStats: true,
+		WithData:  true,
+	}
+
+	res, err := ccms.sendRequest(ctx, &req)
+	if err != nil {
+		log.Error("Error while performing request")
+		return err
+	}
+	defer res.Body.Close()
+
+	dec := json.NewDecoder(bufio.NewReader(res.Body))
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+
+	var errors []string
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		if key != "results" {
+			// Skip everything else (e.g. the echoed queries)
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := expectDelim(dec, '['); err != nil {
+			return err
+		}
+		for i := 0; dec.More(); i++ {
+			if i >= len(req.Queries) {
+				return fmt.Errorf("METRICDATA/CCMS > more results than queries in response")
+			}
+			query := req.Queries[i]
+			metric := ccms.toLocalName(query.Metric)
+
+			if err := expectDelim(dec, '['); err != nil {
+				return err
+			}
+			for ndx := 0; dec.More(); ndx++ {
+				var data ApiMetricData
+				if err := dec.Decode(&data); err != nil {
+					log.Warn("Error while decoding result body")
+					return err
+				}
+
+				if data.Error != nil {
+					errors = append(errors, fmt.Sprintf("failed to fetch '%s' from host '%s': %s", query.Metric, query.Hostname, *data.Error))
+					continue
+				}
+
+				series := toSeries(&query, ndx, &data)
+				if err := handler(metric, assignedScope[i], &series); err != nil {
+					return err
+				}
+			}
+			if err := expectDelim(dec, ']'); err != nil {
+				return err
+			}
+		}
+		if err := expectDelim(dec, ']'); err != nil {
+			return err
+		}
+	}
+
+	if len(errors) != 0 {
+		return fmt.Errorf("METRICDATA/CCMS > Errors: %s", strings.Join(errors, ", "))
+	}
+
+	return nil
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	t, err := dec.Token()
+	if err != nil {
+		log.Warn("Error while decoding result body")
+		return err
+	}
+	if d, ok := t.(json.Delim); !ok || d != delim {
+		return fmt.Errorf("METRICDATA/CCMS > unexpected token in response: expected '%s', got '%v'", delim, t)
+	}
+	return nil
+}
+
+// toSeries converts the ndx-th result of query into a schema.Series.
+func toSeries(query *ApiQuery, ndx int, res *ApiMetricData) schema.Series {
+	id := (*string)(nil)
+	if query.Type != nil {
+		id = new(string)
+		*id = query.TypeIds[ndx]
+	}
+
+	if res.Avg.IsNaN() || res.Min.IsNaN() || res.Max.IsNaN() {
+		// TODO: use schema.Float instead of float64?
+		// This is done because regular float64 can not be JSONed when NaN.
+		res.Avg = schema.Float(0)
+		res.Min = schema.Float(0)
+		res.Max = schema.Float(0)
+	}
+
+	return schema.Series{
+		Hostname: query.Hostname,
+		Id:       id,
+		Statistics: schema.MetricStatistics{
+			Avg: float64(res.Avg),
+			Min: float64(res.Min),
+			Max: float64(res.Max),
+		},
+		Data: res.Data,
+	}
+}
+
 var (
 	hwthreadString     = string(schema.MetricScopeHWThread)
 	coreString         = string(schema.MetricScopeCore)
@@ -0,0 +1,417 @@
+// Copyright (C) 2023 NHR@FAU, University Erlangen-Nuremberg.
+// All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+package metricdata
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ClusterCockpit/cc-backend/pkg/archive"
+	"github.com/ClusterCockpit/cc-backend/pkg/schema"
+)
+
+const (
+	benchNodes  int = 256
+	benchPoints int = 1440
+)
+
+// setupBenchCCMS starts a fake cc-metric-store answering every query with
+// one series of benchPoints values per node.
+func setupBenchCCMS(b *testing.B) (*CCMetricStore, *schema.Job) {
+	b.Helper()
+
+	archive.Clusters = []*schema.Cluster{{
+		Name: "bench",
+		MetricConfig: []*schema.MetricConfig{
+			{Name: "flops_any", Scope: schema.MetricScopeNode, Timestep: 60},
+		},
+		SubClusters: []*schema.SubCluster{
+			{Name: "main", Topology: schema.Topology{Node: []int{0, 1, 2, 3}}},
+		},
+	}}
+
+	job := &schema.Job{
+		BaseJob: schema.BaseJob{
+			JobID:      1,
+			Cluster:    "bench",
+			SubCluster: "main",
+			Duration:   int32(benchPoints * 60),
+			State:      schema.JobStateRunning,
+		},
+		StartTime: time.Unix(1675957496, 0),
+	}
+
+	res := ApiQueryResponse{}
+	data := make([]schema.Float, benchPoints)
+	for i := range data {
+		data[i] = schema.Float(i)
+	}
+	for i := 0; i < benchNodes; i++ {
+		job.Resources = append(job.Resources, &schema.Resource{Hostname: fmt.Sprintf("node%03d", i)})
+		res.Results = append(res.Results, []ApiMetricData{{Data: data, Avg: 1, Min: 0, Max: 2}})
+	}
+
+	body, err := json.Marshal(res)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+		rw.Write(body)
+	}))
+	b.Cleanup(server.Close)
+
+	ccms := &CCMetricStore{}
+	if err := ccms.Init(json.RawMessage(fmt.Sprintf(`{"kind": "cc-metric-store", "url": "%s"}`, server.URL))); err != nil {
+		b.Fatal(err)
+	}
+
+	return ccms, job
+}
+
+// Compare the B/op of both benchmarks: LoadData keeps the complete response
+// and the resulting JobData in memory, StreamData only a single series.
+func BenchmarkCCMetricStoreLoadData(b *testing.B) {
+	ccms, job := setupBenchCCMS(b)
+	metrics, scopes := []string{"flops_any"}, []schema.MetricScope{schema.MetricScopeNode}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		jd, err := ccms.LoadData(job, metrics, scopes, context.Background())
+		if err != nil {
+			b.Fatal(err)
+		}
+		if n := len(jd["flops_any"][schema.MetricScopeNode].Series); n != benchNodes {
+			b.Fatalf("expected %d series, got %d", benchNodes, n)
+		}
+	}
+}
+
+func BenchmarkCCMetricStoreStreamData(b *testing.B) {
+	ccms, job := setupBenchCCMS(b)
+	metrics, scopes := []string{"flops_any"}, []schema.MetricScope{schema.MetricScopeNode}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		n := 0
+		err := ccms.StreamData(job, metrics, scopes, context.Background(),
+			func(metric string, scope schema.MetricScope, series *schema.Series) error {
+				n++
+				return nil
+			})
+		if err != nil {
+			b.Fatal(err)
+		}
+		if n != benchNodes {
+			b.Fatalf("expected %d series, got %d", benchNodes, n)
+		}
+	}
+}
+
+func TestCCMetricStoreStreamDataSkipsQueries(t *testing.T) {
+	body := []byte(`{"queries": [{"metric": "flops_any", "host": "node001"}], "results": [[{"data": [1, 2, null], "avg": 1, "min": 1, "max": 2}]]}`)
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Write(body)
+	}))
+	defer server.Close()
+
+	archive.Clusters = []*schema.Cluster{{
+		Name:         "stream",
+		MetricConfig: []*schema.MetricConfig{{Name: "flops_any", Scope: schema.MetricScopeNode, Timestep: 60}},
+		SubClusters:  []*schema.SubCluster{{Name: "main", Topology: schema.Topology{Node: []int{0}}}},
+	}}
+	job := &schema.Job{
+		BaseJob: schema.BaseJob{
+			Cluster:    "stream",
+			SubCluster: "main",
+			Resources:  []*schema.Resource{{Hostname: "node001"}},
+		},
+		StartTime: time.Unix(1675957496, 0),
+	}
+
+	ccms := &CCMetricStore{}
+	if err := ccms.Init(json.RawMessage(fmt.Sprintf(`{"kind": "cc-metric-store", "url": "%s"}`, server.URL))); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []*schema.Series
+	err := ccms.StreamData(job, []string{"flops_any"}, []schema.MetricScope{schema.MetricScopeNode}, context.Background(),
+		func(metric string, scope schema.MetricScope, series *schema.Series) error {
+			if metric != "flops_any" || scope != schema.MetricScopeNode {
+				t.Errorf("unexpected metric/scope: %s/%s", metric, scope)
+			}
+			got = append(got, series)
+			return nil
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got) != 1 || got[0].Hostname != "node001" || len(got[0].Data) != 3 || !got[0].Data[2].IsNaN() {
+		t.Fatalf("unexpected series: %#v", got)
+	}
+}
+
+func TestCCMetricStoreLoadDataMissingMetrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		var req ApiQueryRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+
+		// The metric store only knows flops_any.
+		res := ApiQueryResponse{}
+		for _, q := range req.Queries {
+			if q.Metric == "flops_any" {
+				res.Results = append(res.Results, []ApiMetricData{{Data: []schema.Float{1, 2, 3}, Avg: 2, Min: 1, Max: 3}})
+			} else {
+				msg := "unknown metric"
+				res.Results = append(res.Results, []ApiMetricData{{Error: &msg}})
+			}
+		}
+		json.NewEncoder(rw).Encode(res)
+	}))
+	defer server.Close()
+
+	clusters, archived := archive.Clusters, useArchive
+	t.Cleanup(func() { archive.Clusters, useArchive = clusters, archived })
+
+	archive.Clusters = []*schema.Cluster{{
+		Name: "partial",
+		MetricConfig: []*schema.MetricConfig{
+			{Name: "flops_any", Scope: schema.MetricScopeNode, Timestep: 60},
+			{Name: "mem_bw", Scope: schema.MetricScopeNode, Timestep: 60},
+		},
+		SubClusters: []*schema.SubCluster{{Name: "main", Topology: schema.Topology{Node: []int{0}}}},
+	}}
+	job := &schema.Job{
+		BaseJob: schema.BaseJob{
+			Cluster:    "partial",
+			SubCluster: "main",
+			Resources:  []*schema.Resource{{Hostname: "node001"}},
+			State:      schema.JobStateRunning,
+		},
+		StartTime: time.Unix(1675957496, 0),
+	}
+
+	ccms := &CCMetricStore{}
+	if err := ccms.Init(json.RawMessage(fmt.Sprintf(`{"kind": "cc-metric-store", "url": "%s"}`, server.URL))); err != nil {
+		t.Fatal(err)
+	}
+
+	metrics, scopes := []string{"flops_any", "mem_bw", "nonexistent"}, []schema.MetricScope{schema.MetricScopeNode}
+	jd, err := ccms.LoadData(job, metrics, scopes, context.Background())
+	var missing *MissingMetricsError
+	if !errors.As(err, &missing) {
+		t.Fatalf("expected MissingMetricsError, got %v", err)
+	}
+	if !reflect.DeepEqual(missing.NoData, []string{"mem_bw"}) || !reflect.DeepEqual(missing.NotConfigured, []string{"nonexistent"}) {
+		t.Errorf("unexpected missing metrics: %#v", missing)
+	}
+	if _, ok := jd["flops_any"][schema.MetricScopeNode]; !ok || len(jd) != 1 {
+		t.Errorf("unexpected job data: %#v", jd)
+	}
+
+	// LoadData returns the partial data without error.
+	metricDataRepos["partial"], useArchive = []MetricDataRepository{ccms}, false
+	t.Cleanup(func() { delete(metricDataRepos, "partial") })
+
+	jd, err = LoadData(job, metrics, scopes, context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	noData, notConfigured := MissingMetrics(job, metrics, jd)
+	if !reflect.DeepEqual(noData, []string{"mem_bw"}) || !reflect.DeepEqual(notConfigured, []string{"nonexistent"}) {
+		t.Errorf("unexpected missing metrics: %v, %v", noData, notConfigured)
+	}
+}
+
+func TestCCMetricStoreHealthCheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.NewEncoder(rw).Encode(ApiQueryResponse{Results: [][]ApiMetricData{}})
+	}))
+	defer server.Close()
+
+	ccms := &CCMetricStore{}
+	if err := ccms.Init(json.RawMessage(fmt.Sprintf(`{"kind": "cc-metric-store", "url": "%s", "token": "secret"}`, server.URL))); err != nil {
+		t.Fatal(err)
+	}
+	if err := ccms.HealthCheck(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ccms.Init(json.RawMessage(fmt.Sprintf(`{"kind": "cc-metric-store", "url": "%s", "token": "wrong"}`, server.URL))); err != nil {
+		t.Fatal(err)
+	}
+	if err := ccms.HealthCheck(context.Background()); err == nil {
+		t.Fatal("expected health check to fail with wrong token")
+	}
+
+	server.Close()
+	if err := ccms.HealthCheck(context.Background()); err == nil {
+		t.Fatal("expected health check to fail with metric store down")
+	}
+}
+
+// setupBatchCCMS starts a fake cc-metric-store that answers every query with
+// one data point per minute of the requested timespan, the value being the
+// minute of the timestamp. Every request is delayed by latency and counted in
+// requests. Returns the repository and njobs running jobs on two nodes each
+// with different timespans.
+func setupBatchCCMS(tb testing.TB, njobs int, latency time.Duration, requests *int64) (*CCMetricStore, []*schema.Job) {
+	tb.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(requests, 1)
+		time.Sleep(latency)
+
+		var req ApiQueryRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			tb.Error(err)
+			return
+		}
+
+		from := (req.From + 59) / 60 * 60
+		data := make([]schema.Float, 0, (req.To-from)/60+1)
+		for ts := from; ts <= req.To; ts += 60 {
+			data = append(data, schema.Float(ts/60%1000))
+		}
+		stats := seriesStatistics(data)
+
+		res := ApiQueryResponse{}
+		for range req.Queries {
+			res.Results = append(res.Results, []ApiMetricData{{
+				Data: data, From: from, To: req.To,
+				Min: schema.Float(stats.Min), Avg: schema.Float(stats.Avg), Max: schema.Float(stats.Max),
+			}})
+		}
+		json.NewEncoder(rw).Encode(res)
+	}))
+	tb.Cleanup(server.Close)
+
+	archive.Clusters = []*schema.Cluster{{
+		Name: "batch",
+		MetricConfig: []*schema.MetricConfig{
+			{Name: "flops_any", Scope: schema.MetricScopeNode, Timestep: 60},
+			{Name: "mem_bw", Scope: schema.MetricScopeNode, Timestep: 60},
+		},
+		SubClusters: []*schema.SubCluster{{Name: "main", Topology: schema.Topology{Node: []int{0}}}},
+	}}
+
+	jobs := make([]*schema.Job, 0, njobs)
+	for i := 0; i < njobs; i++ {
+		jobs = append(jobs, &schema.Job{
+			ID: int64(1000 + i),
+			BaseJob: schema.BaseJob{
+				JobID:      int64(i),
+				Cluster:    "batch",
+				SubCluster: "main",
+				State:      schema.JobStateRunning,
+				Duration:   int32(3600 + i*60),
+				Resources: []*schema.Resource{
+					{Hostname: fmt.Sprintf("node%03d", 2*i)},
+					{Hostname: fmt.Sprintf("node%03d", 2*i+1)},
+				},
+			},
+			StartTime: time.Unix(int64(1675954800+i*123), 0),
+		})
+	}
+
+	ccms := &CCMetricStore{}
+	if err := ccms.Init(json.RawMessage(fmt.Sprintf(`{"kind": "cc-metric-store", "url": "%s"}`, server.URL))); err != nil {
+		tb.Fatal(err)
+	}
+
+	return ccms, jobs
+}
+
+func TestCCMetricStoreLoadDataForJobs(t *testing.T) {
+	var requests int64
+	ccms, jobs := setupBatchCCMS(t, 5, 0, &requests)
+	metrics := []string{"flops_any", "mem_bw"}
+
+	batched, err := ccms.LoadDataForJobs(jobs, metrics, context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt64(&requests); n != 1 {
+		t.Fatalf("expected one request, got %d", n)
+	}
+
+	for _, job := range jobs {
+		jd, err := ccms.LoadData(job, metrics, []schema.MetricScope{schema.MetricScopeNode}, context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(batched[job.ID], jd) {
+			t.Errorf("job %d: batched data differs:\n%v\n%v", job.ID,
+				batched[job.ID]["flops_any"][schema.MetricScopeNode].Series[0],
+				jd["flops_any"][schema.MetricScopeNode].Series[0])
+		}
+	}
+
+	// The batched data is added to the cache of LoadData.
+	metricDataRepos["batch"], useArchive = []MetricDataRepository{ccms}, false
+	t.Cleanup(func() { delete(metricDataRepos, "batch") })
+	atomic.StoreInt64(&requests, 0)
+	for i := 0; i < 2; i++ {
+		res, err := LoadDataForJobs(jobs, metrics, context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res) != len(jobs) {
+			t.Fatalf("expected data of %d jobs, got %d", len(jobs), len(res))
+		}
+	}
+	if _, err := LoadData(jobs[0], metrics, []schema.MetricScope{schema.MetricScopeNode}, context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt64(&requests); n != 1 {
+		t.Errorf("expected one request, got %d", n)
+	}
+}
+
+// Loads the data of 50 jobs from a metric store with a latency of 1ms, in
+// one request or in one request per job. The batched request covers the
+// timespan of all jobs, so it only pays off for jobs running at similar times
+// like the ones of a dashboard.
+func BenchmarkCCMetricStoreLoadDataForJobs(b *testing.B) {
+	var requests int64
+	ccms, jobs := setupBatchCCMS(b, 50, time.Millisecond, &requests)
+	metrics, scopes := []string{"flops_any", "mem_bw"}, []schema.MetricScope{schema.MetricScopeNode}
+
+	b.Run("batched", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := ccms.LoadDataForJobs(jobs, metrics, context.Background()); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("per-job", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, job := range jobs {
+				if _, err := ccms.LoadData(job, metrics, scopes, context.Background()); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+}
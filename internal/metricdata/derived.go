@@ -0,0 +1,386 @@
+// Copyright (C) 2023 NHR@FAU, University Erlangen-Nuremberg.
+// All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+package metricdata
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"unicode"
+
+	"github.com/ClusterCockpit/cc-backend/pkg/log"
+	"github.com/ClusterCockpit/cc-backend/pkg/schema"
+)
+
+// A metric computed point by point from the loaded metrics of a job, see
+// schema.DerivedMetricConfig.
+type derivedMetric struct {
+	name string
+	unit schema.Unit
+	expr expr
+
+	// Metrics referenced by expr, in order of appearance.
+	refs []string
+
+	// Stored metrics needed to compute the metric, derived metrics in refs
+	// are replaced by their inputs.
+	inputs []string
+}
+
+var derivedMetrics []*derivedMetric
+
+// initDerivedMetrics parses the expressions of configs. Derived metrics may
+// reference derived metrics defined before them.
+func initDerivedMetrics(configs []*schema.DerivedMetricConfig) error {
+	dms := make([]*derivedMetric, 0, len(configs))
+	byName := make(map[string]*derivedMetric, len(configs))
+	for _, c := range configs {
+		e, err := parseExpr(c.Expression)
+		if err != nil {
+			return fmt.Errorf("METRICDATA/DERIVED > invalid expression for '%s': %w", c.Name, err)
+		}
+
+		dm := &derivedMetric{name: c.Name, unit: c.Unit, expr: e}
+		e.refs(func(metric string) {
+			dm.refs = appendUnique(dm.refs, metric)
+			if input, ok := byName[metric]; ok {
+				for _, metric := range input.inputs {
+					dm.inputs = appendUnique(dm.inputs, metric)
+				}
+			} else {
+				dm.inputs = appendUnique(dm.inputs, metric)
+			}
+		})
+		if len(dm.refs) == 0 {
+			return fmt.Errorf("METRICDATA/DERIVED > expression for '%s' references no metric", c.Name)
+		}
+
+		dms = append(dms, dm)
+		byName[dm.name] = dm
+	}
+
+	derivedMetrics = dms
+	return nil
+}
+
+// derivedInputs returns metrics with the derived metrics replaced by the
+// metrics needed to compute them. If no derived metric is requested, metrics
+// is returned as is.
+func derivedInputs(metrics []string) []string {
+	var res []string
+	for i, metric := range metrics {
+		dm := findDerivedMetric(metric)
+		if dm == nil {
+			if res != nil {
+				res = appendUnique(res, metric)
+			}
+			continue
+		}
+
+		if res == nil {
+			res = make([]string, 0, len(metrics)+len(dm.inputs))
+			for _, metric := range metrics[:i] {
+				res = appendUnique(res, metric)
+			}
+		}
+		for _, input := range dm.inputs {
+			res = appendUnique(res, input)
+		}
+	}
+
+	if res == nil {
+		return metrics
+	}
+	return res
+}
+
+// addDerivedMetrics computes all derived metrics whose inputs are part of
+// jobData for the scopes present in all inputs and returns the names of the
+// metrics added. Series of the inputs are matched by hostname and id, the
+// data is cut to the shortest series.
+func addDerivedMetrics(jobData schema.JobData) []string {
+	added := make([]string, 0)
+	for _, dm := range derivedMetrics {
+		if _, ok := jobData[dm.name]; ok {
+			continue
+		}
+
+		scopes := make(map[schema.MetricScope]*schema.JobMetric)
+		for scope := range jobData[dm.refs[0]] {
+			if jm := dm.compute(jobData, scope); jm != nil {
+				scopes[scope] = jm
+			}
+		}
+
+		if len(scopes) > 0 {
+			jobData[dm.name] = scopes
+			added = append(added, dm.name)
+		}
+	}
+
+	return added
+}
+
+func (dm *derivedMetric) compute(jobData schema.JobData, scope schema.MetricScope) *schema.JobMetric {
+	inputs := make(map[string]map[string]*schema.Series, len(dm.refs))
+	timestep := jobData[dm.refs[0]][scope].Timestep
+	for _, metric := range dm.refs {
+		jm, ok := jobData[metric][scope]
+		if !ok {
+			return nil
+		}
+		if jm.Timestep != timestep {
+			log.Warnf("METRICDATA/DERIVED > cannot compute %s (%s): timesteps of inputs differ", dm.name, scope)
+			return nil
+		}
+
+		series := make(map[string]*schema.Series, len(jm.Series))
+		for i := range jm.Series {
+			series[seriesKey(&jm.Series[i])] = &jm.Series[i]
+		}
+		inputs[metric] = series
+	}
+
+	res := &schema.JobMetric{
+		Unit:     dm.unit,
+		Timestep: timestep,
+		Series:   make([]schema.Series, 0, len(jobData[dm.refs[0]][scope].Series)),
+	}
+	for _, first := range jobData[dm.refs[0]][scope].Series {
+		key := seriesKey(&first)
+		matched := make(map[string]*schema.Series, len(dm.refs))
+		n := len(first.Data)
+		for metric, series := range inputs {
+			s, ok := series[key]
+			if !ok {
+				break
+			}
+			matched[metric] = s
+			if len(s.Data) < n {
+				n = len(s.Data)
+			}
+		}
+		if len(matched) != len(inputs) {
+			continue
+		}
+
+		data := make([]schema.Float, n)
+		for i := range data {
+			data[i] = schema.Float(dm.expr.eval(func(metric string) float64 {
+				return float64(matched[metric].Data[i])
+			}))
+		}
+
+		res.Series = append(res.Series, schema.Series{
+			Hostname:   first.Hostname,
+			Id:         first.Id,
+			Statistics: seriesStatistics(data),
+			Data:       data,
+		})
+	}
+
+	if len(res.Series) == 0 {
+		return nil
+	}
+	return res
+}
+
+func findDerivedMetric(name string) *derivedMetric {
+	for _, dm := range derivedMetrics {
+		if dm.name == name {
+			return dm
+		}
+	}
+	return nil
+}
+
+func seriesKey(s *schema.Series) string {
+	if s.Id == nil {
+		return s.Hostname
+	}
+	return s.Hostname + "/" + *s.Id
+}
+
+func appendUnique(list []string, s string) []string {
+	for _, x := range list {
+		if x == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
+
+// An arithmetic expression over metrics evaluated for one data point.
+type expr interface {
+	eval(value func(metric string) float64) float64
+	refs(visit func(metric string))
+}
+
+type numberExpr float64
+
+func (e numberExpr) eval(_ func(string) float64) float64 { return float64(e) }
+func (e numberExpr) refs(_ func(string))                 {}
+
+type metricExpr string
+
+func (e metricExpr) eval(value func(string) float64) float64 { return value(string(e)) }
+func (e metricExpr) refs(visit func(string))                 { visit(string(e)) }
+
+type negExpr struct{ x expr }
+
+func (e negExpr) eval(value func(string) float64) float64 { return -e.x.eval(value) }
+func (e negExpr) refs(visit func(string))                 { e.x.refs(visit) }
+
+type binaryExpr struct {
+	op   byte
+	l, r expr
+}
+
+func (e binaryExpr) eval(value func(string) float64) float64 {
+	l, r := e.l.eval(value), e.r.eval(value)
+	switch e.op {
+	case '+':
+		return l + r
+	case '-':
+		return l - r
+	case '*':
+		return l * r
+	default:
+		if r == 0 {
+			return math.NaN()
+		}
+		return l / r
+	}
+}
+
+func (e binaryExpr) refs(visit func(string)) {
+	e.l.refs(visit)
+	e.r.refs(visit)
+}
+
+// A recursive descent parser of derived metric expressions.
+type exprParser struct {
+	src string
+	pos int
+}
+
+// parseExpr parses expressions of the grammar:
+//
+//	expr   = term { ("+" | "-") term }
+//	term   = factor { ("*" | "/") factor }
+//	factor = number | metric | "(" expr ")" | "-" factor
+func parseExpr(src string) (expr, error) {
+	p := &exprParser{src: src}
+	e, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	if p.skipSpace(); p.pos < len(p.src) {
+		return nil, fmt.Errorf("unexpected '%c' at position %d", p.src[p.pos], p.pos)
+	}
+	return e, nil
+}
+
+func (p *exprParser) skipSpace() {
+	for p.pos < len(p.src) && unicode.IsSpace(rune(p.src[p.pos])) {
+		p.pos++
+	}
+}
+
+// next skips whitespace and consumes the next byte if it is one of ops.
+func (p *exprParser) next(ops string) (byte, bool) {
+	p.skipSpace()
+	if p.pos < len(p.src) {
+		for i := 0; i < len(ops); i++ {
+			if p.src[p.pos] == ops[i] {
+				p.pos++
+				return ops[i], true
+			}
+		}
+	}
+	return 0, false
+}
+
+func (p *exprParser) expr() (expr, error) {
+	e, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.next("+-")
+		if !ok {
+			return e, nil
+		}
+		r, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		e = binaryExpr{op: op, l: e, r: r}
+	}
+}
+
+func (p *exprParser) term() (expr, error) {
+	e, err := p.factor()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.next("*/")
+		if !ok {
+			return e, nil
+		}
+		r, err := p.factor()
+		if err != nil {
+			return nil, err
+		}
+		e = binaryExpr{op: op, l: e, r: r}
+	}
+}
+
+func (p *exprParser) factor() (expr, error) {
+	if _, ok := p.next("-"); ok {
+		x, err := p.factor()
+		if err != nil {
+			return nil, err
+		}
+		return negExpr{x: x}, nil
+	}
+	if _, ok := p.next("("); ok {
+		e, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := p.next(")"); !ok {
+			return nil, fmt.Errorf("missing ')' at position %d", p.pos)
+		}
+		return e, nil
+	}
+
+	p.skipSpace()
+	start := p.pos
+	if start == len(p.src) {
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+
+	c := p.src[start]
+	switch {
+	case c >= '0' && c <= '9' || c == '.':
+		for p.pos < len(p.src) && (p.src[p.pos] >= '0' && p.src[p.pos] <= '9' || p.src[p.pos] == '.') {
+			p.pos++
+		}
+		x, err := strconv.ParseFloat(p.src[start:p.pos], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number '%s' at position %d", p.src[start:p.pos], start)
+		}
+		return numberExpr(x), nil
+	case c == '_' || unicode.IsLetter(rune(c)):
+		for p.pos < len(p.src) && (p.src[p.pos] == '_' || unicode.IsLetter(rune(p.src[p.pos])) || unicode.IsDigit(rune(p.src[p.pos]))) {
+			p.pos++
+		}
+		return metricExpr(p.src[start:p.pos]), nil
+	default:
+		return nil, fmt.Errorf("unexpected '%c' at position %d", c, start)
+	}
+}
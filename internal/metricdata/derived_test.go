@@ -0,0 +1,123 @@
+// Copyright (C) 2023 NHR@FAU, University Erlangen-Nuremberg.
+// All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+package metricdata
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ClusterCockpit/cc-backend/pkg/schema"
+)
+
+func setDerivedMetrics(t *testing.T, configs ...*schema.DerivedMetricConfig) {
+	t.Helper()
+	dms := derivedMetrics
+	t.Cleanup(func() { derivedMetrics = dms })
+	if err := initDerivedMetrics(configs); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDerivedMetrics(t *testing.T) {
+	setDerivedMetrics(t,
+		&schema.DerivedMetricConfig{Name: "flops_per_watt", Expression: "flops_any / power", Unit: schema.Unit{Base: "F/J"}},
+		&schema.DerivedMetricConfig{Name: "mflops_per_watt", Expression: "1000 * flops_per_watt"},
+		&schema.DerivedMetricConfig{Name: "idle", Expression: "-(cpu_load - 2) * 0.5"})
+
+	jd := schema.JobData{
+		"flops_any": {schema.MetricScopeNode: &schema.JobMetric{
+			Timestep: 60,
+			Series: []schema.Series{
+				{Hostname: "n1", Data: []schema.Float{100, 200, 300}},
+				{Hostname: "n2", Data: []schema.Float{10, 20, 30}},
+			},
+		}},
+		"power": {schema.MetricScopeNode: &schema.JobMetric{
+			Timestep: 60,
+			Series: []schema.Series{
+				{Hostname: "n2", Data: []schema.Float{5, 0, 10, 20}},
+				{Hostname: "n1", Data: []schema.Float{50, 100, schema.NaN}},
+			},
+		}},
+	}
+
+	if got, want := addDerivedMetrics(jd), []string{"flops_per_watt", "mflops_per_watt"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("wrong derived metrics: want %v, got %v", want, got)
+	}
+
+	jm := jd["flops_per_watt"][schema.MetricScopeNode]
+	if jm.Unit.Base != "F/J" || jm.Timestep != 60 || len(jm.Series) != 2 {
+		t.Fatalf("unexpected metric: %#v", jm)
+	}
+	if s := jm.Series[0]; s.Hostname != "n1" || s.Data[0] != 2 || s.Data[1] != 2 || !s.Data[2].IsNaN() {
+		t.Errorf("unexpected series of n1: %v %v", s.Hostname, s.Data)
+	}
+	s := jm.Series[1]
+	if len(s.Data) != 3 || s.Data[0] != 2 || !s.Data[1].IsNaN() || s.Data[2] != 3 {
+		t.Errorf("division by zero not NaN or series not aligned: %v", s.Data)
+	}
+	if s.Statistics.Min != 2 || s.Statistics.Avg != 2.5 || s.Statistics.Max != 3 {
+		t.Errorf("unexpected statistics: %v", s.Statistics)
+	}
+
+	if s := jd["mflops_per_watt"][schema.MetricScopeNode].Series[0]; s.Data[0] != 2000 {
+		t.Errorf("derived metric of derived metric not computed: %v", s.Data)
+	}
+}
+
+func TestDerivedMetricsInvalid(t *testing.T) {
+	dms := derivedMetrics
+	t.Cleanup(func() { derivedMetrics = dms })
+
+	for _, expr := range []string{"", "flops_any /", "(flops_any + power", "flops_any power", "2 * 3", "flops_any % 2"} {
+		if err := initDerivedMetrics([]*schema.DerivedMetricConfig{{Name: "x", Expression: expr}}); err == nil {
+			t.Errorf("expected error for expression '%s'", expr)
+		}
+	}
+}
+
+func TestLoadDataDerivedMetrics(t *testing.T) {
+	callback := TestLoadDataCallback
+	metricDataRepos["derivedtest"] = []MetricDataRepository{&TestMetricDataRepository{}}
+	t.Cleanup(func() {
+		TestLoadDataCallback = callback
+		delete(metricDataRepos, "derivedtest")
+	})
+	setDerivedMetrics(t, &schema.DerivedMetricConfig{Name: "flops_per_watt", Expression: "flops_any / power"})
+
+	var queried []string
+	TestLoadDataCallback = func(job *schema.Job, metrics []string, scopes []schema.MetricScope, ctx context.Context) (schema.JobData, error) {
+		queried = metrics
+		jd := schema.JobData{}
+		for i, metric := range metrics {
+			jd[metric] = map[schema.MetricScope]*schema.JobMetric{schema.MetricScopeNode: {
+				Timestep: 60,
+				Series:   []schema.Series{{Hostname: "n1", Data: []schema.Float{schema.Float(i + 1), 4}}},
+			}}
+		}
+		return jd, nil
+	}
+
+	job := &schema.Job{
+		ID:        4251,
+		BaseJob:   schema.BaseJob{Cluster: "derivedtest", State: schema.JobStateRunning, Duration: 120},
+		StartTime: time.Unix(10000, 0),
+	}
+	jd, err := LoadData(job, []string{"flops_per_watt", "power"}, []schema.MetricScope{schema.MetricScopeNode}, context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"flops_any", "power"}; !reflect.DeepEqual(queried, want) {
+		t.Errorf("wrong metrics loaded: want %v, got %v", want, queried)
+	}
+	if _, ok := jd["flops_any"]; ok || len(jd) != 2 {
+		t.Errorf("unrequested input returned: %v", jd)
+	}
+	if s := jd["flops_per_watt"][schema.MetricScopeNode].Series[0]; s.Data[0] != 0.5 || s.Data[1] != 1 {
+		t.Errorf("unexpected data: %v", s.Data)
+	}
+}
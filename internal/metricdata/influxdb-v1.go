@@ -0,0 +1,317 @@
+// Copyright (C) 2023 NHR@FAU, University Erlangen-Nuremberg.
+// All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+package metricdata
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ClusterCockpit/cc-backend/pkg/archive"
+	"github.com/ClusterCockpit/cc-backend/pkg/log"
+	"github.com/ClusterCockpit/cc-backend/pkg/schema"
+)
+
+type InfluxDBv1DataRepositoryConfig struct {
+	Url             string `json:"url"`
+	Database        string `json:"database"`
+	RetentionPolicy string `json:"retentionPolicy"`
+	Username        string `json:"username"`
+	Password        string `json:"password"`
+	SkipTls         bool   `json:"skiptls"`
+}
+
+// InfluxDBv1DataRepository queries InfluxDB 1.x via the InfluxQL HTTP API.
+// Like the v2 repository, only the node scope is supported, the data is
+// expected in the field "value" of measurements named like the metrics and
+// tagged with the hostname.
+type InfluxDBv1DataRepository struct {
+	client                    http.Client
+	url                       string
+	database, retentionPolicy string
+	username, password        string
+}
+
+// Response of the /query endpoint with one result per statement.
+type influxQLResponse struct {
+	Results []struct {
+		StatementId int              `json:"statement_id"`
+		Series      []influxQLSeries `json:"series"`
+		Error       string           `json:"error"`
+	} `json:"results"`
+	Error string `json:"error"`
+}
+
+// A series of an InfluxQL result. With epoch=s, the time column is in seconds
+// and all columns can be decoded as (nullable) floats.
+type influxQLSeries struct {
+	Name    string            `json:"name"`
+	Tags    map[string]string `json:"tags"`
+	Columns []string          `json:"columns"`
+	Values  [][]*float64      `json:"values"`
+}
+
+func (idb *InfluxDBv1DataRepository) Init(rawConfig json.RawMessage) error {
+	var config InfluxDBv1DataRepositoryConfig
+	if err := json.Unmarshal(rawConfig, &config); err != nil {
+		log.Warn("Error while unmarshaling raw json config")
+		return err
+	}
+
+	if config.Database == "" {
+		return errors.New("METRICDATA/INFLUXV1 > no database configured")
+	}
+
+	idb.url = strings.TrimSuffix(config.Url, "/")
+	idb.database, idb.retentionPolicy = config.Database, config.RetentionPolicy
+	idb.username, idb.password = config.Username, config.Password
+	idb.client = http.Client{
+		Timeout:   10 * time.Second,
+		Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: config.SkipTls}},
+	}
+
+	return nil
+}
+
+func (idb *InfluxDBv1DataRepository) HealthCheck(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, idb.url+"/ping", nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := idb.client.Do(req)
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusNoContent && res.StatusCode != http.StatusOK {
+		return fmt.Errorf("METRICDATA/INFLUXV1 > ping failed: %s", res.Status)
+	}
+
+	return nil
+}
+
+// query runs the InfluxQL statements with one request, the results are in
+// the order of the statements.
+func (idb *InfluxDBv1DataRepository) query(ctx context.Context, statements []string) (*influxQLResponse, error) {
+	params := url.Values{}
+	params.Set("db", idb.database)
+	if idb.retentionPolicy != "" {
+		params.Set("rp", idb.retentionPolicy)
+	}
+	params.Set("epoch", "s")
+	params.Set("q", strings.Join(statements, ";\n"))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, idb.url+"/query", strings.NewReader(params.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if idb.username != "" {
+		req.SetBasicAuth(idb.username, idb.password)
+	}
+
+	res, err := idb.client.Do(req)
+	if err != nil {
+		log.Error("Error while performing query")
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var resBody influxQLResponse
+	if err := json.NewDecoder(bufio.NewReader(res.Body)).Decode(&resBody); err != nil {
+		if res.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("METRICDATA/INFLUXV1 > query failed: %s", res.Status)
+		}
+		log.Warn("Error while decoding result body")
+		return nil, err
+	}
+	if resBody.Error != "" {
+		return nil, fmt.Errorf("METRICDATA/INFLUXV1 > query failed: %s", resBody.Error)
+	}
+	if len(resBody.Results) != len(statements) {
+		return nil, fmt.Errorf("METRICDATA/INFLUXV1 > expected %d results, got %d", len(statements), len(resBody.Results))
+	}
+	for _, result := range resBody.Results {
+		if result.Error != "" {
+			return nil, fmt.Errorf("METRICDATA/INFLUXV1 > statement %d failed: %s", result.StatementId, result.Error)
+		}
+	}
+
+	return &resBody, nil
+}
+
+// where returns the InfluxQL condition selecting the nodes and the timespan
+// of job.
+func (idb *InfluxDBv1DataRepository) where(job *schema.Job) string {
+	hostsConds := make([]string, 0, len(job.Resources))
+	for _, h := range job.Resources {
+		hostsConds = append(hostsConds, fmt.Sprintf(`"hostname" = '%s'`, influxQLString(h.Hostname)))
+	}
+
+	return fmt.Sprintf(`(%s) AND time >= %ds AND time <= %ds`,
+		strings.Join(hostsConds, " OR "), job.StartTimeUnix, job.StartTimeUnix+int64(job.Duration)+int64(1))
+}
+
+func (idb *InfluxDBv1DataRepository) LoadData(
+	job *schema.Job,
+	metrics []string,
+	scopes []schema.MetricScope,
+	ctx context.Context) (schema.JobData, error) {
+
+	jobData := make(schema.JobData)
+	nodeScope := false
+	for _, scope := range scopes {
+		if scope == schema.MetricScopeNode {
+			nodeScope = true
+		} else {
+			log.Infof("Scope '%s' requested, but not yet supported: Will return 'node' scope only.", scope)
+		}
+	}
+	if !nodeScope {
+		return jobData, nil
+	}
+
+	// Metrics not in the cluster.json can not be mapped and are reported as
+	// missing below.
+	queried := make([]string, 0, len(metrics))
+	statements := make([]string, 0, len(metrics))
+	for _, metric := range metrics {
+		mc := archive.GetMetricConfig(job.Cluster, metric)
+		if mc == nil {
+			continue
+		}
+
+		queried = append(queried, metric)
+		statements = append(statements, fmt.Sprintf(`SELECT mean("value") FROM "%s" WHERE %s GROUP BY time(%ds), "hostname" fill(null)`,
+			influxQLIdentifier(metric), idb.where(job), mc.Timestep))
+	}
+
+	if len(statements) != 0 {
+		res, err := idb.query(ctx, statements)
+		if err != nil {
+			return nil, err
+		}
+
+		loaded := make([]string, 0, len(queried))
+		for i, result := range res.Results {
+			if len(result.Series) == 0 {
+				continue
+			}
+
+			jobMetric := newInfluxJobMetric(job, queried[i])
+			for _, series := range result.Series {
+				data := make([]schema.Float, 0, len(series.Values))
+				for _, row := range series.Values {
+					if len(row) < 2 || row[1] == nil {
+						data = append(data, schema.NaN)
+					} else {
+						data = append(data, schema.Float(*row[1]))
+					}
+				}
+
+				jobMetric.Series = append(jobMetric.Series, schema.Series{
+					Hostname: series.Tags["hostname"],
+					Data:     data,
+				})
+			}
+			jobData[queried[i]] = map[schema.MetricScope]*schema.JobMetric{schema.MetricScopeNode: jobMetric}
+			loaded = append(loaded, queried[i])
+		}
+
+		stats, err := idb.LoadStats(job, loaded, ctx)
+		if err != nil {
+			log.Warn("Error while loading statistics")
+			return nil, err
+		}
+		setInfluxStatistics(jobData, stats)
+	}
+
+	if noData, notConfigured := MissingMetrics(job, metrics, jobData); len(noData) != 0 || len(notConfigured) != 0 {
+		return jobData, &MissingMetricsError{NoData: noData, NotConfigured: notConfigured}
+	}
+
+	return jobData, nil
+}
+
+func (idb *InfluxDBv1DataRepository) LoadStats(
+	job *schema.Job,
+	metrics []string,
+	ctx context.Context) (map[string]map[string]schema.MetricStatistics, error) {
+
+	stats := map[string]map[string]schema.MetricStatistics{}
+	if len(metrics) == 0 {
+		return stats, nil
+	}
+
+	statements := make([]string, 0, len(metrics))
+	for _, metric := range metrics {
+		statements = append(statements, fmt.Sprintf(`SELECT mean("value") AS "avg", min("value") AS "min", max("value") AS "max" FROM "%s" WHERE %s GROUP BY "hostname"`,
+			influxQLIdentifier(metric), idb.where(job)))
+	}
+
+	res, err := idb.query(ctx, statements)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, result := range res.Results {
+		nodes := map[string]schema.MetricStatistics{}
+		for _, series := range result.Series {
+			if len(series.Values) == 0 {
+				continue
+			}
+
+			var s schema.MetricStatistics
+			for j, column := range series.Columns {
+				if j >= len(series.Values[0]) || series.Values[0][j] == nil {
+					continue
+				}
+				switch column {
+				case "avg":
+					s.Avg = *series.Values[0][j]
+				case "min":
+					s.Min = *series.Values[0][j]
+				case "max":
+					s.Max = *series.Values[0][j]
+				}
+			}
+			nodes[series.Tags["hostname"]] = s
+		}
+		stats[metrics[i]] = nodes
+	}
+
+	return stats, nil
+}
+
+func (idb *InfluxDBv1DataRepository) LoadNodeData(
+	cluster string,
+	metrics, nodes []string,
+	scopes []schema.MetricScope,
+	from, to time.Time,
+	ctx context.Context) (map[string]map[string][]*schema.JobMetric, error) {
+
+	// TODO : Implement to be used in Analysis- und System/Node-View
+	log.Infof("LoadNodeData unimplemented for InfluxDBv1DataRepository, Args: cluster %s, metrics %v, nodes %v, scopes %v", cluster, metrics, nodes, scopes)
+
+	return nil, errors.New("METRICDATA/INFLUXV1 > unimplemented for InfluxDBv1DataRepository")
+}
+
+// influxQLString escapes s for use in a single quoted string literal.
+func influxQLString(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s)
+}
+
+// influxQLIdentifier escapes s for use in a double quoted identifier.
+func influxQLIdentifier(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
+}
@@ -0,0 +1,157 @@
+// Copyright (C) 2023 NHR@FAU, University Erlangen-Nuremberg.
+// All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+package metricdata
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ClusterCockpit/cc-backend/pkg/archive"
+	"github.com/ClusterCockpit/cc-backend/pkg/schema"
+)
+
+// Responses of InfluxDB 1.8 to the statements built by LoadData and
+// LoadStats for the job in setupInfluxV1 (there is no data of mem_bw).
+const (
+	influxV1DataResponse = `{"results":[{"statement_id":0,"series":[{"name":"flops_any","tags":{"hostname":"node001"},"columns":["time","mean"],"values":[[1675957440,null],[1675957500,12.5],[1675957560,15]]},{"name":"flops_any","tags":{"hostname":"node002"},"columns":["time","mean"],"values":[[1675957440,null],[1675957500,2],[1675957560,4]]}]},{"statement_id":1}]}
+`
+	influxV1StatsResponse = `{"results":[{"statement_id":0,"series":[{"name":"flops_any","tags":{"hostname":"node001"},"columns":["time","avg","min","max"],"values":[[0,13.75,12.5,15]]},{"name":"flops_any","tags":{"hostname":"node002"},"columns":["time","avg","min","max"],"values":[[0,3,2,4]]}]}]}
+`
+	influxV1ErrorResponse = `{"results":[{"statement_id":0,"error":"database not found: cc"}]}
+`
+)
+
+func setupInfluxV1(t *testing.T, handler http.HandlerFunc) (*InfluxDBv1DataRepository, *schema.Job) {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	clusters := archive.Clusters
+	t.Cleanup(func() { archive.Clusters = clusters })
+	archive.Clusters = []*schema.Cluster{{
+		Name: "influxv1",
+		MetricConfig: []*schema.MetricConfig{
+			{Name: "flops_any", Scope: schema.MetricScopeNode, Timestep: 60, Unit: schema.Unit{Base: "F/s", Prefix: "G"}},
+			{Name: "mem_bw", Scope: schema.MetricScopeNode, Timestep: 60},
+		},
+		SubClusters: []*schema.SubCluster{{Name: "main", Topology: schema.Topology{Node: []int{0}}}},
+	}}
+	job := &schema.Job{
+		BaseJob: schema.BaseJob{
+			Cluster:    "influxv1",
+			SubCluster: "main",
+			Resources:  []*schema.Resource{{Hostname: "node001"}, {Hostname: "node002"}},
+			State:      schema.JobStateRunning,
+			Duration:   120,
+		},
+		StartTime:     time.Unix(1675957440, 0),
+		StartTimeUnix: 1675957440,
+	}
+
+	idb := &InfluxDBv1DataRepository{}
+	if err := idb.Init(json.RawMessage(fmt.Sprintf(`{"kind": "influxdb-v1", "url": "%s", "database": "cc", "retentionPolicy": "one_week", "username": "cc", "password": "secret"}`, server.URL))); err != nil {
+		t.Fatal(err)
+	}
+
+	return idb, job
+}
+
+func TestInfluxDBv1LoadData(t *testing.T) {
+	var queries []string
+	idb, job := setupInfluxV1(t, func(rw http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "cc" || pass != "secret" {
+			t.Errorf("missing credentials")
+		}
+		if db, rp, epoch := r.FormValue("db"), r.FormValue("rp"), r.FormValue("epoch"); db != "cc" || rp != "one_week" || epoch != "s" {
+			t.Errorf("unexpected parameters: db=%s rp=%s epoch=%s", db, rp, epoch)
+		}
+
+		q := r.FormValue("q")
+		queries = append(queries, q)
+		if strings.HasPrefix(q, `SELECT mean("value") AS "avg"`) {
+			rw.Write([]byte(influxV1StatsResponse))
+		} else {
+			rw.Write([]byte(influxV1DataResponse))
+		}
+	})
+
+	jd, err := idb.LoadData(job, []string{"flops_any", "mem_bw", "nonexistent"}, []schema.MetricScope{schema.MetricScopeNode}, context.Background())
+	var missing *MissingMetricsError
+	if !errors.As(err, &missing) {
+		t.Fatalf("expected MissingMetricsError, got %v", err)
+	}
+	if !reflect.DeepEqual(missing.NoData, []string{"mem_bw"}) || !reflect.DeepEqual(missing.NotConfigured, []string{"nonexistent"}) {
+		t.Errorf("unexpected missing metrics: %#v", missing)
+	}
+
+	if len(queries) != 2 {
+		t.Fatalf("expected data and stats query, got %v", queries)
+	}
+	want := `SELECT mean("value") FROM "flops_any" WHERE ("hostname" = 'node001' OR "hostname" = 'node002') AND time >= 1675957440s AND time <= 1675957561s GROUP BY time(60s), "hostname" fill(null);
+SELECT mean("value") FROM "mem_bw" WHERE ("hostname" = 'node001' OR "hostname" = 'node002') AND time >= 1675957440s AND time <= 1675957561s GROUP BY time(60s), "hostname" fill(null)`
+	if queries[0] != want {
+		t.Errorf("unexpected query:\n%s\nwant:\n%s", queries[0], want)
+	}
+
+	jm, ok := jd["flops_any"][schema.MetricScopeNode]
+	if !ok || len(jd) != 1 {
+		t.Fatalf("unexpected job data: %#v", jd)
+	}
+	if jm.Unit.Base != "F/s" || jm.Unit.Prefix != "G" || jm.Timestep != 60 || len(jm.Series) != 2 {
+		t.Fatalf("unexpected metric: %#v", jm)
+	}
+	s := jm.Series[0]
+	if s.Hostname != "node001" || len(s.Data) != 3 || !s.Data[0].IsNaN() || s.Data[1] != 12.5 || s.Data[2] != 15 {
+		t.Errorf("unexpected series: %s %v", s.Hostname, s.Data)
+	}
+	if s.Statistics != (schema.MetricStatistics{Avg: 13.75, Min: 12.5, Max: 15}) {
+		t.Errorf("unexpected statistics: %v", s.Statistics)
+	}
+	if s := jm.Series[1]; s.Hostname != "node002" || s.Statistics.Avg != 3 {
+		t.Errorf("unexpected series: %s %v", s.Hostname, s.Statistics)
+	}
+}
+
+func TestInfluxDBv1QueryError(t *testing.T) {
+	idb, job := setupInfluxV1(t, func(rw http.ResponseWriter, r *http.Request) {
+		rw.Write([]byte(influxV1ErrorResponse))
+	})
+
+	_, err := idb.LoadData(job, []string{"flops_any"}, []schema.MetricScope{schema.MetricScopeNode}, context.Background())
+	if err == nil || !strings.Contains(err.Error(), "database not found") {
+		t.Errorf("expected error of statement, got %v", err)
+	}
+}
+
+func TestInfluxDBv1HealthCheck(t *testing.T) {
+	up := true
+	idb, _ := setupInfluxV1(t, func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ping" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if up {
+			rw.WriteHeader(http.StatusNoContent)
+		} else {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+		}
+	})
+
+	if err := idb.HealthCheck(context.Background()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	up = false
+	if err := idb.HealthCheck(context.Background()); err == nil {
+		t.Error("expected error")
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/ClusterCockpit/cc-backend/pkg/schema"
 	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
 	influxdb2Api "github.com/influxdata/influxdb-client-go/v2/api"
+	"github.com/influxdata/influxdb-client-go/v2/domain"
 )
 
 type InfluxDBv2DataRepositoryConfig struct {
@@ -48,6 +49,21 @@ func (idb *InfluxDBv2DataRepository) Init(rawConfig json.RawMessage) error {
 	return nil
 }
 
+func (idb *InfluxDBv2DataRepository) HealthCheck(ctx context.Context) error {
+	health, err := idb.client.Health(ctx)
+	if err != nil {
+		return err
+	}
+	if health.Status != domain.HealthCheckStatusPass {
+		if health.Message != nil {
+			return fmt.Errorf("METRICDATA/INFLUXV2 > status '%s': %s", health.Status, *health.Message)
+		}
+		return fmt.Errorf("METRICDATA/INFLUXV2 > status '%s'", health.Status)
+	}
+
+	return nil
+}
+
 func (idb *InfluxDBv2DataRepository) formatTime(t time.Time) string {
 	return t.Format(time.RFC3339) // Like “2006-01-02T15:04:05Z07:00”
 }
@@ -130,14 +146,8 @@ func (idb *InfluxDBv2DataRepository) LoadData(
 		for _, metric := range metrics {
 			jobMetric, ok := jobData[metric]
 			if !ok {
-				mc := archive.GetMetricConfig(job.Cluster, metric)
 				jobMetric = map[schema.MetricScope]*schema.JobMetric{
-					scope: { // uses scope var from above!
-						Unit:             mc.Unit,
-						Timestep:         mc.Timestep,
-						Series:           make([]schema.Series, 0, len(job.Resources)),
-						StatisticsSeries: nil, // Should be: &schema.StatsSeries{},
-					},
+					scope: newInfluxJobMetric(job, metric), // uses scope var from above!
 				}
 			}
 			jobData[metric] = jobMetric
@@ -210,21 +220,44 @@ func (idb *InfluxDBv2DataRepository) LoadData(
 
 	for _, scope := range scopes {
 		if scope == "node" { // No 'socket/core' support yet
-			for metric, nodes := range stats {
-				for node, stats := range nodes {
-					for index, _ := range jobData[metric][scope].Series {
-						if jobData[metric][scope].Series[index].Hostname == node {
-							jobData[metric][scope].Series[index].Statistics = schema.MetricStatistics{Avg: stats.Avg, Min: stats.Min, Max: stats.Max}
-						}
-					}
-				}
-			}
+			setInfluxStatistics(jobData, stats)
 		}
 	}
 
 	return jobData, nil
 }
 
+// newInfluxJobMetric returns an empty node scope JobMetric with the unit and
+// timestep of metric from the cluster.json. Used by the InfluxDB v1 and v2
+// repositories.
+func newInfluxJobMetric(job *schema.Job, metric string) *schema.JobMetric {
+	mc := archive.GetMetricConfig(job.Cluster, metric)
+	return &schema.JobMetric{
+		Unit:             mc.Unit,
+		Timestep:         mc.Timestep,
+		Series:           make([]schema.Series, 0, len(job.Resources)),
+		StatisticsSeries: nil, // Should be: &schema.StatsSeries{},
+	}
+}
+
+// setInfluxStatistics sets the statistics of the node scope series in jobData
+// from the result of LoadStats. Used by the InfluxDB v1 and v2 repositories.
+func setInfluxStatistics(jobData schema.JobData, stats map[string]map[string]schema.MetricStatistics) {
+	for metric, nodes := range stats {
+		jm, ok := jobData[metric][schema.MetricScopeNode]
+		if !ok {
+			continue
+		}
+		for node, stats := range nodes {
+			for index := range jm.Series {
+				if jm.Series[index].Hostname == node {
+					jm.Series[index].Statistics = schema.MetricStatistics{Avg: stats.Avg, Min: stats.Min, Max: stats.Max}
+				}
+			}
+		}
+	}
+}
+
 func (idb *InfluxDBv2DataRepository) LoadStats(
 	job *schema.Job,
 	metrics []string,
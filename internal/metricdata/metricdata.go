@@ -5,17 +5,25 @@
 package metricdata
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/ClusterCockpit/cc-backend/internal/config"
+	ccmetrics "github.com/ClusterCockpit/cc-backend/internal/metrics"
+	"github.com/ClusterCockpit/cc-backend/internal/tracing"
 	"github.com/ClusterCockpit/cc-backend/pkg/archive"
 	"github.com/ClusterCockpit/cc-backend/pkg/log"
 	"github.com/ClusterCockpit/cc-backend/pkg/lrucache"
 	"github.com/ClusterCockpit/cc-backend/pkg/schema"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type MetricDataRepository interface {
@@ -31,57 +39,288 @@ type MetricDataRepository interface {
 
 	// Return a map of hosts to a map of metrics at the requested scopes for that node.
 	LoadNodeData(cluster string, metrics, nodes []string, scopes []schema.MetricScope, from, to time.Time, ctx context.Context) (map[string]map[string][]*schema.JobMetric, error)
+
+	// Return an error if the backend of this MetricDataRepository is not
+	// reachable. Repositories without a way to check this return nil.
+	HealthCheck(ctx context.Context) error
+}
+
+// SeriesHandler is called once for every series of a job while streaming its
+// metric data. Returning an error aborts the stream.
+type SeriesHandler func(metric string, scope schema.MetricScope, series *schema.Series) error
+
+// MetricDataStreamer can optionally be implemented by a MetricDataRepository
+// that is able to hand out series while the response of its backend is still
+// being decoded, so that the data of a job is never kept in memory as a whole.
+// Repositories not implementing it fall back to LoadData (see StreamData).
+type MetricDataStreamer interface {
+	StreamData(job *schema.Job, metrics []string, scopes []schema.MetricScope, ctx context.Context, handler SeriesHandler) error
+}
+
+// MetricDataBatchLoader can optionally be implemented by a MetricDataRepository
+// that is able to load the node data of several jobs of its cluster with a
+// single request to its backend. The result is keyed by the database id of
+// the jobs, jobs whose data could not be loaded are left out. Repositories not
+// implementing it are queried job by job (see LoadDataForJobs).
+type MetricDataBatchLoader interface {
+	LoadDataForJobs(jobs []*schema.Job, metrics []string, ctx context.Context) (map[int64]schema.JobData, error)
 }
 
-var metricDataRepos map[string]MetricDataRepository = map[string]MetricDataRepository{}
+// MissingMetricsError is returned by a MetricDataRepository together with
+// the data it could load if some of the requested metrics are not part of
+// the result. LoadData logs it and returns the partial data without error,
+// use MissingMetrics to find out which metrics are missing and why.
+type MissingMetricsError struct {
+	NoData        []string // In the metric config of the cluster, but no data available
+	NotConfigured []string // Not in the metric config of the cluster
+}
+
+func (e *MissingMetricsError) Error() string {
+	return fmt.Sprintf("METRICDATA/METRICDATA > missing metrics: no data for %v, not configured %v", e.NoData, e.NotConfigured)
+}
+
+// MissingMetrics returns the requested metrics not contained in jd, split into
+// metrics without data and metrics not configured for the cluster of job.
+func MissingMetrics(job *schema.Job, metrics []string, jd schema.JobData) (noData []string, notConfigured []string) {
+	for _, metric := range metrics {
+		if _, ok := jd[metric]; ok {
+			continue
+		}
+
+		if archive.GetMetricConfig(job.Cluster, metric) == nil {
+			notConfigured = append(notConfigured, metric)
+		} else {
+			noData = append(noData, metric)
+		}
+	}
+
+	return noData, notConfigured
+}
+
+// The metric data repositories of every cluster in the order in which they
+// are queried. Repositories after the first one only have to supply what the
+// ones before are missing, for example historical data.
+var metricDataRepos map[string][]MetricDataRepository = map[string][]MetricDataRepository{}
 
 var useArchive bool
 
+// ErrTimeout is returned by LoadNodeData if the metric data repository did
+// not respond within the node-data-timeout.
+var ErrTimeout = errors.New("METRICDATA/METRICDATA > timeout while loading node data")
+
+// Timeout of LoadNodeData, no timeout if zero.
+var nodeDataTimeout time.Duration
+
 func Init(disableArchive bool) error {
 	useArchive = !disableArchive
+	nodeDataTimeout = 0
+	if config.Keys.NodeDataTimeout != "" {
+		timeout, err := time.ParseDuration(config.Keys.NodeDataTimeout)
+		if err != nil {
+			return fmt.Errorf("METRICDATA/METRICDATA > invalid node-data-timeout: %w", err)
+		}
+		nodeDataTimeout = timeout
+	}
+	for _, cluster := range config.Keys.Clusters {
+		if cluster.MetricDataRateLimit > 0 {
+			rateLimiters[cluster.Name] = newRateLimiter(cluster.MetricDataRateLimit, cluster.MetricDataBurst)
+		}
+	}
+	if err := initDerivedMetrics(config.Keys.DerivedMetrics); err != nil {
+		return err
+	}
 	for _, cluster := range config.Keys.Clusters {
 		if cluster.MetricDataRepository != nil {
-			var kind struct {
-				Kind string `json:"kind"`
-			}
-			if err := json.Unmarshal(cluster.MetricDataRepository, &kind); err != nil {
-				log.Warn("Error while unmarshaling raw json MetricDataRepository")
-				return err
-			}
-
-			var mdr MetricDataRepository
-			switch kind.Kind {
-			case "cc-metric-store":
-				mdr = &CCMetricStore{}
-			case "influxdb":
-				mdr = &InfluxDBv2DataRepository{}
-			case "prometheus":
-				mdr = &PrometheusDataRepository{}
-			case "test":
-				mdr = &TestMetricDataRepository{}
-			default:
-				return fmt.Errorf("METRICDATA/METRICDATA > Unknown MetricDataRepository %v for cluster %v", kind.Kind, cluster.Name)
+			// Either a single repository or a list of them.
+			rawConfigs := []json.RawMessage{cluster.MetricDataRepository}
+			if raw := bytes.TrimSpace(cluster.MetricDataRepository); len(raw) > 0 && raw[0] == '[' {
+				rawConfigs = nil
+				if err := json.Unmarshal(raw, &rawConfigs); err != nil {
+					log.Warn("Error while unmarshaling raw json MetricDataRepository")
+					return err
+				}
 			}
 
-			if err := mdr.Init(cluster.MetricDataRepository); err != nil {
-				log.Errorf("Error initializing MetricDataRepository %v for cluster %v", kind.Kind, cluster.Name)
-				return err
+			repos := make([]MetricDataRepository, 0, len(rawConfigs))
+			for _, rawConfig := range rawConfigs {
+				mdr, err := newMetricDataRepository(cluster.Name, rawConfig)
+				if err != nil {
+					return err
+				}
+				repos = append(repos, mdr)
 			}
-			metricDataRepos[cluster.Name] = mdr
+			metricDataRepos[cluster.Name] = repos
 		}
 	}
 	return nil
 }
 
-var cache *lrucache.Cache = lrucache.New(128 * 1024 * 1024)
+func newMetricDataRepository(cluster string, rawConfig json.RawMessage) (MetricDataRepository, error) {
+	var kind struct {
+		Kind string `json:"kind"`
+	}
+	if err := json.Unmarshal(rawConfig, &kind); err != nil {
+		log.Warn("Error while unmarshaling raw json MetricDataRepository")
+		return nil, err
+	}
+
+	var mdr MetricDataRepository
+	switch kind.Kind {
+	case "cc-metric-store":
+		mdr = &CCMetricStore{}
+	case "influxdb":
+		mdr = &InfluxDBv2DataRepository{}
+	case "influxdb-v1":
+		mdr = &InfluxDBv1DataRepository{}
+	case "prometheus":
+		mdr = &PrometheusDataRepository{}
+	case "test":
+		mdr = &TestMetricDataRepository{}
+	default:
+		return nil, fmt.Errorf("METRICDATA/METRICDATA > Unknown MetricDataRepository %v for cluster %v", kind.Kind, cluster)
+	}
+
+	if err := mdr.Init(rawConfig); err != nil {
+		log.Errorf("Error initializing MetricDataRepository %v for cluster %v", kind.Kind, cluster)
+		return nil, err
+	}
+	return mdr, nil
+}
+
+// The job data is kept gzip compressed, which takes a fraction of the memory
+// at the cost of decompressing it on every hit.
+var cache *lrucache.Cache = lrucache.NewWithCodec(128*1024*1024, jobDataCodec{})
+
+// jobDataCodec stores schema.JobData as gzip compressed JSON in the cache.
+// Other values, like errors, are stored as they are.
+type jobDataCodec struct{}
+
+func (jobDataCodec) Marshal(value interface{}) ([]byte, error) {
+	jd, ok := value.(schema.JobData)
+	if !ok {
+		return nil, errors.New("METRICDATA/METRICDATA > not a schema.JobData")
+	}
+
+	buf := &bytes.Buffer{}
+	zw, err := gzip.NewWriterLevel(buf, gzip.BestSpeed)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.NewEncoder(zw).Encode(jd); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (jobDataCodec) Unmarshal(data []byte) (interface{}, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
 
-// Fetches the metric data for a job.
+	var jd schema.JobData
+	if err := json.NewDecoder(zr).Decode(&jd); err != nil {
+		log.Warn("Error while decoding cached job data")
+		return nil, err
+	}
+	return jd, nil
+}
+
+// Fetches the metric data for a job. Nil metrics or scopes default to the
+// defaultMetrics and defaultScopes of the cluster config, if declared.
 func LoadData(job *schema.Job,
 	metrics []string,
 	scopes []schema.MetricScope,
 	ctx context.Context,
 ) (schema.JobData, error) {
-	data := cache.Get(cacheKey(job, metrics, scopes), func() (_ interface{}, ttl time.Duration, size int) {
+	return LoadDataWindow(job, metrics, scopes, time.Time{}, time.Time{}, ctx)
+}
+
+// Fetches the metric data for a job, restricted to the data points between
+// from and to. A zero from or to stands for the start or end of the job.
+// Repositories are queried for the window only, archived data is sliced.
+func LoadDataWindow(job *schema.Job,
+	metrics []string,
+	scopes []schema.MetricScope,
+	from, to time.Time,
+	ctx context.Context,
+) (schema.JobData, error) {
+	return loadDataWindow(job, metrics, scopes, from, to, nil, 0, ctx)
+}
+
+// Fetches the metric data for a job like LoadData, the metrics in units are
+// converted to the given unit (see schema.JobMetric.ConvertUnit).
+func LoadDataWithUnits(job *schema.Job,
+	metrics []string,
+	scopes []schema.MetricScope,
+	units map[string]schema.Unit,
+	ctx context.Context,
+) (schema.JobData, error) {
+	return loadDataWindow(job, metrics, scopes, time.Time{}, time.Time{}, units, 0, ctx)
+}
+
+// Fetches the metric data for a job like LoadDataWithUnits, every series is
+// downsampled to at most maxPoints data points (see schema.JobData.Downsample),
+// e.g. for jobs running for days. The cache keeps the full resolution.
+func LoadDataDownsampled(job *schema.Job,
+	metrics []string,
+	scopes []schema.MetricScope,
+	units map[string]schema.Unit,
+	maxPoints int,
+	ctx context.Context,
+) (schema.JobData, error) {
+	return loadDataWindow(job, metrics, scopes, time.Time{}, time.Time{}, units, maxPoints, ctx)
+}
+
+func loadDataWindow(job *schema.Job,
+	metrics []string,
+	scopes []schema.MetricScope,
+	from, to time.Time,
+	units map[string]schema.Unit,
+	maxPoints int,
+	ctx context.Context,
+) (schema.JobData, error) {
+	ctx, span := tracing.Start(ctx, "metricdata.LoadData",
+		attribute.Int64("job.id", job.ID), attribute.String("job.cluster", job.Cluster))
+	defer span.End()
+
+	from, to, windowed := jobWindow(job, from, to)
+	if to.Before(from) {
+		err := fmt.Errorf("METRICDATA/METRICDATA > time window outside of job %d", job.JobID)
+		tracing.SetError(span, err)
+		return nil, err
+	}
+
+	if metrics == nil || scopes == nil {
+		defaultMetrics, defaultScopes := config.GetDefaultMetrics(job.Cluster)
+		if metrics == nil {
+			metrics = defaultMetrics
+		}
+		if scopes == nil {
+			scopes = defaultScopes
+		}
+	}
+
+	key := cacheKey(job, metrics, scopes)
+	if windowed {
+		key = fmt.Sprintf("%s:%d-%d", key, from.Unix(), to.Unix())
+	}
+	if len(units) != 0 {
+		converted := make([]string, 0, len(units))
+		for metric, unit := range units {
+			converted = append(converted, metric+"="+unit.String())
+		}
+		sort.Strings(converted)
+		key = fmt.Sprintf("%s:%v", key, converted)
+	}
+
+	hit := true
+	data := cache.Get(key, func() (_ interface{}, ttl time.Duration, size int) {
+		hit = false
 		var jd schema.JobData
 		var err error
 
@@ -89,7 +328,7 @@ func LoadData(job *schema.Job,
 			job.MonitoringStatus == schema.MonitoringStatusRunningOrArchiving ||
 			!useArchive {
 
-			repo, ok := metricDataRepos[job.Cluster]
+			repos, ok := metricDataRepos[job.Cluster]
 
 			if !ok {
 				return fmt.Errorf("METRICDATA/METRICDATA > no metric data repository configured for '%s'", job.Cluster), 0, 0
@@ -99,20 +338,32 @@ func LoadData(job *schema.Job,
 				scopes = append(scopes, schema.MetricScopeNode)
 			}
 
+			loadMetrics := derivedInputs(metrics)
 			if metrics == nil {
 				cluster := archive.GetCluster(job.Cluster)
 				for _, mc := range cluster.MetricConfig {
-					metrics = append(metrics, mc.Name)
+					loadMetrics = append(loadMetrics, mc.Name)
 				}
 			}
 
-			jd, err = repo.LoadData(job, metrics, scopes, ctx)
+			rjob := job
+			if windowed {
+				// The repositories query the timespan of the job.
+				wjob := *job
+				wjob.StartTime = from
+				wjob.StartTimeUnix = from.Unix()
+				wjob.Duration = int32(to.Sub(from).Seconds())
+				rjob = &wjob
+			}
+
+			jd, err = loadData(repos, rjob, loadMetrics, scopes, ctx)
 			if err != nil {
+				fields := log.Fields{"job_id": job.JobID, "dbid": job.ID, "cluster": job.Cluster, "error": err}
 				if len(jd) != 0 {
-					log.Errorf("partial error: %s", err.Error())
+					log.WithFields(fields).Errorf("partial error: %s", err.Error())
 					return err, 0, 0
 				} else {
-					log.Error("Error while loading job data from metric repository")
+					log.WithFields(fields).Error("Error while loading job data from metric repository")
 					return err, 0, 0
 				}
 			}
@@ -120,21 +371,23 @@ func LoadData(job *schema.Job,
 		} else {
 			jd, err = archive.GetHandle().LoadJobData(job)
 			if err != nil {
-				log.Error("Error while loading job data from archive")
+				log.WithFields(log.Fields{"job_id": job.JobID, "dbid": job.ID, "cluster": job.Cluster, "error": err}).
+					Error("Error while loading job data from archive")
 				return err, 0, 0
 			}
 
 			// Avoid sending unrequested data to the client:
 			if metrics != nil || scopes != nil {
+				loadMetrics := derivedInputs(metrics)
 				if metrics == nil {
-					metrics = make([]string, 0, len(jd))
+					loadMetrics = make([]string, 0, len(jd))
 					for k := range jd {
-						metrics = append(metrics, k)
+						loadMetrics = append(loadMetrics, k)
 					}
 				}
 
 				res := schema.JobData{}
-				for _, metric := range metrics {
+				for _, metric := range loadMetrics {
 					if perscope, ok := jd[metric]; ok {
 						if len(perscope) > 1 {
 							subset := make(map[schema.MetricScope]*schema.JobMetric)
@@ -154,6 +407,10 @@ func LoadData(job *schema.Job,
 				}
 				jd = res
 			}
+
+			if windowed {
+				jd = sliceJobData(job, jd, from, to)
+			}
 			size = jd.Size()
 		}
 
@@ -162,17 +419,234 @@ func LoadData(job *schema.Job,
 			ttl = 2 * time.Minute
 		}
 
-		prepareJobData(job, jd, scopes)
+		if err := prepareJobData(job, jd, scopes, units); err != nil {
+			return err, 0, 0
+		}
+
+		// Drop the inputs of derived metrics that were not requested.
+		if metrics != nil {
+			res := make(schema.JobData, len(metrics))
+			for _, metric := range metrics {
+				if perscope, ok := jd[metric]; ok {
+					res[metric] = perscope
+				}
+			}
+			jd, size = res, res.Size()
+		}
 
 		return jd, ttl, size
 	})
+	if hit {
+		ccmetrics.MetricDataCacheRequests.WithLabelValues("hit").Inc()
+	} else {
+		ccmetrics.MetricDataCacheRequests.WithLabelValues("miss").Inc()
+	}
 
 	if err, ok := data.(error); ok {
 		log.Error("Error in returned dataset")
+		tracing.SetError(span, err)
+		return nil, err
+	}
+
+	return data.(schema.JobData).Downsample(maxPoints), nil
+}
+
+// Fetches the node scope metric data of several jobs, keyed by the database id
+// of the jobs. Jobs not in the cache of LoadData are loaded with one request
+// per cluster if the repository of the cluster implements
+// MetricDataBatchLoader, all others (including archived jobs) via LoadData.
+// The results are added to the cache of LoadData.
+func LoadDataForJobs(
+	jobs []*schema.Job,
+	metrics []string,
+	ctx context.Context,
+) (map[int64]schema.JobData, error) {
+	scopes := []schema.MetricScope{schema.MetricScopeNode}
+	res := make(map[int64]schema.JobData, len(jobs))
+	batches := make(map[string][]*schema.Job)
+	for _, job := range jobs {
+		if jd, ok := cache.Get(cacheKey(job, metrics, scopes), nil).(schema.JobData); ok {
+			ccmetrics.MetricDataCacheRequests.WithLabelValues("hit").Inc()
+			res[job.ID] = jd
+			continue
+		}
+
+		if job.State == schema.JobStateRunning ||
+			job.MonitoringStatus == schema.MonitoringStatusRunningOrArchiving ||
+			!useArchive {
+			// With several repositories, the fallback of LoadData is needed.
+			if repos := metricDataRepos[job.Cluster]; len(repos) == 1 {
+				if _, ok := repos[0].(MetricDataBatchLoader); ok {
+					batches[job.Cluster] = append(batches[job.Cluster], job)
+					continue
+				}
+			}
+		}
+
+		jd, err := LoadData(job, metrics, scopes, ctx)
+		if err != nil {
+			return nil, err
+		}
+		res[job.ID] = jd
+	}
+
+	for cluster, jobs := range batches {
+		clusterMetrics := metrics
+		if clusterMetrics == nil {
+			for _, mc := range archive.GetCluster(cluster).MetricConfig {
+				clusterMetrics = append(clusterMetrics, mc.Name)
+			}
+		}
+
+		if err := rateLimit(ctx, cluster); err != nil {
+			return nil, err
+		}
+
+		loader := metricDataRepos[cluster][0].(MetricDataBatchLoader)
+		data, err := loader.LoadDataForJobs(jobs, clusterMetrics, ctx)
+		if err != nil {
+			log.Errorf("Error while loading job data of %d jobs from metric repository", len(jobs))
+			return nil, err
+		}
+
+		for _, job := range jobs {
+			jd, ok := data[job.ID]
+			if !ok {
+				// Retry on its own to get the error (or partial data) of the job.
+				if jd, err = LoadData(job, metrics, scopes, ctx); err != nil {
+					return nil, err
+				}
+				res[job.ID] = jd
+				continue
+			}
+
+			ccmetrics.MetricDataCacheRequests.WithLabelValues("miss").Inc()
+			prepareJobData(job, jd, scopes, nil)
+			// If a concurrent LoadData cached the job in the meantime, its
+			// data is returned instead.
+			cached := cache.Get(cacheKey(job, metrics, scopes), func() (interface{}, time.Duration, int) {
+				ttl := 5 * time.Hour
+				if job.State == schema.JobStateRunning {
+					ttl = 2 * time.Minute
+				}
+				return jd, ttl, jd.Size()
+			})
+			if cjd, ok := cached.(schema.JobData); ok {
+				jd = cjd
+			}
+			res[job.ID] = jd
+		}
+	}
+
+	return res, nil
+}
+
+// Streams the metric data of a job series by series to handler. Unlike
+// LoadData, nothing is cached and no statistics series or node scope
+// aggregates are added. Archived jobs and repositories that do not implement
+// MetricDataStreamer are loaded via LoadData and then iterated.
+func StreamData(job *schema.Job,
+	metrics []string,
+	scopes []schema.MetricScope,
+	ctx context.Context,
+	handler SeriesHandler,
+) error {
+	if job.State == schema.JobStateRunning ||
+		job.MonitoringStatus == schema.MonitoringStatusRunningOrArchiving ||
+		!useArchive {
+
+		repos, ok := metricDataRepos[job.Cluster]
+		if !ok {
+			return fmt.Errorf("METRICDATA/METRICDATA > no metric data repository configured for '%s'", job.Cluster)
+		}
+
+		// With several repositories, the fallback of LoadData is needed.
+		if streamer, ok := repos[0].(MetricDataStreamer); ok && len(repos) == 1 {
+			if scopes == nil {
+				scopes = append(scopes, schema.MetricScopeNode)
+			}
+
+			if metrics == nil {
+				cluster := archive.GetCluster(job.Cluster)
+				for _, mc := range cluster.MetricConfig {
+					metrics = append(metrics, mc.Name)
+				}
+			}
+
+			if err := rateLimit(ctx, job.Cluster); err != nil {
+				return err
+			}
+			return streamer.StreamData(job, metrics, scopes, ctx, handler)
+		}
+	}
+
+	jd, err := LoadData(job, metrics, scopes, ctx)
+	if err != nil {
+		return err
+	}
+
+	return streamJobData(jd, handler)
+}
+
+// Hands all series of jd to handler, ordered by metric name and scope.
+func streamJobData(jd schema.JobData, handler SeriesHandler) error {
+	metrics := make([]string, 0, len(jd))
+	for metric := range jd {
+		metrics = append(metrics, metric)
+	}
+	sort.Strings(metrics)
+
+	for _, metric := range metrics {
+		scopes := make([]schema.MetricScope, 0, len(jd[metric]))
+		for scope := range jd[metric] {
+			scopes = append(scopes, scope)
+		}
+		sort.Slice(scopes, func(i, j int) bool { return scopes[i] < scopes[j] })
+
+		for _, scope := range scopes {
+			series := jd[metric][scope].Series
+			for i := range series {
+				if err := handler(metric, scope, &series[i]); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// Fetches the node scope statistics (min/avg/max per node) of a job as map
+// metric -> hostname -> stats. Archived jobs are read from the job archive.
+func LoadStats(
+	job *schema.Job,
+	metrics []string,
+	ctx context.Context,
+) (map[string]map[string]schema.MetricStatistics, error) {
+	if job.State != schema.JobStateRunning &&
+		job.MonitoringStatus != schema.MonitoringStatusRunningOrArchiving &&
+		useArchive {
+		return archive.LoadStatsFromArchive(job, metrics)
+	}
+
+	repos, ok := metricDataRepos[job.Cluster]
+	if !ok {
+		return nil, fmt.Errorf("METRICDATA/METRICDATA > no metric data repository configured for '%s'", job.Cluster)
+	}
+
+	if metrics == nil {
+		for _, mc := range archive.GetCluster(job.Cluster).MetricConfig {
+			metrics = append(metrics, mc.Name)
+		}
+	}
+
+	stats, err := loadStats(repos, job, metrics, ctx)
+	if err != nil {
+		log.Errorf("Error while loading statistics for job %v (User %v, Project %v)", job.JobID, job.User, job.Project)
 		return nil, err
 	}
 
-	return data.(schema.JobData), nil
+	return stats, nil
 }
 
 // Used for the jobsFootprint GraphQL-Query. TODO: Rename/Generalize.
@@ -186,12 +660,12 @@ func LoadAverages(
 		return archive.LoadAveragesFromArchive(job, metrics, data) // #166 change also here?
 	}
 
-	repo, ok := metricDataRepos[job.Cluster]
+	repos, ok := metricDataRepos[job.Cluster]
 	if !ok {
 		return fmt.Errorf("METRICDATA/METRICDATA > no metric data repository configured for '%s'", job.Cluster)
 	}
 
-	stats, err := repo.LoadStats(job, metrics, ctx) // #166 how to handle stats for acc normalizazion?
+	stats, err := loadStats(repos, job, metrics, ctx) // #166 how to handle stats for acc normalizazion?
 	if err != nil {
 		log.Errorf("Error while loading statistics for job %v (User %v, Project %v)", job.JobID, job.User, job.Project)
 		return err
@@ -214,15 +688,83 @@ func LoadAverages(
 	return nil
 }
 
+// Cache of LoadEnergy, keyed by job id and state.
+var energyCache = lrucache.New(1024 * 1024)
+
+// LoadEnergy returns the energy consumed by job in kWh, integrated over the
+// node scope series of the metric "power" (assumed to be in W if it has no
+// unit). Nil is returned if the cluster or the job has no power metric.
+func LoadEnergy(job *schema.Job, ctx context.Context) (*float64, error) {
+	if archive.GetMetricConfig(job.Cluster, "power") == nil {
+		return nil, nil
+	}
+
+	res := energyCache.Get(fmt.Sprintf("%d(%s)", job.ID, job.State), func() (_ interface{}, ttl time.Duration, size int) {
+		jd, err := LoadData(job, []string{"power"}, []schema.MetricScope{schema.MetricScopeNode}, ctx)
+		if err != nil {
+			return err, 0, 0
+		}
+
+		ttl = 5 * time.Hour
+		if job.State == schema.JobStateRunning {
+			ttl = 2 * time.Minute
+		}
+
+		jm, ok := jd["power"][schema.MetricScopeNode]
+		if !ok {
+			return (*float64)(nil), ttl, 8
+		}
+
+		factor := 1.0
+		if jm.Unit.Base != "" {
+			if factor, err = schema.UnitFactor(jm.Unit, schema.Unit{Base: "W"}); err != nil {
+				return err, 0, 0
+			}
+		}
+
+		joules := 0.0
+		for _, series := range jm.Series {
+			for _, x := range series.Data {
+				if !x.IsNaN() {
+					joules += float64(x) * factor * float64(jm.Timestep)
+				}
+			}
+		}
+
+		kwh := joules / 3.6e6
+		return &kwh, ttl, 16
+	})
+
+	if err, ok := res.(error); ok {
+		return nil, err
+	}
+	return res.(*float64), nil
+}
+
 // Used for the node/system view. Returns a map of nodes to a map of metrics.
+// If the metric data repository does not respond within the node-data-timeout,
+// an error wrapping ErrTimeout is returned together with the data loaded so
+// far, which may be nil. If user may not access cluster, an error wrapping
+// config.ErrClusterForbidden is returned. A nil user is not checked.
 func LoadNodeData(
+	user *schema.User,
 	cluster string,
 	metrics, nodes []string,
 	scopes []schema.MetricScope,
 	from, to time.Time,
 	ctx context.Context,
 ) (map[string]map[string][]*schema.JobMetric, error) {
-	repo, ok := metricDataRepos[cluster]
+	if err := config.CheckClusterAccess(user, cluster); err != nil {
+		return nil, err
+	}
+
+	if nodeDataTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, nodeDataTimeout)
+		defer cancel()
+	}
+
+	repos, ok := metricDataRepos[cluster]
 	if !ok {
 		return nil, fmt.Errorf("METRICDATA/METRICDATA > no metric data repository configured for '%s'", cluster)
 	}
@@ -233,7 +775,22 @@ func LoadNodeData(
 		}
 	}
 
-	data, err := repo.LoadNodeData(cluster, metrics, nodes, scopes, from, to, ctx)
+	// The first repository returning data is used.
+	var data map[string]map[string][]*schema.JobMetric
+	var err error
+	for _, repo := range repos {
+		if err = rateLimit(ctx, cluster); err != nil {
+			break
+		}
+		data, err = repo.LoadNodeData(cluster, metrics, nodes, scopes, from, to, ctx)
+		if len(data) != 0 {
+			break
+		}
+	}
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		log.Warnf("loading node data of cluster '%s' timed out: %s", cluster, err.Error())
+		return data, fmt.Errorf("%w: %s", ErrTimeout, err.Error())
+	}
 	if err != nil {
 		if len(data) != 0 {
 			log.Warnf("partial error: %s", err.Error())
@@ -250,26 +807,301 @@ func LoadNodeData(
 	return data, nil
 }
 
+// HealthCheck checks the metric data repositories of all clusters. It returns
+// a map of cluster names to the result of the check, nil if healthy.
+func HealthCheck(ctx context.Context) map[string]error {
+	results := make(map[string]error, len(metricDataRepos))
+	for cluster, repos := range metricDataRepos {
+		var err error
+		for i, repo := range repos {
+			if err = repo.HealthCheck(ctx); err != nil {
+				log.Warnf("metric data repository %d for '%s' is unhealthy: %s", i, cluster, err.Error())
+				break
+			}
+		}
+		results[cluster] = err
+	}
+
+	return results
+}
+
+// repositoryErrors are the errors of the failed metric data repositories of
+// a cluster, see loadData.
+type repositoryErrors []error
+
+func (e repositoryErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e repositoryErrors) Is(target error) bool {
+	for _, err := range e {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e repositoryErrors) As(target interface{}) bool {
+	for _, err := range e {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// loadData queries repos in order, each one for the metrics the ones before
+// did not return data for. The results are merged per metric. If metrics are
+// still missing and a repository failed, the partial data is returned together
+// with the errors of all failed repositories. Metrics missing without an error
+// are only logged, like a *MissingMetricsError of a single repository.
+func loadData(
+	repos []MetricDataRepository,
+	job *schema.Job,
+	metrics []string,
+	scopes []schema.MetricScope,
+	ctx context.Context,
+) (schema.JobData, error) {
+	jd := schema.JobData{}
+	pending := metrics
+	var errs repositoryErrors
+	for i, repo := range repos {
+		if err := rateLimit(ctx, job.Cluster); err != nil {
+			if len(errs) != 0 {
+				return jd, append(errs, err)
+			}
+			return jd, err
+		}
+
+		data, err := repo.LoadData(job, pending, scopes, ctx)
+		var missing *MissingMetricsError
+		if err != nil && !errors.As(err, &missing) {
+			log.Warnf("job %d: metric data repository %d failed: %s", job.JobID, i, err.Error())
+			errs = append(errs, fmt.Errorf("metric data repository %d: %w", i, err))
+		}
+
+		for metric, perscope := range data {
+			if _, ok := jd[metric]; !ok {
+				jd[metric] = perscope
+			}
+		}
+
+		pending = pending[:0:0]
+		for _, metric := range metrics {
+			if _, ok := jd[metric]; !ok {
+				pending = append(pending, metric)
+			}
+		}
+		if len(pending) == 0 {
+			return jd, nil
+		}
+	}
+
+	if len(errs) != 0 {
+		return jd, errs
+	}
+
+	noData, notConfigured := MissingMetrics(job, pending, jd)
+	log.Infof("job %d: %s", job.JobID, (&MissingMetricsError{NoData: noData, NotConfigured: notConfigured}).Error())
+	return jd, nil
+}
+
+// loadStats queries repos in order like loadData.
+func loadStats(
+	repos []MetricDataRepository,
+	job *schema.Job,
+	metrics []string,
+	ctx context.Context,
+) (map[string]map[string]schema.MetricStatistics, error) {
+	stats := make(map[string]map[string]schema.MetricStatistics, len(metrics))
+	pending := metrics
+	var errs repositoryErrors
+	for i, repo := range repos {
+		if err := rateLimit(ctx, job.Cluster); err != nil {
+			if len(errs) != 0 {
+				return stats, append(errs, err)
+			}
+			return stats, err
+		}
+
+		data, err := repo.LoadStats(job, pending, ctx)
+		if err != nil {
+			log.Warnf("job %d: metric data repository %d failed: %s", job.JobID, i, err.Error())
+			errs = append(errs, fmt.Errorf("metric data repository %d: %w", i, err))
+		}
+
+		for metric, nodes := range data {
+			if _, ok := stats[metric]; !ok {
+				stats[metric] = nodes
+			}
+		}
+
+		pending = pending[:0:0]
+		for _, metric := range metrics {
+			if _, ok := stats[metric]; !ok {
+				pending = append(pending, metric)
+			}
+		}
+		if len(pending) == 0 {
+			return stats, nil
+		}
+	}
+
+	if len(errs) != 0 {
+		return stats, errs
+	}
+	return stats, nil
+}
+
+// jobWindow clamps from and to to the timespan of job, a zero time is replaced
+// by the start or end of the job. windowed is false if the result is the
+// complete timespan.
+func jobWindow(job *schema.Job, from, to time.Time) (_ time.Time, _ time.Time, windowed bool) {
+	start := job.StartTime
+	end := start.Add(time.Duration(job.Duration) * time.Second)
+	if from.IsZero() || from.Before(start) {
+		from = start
+	}
+	if to.IsZero() || to.After(end) {
+		to = end
+	}
+
+	return from, to, !from.Equal(start) || !to.Equal(end)
+}
+
+// sliceJobData returns a copy of jd with only the data points between from
+// and to. The statistics of the series are recomputed for the window, the
+// statistics series are sliced like the data.
+func sliceJobData(job *schema.Job, jd schema.JobData, from, to time.Time) schema.JobData {
+	res := make(schema.JobData, len(jd))
+	for metric, perscope := range jd {
+		res[metric] = make(map[schema.MetricScope]*schema.JobMetric, len(perscope))
+		for scope, jm := range perscope {
+			if jm.Timestep <= 0 {
+				res[metric][scope] = jm
+				continue
+			}
+
+			// Data point i was measured at StartTime + i * Timestep.
+			timestep := int64(jm.Timestep)
+			first := (from.Unix() - job.StartTime.Unix() + timestep - 1) / timestep
+			last := (to.Unix() - job.StartTime.Unix()) / timestep
+			sliced := &schema.JobMetric{
+				Unit:     jm.Unit,
+				Timestep: jm.Timestep,
+				Series:   make([]schema.Series, 0, len(jm.Series)),
+			}
+			for _, series := range jm.Series {
+				series.Data = sliceData(series.Data, first, last)
+				if series.Min != nil && series.Max != nil {
+					series.Min = sliceData(series.Min, first, last)
+					series.Max = sliceData(series.Max, first, last)
+				}
+				series.Statistics = seriesStatistics(series.Data)
+				sliced.Series = append(sliced.Series, series)
+			}
+
+			if ss := jm.StatisticsSeries; ss != nil {
+				sliced.StatisticsSeries = &schema.StatsSeries{
+					Mean: sliceData(ss.Mean, first, last),
+					Min:  sliceData(ss.Min, first, last),
+					Max:  sliceData(ss.Max, first, last),
+				}
+				if ss.Percentiles != nil {
+					sliced.StatisticsSeries.Percentiles = make(map[int][]schema.Float, len(ss.Percentiles))
+					for p, data := range ss.Percentiles {
+						sliced.StatisticsSeries.Percentiles[p] = sliceData(data, first, last)
+					}
+				}
+			}
+			res[metric][scope] = sliced
+		}
+	}
+
+	return res
+}
+
+// sliceData returns the data points first to last (inclusive), limited to the
+// available ones.
+func sliceData(data []schema.Float, first, last int64) []schema.Float {
+	n := int64(len(data))
+	if first < 0 {
+		first = 0
+	}
+	if last >= n {
+		last = n - 1
+	}
+	if first > last {
+		return []schema.Float{}
+	}
+
+	return data[first : last+1]
+}
+
+// seriesStatistics returns min, avg and max of data, ignoring NaNs. Without
+// any data point all three are 0 so that they can still be encoded as JSON.
+func seriesStatistics(data []schema.Float) schema.MetricStatistics {
+	stats := schema.MetricStatistics{}
+	sum, n := 0.0, 0
+	for _, x := range data {
+		if x.IsNaN() {
+			continue
+		}
+		if n == 0 || float64(x) < stats.Min {
+			stats.Min = float64(x)
+		}
+		if n == 0 || float64(x) > stats.Max {
+			stats.Max = float64(x)
+		}
+		sum += float64(x)
+		n++
+	}
+	if n > 0 {
+		stats.Avg = sum / float64(n)
+	}
+
+	return stats
+}
+
 func cacheKey(
 	job *schema.Job,
 	metrics []string,
 	scopes []schema.MetricScope,
 ) string {
+	// The order of metrics and scopes does not change the result, sort
+	// copies so that equal requests share a cache entry.
+	if metrics != nil {
+		metrics = append(make([]string, 0, len(metrics)), metrics...)
+		sort.Strings(metrics)
+	}
+	if scopes != nil {
+		scopes = append(make([]schema.MetricScope, 0, len(scopes)), scopes...)
+		sort.Slice(scopes, func(i, j int) bool { return scopes[i] < scopes[j] })
+	}
+
 	// Duration and StartTime do not need to be in the cache key as StartTime is less unique than
 	// job.ID and the TTL of the cache entry makes sure it does not stay there forever.
-	return fmt.Sprintf("%d(%s):[%v],[%v]",
-		job.ID, job.State, metrics, scopes)
+	// The monitoring status changes when the retention reduces the archived data.
+	return fmt.Sprintf("%d(%s,%d):[%v],[%v]",
+		job.ID, job.State, job.MonitoringStatus, metrics, scopes)
 }
 
 // For /monitoring/job/<job> and some other places, flops_any and mem_bw need
 // to be available at the scope 'node'. If a job has a lot of nodes,
 // statisticsSeries should be available so that a min/mean/max Graph can be
-// used instead of a lot of single lines.
+// used instead of a lot of single lines. The configured percentiles are
+// added to the statisticsSeries.
 func prepareJobData(
 	job *schema.Job,
 	jobData schema.JobData,
 	scopes []schema.MetricScope,
-) {
+	units map[string]schema.Unit,
+) error {
 	const maxSeriesSize int = 15
 	for _, scopes := range jobData {
 		for _, jm := range scopes {
@@ -277,7 +1109,7 @@ func prepareJobData(
 				continue
 			}
 
-			jm.AddStatisticsSeries()
+			jm.AddStatisticsSeries(config.Keys.StatisticsSeriesPercentiles...)
 		}
 	}
 
@@ -289,12 +1121,42 @@ func prepareJobData(
 	}
 
 	if nodeScopeRequested {
-		jobData.AddNodeScope("flops_any")
-		jobData.AddNodeScope("mem_bw")
+		for _, metric := range []string{"flops_any", "mem_bw"} {
+			aggregation := ""
+			if mc := archive.GetMetricConfig(job.Cluster, metric); mc != nil {
+				aggregation = mc.Aggregation
+			}
+			jobData.AddNodeScope(metric, aggregation)
+		}
+	}
+
+	for _, metric := range addDerivedMetrics(jobData) {
+		for _, jm := range jobData[metric] {
+			if len(jm.Series) > maxSeriesSize {
+				jm.AddStatisticsSeries(config.Keys.StatisticsSeriesPercentiles...)
+			}
+		}
 	}
+
+	return normalizeUnits(jobData, units)
 }
 
-// Writes a running job to the job-archive
+// normalizeUnits converts the metrics in units to the given unit, metrics
+// without data are skipped.
+func normalizeUnits(jobData schema.JobData, units map[string]schema.Unit) error {
+	for metric, unit := range units {
+		for scope, jm := range jobData[metric] {
+			if err := jm.ConvertUnit(unit); err != nil {
+				return fmt.Errorf("METRICDATA/METRICDATA > converting %s (%s) failed: %w", metric, scope, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Writes a running job to the job-archive. The completeness of its metric
+// data is set in job.
 func ArchiveJob(job *schema.Job, ctx context.Context) (*schema.JobMeta, error) {
 	allMetrics := make([]string, 0)
 	metricConfigs := archive.GetCluster(job.Cluster).MetricConfig
@@ -308,7 +1170,9 @@ func ArchiveJob(job *schema.Job, ctx context.Context) (*schema.JobMeta, error) {
 		scopes = append(scopes, schema.MetricScopeCore)
 	}
 
-	jobData, err := LoadData(job, allMetrics, scopes, ctx)
+	// Archiving is not time critical, it waits for the rate limit instead of
+	// failing.
+	jobData, err := LoadData(job, allMetrics, scopes, waitForRateLimit(ctx))
 	if err != nil {
 		log.Error("Error wile loading job data for archiving")
 		return nil, err
@@ -345,6 +1209,9 @@ func ArchiveJob(job *schema.Job, ctx context.Context) (*schema.JobMeta, error) {
 		}
 	}
 
+	completeness := jobData.Completeness(int(job.NumNodes))
+	job.DataCompleteness = &completeness
+
 	// If the file based archive is disabled,
 	// only return the JobMeta structure as the
 	// statistics in there are needed.
@@ -0,0 +1,832 @@
+// Copyright (C) 2023 NHR@FAU, University Erlangen-Nuremberg.
+// All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+package metricdata
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ClusterCockpit/cc-backend/internal/config"
+	"github.com/ClusterCockpit/cc-backend/pkg/archive"
+	"github.com/ClusterCockpit/cc-backend/pkg/lrucache"
+	"github.com/ClusterCockpit/cc-backend/pkg/schema"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestLoadDataCacheKeyOrder(t *testing.T) {
+	callback := TestLoadDataCallback
+	metricDataRepos["cachetest"] = []MetricDataRepository{&TestMetricDataRepository{}}
+	t.Cleanup(func() {
+		TestLoadDataCallback = callback
+		delete(metricDataRepos, "cachetest")
+	})
+
+	calls := 0
+	TestLoadDataCallback = func(job *schema.Job, metrics []string, scopes []schema.MetricScope, ctx context.Context) (schema.JobData, error) {
+		calls++
+		jd := schema.JobData{}
+		for _, metric := range metrics {
+			jd[metric] = map[schema.MetricScope]*schema.JobMetric{
+				schema.MetricScopeNode: {Timestep: 60, Series: []schema.Series{{Hostname: "n1", Data: []schema.Float{1, 2, 3}}}},
+			}
+		}
+		return jd, nil
+	}
+
+	job := &schema.Job{ID: 4242, BaseJob: schema.BaseJob{Cluster: "cachetest", State: schema.JobStateRunning}}
+	metrics := []string{"mem_used", "cpu_load"}
+	scopes := []schema.MetricScope{schema.MetricScopeSocket, schema.MetricScopeNode}
+
+	if _, err := LoadData(job, metrics, scopes, context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	jd, err := LoadData(job, []string{"cpu_load", "mem_used"},
+		[]schema.MetricScope{schema.MetricScopeNode, schema.MetricScopeSocket}, context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if calls != 1 {
+		t.Errorf("expected one backend call, got %d", calls)
+	}
+	if _, ok := jd["mem_used"]; !ok {
+		t.Errorf("metric missing in cached data: %v", jd)
+	}
+	if !reflect.DeepEqual(metrics, []string{"mem_used", "cpu_load"}) ||
+		!reflect.DeepEqual(scopes, []schema.MetricScope{schema.MetricScopeSocket, schema.MetricScopeNode}) {
+		t.Errorf("arguments modified: %v, %v", metrics, scopes)
+	}
+}
+
+func TestLoadDataTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider, callback := otel.GetTracerProvider(), TestLoadDataCallback
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	metricDataRepos["tracingtest"] = []MetricDataRepository{&TestMetricDataRepository{}}
+	t.Cleanup(func() {
+		otel.SetTracerProvider(provider)
+		TestLoadDataCallback = callback
+		delete(metricDataRepos, "tracingtest")
+	})
+
+	TestLoadDataCallback = func(job *schema.Job, metrics []string, scopes []schema.MetricScope, ctx context.Context) (schema.JobData, error) {
+		return schema.JobData{}, nil
+	}
+
+	job := &schema.Job{ID: 4245, BaseJob: schema.BaseJob{Cluster: "tracingtest", State: schema.JobStateRunning}}
+	if _, err := LoadData(job, []string{"cpu_load"}, nil, context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 || spans[0].Name() != "metricdata.LoadData" {
+		t.Fatalf("expected one span metricdata.LoadData, got %v", spans)
+	}
+	found := false
+	for _, attr := range spans[0].Attributes() {
+		if attr.Key == "job.id" && attr.Value.AsInt64() == 4245 {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("job id missing in span attributes: %v", spans[0].Attributes())
+	}
+}
+
+func TestLoadDataForJobsFallback(t *testing.T) {
+	callback := TestLoadDataCallback
+	metricDataRepos["fallbacktest"] = []MetricDataRepository{&TestMetricDataRepository{}}
+	t.Cleanup(func() {
+		TestLoadDataCallback = callback
+		delete(metricDataRepos, "fallbacktest")
+	})
+
+	calls := 0
+	TestLoadDataCallback = func(job *schema.Job, metrics []string, scopes []schema.MetricScope, ctx context.Context) (schema.JobData, error) {
+		calls++
+		return schema.JobData{"cpu_load": {schema.MetricScopeNode: &schema.JobMetric{
+			Timestep: 60,
+			Series:   []schema.Series{{Hostname: fmt.Sprintf("n%d", job.ID), Data: []schema.Float{1}}},
+		}}}, nil
+	}
+
+	jobs := []*schema.Job{
+		{ID: 4246, BaseJob: schema.BaseJob{Cluster: "fallbacktest", State: schema.JobStateRunning}},
+		{ID: 4247, BaseJob: schema.BaseJob{Cluster: "fallbacktest", State: schema.JobStateRunning}},
+	}
+	res, err := LoadDataForJobs(jobs, []string{"cpu_load"}, context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Errorf("expected one call per job, got %d", calls)
+	}
+	for _, job := range jobs {
+		if res[job.ID]["cpu_load"][schema.MetricScopeNode].Series[0].Hostname != fmt.Sprintf("n%d", job.ID) {
+			t.Errorf("unexpected data for job %d: %v", job.ID, res[job.ID])
+		}
+	}
+}
+
+// staticRepository returns the data of the requested metrics found in data.
+type staticRepository struct {
+	TestMetricDataRepository
+	data    schema.JobData
+	err     error
+	queried [][]string
+}
+
+func (r *staticRepository) LoadData(job *schema.Job, metrics []string, scopes []schema.MetricScope, ctx context.Context) (schema.JobData, error) {
+	r.queried = append(r.queried, metrics)
+	jd := schema.JobData{}
+	for _, metric := range metrics {
+		if perscope, ok := r.data[metric]; ok {
+			jd[metric] = perscope
+		}
+	}
+	return jd, r.err
+}
+
+func (r *staticRepository) LoadStats(job *schema.Job, metrics []string, ctx context.Context) (map[string]map[string]schema.MetricStatistics, error) {
+	jd, err := r.LoadData(job, metrics, nil, ctx)
+	stats := map[string]map[string]schema.MetricStatistics{}
+	for metric, perscope := range jd {
+		stats[metric] = map[string]schema.MetricStatistics{}
+		for _, series := range perscope[schema.MetricScopeNode].Series {
+			stats[metric][series.Hostname] = series.Statistics
+		}
+	}
+	return stats, err
+}
+
+func TestLoadDataMultipleRepositories(t *testing.T) {
+	metric := func(avg float64) map[schema.MetricScope]*schema.JobMetric {
+		return map[schema.MetricScope]*schema.JobMetric{schema.MetricScopeNode: {
+			Timestep: 60,
+			Series: []schema.Series{{
+				Hostname:   "n1",
+				Statistics: schema.MetricStatistics{Min: avg, Avg: avg, Max: avg},
+				Data:       []schema.Float{schema.Float(avg)},
+			}},
+		}}
+	}
+
+	tests := map[string]struct {
+		first, second *staticRepository
+		wantQueried   []string // metrics requested from the second repository
+	}{
+		"FirstEmpty": {
+			first:       &staticRepository{},
+			second:      &staticRepository{data: schema.JobData{"cpu_load": metric(2), "mem_used": metric(2)}},
+			wantQueried: []string{"cpu_load", "mem_used"},
+		},
+		"FirstFails": {
+			first:       &staticRepository{err: errors.New("connection refused")},
+			second:      &staticRepository{data: schema.JobData{"cpu_load": metric(2), "mem_used": metric(2)}},
+			wantQueried: []string{"cpu_load", "mem_used"},
+		},
+		"Merged": {
+			first:       &staticRepository{data: schema.JobData{"cpu_load": metric(1)}},
+			second:      &staticRepository{data: schema.JobData{"cpu_load": metric(2), "mem_used": metric(2)}},
+			wantQueried: []string{"mem_used"},
+		},
+	}
+
+	i := 0
+	for name, tc := range tests {
+		i++
+		cluster := fmt.Sprintf("multirepotest%d", i)
+		metricDataRepos[cluster] = []MetricDataRepository{tc.first, tc.second}
+		t.Cleanup(func() { delete(metricDataRepos, cluster) })
+
+		t.Run(name, func(t *testing.T) {
+			job := &schema.Job{ID: 4260 + int64(i), BaseJob: schema.BaseJob{Cluster: cluster, State: schema.JobStateRunning}}
+			metrics := []string{"cpu_load", "mem_used"}
+
+			jd, err := LoadData(job, metrics, []schema.MetricScope{schema.MetricScopeNode}, context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := map[string]float64{"cpu_load": 2, "mem_used": 2}
+			if tc.first.data != nil {
+				want["cpu_load"] = 1
+			}
+			for metric, avg := range want {
+				if jm, ok := jd[metric][schema.MetricScopeNode]; !ok || jm.Series[0].Statistics.Avg != avg {
+					t.Errorf("unexpected data for %s: %v", metric, jd[metric])
+				}
+			}
+			if len(tc.second.queried) != 1 || !reflect.DeepEqual(tc.second.queried[0], tc.wantQueried) {
+				t.Errorf("second repository queried for %v, want %v", tc.second.queried, tc.wantQueried)
+			}
+
+			stats, err := LoadStats(job, metrics, context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			for metric, avg := range want {
+				if stats[metric]["n1"].Avg != avg {
+					t.Errorf("unexpected statistics for %s: %v", metric, stats[metric])
+				}
+			}
+		})
+	}
+
+	// Without any data, the error of the last repository is returned.
+	metricDataRepos["multirepotest"] = []MetricDataRepository{
+		&staticRepository{}, &staticRepository{err: errors.New("connection refused")}}
+	t.Cleanup(func() { delete(metricDataRepos, "multirepotest") })
+	job := &schema.Job{ID: 4269, BaseJob: schema.BaseJob{Cluster: "multirepotest", State: schema.JobStateRunning}}
+	if _, err := LoadData(job, []string{"cpu_load"}, nil, context.Background()); err == nil {
+		t.Error("expected error if no repository returns data")
+	}
+
+	// If metrics are still missing, the errors of all failed repositories
+	// are returned, not only the one of the last repository.
+	errFirst, errSecond := errors.New("connection refused"), errors.New("timeout")
+	repos := []MetricDataRepository{
+		&staticRepository{err: errFirst},
+		&staticRepository{data: schema.JobData{"cpu_load": metric(2)}, err: errSecond},
+		&staticRepository{data: schema.JobData{"cpu_load": metric(3)}},
+	}
+	jd, err := loadData(repos, job, []string{"cpu_load", "mem_used"}, nil, context.Background())
+	if !errors.Is(err, errFirst) || !errors.Is(err, errSecond) {
+		t.Errorf("expected errors of both failed repositories, got %v", err)
+	}
+	if _, ok := jd["cpu_load"]; !ok {
+		t.Errorf("expected partial data, got %v", jd)
+	}
+	_, err = loadStats(repos, job, []string{"cpu_load", "mem_used"}, context.Background())
+	if !errors.Is(err, errFirst) || !errors.Is(err, errSecond) {
+		t.Errorf("expected errors of both failed repositories for the statistics, got %v", err)
+	}
+
+	// Metrics missing from every repository without an error are no error.
+	if _, err := loadData(repos[2:], job, []string{"cpu_load", "mem_used"}, nil, context.Background()); err != nil {
+		t.Errorf("unexpected error for missing metrics: %v", err)
+	}
+}
+
+// slowRepository returns partial node data once ctx is done.
+type slowRepository struct {
+	TestMetricDataRepository
+}
+
+func (r *slowRepository) LoadNodeData(
+	cluster string,
+	metrics, nodes []string,
+	scopes []schema.MetricScope,
+	from, to time.Time,
+	ctx context.Context) (map[string]map[string][]*schema.JobMetric, error) {
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * time.Second):
+	}
+	return map[string]map[string][]*schema.JobMetric{
+		nodes[0]: {metrics[0]: {{Timestep: 60}}},
+	}, ctx.Err()
+}
+
+func TestLoadNodeDataTimeout(t *testing.T) {
+	timeout := nodeDataTimeout
+	nodeDataTimeout = 100 * time.Millisecond
+	t.Cleanup(func() {
+		nodeDataTimeout = timeout
+		delete(metricDataRepos, "slowccms")
+		delete(metricDataRepos, "slowpartial")
+	})
+
+	// The request to the cc-metric-store is cancelled after the timeout.
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	t.Cleanup(server.Close)
+	t.Cleanup(func() { close(done) })
+	ccms := &CCMetricStore{}
+	if err := ccms.Init(json.RawMessage(fmt.Sprintf(`{"kind": "cc-metric-store", "url": "%s"}`, server.URL))); err != nil {
+		t.Fatal(err)
+	}
+	metricDataRepos["slowccms"] = []MetricDataRepository{ccms}
+	metricDataRepos["slowpartial"] = []MetricDataRepository{&slowRepository{}}
+
+	from, to := time.Unix(1675957496, 0), time.Unix(1675961096, 0)
+	for _, tc := range []struct {
+		cluster string
+		partial bool
+	}{{"slowccms", false}, {"slowpartial", true}} {
+		start := time.Now()
+		data, err := LoadNodeData(nil, tc.cluster, []string{"cpu_load"}, []string{"n1", "n2"},
+			[]schema.MetricScope{schema.MetricScopeNode}, from, to, context.Background())
+		if !errors.Is(err, ErrTimeout) {
+			t.Errorf("%s: expected ErrTimeout, got %v", tc.cluster, err)
+		}
+		if d := time.Since(start); d > 2*time.Second {
+			t.Errorf("%s: timeout not honored, took %s", tc.cluster, d)
+		}
+		if tc.partial && len(data["n1"]["cpu_load"]) != 1 {
+			t.Errorf("%s: expected partial data, got %v", tc.cluster, data)
+		} else if !tc.partial && data != nil {
+			t.Errorf("%s: unexpected data %v", tc.cluster, data)
+		}
+	}
+}
+
+func TestInitMultipleRepositories(t *testing.T) {
+	clusters := config.Keys.Clusters
+	t.Cleanup(func() {
+		config.Keys.Clusters = clusters
+		delete(metricDataRepos, "initsingle")
+		delete(metricDataRepos, "initmulti")
+	})
+
+	config.Keys.Clusters = []*schema.ClusterConfig{
+		{Name: "initsingle", MetricDataRepository: json.RawMessage(`{"kind": "test"}`)},
+		{Name: "initmulti", MetricDataRepository: json.RawMessage(` [{"kind": "test"}, {"kind": "test"}]`)},
+	}
+	if err := Init(false); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(metricDataRepos["initsingle"]); n != 1 {
+		t.Errorf("expected 1 repository for initsingle, got %d", n)
+	}
+	if n := len(metricDataRepos["initmulti"]); n != 2 {
+		t.Errorf("expected 2 repositories for initmulti, got %d", n)
+	}
+
+	config.Keys.Clusters = []*schema.ClusterConfig{
+		{Name: "initmulti", MetricDataRepository: json.RawMessage(`[{"kind": "test"}, {"kind": "unknown"}]`)},
+	}
+	if err := Init(false); err == nil {
+		t.Error("expected error for unknown repository kind")
+	}
+}
+
+func TestSliceJobData(t *testing.T) {
+	job := &schema.Job{BaseJob: schema.BaseJob{Duration: 600}, StartTime: time.Unix(1000, 0)}
+	jd := schema.JobData{
+		"cpu_load": {
+			schema.MetricScopeNode: &schema.JobMetric{
+				Timestep: 60,
+				Series: []schema.Series{{
+					Hostname:   "n1",
+					Statistics: schema.MetricStatistics{Min: 0, Avg: 5, Max: 10},
+					Data:       []schema.Float{0, 1, 2, 3, schema.NaN, 5, 6, 7, 8, 9, 10},
+				}},
+				StatisticsSeries: &schema.StatsSeries{
+					Mean:        []schema.Float{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+					Min:         []schema.Float{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+					Max:         []schema.Float{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+					Percentiles: map[int][]schema.Float{50: {0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}},
+				},
+			},
+		},
+	}
+
+	// From 1110 the first data point is the one at 1120, 1300 is the last one.
+	res := sliceJobData(job, jd, time.Unix(1110, 0), time.Unix(1300, 0))
+	jm := res["cpu_load"][schema.MetricScopeNode]
+	series := jm.Series[0]
+	if len(series.Data) != 4 || series.Data[0] != 2 || !series.Data[2].IsNaN() || series.Data[3] != 5 {
+		t.Fatalf("unexpected data: %v", series.Data)
+	}
+	if series.Statistics != (schema.MetricStatistics{Min: 2, Avg: 10.0 / 3.0, Max: 5}) {
+		t.Errorf("unexpected statistics: %#v", series.Statistics)
+	}
+	expected := []schema.Float{2, 3, 4, 5}
+	if !reflect.DeepEqual(jm.StatisticsSeries.Mean, expected) || !reflect.DeepEqual(jm.StatisticsSeries.Min, expected) ||
+		!reflect.DeepEqual(jm.StatisticsSeries.Max, expected) || !reflect.DeepEqual(jm.StatisticsSeries.Percentiles[50], expected) {
+		t.Errorf("unexpected statistics series: %#v", jm.StatisticsSeries)
+	}
+
+	if len(jd["cpu_load"][schema.MetricScopeNode].Series[0].Data) != 11 ||
+		jd["cpu_load"][schema.MetricScopeNode].Series[0].Statistics.Avg != 5 {
+		t.Error("original job data modified")
+	}
+}
+
+func TestPrepareJobDataPercentiles(t *testing.T) {
+	percentiles := config.Keys.StatisticsSeriesPercentiles
+	t.Cleanup(func() { config.Keys.StatisticsSeriesPercentiles = percentiles })
+
+	// 20 nodes with the values 1 to 20 in the first and 2 to 40 in the
+	// second timestep. In the third, the value of the first node is missing.
+	newJobData := func() schema.JobData {
+		series := make([]schema.Series, 20)
+		for j := range series {
+			x := schema.Float(j + 1)
+			series[j] = schema.Series{Hostname: fmt.Sprintf("n%d", j), Data: []schema.Float{x, 2 * x, x}}
+		}
+		series[0].Data[2] = schema.NaN
+		return schema.JobData{"cpu_load": {schema.MetricScopeNode: &schema.JobMetric{Timestep: 60, Series: series}}}
+	}
+	job := &schema.Job{BaseJob: schema.BaseJob{Duration: 180}, StartTime: time.Unix(1000, 0)}
+	scopes := []schema.MetricScope{schema.MetricScopeNode}
+
+	jd := newJobData()
+	if err := prepareJobData(job, jd, scopes, nil); err != nil {
+		t.Fatal(err)
+	}
+	if ss := jd["cpu_load"][schema.MetricScopeNode].StatisticsSeries; ss == nil || ss.Percentiles != nil {
+		t.Fatalf("expected statistics series without percentiles by default, got %#v", ss)
+	}
+
+	config.Keys.StatisticsSeriesPercentiles = []int{25, 75}
+	jd = newJobData()
+	if err := prepareJobData(job, jd, scopes, nil); err != nil {
+		t.Fatal(err)
+	}
+	ss := jd["cpu_load"][schema.MetricScopeNode].StatisticsSeries
+	if !reflect.DeepEqual(ss.Min, []schema.Float{1, 2, 2}) || !reflect.DeepEqual(ss.Max, []schema.Float{20, 40, 20}) {
+		t.Errorf("unexpected min/max: %v, %v", ss.Min, ss.Max)
+	}
+	for p, expected := range map[int][]schema.Float{25: {5, 10, 6}, 75: {15, 30, 16}} {
+		if !reflect.DeepEqual(ss.Percentiles[p], expected) {
+			t.Errorf("percentile %d: expected %v, got %v", p, expected, ss.Percentiles[p])
+		}
+	}
+}
+
+func TestLoadDataWindowArchive(t *testing.T) {
+	if err := archive.Init(json.RawMessage(`{"kind": "file", "path": "../../pkg/archive/testdata/archive"}`), false); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { useArchive = false })
+	useArchive = true
+
+	job := &schema.Job{
+		ID: 4243,
+		BaseJob: schema.BaseJob{
+			JobID:    1403244,
+			Cluster:  "emmy",
+			State:    schema.JobStateCompleted,
+			Duration: 86486,
+		},
+		StartTime: time.Unix(1608923076, 0),
+	}
+
+	// A window of 10 minutes starting 10 minutes after the job start.
+	from := job.StartTime.Add(10 * time.Minute)
+	jd, err := LoadDataWindow(job, []string{"cpu_load"}, []schema.MetricScope{schema.MetricScopeNode},
+		from, from.Add(10*time.Minute), context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	jm := jd["cpu_load"][schema.MetricScopeNode]
+	if len(jm.Series) != 32 || len(jm.Series[0].Data) != 11 || jm.Series[0].Data[0] != 18.9 {
+		t.Fatalf("unexpected series: %v", jm.Series[0])
+	}
+	if jm.StatisticsSeries == nil || len(jm.StatisticsSeries.Mean) != 11 {
+		t.Errorf("statistics series not computed for window: %#v", jm.StatisticsSeries)
+	}
+	for _, series := range jm.Series {
+		for _, x := range series.Data {
+			if float64(x) < series.Statistics.Min || float64(x) > series.Statistics.Max {
+				t.Fatalf("%s: %f outside of statistics %#v", series.Hostname, x, series.Statistics)
+			}
+		}
+	}
+
+	// The complete data is cached separately.
+	jd, err = LoadData(job, []string{"cpu_load"}, []schema.MetricScope{schema.MetricScopeNode}, context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(jd["cpu_load"][schema.MetricScopeNode].Series[0].Data); n != 1441 {
+		t.Errorf("expected complete series, got %d data points", n)
+	}
+
+	if _, err := LoadDataWindow(job, []string{"cpu_load"}, nil, job.StartTime.Add(-time.Hour),
+		job.StartTime.Add(-time.Minute), context.Background()); err == nil {
+		t.Error("expected error for window before job start")
+	}
+}
+
+func TestLoadDataWindowRepository(t *testing.T) {
+	callback := TestLoadDataCallback
+	metricDataRepos["windowtest"] = []MetricDataRepository{&TestMetricDataRepository{}}
+	t.Cleanup(func() {
+		TestLoadDataCallback = callback
+		delete(metricDataRepos, "windowtest")
+	})
+
+	var queried *schema.Job
+	TestLoadDataCallback = func(job *schema.Job, metrics []string, scopes []schema.MetricScope, ctx context.Context) (schema.JobData, error) {
+		queried = job
+		return schema.JobData{}, nil
+	}
+
+	job := &schema.Job{
+		ID:        4244,
+		BaseJob:   schema.BaseJob{Cluster: "windowtest", State: schema.JobStateRunning, Duration: 3600},
+		StartTime: time.Unix(10000, 0),
+	}
+	if _, err := LoadDataWindow(job, []string{"cpu_load"}, nil, time.Unix(11000, 0), time.Time{}, context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if queried == nil || queried.StartTime.Unix() != 11000 || queried.StartTimeUnix != 11000 || queried.Duration != 2600 {
+		t.Fatalf("window not passed to repository: %#v", queried)
+	}
+	if job.StartTime.Unix() != 10000 || job.Duration != 3600 {
+		t.Errorf("job modified: %#v", job)
+	}
+}
+
+func TestLoadDataWithUnits(t *testing.T) {
+	callback := TestLoadDataCallback
+	metricDataRepos["unittest"] = []MetricDataRepository{&TestMetricDataRepository{}}
+	t.Cleanup(func() {
+		TestLoadDataCallback = callback
+		delete(metricDataRepos, "unittest")
+	})
+
+	TestLoadDataCallback = func(job *schema.Job, metrics []string, scopes []schema.MetricScope, ctx context.Context) (schema.JobData, error) {
+		return schema.JobData{"mem_bw": {schema.MetricScopeNode: &schema.JobMetric{
+			Unit:     schema.Unit{Base: "B/s", Prefix: "G"},
+			Timestep: 60,
+			Series: []schema.Series{{
+				Hostname:   "n1",
+				Data:       []schema.Float{1.5, 2},
+				Statistics: schema.MetricStatistics{Min: 1.5, Avg: 1.75, Max: 2},
+			}},
+		}}}, nil
+	}
+
+	job := &schema.Job{
+		ID:        4245,
+		BaseJob:   schema.BaseJob{Cluster: "unittest", State: schema.JobStateRunning, Duration: 120},
+		StartTime: time.Unix(10000, 0),
+	}
+	jd, err := LoadDataWithUnits(job, []string{"mem_bw"}, nil,
+		map[string]schema.Unit{"mem_bw": {Base: "B/s", Prefix: "M"}}, context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	jm := jd["mem_bw"][schema.MetricScopeNode]
+	if jm.Unit.String() != "MB/s" {
+		t.Errorf("unexpected unit: %s", jm.Unit)
+	}
+	if s := jm.Series[0]; s.Data[0] != 1500 || s.Data[1] != 2000 || s.Statistics.Avg != 1750 {
+		t.Errorf("data not converted: %v %v", s.Data, s.Statistics)
+	}
+
+	if _, err := LoadDataWithUnits(job, []string{"mem_bw"}, nil,
+		map[string]schema.Unit{"mem_bw": {Base: "Hz"}}, context.Background()); err == nil {
+		t.Error("expected error for incompatible unit")
+	}
+}
+
+func TestLoadDataDownsampled(t *testing.T) {
+	callback := TestLoadDataCallback
+	metricDataRepos["downsampletest"] = []MetricDataRepository{&TestMetricDataRepository{}}
+	t.Cleanup(func() {
+		TestLoadDataCallback = callback
+		delete(metricDataRepos, "downsampletest")
+	})
+
+	// Two days at a timestep of 60s.
+	data := make([]schema.Float, 2880)
+	sum := 0.0
+	for i := range data {
+		data[i] = schema.Float(100 + 50*math.Sin(float64(i)/100) + float64(i%7))
+		sum += float64(data[i])
+	}
+	TestLoadDataCallback = func(job *schema.Job, metrics []string, scopes []schema.MetricScope, ctx context.Context) (schema.JobData, error) {
+		return schema.JobData{"flops_any": {schema.MetricScopeNode: &schema.JobMetric{
+			Timestep: 60,
+			Series:   []schema.Series{{Hostname: "n1", Data: data}},
+		}}}, nil
+	}
+
+	job := &schema.Job{
+		ID:        4249,
+		BaseJob:   schema.BaseJob{Cluster: "downsampletest", State: schema.JobStateRunning, Duration: 2880 * 60},
+		StartTime: time.Unix(10000, 0),
+	}
+	jd, err := LoadDataDownsampled(job, []string{"flops_any"}, []schema.MetricScope{schema.MetricScopeNode}, nil, 500, context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	jm := jd["flops_any"][schema.MetricScopeNode]
+	if jm.Timestep != 360 || len(jm.Series[0].Data) != 480 {
+		t.Fatalf("expected 480 points at a timestep of 360s, got %d at %ds", len(jm.Series[0].Data), jm.Timestep)
+	}
+	downsampledSum := 0.0
+	for _, x := range jm.Series[0].Data {
+		downsampledSum += float64(x)
+	}
+	if avg, downsampledAvg := sum/2880, downsampledSum/480; math.Abs(avg-downsampledAvg) > 1e-6*avg {
+		t.Errorf("average not preserved: %f, got %f", avg, downsampledAvg)
+	}
+
+	// The cache keeps the full resolution.
+	jd, err = LoadData(job, []string{"flops_any"}, []schema.MetricScope{schema.MetricScopeNode}, context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(jd["flops_any"][schema.MetricScopeNode].Series[0].Data); n != 2880 {
+		t.Errorf("expected 2880 points without downsampling, got %d", n)
+	}
+}
+
+func TestLoadDataDefaultMetrics(t *testing.T) {
+	callback, clusters := TestLoadDataCallback, config.Keys.Clusters
+	metricDataRepos["defaulttest"] = []MetricDataRepository{&TestMetricDataRepository{}}
+	t.Cleanup(func() {
+		TestLoadDataCallback, config.Keys.Clusters = callback, clusters
+		delete(metricDataRepos, "defaulttest")
+	})
+
+	config.Keys.Clusters = []*schema.ClusterConfig{{
+		Name:           "defaulttest",
+		DefaultMetrics: []string{"flops_any", "mem_bw"},
+		DefaultScopes:  []schema.MetricScope{schema.MetricScopeCore},
+	}}
+
+	var queriedMetrics []string
+	var queriedScopes []schema.MetricScope
+	TestLoadDataCallback = func(job *schema.Job, metrics []string, scopes []schema.MetricScope, ctx context.Context) (schema.JobData, error) {
+		queriedMetrics, queriedScopes = metrics, scopes
+		return schema.JobData{}, nil
+	}
+
+	job := &schema.Job{
+		ID:        4250,
+		BaseJob:   schema.BaseJob{Cluster: "defaulttest", State: schema.JobStateRunning, Duration: 120},
+		StartTime: time.Unix(10000, 0),
+	}
+	if _, err := LoadData(job, nil, nil, context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(queriedMetrics, []string{"flops_any", "mem_bw"}) ||
+		!reflect.DeepEqual(queriedScopes, []schema.MetricScope{schema.MetricScopeCore}) {
+		t.Errorf("defaults not used: %v %v", queriedMetrics, queriedScopes)
+	}
+
+	// Requested metrics and scopes take precedence over the defaults.
+	if _, err := LoadData(job, []string{"cpu_load"}, []schema.MetricScope{schema.MetricScopeNode}, context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(queriedMetrics, []string{"cpu_load"}) ||
+		!reflect.DeepEqual(queriedScopes, []schema.MetricScope{schema.MetricScopeNode}) {
+		t.Errorf("requested metrics not used: %v %v", queriedMetrics, queriedScopes)
+	}
+}
+
+func TestLoadEnergy(t *testing.T) {
+	callback, clusters := TestLoadDataCallback, archive.Clusters
+	metricDataRepos["energytest"] = []MetricDataRepository{&TestMetricDataRepository{}}
+	t.Cleanup(func() {
+		TestLoadDataCallback, archive.Clusters = callback, clusters
+		delete(metricDataRepos, "energytest")
+	})
+
+	archive.Clusters = []*schema.Cluster{
+		{Name: "energytest", MetricConfig: []*schema.MetricConfig{{Name: "power", Unit: schema.Unit{Base: "W", Prefix: "K"}, Scope: schema.MetricScopeNode, Timestep: 60}}},
+		{Name: "nopower", MetricConfig: []*schema.MetricConfig{{Name: "cpu_load", Scope: schema.MetricScopeNode, Timestep: 60}}},
+	}
+
+	// Two nodes drawing a constant 0.5 kW for 10 minutes.
+	calls := 0
+	TestLoadDataCallback = func(job *schema.Job, metrics []string, scopes []schema.MetricScope, ctx context.Context) (schema.JobData, error) {
+		calls++
+		jm := &schema.JobMetric{Unit: schema.Unit{Base: "W", Prefix: "K"}, Timestep: 60}
+		for _, host := range []string{"n1", "n2"} {
+			data := make([]schema.Float, 10)
+			for i := range data {
+				data[i] = 0.5
+			}
+			jm.Series = append(jm.Series, schema.Series{Hostname: host, Data: data})
+		}
+		return schema.JobData{"power": {schema.MetricScopeNode: jm}}, nil
+	}
+
+	job := &schema.Job{
+		ID:        4252,
+		BaseJob:   schema.BaseJob{Cluster: "energytest", State: schema.JobStateRunning, Duration: 600},
+		StartTime: time.Unix(10000, 0),
+	}
+	for i := 0; i < 2; i++ {
+		energy, err := LoadEnergy(job, context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if energy == nil || math.Abs(*energy-2*0.5*600/3600) > 1e-9 {
+			t.Fatalf("wrong energy: %v", energy)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("energy not cached, loaded %d times", calls)
+	}
+
+	job = &schema.Job{
+		ID:        4253,
+		BaseJob:   schema.BaseJob{Cluster: "nopower", State: schema.JobStateRunning, Duration: 600},
+		StartTime: time.Unix(10000, 0),
+	}
+	if energy, err := LoadEnergy(job, context.Background()); err != nil || energy != nil {
+		t.Errorf("expected nil without power metric, got %v, %v", energy, err)
+	}
+}
+
+func TestJobDataCodec(t *testing.T) {
+	jd := schema.JobData{
+		"cpu_load": {
+			schema.MetricScopeNode: &schema.JobMetric{
+				Unit:     schema.Unit{Base: "load"},
+				Timestep: 60,
+				Series:   []schema.Series{{Hostname: "n1", Statistics: schema.MetricStatistics{Min: 1, Avg: 2, Max: 3}, Data: []schema.Float{1, schema.NaN, 3}}},
+			},
+		},
+	}
+
+	data, err := jobDataCodec{}.Marshal(jd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, err := jobDataCodec{}.Unmarshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	series := value.(schema.JobData)["cpu_load"][schema.MetricScopeNode].Series[0]
+	if series.Hostname != "n1" || series.Statistics.Max != 3 || series.Data[0] != 1 || !series.Data[1].IsNaN() {
+		t.Fatalf("unexpected series after decoding: %#v", series)
+	}
+
+	if _, err := (jobDataCodec{}).Marshal(errors.New("failed")); err == nil {
+		t.Error("expected errors not to be encoded")
+	}
+}
+
+// benchmarkJobData returns job data of 8 nodes and 10 metrics for 24 hours,
+// with noisy values rounded like real measurements.
+func benchmarkJobData(rng *rand.Rand) schema.JobData {
+	jd := schema.JobData{}
+	for m := 0; m < 10; m++ {
+		jm := &schema.JobMetric{Timestep: 60}
+		for n := 0; n < 8; n++ {
+			data := make([]schema.Float, 1440)
+			level := rng.Float64() * 100
+			for i := range data {
+				data[i] = schema.Float(math.Round((level+rng.Float64()*5)*100) / 100)
+			}
+			jm.Series = append(jm.Series, schema.Series{Hostname: fmt.Sprintf("node%03d", n), Data: data})
+		}
+		jd[fmt.Sprintf("metric%d", m)] = map[schema.MetricScope]*schema.JobMetric{schema.MetricScopeNode: jm}
+	}
+	return jd
+}
+
+// Compares the metric data cache with and without compression for a cache
+// that can hold the raw data of 16 of 64 jobs. Reports the hit rate and the
+// memory needed per cached job.
+func BenchmarkJobDataCache(b *testing.B) {
+	rng := rand.New(rand.NewSource(0))
+	jobs := make([]schema.JobData, 64)
+	for i := range jobs {
+		jobs[i] = benchmarkJobData(rng)
+	}
+	maxmemory := 16 * jobs[0].Size()
+
+	for _, bc := range []struct {
+		name  string
+		cache func() *lrucache.Cache
+	}{
+		{"raw", func() *lrucache.Cache { return lrucache.New(maxmemory) }},
+		{"gzip", func() *lrucache.Cache { return lrucache.NewWithCodec(maxmemory, jobDataCodec{}) }},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			c := bc.cache()
+			rng := rand.New(rand.NewSource(1))
+			misses := 0
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				job := rng.Intn(len(jobs))
+				c.Get(fmt.Sprint(job), func() (interface{}, time.Duration, int) {
+					misses++
+					return jobs[job], time.Hour, jobs[job].Size()
+				})
+			}
+			b.StopTimer()
+
+			entries := 0
+			c.Keys(func(key string, val interface{}) { entries++ })
+			b.ReportMetric(float64(b.N-misses)/float64(b.N), "hits/op")
+			b.ReportMetric(float64(maxmemory)/float64(entries), "bytes/job")
+		})
+	}
+}
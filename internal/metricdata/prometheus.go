@@ -199,6 +199,11 @@ func (pdb *PrometheusDataRepository) Init(rawConfig json.RawMessage) error {
 	return nil
 }
 
+func (pdb *PrometheusDataRepository) HealthCheck(ctx context.Context) error {
+	_, err := pdb.queryClient.Buildinfo(ctx)
+	return err
+}
+
 // TODO: respect scope argument
 func (pdb *PrometheusDataRepository) FormatQuery(
 	metric string,
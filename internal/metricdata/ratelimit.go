@@ -0,0 +1,109 @@
+// Copyright (C) 2023 NHR@FAU, University Erlangen-Nuremberg.
+// All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+package metricdata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited is returned if a request to the metric data repositories of
+// a cluster would have to wait longer than rateLimitMaxWait for the rate
+// limit of the cluster.
+var ErrRateLimited = errors.New("METRICDATA/RATELIMIT > too many requests to the metric data repository")
+
+// Maximum time a request waits for the rate limit of its cluster.
+var rateLimitMaxWait = 5 * time.Second
+
+// Context key marking requests that wait for the rate limit as long as
+// needed, see waitForRateLimit.
+type rateLimitNoMaxWaitKey struct{}
+
+// waitForRateLimit returns a context whose requests wait for the rate limit
+// of their cluster without rateLimitMaxWait, until ctx is done. Used for
+// archiving, which would otherwise fail under load.
+func waitForRateLimit(ctx context.Context) context.Context {
+	return context.WithValue(ctx, rateLimitNoMaxWaitKey{}, true)
+}
+
+// The rate limiters of the clusters with a configured metricDataRateLimit.
+var rateLimiters map[string]*rateLimiter = map[string]*rateLimiter{}
+
+// A token bucket refilled with rate tokens per second up to burst tokens.
+// Every request to a metric data repository takes one token.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	if burst < 1 {
+		burst = int(math.Ceil(rate))
+	}
+	return &rateLimiter{rate: rate, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+// wait blocks until a token is available. If that takes longer than maxWait
+// or than the deadline of ctx, ErrRateLimited is returned.
+func (l *rateLimiter) wait(ctx context.Context, maxWait time.Duration) error {
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+
+	// Take the token now so that waiting requests are served in order.
+	l.tokens--
+	var delay time.Duration
+	if l.tokens < 0 {
+		delay = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	if deadline, ok := ctx.Deadline(); delay > maxWait || (ok && now.Add(delay).After(deadline)) {
+		l.tokens++
+		l.mu.Unlock()
+		return ErrRateLimited
+	}
+	l.mu.Unlock()
+
+	if delay == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return ErrRateLimited
+	}
+}
+
+// rateLimit waits for the rate limit of cluster, if it has one, before a
+// request to one of its metric data repositories.
+func rateLimit(ctx context.Context, cluster string) error {
+	l, ok := rateLimiters[cluster]
+	if !ok {
+		return nil
+	}
+
+	maxWait := rateLimitMaxWait
+	if ctx.Value(rateLimitNoMaxWaitKey{}) != nil {
+		maxWait = time.Duration(math.MaxInt64)
+	}
+	if err := l.wait(ctx, maxWait); err != nil {
+		return fmt.Errorf("%w of cluster '%s'", err, cluster)
+	}
+	return nil
+}
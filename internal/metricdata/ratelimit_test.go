@@ -0,0 +1,96 @@
+// Copyright (C) 2023 NHR@FAU, University Erlangen-Nuremberg.
+// All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+package metricdata
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ClusterCockpit/cc-backend/pkg/schema"
+)
+
+func TestRateLimiterPacing(t *testing.T) {
+	l := newRateLimiter(20, 2)
+
+	// The burst passes at once, every further request waits 1/20 s.
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		if err := l.wait(context.Background(), time.Second); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 190*time.Millisecond || elapsed > time.Second {
+		t.Errorf("6 requests at 20/s with burst 2 took %s, expected about 200ms", elapsed)
+	}
+}
+
+func TestRateLimiterTimeout(t *testing.T) {
+	l := newRateLimiter(1, 1)
+	if err := l.wait(context.Background(), 10*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.wait(context.Background(), 10*time.Millisecond); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("expected ErrRateLimited, got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.wait(ctx, time.Minute); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("expected ErrRateLimited before the deadline of the context, got %v", err)
+	}
+}
+
+func TestLoadDataRateLimited(t *testing.T) {
+	callback, maxWait := TestLoadDataCallback, rateLimitMaxWait
+	metricDataRepos["ratetest"] = []MetricDataRepository{&TestMetricDataRepository{}}
+	rateLimiters["ratetest"] = newRateLimiter(1, 1)
+	t.Cleanup(func() {
+		TestLoadDataCallback, rateLimitMaxWait = callback, maxWait
+		delete(metricDataRepos, "ratetest")
+		delete(rateLimiters, "ratetest")
+	})
+
+	rateLimitMaxWait = 10 * time.Millisecond
+	calls := 0
+	TestLoadDataCallback = func(job *schema.Job, metrics []string, scopes []schema.MetricScope, ctx context.Context) (schema.JobData, error) {
+		calls++
+		return schema.JobData{}, nil
+	}
+
+	for i, id := range []int64{4254, 4255} {
+		job := &schema.Job{
+			ID:        id,
+			BaseJob:   schema.BaseJob{Cluster: "ratetest", State: schema.JobStateRunning, Duration: 60},
+			StartTime: time.Unix(10000, 0),
+		}
+		_, err := LoadData(job, []string{"cpu_load"}, []schema.MetricScope{schema.MetricScopeNode}, context.Background())
+		if i == 0 && err != nil {
+			t.Fatal(err)
+		}
+		if i == 1 && !errors.Is(err, ErrRateLimited) {
+			t.Errorf("expected ErrRateLimited, got %v", err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 request to the repository, got %d", calls)
+	}
+
+	// Archiving waits longer than rateLimitMaxWait for the next token.
+	job := &schema.Job{
+		ID:        4256,
+		BaseJob:   schema.BaseJob{Cluster: "ratetest", State: schema.JobStateRunning, Duration: 60},
+		StartTime: time.Unix(10000, 0),
+	}
+	rateLimiters["ratetest"] = newRateLimiter(20, 1)
+	rateLimiters["ratetest"].tokens = 0
+	if _, err := LoadData(job, []string{"cpu_load"}, []schema.MetricScope{schema.MetricScopeNode}, waitForRateLimit(context.Background())); err != nil {
+		t.Errorf("expected archiving to wait for the rate limit, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 requests to the repository, got %d", calls)
+	}
+}
@@ -7,6 +7,7 @@ package metricdata
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"time"
 
 	"github.com/ClusterCockpit/cc-backend/pkg/schema"
@@ -16,6 +17,10 @@ var TestLoadDataCallback func(job *schema.Job, metrics []string, scopes []schema
 	panic("TODO")
 }
 
+var TestLoadNodeDataCallback func(cluster string, metrics, nodes []string, scopes []schema.MetricScope, from, to time.Time, ctx context.Context) (map[string]map[string][]*schema.JobMetric, error) = func(cluster string, metrics, nodes []string, scopes []schema.MetricScope, from, to time.Time, ctx context.Context) (map[string]map[string][]*schema.JobMetric, error) {
+	return nil, errors.New("TestLoadNodeDataCallback not set")
+}
+
 // Only a mock for unit-testing.
 type TestMetricDataRepository struct{}
 
@@ -36,7 +41,22 @@ func (tmdr *TestMetricDataRepository) LoadStats(
 	job *schema.Job,
 	metrics []string, ctx context.Context) (map[string]map[string]schema.MetricStatistics, error) {
 
-	panic("TODO")
+	data, err := TestLoadDataCallback(job, metrics, []schema.MetricScope{schema.MetricScopeNode}, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make(map[string]map[string]schema.MetricStatistics, len(metrics))
+	for _, m := range metrics {
+		if jm, ok := data[m][schema.MetricScopeNode]; ok {
+			stats[m] = make(map[string]schema.MetricStatistics, len(jm.Series))
+			for _, series := range jm.Series {
+				stats[m][series.Hostname] = series.Statistics
+			}
+		}
+	}
+
+	return stats, nil
 }
 
 func (tmdr *TestMetricDataRepository) LoadNodeData(
@@ -46,5 +66,9 @@ func (tmdr *TestMetricDataRepository) LoadNodeData(
 	from, to time.Time,
 	ctx context.Context) (map[string]map[string][]*schema.JobMetric, error) {
 
-	panic("TODO")
+	return TestLoadNodeDataCallback(cluster, metrics, nodes, scopes, from, to, ctx)
+}
+
+func (tmdr *TestMetricDataRepository) HealthCheck(_ context.Context) error {
+	return nil
 }
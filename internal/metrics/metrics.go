@@ -0,0 +1,83 @@
+// Copyright (C) 2023 NHR@FAU, University Erlangen-Nuremberg.
+// All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package metrics exports metrics about cc-backend itself in the Prometheus
+// format. The collectors are updated by the instrumented packages.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/felixge/httpsnoop"
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "cc_backend"
+
+var registry = prometheus.NewRegistry()
+
+var (
+	// Requests to the REST API by route template and response status.
+	ApiRequests = promauto.With(registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "api_requests_total",
+		Help:      "Number of REST API requests by method, route and status code.",
+	}, []string{"method", "route", "code"})
+
+	// Jobs queued for or being archived.
+	ArchivingPending = promauto.With(registry).NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "archiving_pending",
+		Help:      "Number of jobs waiting for or in archiving.",
+	})
+
+	// Lookups of job metric data in the cache of metricdata.LoadData,
+	// result is "hit" or "miss".
+	MetricDataCacheRequests = promauto.With(registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "metricdata_cache_requests_total",
+		Help:      "Number of job metric data cache lookups by result (hit or miss).",
+	}, []string{"result"})
+
+	// Duration of successful database queries by SQL statement type.
+	DBQueryDuration = promauto.With(registry).NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "db_query_duration_seconds",
+		Help:      "Duration of database queries by statement type.",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+	}, []string{"statement"})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+}
+
+// Handler serves all metrics in the Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// Middleware counts the requests per route template of the gorilla/mux
+// router, so that path parameters like job ids do not create new series.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		route := "unknown"
+		if current := mux.CurrentRoute(r); current != nil {
+			if tmpl, err := current.GetPathTemplate(); err == nil {
+				route = tmpl
+			}
+		}
+
+		m := httpsnoop.CaptureMetrics(next, rw, r)
+		ApiRequests.WithLabelValues(r.Method, route, strconv.Itoa(m.Code)).Inc()
+	})
+}
@@ -0,0 +1,152 @@
+// Copyright (C) 2023 NHR@FAU, University Erlangen-Nuremberg.
+// All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+package repository
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/ClusterCockpit/cc-backend/pkg/schema"
+)
+
+// Phases of an archiving operation.
+const (
+	ArchivingQueued   = "queued"   // Waiting for a free archiving worker
+	ArchivingLoading  = "loading"  // Loading the metric data and writing the job-archive
+	ArchivingUpdating = "updating" // Updating the database and applying the tag rules
+)
+
+// ArchivingTask is an archiving operation triggered by TriggerArchiving that
+// has not finished yet.
+type ArchivingTask struct {
+	ID        int64 // Database ID of the job
+	JobID     int64
+	Cluster   string
+	Queued    time.Time
+	Started   time.Time // Zero while queued
+	Phase     string
+	Cancelled bool
+
+	job    *schema.Job
+	cancel context.CancelFunc
+	done   chan struct{} // Closed when the operation is finished
+}
+
+// Archivings returns the archiving operations that have not finished yet,
+// ordered by the time they were triggered.
+func (r *JobRepository) Archivings() []ArchivingTask {
+	r.archivingsLock.Lock()
+	defer r.archivingsLock.Unlock()
+
+	tasks := make([]ArchivingTask, 0, len(r.archivings))
+	for _, task := range r.archivings {
+		tasks = append(tasks, *task)
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		if tasks[i].Queued.Equal(tasks[j].Queued) {
+			return tasks[i].ID < tasks[j].ID
+		}
+		return tasks[i].Queued.Before(tasks[j].Queued)
+	})
+	return tasks
+}
+
+// CancelArchiving cancels the archiving of the job with database ID id. A
+// queued job is skipped, the context of a job being archived is cancelled.
+// In both cases, archiving the job fails. Returns ErrNotFound if the job is
+// not being archived.
+func (r *JobRepository) CancelArchiving(id int64) error {
+	r.archivingsLock.Lock()
+	defer r.archivingsLock.Unlock()
+
+	task, ok := r.archivings[id]
+	if !ok {
+		return ErrNotFound
+	}
+
+	task.Cancelled = true
+	if task.cancel != nil {
+		task.cancel()
+	}
+	return nil
+}
+
+// addArchiving registers a queued archiving operation for job. If the job is
+// already queued or being archived, its existing operation is returned and
+// added is false.
+func (r *JobRepository) addArchiving(job *schema.Job) (task *ArchivingTask, added bool) {
+	r.archivingsLock.Lock()
+	defer r.archivingsLock.Unlock()
+	if existing, ok := r.archivings[job.ID]; ok {
+		return existing, false
+	}
+	if r.archivings == nil {
+		r.archivings = make(map[int64]*ArchivingTask)
+	}
+
+	task = &ArchivingTask{
+		ID:      job.ID,
+		JobID:   job.JobID,
+		Cluster: job.Cluster,
+		Queued:  time.Now(),
+		Phase:   ArchivingQueued,
+		job:     job,
+		done:    make(chan struct{}),
+	}
+	r.archivings[job.ID] = task
+	return task, true
+}
+
+// startArchiving moves task to the loading phase and returns the context for
+// archiving its job, or false if task was cancelled while queued.
+func (r *JobRepository) startArchiving(task *ArchivingTask) (context.Context, bool) {
+	r.archivingsLock.Lock()
+	defer r.archivingsLock.Unlock()
+	if task.Cancelled {
+		return nil, false
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	task.Started, task.Phase, task.cancel = time.Now(), ArchivingLoading, cancel
+	return ctx, true
+}
+
+func (r *JobRepository) setArchivingPhase(task *ArchivingTask, phase string) {
+	r.archivingsLock.Lock()
+	defer r.archivingsLock.Unlock()
+	task.Phase = phase
+}
+
+// removeArchiving unregisters task, releases its context and wakes up the
+// waiting callers. The job may have been triggered again meanwhile, so only
+// task itself is removed.
+func (r *JobRepository) removeArchiving(task *ArchivingTask) {
+	r.archivingsLock.Lock()
+	defer r.archivingsLock.Unlock()
+	if task.cancel != nil {
+		task.cancel()
+	}
+	if r.archivings[task.ID] == task {
+		delete(r.archivings, task.ID)
+	}
+	close(task.done)
+}
+
+// WaitForArchivingOf blocks until the archiving of the job with database ID
+// id, if any, is finished. Other archiving operations are not waited for.
+// Returns true if the job was being archived.
+func (r *JobRepository) WaitForArchivingOf(id int64) (waited bool) {
+	for {
+		r.archivingsLock.Lock()
+		task, ok := r.archivings[id]
+		r.archivingsLock.Unlock()
+		if !ok {
+			return waited
+		}
+		<-task.done
+		waited = true
+	}
+}
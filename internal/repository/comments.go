@@ -0,0 +1,99 @@
+// Copyright (C) 2023 NHR@FAU, University Erlangen-Nuremberg.
+// All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+package repository
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/ClusterCockpit/cc-backend/pkg/log"
+	"github.com/ClusterCockpit/cc-backend/pkg/schema"
+	sq "github.com/Masterminds/squirrel"
+)
+
+// AddComment adds a comment with user as author to the job with the database
+// id job. Comments are deleted together with their job.
+func (r *JobRepository) AddComment(user *schema.User, job int64, text string) (*schema.JobComment, error) {
+	if user == nil {
+		return nil, ErrForbidden
+	}
+
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, errors.New("comment text is empty")
+	}
+
+	comment := &schema.JobComment{
+		JobID:     job,
+		Author:    user.Username,
+		CreatedAt: time.Unix(time.Now().Unix(), 0),
+		Text:      text,
+	}
+	q := sq.Insert("job_comment").Columns("job_id", "author", "created_at", "text").
+		Values(comment.JobID, comment.Author, comment.CreatedAt.Unix(), comment.Text)
+
+	res, err := q.RunWith(r.stmtCache).Exec()
+	if err != nil {
+		s, _, _ := q.ToSql()
+		log.Errorf("Error adding comment with %s: %v", s, err)
+		return nil, err
+	}
+
+	if comment.ID, err = res.LastInsertId(); err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+// GetComments returns the comments of the job with the database id job,
+// oldest first.
+func (r *JobRepository) GetComments(job int64) ([]*schema.JobComment, error) {
+	rows, err := sq.Select("id", "job_id", "author", "created_at", "text").From("job_comment").
+		Where("job_comment.job_id = ?", job).OrderBy("created_at ASC", "id ASC").
+		RunWith(r.stmtCache).Query()
+	if err != nil {
+		log.Error("Error while running query")
+		return nil, err
+	}
+	defer rows.Close()
+
+	comments := make([]*schema.JobComment, 0)
+	for rows.Next() {
+		comment := &schema.JobComment{}
+		var createdAt int64
+		if err := rows.Scan(&comment.ID, &comment.JobID, &comment.Author, &createdAt, &comment.Text); err != nil {
+			log.Warn("Error while scanning rows")
+			return nil, err
+		}
+		comment.CreatedAt = time.Unix(createdAt, 0)
+		comments = append(comments, comment)
+	}
+
+	return comments, rows.Err()
+}
+
+// DeleteComment deletes the comment with the id comment. Only its author and
+// admins may delete a comment, for everyone else ErrForbidden is returned.
+func (r *JobRepository) DeleteComment(user *schema.User, comment int64) error {
+	var author string
+	if err := sq.Select("author").From("job_comment").Where("job_comment.id = ?", comment).
+		RunWith(r.stmtCache).QueryRow().Scan(&author); err != nil {
+		return err
+	}
+
+	if user == nil || (user.Username != author && !user.HasRole(schema.RoleAdmin)) {
+		return ErrForbidden
+	}
+
+	q := sq.Delete("job_comment").Where("job_comment.id = ?", comment)
+	if _, err := q.RunWith(r.stmtCache).Exec(); err != nil {
+		s, _, _ := q.ToSql()
+		log.Errorf("Error deleting comment with %s: %v", s, err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+// Copyright (C) 2023 NHR@FAU, University Erlangen-Nuremberg.
+// All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/ClusterCockpit/cc-backend/pkg/schema"
+)
+
+func TestComments(t *testing.T) {
+	r := setup(t)
+	t.Cleanup(func() {
+		r.DB.Exec(`DELETE FROM job WHERE cluster = 'commenttest'`)
+	})
+
+	const input = `{"jobId": 5001, "user": "alice", "project": "p1", "cluster": "commenttest", "subCluster": "main", "numNodes": 1, "exclusive": 1, "jobState": "completed", "duration": 60, "resources": [{"hostname": "n1"}], "startTime": 1675957000}
+`
+	if _, _, err := r.ImportNDJSON(strings.NewReader(input)); err != nil {
+		t.Fatal(err)
+	}
+	jobId, cluster := int64(5001), "commenttest"
+	job, err := r.Find(context.Background(), &jobId, &cluster, nil)
+	noErr(t, err)
+
+	alice := &schema.User{Username: "alice", Roles: []string{schema.GetRoleString(schema.RoleUser)}}
+	bob := &schema.User{Username: "bob", Roles: []string{schema.GetRoleString(schema.RoleUser)}}
+	admin := &schema.User{Username: "admin", Roles: []string{schema.GetRoleString(schema.RoleAdmin)}}
+
+	first, err := r.AddComment(alice, job.ID, " bad node, rerun ")
+	noErr(t, err)
+	second, err := r.AddComment(bob, job.ID, "same here")
+	noErr(t, err)
+	if _, err := r.AddComment(alice, job.ID, "  "); err == nil {
+		t.Error("expected error for empty comment")
+	}
+	if _, err := r.AddComment(nil, job.ID, "anonymous"); err != ErrForbidden {
+		t.Errorf("expected ErrForbidden without user, got %v", err)
+	}
+
+	comments, err := r.GetComments(job.ID)
+	noErr(t, err)
+	if len(comments) != 2 || comments[0].ID != first.ID || comments[1].ID != second.ID {
+		t.Fatalf("unexpected comments: %v", comments)
+	}
+	if c := comments[0]; c.Author != "alice" || c.Text != "bad node, rerun" || c.JobID != job.ID || !c.CreatedAt.Equal(first.CreatedAt) {
+		t.Errorf("unexpected comment: %#v", c)
+	}
+
+	if err := r.DeleteComment(bob, first.ID); err != ErrForbidden {
+		t.Errorf("expected ErrForbidden for foreign comment, got %v", err)
+	}
+	noErr(t, r.DeleteComment(alice, first.ID))
+	noErr(t, r.DeleteComment(admin, second.ID))
+	if err := r.DeleteComment(alice, first.ID); err != sql.ErrNoRows {
+		t.Errorf("expected sql.ErrNoRows for deleted comment, got %v", err)
+	}
+
+	comments, err = r.GetComments(job.ID)
+	noErr(t, err)
+	if len(comments) != 0 {
+		t.Fatalf("expected no comments, got %v", comments)
+	}
+
+	// Comments are deleted together with their job.
+	_, err = r.AddComment(alice, job.ID, "gone with the job")
+	noErr(t, err)
+	noErr(t, r.DeleteJobById(job.ID, false))
+	var n int
+	noErr(t, r.DB.QueryRow(`SELECT COUNT(*) FROM job_comment WHERE job_id = ?`, job.ID).Scan(&n))
+	if n != 0 {
+		t.Errorf("expected comments to be deleted with job, %d left", n)
+	}
+}
@@ -6,10 +6,14 @@ package repository
 
 import (
 	"database/sql"
+	"fmt"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/ClusterCockpit/cc-backend/internal/config"
 	"github.com/ClusterCockpit/cc-backend/pkg/log"
+	"github.com/go-sql-driver/mysql"
 	"github.com/jmoiron/sqlx"
 	"github.com/mattn/go-sqlite3"
 	"github.com/qustavo/sqlhooks/v2"
@@ -33,50 +37,26 @@ type DatabaseOptions struct {
 	ConnectionMaxIdleTime time.Duration
 }
 
-func Connect(driver string, db string) {
-	var err error
-	var dbHandle *sqlx.DB
+func init() {
+	// The hooks log the queries in debug mode and measure their duration,
+	// they are only used if needed (see openDB). sqlx does not know the
+	// wrapped drivers, both use '?' placeholders.
+	sql.Register("sqlite3WithHooks", sqlhooks.Wrap(&sqlite3.SQLiteDriver{}, &Hooks{}))
+	sqlx.BindDriver("sqlite3WithHooks", sqlx.QUESTION)
+	sql.Register("mysqlWithHooks", sqlhooks.Wrap(&mysql.MySQLDriver{}, &Hooks{}))
+	sqlx.BindDriver("mysqlWithHooks", sqlx.QUESTION)
+}
 
+// Connect opens the database connection shared by all repositories. All
+// queries are built with '?' placeholders, the default of squirrel, which
+// both supported drivers use.
+func Connect(driver string, db string) {
 	dbConnOnce.Do(func() {
-		opts := DatabaseOptions{
-			URL:                   db,
-			MaxOpenConnections:    4,
-			MaxIdleConnections:    4,
-			ConnectionMaxLifetime: time.Hour,
-			ConnectionMaxIdleTime: time.Hour,
-		}
-
-		switch driver {
-		case "sqlite3":
-			// - Set WAL mode (not strictly necessary each time because it's persisted in the database, but good for first run)
-			// - Set busy timeout, so concurrent writers wait on each other instead of erroring immediately
-			// - Enable foreign key checks
-			opts.URL += "?_journal=WAL&_timeout=5000&_fk=true"
-
-			if log.Loglevel() == "debug" {
-				sql.Register("sqlite3WithHooks", sqlhooks.Wrap(&sqlite3.SQLiteDriver{}, &Hooks{}))
-				dbHandle, err = sqlx.Open("sqlite3WithHooks", opts.URL)
-			} else {
-				dbHandle, err = sqlx.Open("sqlite3", opts.URL)
-			}
-			if err != nil {
-				log.Fatal(err)
-			}
-		case "mysql":
-			opts.URL += "?multiStatements=true"
-			dbHandle, err = sqlx.Open("mysql", opts.URL)
-			if err != nil {
-				log.Fatalf("sqlx.Open() error: %v", err)
-			}
-		default:
-			log.Fatalf("unsupported database driver: %s", driver)
+		dbHandle, err := openDB(driver, db)
+		if err != nil {
+			log.Fatal(err)
 		}
 
-		dbHandle.SetMaxOpenConns(opts.MaxOpenConnections)
-		dbHandle.SetMaxIdleConns(opts.MaxIdleConnections)
-		dbHandle.SetConnMaxLifetime(opts.ConnectionMaxLifetime)
-		dbHandle.SetConnMaxIdleTime(opts.ConnectionMaxIdleTime)
-
 		dbConnInstance = &DBConnection{DB: dbHandle, Driver: driver}
 		err = checkDBVersion(driver, dbHandle.DB)
 		if err != nil {
@@ -85,6 +65,64 @@ func Connect(driver string, db string) {
 	})
 }
 
+// openDB opens a connection pool to the database db, a filename for sqlite3
+// and a DSN (optionally with parameters) for mysql.
+func openDB(driver string, db string) (*sqlx.DB, error) {
+	var err error
+	var dbHandle *sqlx.DB
+
+	opts := DatabaseOptions{
+		URL:                   db,
+		MaxOpenConnections:    4,
+		MaxIdleConnections:    4,
+		ConnectionMaxLifetime: time.Hour,
+		ConnectionMaxIdleTime: time.Hour,
+	}
+
+	// The hooks cost time on every query, they are only installed if the
+	// queries are logged or measured.
+	hooks := ""
+	if log.Loglevel() == "debug" || config.Keys.EnableMetrics {
+		hooks = "WithHooks"
+	}
+
+	switch driver {
+	case "sqlite3":
+		// - Set WAL mode (not strictly necessary each time because it's persisted in the database, but good for first run)
+		// - Set busy timeout, so concurrent writers wait on each other instead of erroring immediately
+		// - Enable foreign key checks
+		opts.URL = withParams(opts.URL, "_journal=WAL&_timeout=5000&_fk=true")
+		dbHandle, err = sqlx.Open("sqlite3"+hooks, opts.URL)
+		if err != nil {
+			return nil, err
+		}
+	case "mysql":
+		opts.URL = withParams(opts.URL, "multiStatements=true")
+		dbHandle, err = sqlx.Open("mysql"+hooks, opts.URL)
+		if err != nil {
+			return nil, fmt.Errorf("sqlx.Open() error: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported database driver: %s", driver)
+	}
+
+	dbHandle.SetMaxOpenConns(opts.MaxOpenConnections)
+	dbHandle.SetMaxIdleConns(opts.MaxIdleConnections)
+	dbHandle.SetConnMaxLifetime(opts.ConnectionMaxLifetime)
+	dbHandle.SetConnMaxIdleTime(opts.ConnectionMaxIdleTime)
+
+	return dbHandle, nil
+}
+
+// withParams appends the query parameters params to the DSN url, which may
+// already have parameters of its own.
+func withParams(url string, params string) string {
+	if strings.Contains(url, "?") {
+		return url + "&" + params
+	}
+	return url + "?" + params
+}
+
 func GetConnection() *DBConnection {
 	if dbConnInstance == nil {
 		log.Fatalf("Database connection not initialized!")
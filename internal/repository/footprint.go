@@ -0,0 +1,133 @@
+// Copyright (C) 2023 NHR@FAU, University Erlangen-Nuremberg.
+// All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ClusterCockpit/cc-backend/pkg/archive"
+	"github.com/ClusterCockpit/cc-backend/pkg/log"
+	"github.com/ClusterCockpit/cc-backend/pkg/schema"
+	sq "github.com/Masterminds/squirrel"
+)
+
+// FootprintCheckpointFile stores the progress of RecomputeFootprints as
+// `<cluster> <last database id>`, so that an interrupted run continues where
+// it stopped. It is removed once all jobs are processed.
+var FootprintCheckpointFile string = "./var/footprint-recompute.txt"
+
+// RecomputeFootprints reloads the statistics of all archived jobs of cluster
+// (all clusters if empty) from the job archive and updates their footprint
+// columns as MarkArchived does. Jobs are processed ordered by database id in
+// batches of 100, the progress is saved in FootprintCheckpointFile after
+// every batch.
+func (r *JobRepository) RecomputeFootprints(cluster string) error {
+	starttime := time.Now()
+	lastId := readFootprintCheckpoint(cluster)
+	if lastId != 0 {
+		log.Printf("Resuming footprint recompute after job (dbid: %d)", lastId)
+	}
+
+	// Not using log.Print because we want the line to end with `\r` and
+	// this function is only ever called when a special command line flag
+	// is passed anyways.
+	fmt.Printf("%d jobs updated...\r", 0)
+
+	updated, errorOccured := 0, 0
+	for {
+		query := sq.Select(jobColumns...).From("job").
+			Where("job.id > ?", lastId).
+			Where(sq.Eq{"job.monitoring_status": []int32{schema.MonitoringStatusArchivingSuccessful,
+				schema.MonitoringStatusArchiveDownsampled, schema.MonitoringStatusArchiveStatsOnly}}).
+			OrderBy("job.id").Limit(100)
+		if cluster != "" {
+			query = query.Where("job.cluster = ?", cluster)
+		}
+
+		rows, err := query.RunWith(r.stmtCache).Query()
+		if err != nil {
+			log.Error("Error while querying archived jobs")
+			return err
+		}
+
+		jobs := make([]*schema.Job, 0, 100)
+		for rows.Next() {
+			job, err := scanJob(rows)
+			if err != nil {
+				rows.Close()
+				log.Warn("Error while scanning rows")
+				return err
+			}
+			jobs = append(jobs, job)
+		}
+		rows.Close()
+
+		if len(jobs) == 0 {
+			break
+		}
+
+		for _, job := range jobs {
+			lastId = job.ID
+			stats, err := archive.GetStatistics(job)
+			if err != nil {
+				log.Errorf("RecomputeFootprints(): loading statistics of job (dbid: %d) failed: %v", job.ID, err)
+				errorOccured++
+				continue
+			}
+
+			if err := r.MarkArchived(job.ID, job.MonitoringStatus, stats, nil); err != nil {
+				log.Errorf("RecomputeFootprints(): updating job (dbid: %d) failed: %v", job.ID, err)
+				errorOccured++
+				continue
+			}
+			updated++
+		}
+
+		if err := writeFootprintCheckpoint(cluster, lastId); err != nil {
+			log.Warnf("RecomputeFootprints(): writing checkpoint failed: %v", err)
+		}
+		fmt.Printf("%d jobs updated...\r", updated)
+	}
+
+	if err := os.Remove(FootprintCheckpointFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Warnf("RecomputeFootprints(): removing checkpoint failed: %v", err)
+	}
+
+	if errorOccured > 0 {
+		log.Warnf("Error in footprint recompute of %d jobs!", errorOccured)
+	}
+
+	log.Printf("A total of %d jobs updated in %.3f seconds", updated, time.Since(starttime).Seconds())
+	return nil
+}
+
+func readFootprintCheckpoint(cluster string) int64 {
+	b, err := os.ReadFile(FootprintCheckpointFile)
+	if err != nil {
+		return 0
+	}
+
+	fields := strings.Fields(string(b))
+	if len(fields) != 2 || fields[0] != fmt.Sprintf("%q", cluster) {
+		log.Warnf("Ignoring footprint checkpoint of another cluster: %s", string(b))
+		return 0
+	}
+
+	id, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		log.Warnf("Ignoring invalid footprint checkpoint: %v", err)
+		return 0
+	}
+	return id
+}
+
+func writeFootprintCheckpoint(cluster string, lastId int64) error {
+	return os.WriteFile(FootprintCheckpointFile, []byte(fmt.Sprintf("%q %d\n", cluster, lastId)), 0644)
+}
@@ -0,0 +1,98 @@
+// Copyright (C) 2023 NHR@FAU, University Erlangen-Nuremberg.
+// All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ClusterCockpit/cc-backend/internal/util"
+	"github.com/ClusterCockpit/cc-backend/pkg/archive"
+	"github.com/ClusterCockpit/cc-backend/pkg/schema"
+)
+
+func TestRecomputeFootprints(t *testing.T) {
+	r := setup(t)
+
+	tmpdir := t.TempDir()
+	jobarchive := filepath.Join(tmpdir, "job-archive")
+	if err := util.CopyDir("../../pkg/archive/testdata/archive/", jobarchive); err != nil {
+		t.Fatal(err)
+	}
+	clusters := archive.Clusters
+	if err := archive.Init(json.RawMessage(fmt.Sprintf(`{"kind": "file", "path": "%s"}`, jobarchive)), false); err != nil {
+		t.Fatal(err)
+	}
+
+	checkpoint := FootprintCheckpointFile
+	FootprintCheckpointFile = filepath.Join(tmpdir, "footprint-recompute.txt")
+
+	t.Cleanup(func() {
+		archive.Clusters = clusters
+		FootprintCheckpointFile = checkpoint
+		if _, err := r.DB.Exec(`DELETE FROM job WHERE cluster = 'emmy'`); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	// Import the archived jobs of emmy into the database.
+	var ndjson bytes.Buffer
+	for _, dir := range []string{"1403/244/1608923076", "1404/397/1609300556"} {
+		raw, err := os.ReadFile(filepath.Join(jobarchive, "emmy", dir, "meta.json"))
+		noErr(t, err)
+		noErr(t, json.Compact(&ndjson, raw))
+		ndjson.WriteByte('\n')
+	}
+	imported, _, err := r.ImportNDJSON(&ndjson)
+	noErr(t, err)
+	if imported != 2 {
+		t.Fatalf("expected 2 imported jobs, got %d", imported)
+	}
+
+	// Corrupt the footprint columns.
+	_, err = r.DB.Exec(`UPDATE job SET flops_any_avg = -1, mem_bw_avg = -1, monitoring_status = ? WHERE cluster = 'emmy'`,
+		schema.MonitoringStatusArchivingSuccessful)
+	noErr(t, err)
+
+	noErr(t, r.RecomputeFootprints("emmy"))
+
+	jobid, cluster := int64(1403244), "emmy"
+	job, err := r.Find(context.Background(), &jobid, &cluster, nil)
+	noErr(t, err)
+	stats, err := archive.GetStatistics(job)
+	noErr(t, err)
+
+	if job.FlopsAnyAvg != stats["flops_any"].Avg || job.MemBwAvg != stats["mem_bw"].Avg {
+		t.Errorf("footprint not restored: flops_any_avg=%f (want %f), mem_bw_avg=%f (want %f)",
+			job.FlopsAnyAvg, stats["flops_any"].Avg, job.MemBwAvg, stats["mem_bw"].Avg)
+	}
+
+	if util.CheckFileExists(FootprintCheckpointFile) {
+		t.Error("checkpoint not removed after a complete run")
+	}
+}
+
+func TestRecomputeFootprintsCheckpoint(t *testing.T) {
+	checkpoint := FootprintCheckpointFile
+	FootprintCheckpointFile = filepath.Join(t.TempDir(), "footprint-recompute.txt")
+	t.Cleanup(func() { FootprintCheckpointFile = checkpoint })
+
+	if id := readFootprintCheckpoint("emmy"); id != 0 {
+		t.Fatalf("expected no checkpoint, got %d", id)
+	}
+
+	noErr(t, writeFootprintCheckpoint("emmy", 42))
+	if id := readFootprintCheckpoint("emmy"); id != 42 {
+		t.Errorf("expected checkpoint 42, got %d", id)
+	}
+	if id := readFootprintCheckpoint("fritz"); id != 0 {
+		t.Errorf("checkpoint of another cluster used: %d", id)
+	}
+}
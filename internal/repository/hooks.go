@@ -6,8 +6,10 @@ package repository
 
 import (
 	"context"
+	"strings"
 	"time"
 
+	"github.com/ClusterCockpit/cc-backend/internal/metrics"
 	"github.com/ClusterCockpit/cc-backend/pkg/log"
 )
 
@@ -20,9 +22,20 @@ func (h *Hooks) Before(ctx context.Context, query string, args ...interface{}) (
 	return context.WithValue(ctx, "begin", time.Now()), nil
 }
 
-// After hook will get the timestamp registered on the Before hook and print the elapsed time
+// After hook will get the timestamp registered on the Before hook, print the elapsed time
+// and record it in the query duration metric
 func (h *Hooks) After(ctx context.Context, query string, args ...interface{}) (context.Context, error) {
 	begin := ctx.Value("begin").(time.Time)
 	log.Debugf("Took: %s\n", time.Since(begin))
+	metrics.DBQueryDuration.WithLabelValues(statementType(query)).Observe(time.Since(begin).Seconds())
 	return ctx, nil
 }
+
+// statementType returns the first keyword of query, e.g. SELECT. The query
+// text itself would create a new metric series for every filter combination.
+func statementType(query string) string {
+	if fields := strings.Fields(query); len(fields) > 0 {
+		return strings.ToUpper(fields[0])
+	}
+	return "UNKNOWN"
+}
@@ -5,23 +5,32 @@
 package repository
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"math"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/ClusterCockpit/cc-backend/internal/config"
 	"github.com/ClusterCockpit/cc-backend/internal/graph/model"
 	"github.com/ClusterCockpit/cc-backend/internal/metricdata"
+	"github.com/ClusterCockpit/cc-backend/internal/metrics"
+	"github.com/ClusterCockpit/cc-backend/internal/tracing"
 	"github.com/ClusterCockpit/cc-backend/pkg/archive"
 	"github.com/ClusterCockpit/cc-backend/pkg/log"
 	"github.com/ClusterCockpit/cc-backend/pkg/lrucache"
 	"github.com/ClusterCockpit/cc-backend/pkg/schema"
 	sq "github.com/Masterminds/squirrel"
 	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 var (
@@ -33,25 +42,57 @@ type JobRepository struct {
 	DB             *sqlx.DB
 	stmtCache      *sq.StmtCache
 	cache          *lrucache.Cache
-	archiveChannel chan *schema.Job
+	archiveChannel chan *ArchivingTask
 	driver         string
 	archivePending sync.WaitGroup
+
+	archivingsLock sync.Mutex
+	archivings     map[int64]*ArchivingTask
+
+	webhookChannel  chan *webhookRequest
+	webhooksPending sync.WaitGroup
+
+	subscribersLock  sync.Mutex
+	stateSubscribers map[chan *schema.Job]struct{}
+
+	// Set for the repositories passed to the function of WithTx.
+	tx       *sqlx.Tx
+	txParent *JobRepository
+	txHooks  []func()
 }
 
 func GetJobRepository() *JobRepository {
 	jobRepoOnce.Do(func() {
 		db := GetConnection()
 
+		workers, queueSize := config.Keys.ArchiveWorkers, config.Keys.ArchiveQueueSize
+		if workers < 1 {
+			workers = 1
+		}
+		if queueSize < 1 {
+			queueSize = 128
+		}
+		webhookWorkers := config.Keys.WebhookWorkers
+		if webhookWorkers < 1 {
+			webhookWorkers = 1
+		}
+
 		jobRepoInstance = &JobRepository{
 			DB:     db.DB,
 			driver: db.Driver,
 
 			stmtCache:      sq.NewStmtCache(db.DB),
 			cache:          lrucache.New(1024 * 1024),
-			archiveChannel: make(chan *schema.Job, 128),
+			archiveChannel: make(chan *ArchivingTask, queueSize),
+			webhookChannel: make(chan *webhookRequest, webhookQueueSize),
+		}
+		// start archiving workers
+		for i := 0; i < workers; i++ {
+			go jobRepoInstance.archivingWorker()
+		}
+		for i := 0; i < webhookWorkers; i++ {
+			go jobRepoInstance.webhookWorker()
 		}
-		// start archiving worker
-		go jobRepoInstance.archivingWorker()
 	})
 	return jobRepoInstance
 }
@@ -60,6 +101,7 @@ var jobColumns []string = []string{
 	"job.id", "job.job_id", "job.user", "job.project", "job.cluster", "job.subcluster", "job.start_time", "job.partition", "job.array_job_id",
 	"job.num_nodes", "job.num_hwthreads", "job.num_acc", "job.exclusive", "job.monitoring_status", "job.smt", "job.job_state",
 	"job.duration", "job.walltime", "job.resources", "job.mem_used_max", "job.flops_any_avg", "job.mem_bw_avg", "job.load_avg", // "job.meta_data",
+	"job.data_completeness",
 }
 
 func scanJob(row interface{ Scan(...interface{}) error }) (*schema.Job, error) {
@@ -67,7 +109,8 @@ func scanJob(row interface{ Scan(...interface{}) error }) (*schema.Job, error) {
 	if err := row.Scan(
 		&job.ID, &job.JobID, &job.User, &job.Project, &job.Cluster, &job.SubCluster, &job.StartTimeUnix, &job.Partition, &job.ArrayJobId,
 		&job.NumNodes, &job.NumHWThreads, &job.NumAcc, &job.Exclusive, &job.MonitoringStatus, &job.SMT, &job.State,
-		&job.Duration, &job.Walltime, &job.RawResources, &job.MemUsedMax, &job.FlopsAnyAvg, &job.MemBwAvg, &job.LoadAvg /*&job.RawMetaData*/); err != nil {
+		&job.Duration, &job.Walltime, &job.RawResources, &job.MemUsedMax, &job.FlopsAnyAvg, &job.MemBwAvg, &job.LoadAvg, /*&job.RawMetaData*/
+		&job.DataCompleteness); err != nil {
 		log.Warnf("Error while scanning rows (Job): %v", err)
 		return nil, err
 	}
@@ -105,6 +148,98 @@ func (r *JobRepository) Optimize() error {
 	return nil
 }
 
+// JobsDbIndexes are the indexes of the job table as created by the
+// migrations. Reindex can drop and recreate them, e.g. after a bulk import.
+var JobsDbIndexes = []struct {
+	Name    string
+	Columns string
+}{
+	{"job_stats", "cluster, subcluster, user"},
+	{"job_by_user", "user"},
+	{"job_by_starttime", "start_time"},
+	{"job_by_job_id", "job_id, cluster, start_time"},
+	{"job_list", "cluster, job_state"},
+	{"job_list_user", "user, cluster, job_state"},
+	{"job_list_users", "user, job_state"},
+	{"job_list_users_start", "start_time, user, job_state"},
+}
+
+// ReindexTimings are the durations of the steps of Reindex. Recreate is zero
+// if the indexes were not recreated.
+type ReindexTimings struct {
+	Recreate time.Duration
+	Reindex  time.Duration
+	Analyze  time.Duration
+}
+
+// Reindex rebuilds all indexes and updates the statistics used by the query
+// planner. If recreate is set, the JobsDbIndexes are dropped and created
+// again first. For mysql, the tables are rebuilt with OPTIMIZE TABLE instead.
+func (r *JobRepository) Reindex(recreate bool) (ReindexTimings, error) {
+	var timings ReindexTimings
+	var reindex, analyze string
+
+	switch r.driver {
+	case "sqlite3":
+		reindex, analyze = `REINDEX`, `ANALYZE`
+	case "mysql":
+		reindex, analyze = `OPTIMIZE TABLE job, tag, jobtag`, `ANALYZE TABLE job, tag, jobtag`
+	default:
+		return timings, fmt.Errorf("reindex not supported for driver %s", r.driver)
+	}
+
+	if recreate {
+		start := time.Now()
+		for _, idx := range JobsDbIndexes {
+			if err := r.dropIndex(idx.Name); err != nil {
+				log.Warnf("Error while dropping index %s", idx.Name)
+				return timings, err
+			}
+			if _, err := r.DB.Exec(fmt.Sprintf(`CREATE INDEX %s ON job (%s)`, idx.Name, idx.Columns)); err != nil {
+				log.Warnf("Error while creating index %s", idx.Name)
+				return timings, err
+			}
+		}
+		timings.Recreate = time.Since(start)
+	}
+
+	start := time.Now()
+	if _, err := r.DB.Exec(reindex); err != nil {
+		return timings, err
+	}
+	timings.Reindex = time.Since(start)
+
+	start = time.Now()
+	if _, err := r.DB.Exec(analyze); err != nil {
+		return timings, err
+	}
+	timings.Analyze = time.Since(start)
+
+	return timings, nil
+}
+
+// dropIndex drops the index name of the job table if it exists.
+func (r *JobRepository) dropIndex(name string) error {
+	if r.driver == "sqlite3" {
+		_, err := r.DB.Exec(fmt.Sprintf(`DROP INDEX IF EXISTS %s`, name))
+		return err
+	}
+
+	// MySQL only knows DROP INDEX ... ON table without IF EXISTS.
+	var cnt int
+	if err := sq.Select("COUNT(*)").From("information_schema.statistics").
+		Where("table_schema = DATABASE()").Where("table_name = ?", "job").Where("index_name = ?", name).
+		RunWith(r.DB).QueryRow().Scan(&cnt); err != nil {
+		return err
+	}
+	if cnt == 0 {
+		return nil
+	}
+
+	_, err := r.DB.Exec(fmt.Sprintf(`DROP INDEX %s ON job`, name))
+	return err
+}
+
 func (r *JobRepository) Flush() error {
 	var err error
 
@@ -180,6 +315,12 @@ func (r *JobRepository) FetchMetadata(job *schema.Job) (map[string]string, error
 }
 
 func (r *JobRepository) UpdateMetadata(job *schema.Job, key, val string) (err error) {
+	return r.MergeMetadata(job, map[string]string{key: val})
+}
+
+// MergeMetadata sets the keys of metadata in the metadata of job. Existing
+// keys not in metadata are kept.
+func (r *JobRepository) MergeMetadata(job *schema.Job, metadata map[string]string) (err error) {
 	cachekey := fmt.Sprintf("metadata:%d", job.ID)
 	r.cache.Del(cachekey)
 	if job.MetaData == nil {
@@ -189,16 +330,14 @@ func (r *JobRepository) UpdateMetadata(job *schema.Job, key, val string) (err er
 		}
 	}
 
-	if job.MetaData != nil {
-		cpy := make(map[string]string, len(job.MetaData)+1)
-		for k, v := range job.MetaData {
-			cpy[k] = v
-		}
-		cpy[key] = val
-		job.MetaData = cpy
-	} else {
-		job.MetaData = map[string]string{key: val}
+	cpy := make(map[string]string, len(job.MetaData)+len(metadata))
+	for k, v := range job.MetaData {
+		cpy[k] = v
 	}
+	for k, v := range metadata {
+		cpy[k] = v
+	}
+	job.MetaData = cpy
 
 	if job.RawMetaData, err = json.Marshal(job.MetaData); err != nil {
 		log.Warnf("Error while marshaling metadata for job, DB ID '%v'", job.ID)
@@ -220,10 +359,18 @@ func (r *JobRepository) UpdateMetadata(job *schema.Job, key, val string) (err er
 // It returns a pointer to a schema.Job data structure and an error variable.
 // To check if no job was found test err == sql.ErrNoRows
 func (r *JobRepository) Find(
+	ctx context.Context,
 	jobId *int64,
 	cluster *string,
 	startTime *int64,
-) (*schema.Job, error) {
+) (job *schema.Job, err error) {
+	_, span := tracing.Start(ctx, "JobRepository.Find",
+		attribute.Int64("job.jobId", *jobId))
+	defer func() {
+		tracing.SetError(span, err)
+		span.End()
+	}()
+
 	start := time.Now()
 	q := sq.Select(jobColumns...).From("job").
 		Where("job.job_id = ?", *jobId)
@@ -289,6 +436,39 @@ func (r *JobRepository) FindById(jobId int64) (*schema.Job, error) {
 	return scanJob(q.RunWith(r.stmtCache).QueryRow())
 }
 
+// FindByArrayJobId executes a SQL query to find all tasks of an array job
+// on the given cluster, ordered by their job id. Only tasks visible to the
+// user in ctx are returned.
+func (r *JobRepository) FindByArrayJobId(ctx context.Context, arrayJobId int64, cluster string) ([]*schema.Job, error) {
+	start := time.Now()
+	q, qerr := SecurityCheck(ctx, sq.Select(jobColumns...).From("job"))
+	if qerr != nil {
+		return nil, qerr
+	}
+	q = q.Where("job.array_job_id = ?", arrayJobId).
+		Where("job.cluster = ?", cluster).
+		OrderBy("job.job_id ASC")
+
+	rows, err := q.RunWith(r.stmtCache).Query()
+	if err != nil {
+		log.Error("Error while running query")
+		return nil, err
+	}
+	defer rows.Close()
+
+	jobs := make([]*schema.Job, 0, 10)
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			log.Warn("Error while scanning rows")
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	log.Debugf("Timer FindByArrayJobId %s", time.Since(start))
+	return jobs, nil
+}
+
 func (r *JobRepository) FindConcurrentJobs(
 	ctx context.Context,
 	job *schema.Job,
@@ -388,9 +568,68 @@ func (r *JobRepository) FindConcurrentJobs(
 	}, nil
 }
 
+// FindSimilar returns up to limit finished jobs of the same user, project and
+// cluster with a comparable number of nodes and, if the job has a flops_any
+// average, a similar one. Jobs with the closest flops_any average come first.
+// Only jobs visible to the user in ctx are returned.
+func (r *JobRepository) FindSimilar(ctx context.Context, job *schema.Job, limit int) ([]*schema.Job, error) {
+	query, qerr := SecurityCheck(ctx, sq.Select(jobColumns...).From("job"))
+	if qerr != nil {
+		return nil, qerr
+	}
+
+	factor := config.Keys.SimilarJobsNumNodesFactor
+	if factor < 1 {
+		factor = 1
+	}
+	query = query.Where("job.id != ?", job.ID).
+		Where("job.user = ?", job.User).
+		Where("job.project = ?", job.Project).
+		Where("job.cluster = ?", job.Cluster).
+		Where("job.job_state != ?", schema.JobStateRunning).
+		Where("job.num_nodes BETWEEN ? AND ?",
+			int(math.Ceil(float64(job.NumNodes)/factor)), int(math.Floor(float64(job.NumNodes)*factor)))
+
+	if job.FlopsAnyAvg > 0 {
+		band := job.FlopsAnyAvg * config.Keys.SimilarJobsFlopsBand
+		query = query.Where("job.flops_any_avg BETWEEN ? AND ?", job.FlopsAnyAvg-band, job.FlopsAnyAvg+band).
+			OrderByClause("ABS(job.flops_any_avg - ?)", job.FlopsAnyAvg)
+	}
+	query = query.OrderBy("job.start_time DESC")
+	if limit > 0 {
+		query = query.Limit(uint64(limit))
+	}
+
+	rows, err := query.RunWith(r.stmtCache).Query()
+	if err != nil {
+		log.Errorf("Error while running query: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	jobs := make([]*schema.Job, 0)
+	for rows.Next() {
+		j, err := scanJob(rows)
+		if err != nil {
+			log.Warn("Error while scanning rows")
+			return nil, err
+		}
+		jobs = append(jobs, j)
+	}
+
+	return jobs, nil
+}
+
 // Start inserts a new job in the table, returning the unique job ID.
 // Statistics are not transfered!
-func (r *JobRepository) Start(job *schema.JobMeta) (id int64, err error) {
+func (r *JobRepository) Start(ctx context.Context, job *schema.JobMeta) (id int64, err error) {
+	_, span := tracing.Start(ctx, "JobRepository.Start",
+		attribute.Int64("job.jobId", job.JobID), attribute.String("job.cluster", job.Cluster))
+	defer func() {
+		tracing.SetError(span, err)
+		span.End()
+	}()
+
 	job.RawResources, err = json.Marshal(job.Resources)
 	if err != nil {
 		return -1, fmt.Errorf("REPOSITORY/JOB > encoding resources field failed: %w", err)
@@ -401,7 +640,7 @@ func (r *JobRepository) Start(job *schema.JobMeta) (id int64, err error) {
 		return -1, fmt.Errorf("REPOSITORY/JOB > encoding metaData field failed: %w", err)
 	}
 
-	res, err := r.DB.NamedExec(`INSERT INTO job (
+	res, err := r.namedExec(`INSERT INTO job (
 		job_id, user, project, cluster, subcluster, `+"`partition`"+`, array_job_id, num_nodes, num_hwthreads, num_acc,
 		exclusive, monitoring_status, smt, job_state, start_time, duration, walltime, resources, meta_data
 	) VALUES (
@@ -412,43 +651,184 @@ func (r *JobRepository) Start(job *schema.JobMeta) (id int64, err error) {
 		return -1, err
 	}
 
-	return res.LastInsertId()
+	if id, err = res.LastInsertId(); err != nil {
+		return -1, err
+	}
+
+	base, started := r.base(), &schema.Job{ID: id, BaseJob: job.BaseJob, StartTime: time.Unix(job.StartTime, 0)}
+	r.onCommit(func() {
+		base.publishJobState(id)
+		base.notifyWebhooks(WebhookEventStart, started, nil)
+	})
+	return id, nil
+}
+
+// StateTransitionError is returned by Stop if the job can not change from
+// its current state to the requested one.
+type StateTransitionError struct {
+	JobId int64
+	From  schema.JobState
+	To    schema.JobState
+}
+
+func (e *StateTransitionError) Error() string {
+	return fmt.Sprintf("job (dbid: %d): invalid state transition from '%s' to '%s', only running jobs can be stopped",
+		e.JobId, e.From, e.To)
 }
 
 // Stop updates the job with the database id jobId using the provided arguments.
+// Only running jobs can be stopped and the new state must not be running,
+// otherwise a *StateTransitionError is returned and the job is left unchanged.
+// A negative duration is stored as 0 and the job marked as failed to archive.
+// Subscribers are notified and jobs that are not archived are sent to the
+// webhooks once the update is committed (see WithTx), all others after
+// archiving.
 func (r *JobRepository) Stop(
+	ctx context.Context,
 	jobId int64,
 	duration int32,
 	state schema.JobState,
 	monitoringStatus int32,
 ) (err error) {
+	_, span := tracing.Start(ctx, "JobRepository.Stop",
+		attribute.Int64("job.id", jobId), attribute.String("job.state", string(state)))
+	defer func() {
+		tracing.SetError(span, err)
+		span.End()
+	}()
+
+	if state == schema.JobStateRunning {
+		return &StateTransitionError{JobId: jobId, From: schema.JobStateRunning, To: state}
+	}
+	if duration < 0 {
+		log.Warnf("negative duration %d of job (dbid: %d) set to 0", duration, jobId)
+		duration = 0
+		if monitoringStatus != schema.MonitoringStatusDisabled {
+			monitoringStatus = schema.MonitoringStatusArchivingFailed
+		}
+	}
+
 	stmt := sq.Update("job").
 		Set("job_state", state).
 		Set("duration", duration).
 		Set("monitoring_status", monitoringStatus).
-		Where("job.id = ?", jobId)
+		Where("job.id = ?", jobId).
+		Where("job.job_state = ?", schema.JobStateRunning)
 
-	_, err = stmt.RunWith(r.stmtCache).Exec()
-	return
+	res, err := stmt.RunWith(r.stmtCache).Exec()
+	if err != nil {
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n != 0 {
+		base := r.base()
+		r.onCommit(func() {
+			base.publishJobState(jobId)
+			if len(webhooks) != 0 && (monitoringStatus == schema.MonitoringStatusDisabled ||
+				monitoringStatus == schema.MonitoringStatusArchivingFailed) {
+				if job, err := base.FindById(jobId); err == nil {
+					base.notifyWebhooks(WebhookEventStop, job, nil)
+				} else {
+					log.Warnf("loading job (dbid: %d) for webhooks failed: %s", jobId, err.Error())
+				}
+			}
+		})
+		return nil
+	}
+
+	// Nothing updated: The job either does not exist or is not running.
+	var current schema.JobState
+	if err := sq.Select("job.job_state").From("job").Where("job.id = ?", jobId).
+		RunWith(r.stmtCache).QueryRow().Scan(&current); err != nil {
+		return err
+	}
+	return &StateTransitionError{JobId: jobId, From: current, To: state}
 }
 
-func (r *JobRepository) DeleteJobsBefore(startTime int64) (int, error) {
-	var cnt int
-	q := sq.Select("count(*)").From("job").Where("job.start_time < ?", startTime)
-	q.RunWith(r.DB).QueryRow().Scan(cnt)
+// Resume marks the stopped job with the database id jobId as running again.
+// The duration and the monitoring status are reset to their initial values.
+func (r *JobRepository) Resume(jobId int64) (err error) {
+	stmt := sq.Update("job").
+		Set("job_state", schema.JobStateRunning).
+		Set("duration", 0).
+		Set("monitoring_status", schema.MonitoringStatusRunningOrArchiving).
+		Where("job.id = ?", jobId).
+		Where("job.job_state != 'running'")
+
+	res, err := stmt.RunWith(r.stmtCache).Exec()
+	if err != nil {
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n != 0 {
+		base := r.base()
+		r.onCommit(func() { base.publishJobState(jobId) })
+	}
+	return nil
+}
+
+// DeleteJobsBefore deletes all jobs started before startTime and returns
+// their database ids. With dryRun set, the ids are returned but nothing is
+// deleted.
+func (r *JobRepository) DeleteJobsBefore(startTime int64, dryRun bool) ([]int64, error) {
+	ids := make([]int64, 0)
+	q := sq.Select("job.id").From("job").Where("job.start_time < ?", startTime)
+	rows, err := q.RunWith(r.DB).Query()
+	if err != nil {
+		log.Errorf("DeleteJobsBefore(%d): error while querying job ids: %v", startTime, err)
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			log.Warn("Error while scanning rows")
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	if dryRun {
+		log.Infof("DeleteJobsBefore(%d): Dry run, would delete %d jobs", startTime, len(ids))
+		return ids, nil
+	}
+
 	qd := sq.Delete("job").Where("job.start_time < ?", startTime)
-	_, err := qd.RunWith(r.DB).Exec()
+	_, err = qd.RunWith(r.DB).Exec()
 
 	if err != nil {
 		s, _, _ := qd.ToSql()
 		log.Errorf(" DeleteJobsBefore(%d) with %s: error %#v", startTime, s, err)
 	} else {
-		log.Debugf("DeleteJobsBefore(%d): Deleted %d jobs", startTime, cnt)
+		// SQLite reuses the ids of deleted jobs.
+		for _, id := range ids {
+			r.cache.Del(fmt.Sprintf("metadata:%d", id))
+		}
+		log.Debugf("DeleteJobsBefore(%d): Deleted %d jobs", startTime, len(ids))
 	}
-	return cnt, err
+	return ids, err
 }
 
-func (r *JobRepository) DeleteJobById(id int64) error {
+// DeleteJobById deletes the job with the given database id. With dryRun set,
+// it only checks that the job exists and returns sql.ErrNoRows otherwise.
+func (r *JobRepository) DeleteJobById(id int64, dryRun bool) error {
+	if dryRun {
+		var cnt int
+		if err := sq.Select("count(*)").From("job").Where("job.id = ?", id).
+			RunWith(r.DB).QueryRow().Scan(&cnt); err != nil {
+			return err
+		}
+		if cnt == 0 {
+			return sql.ErrNoRows
+		}
+		log.Infof("DeleteJobById(%d): Dry run, would delete job", id)
+		return nil
+	}
+
 	qd := sq.Delete("job").Where("job.id = ?", id)
 	_, err := qd.RunWith(r.DB).Exec()
 
@@ -456,6 +836,7 @@ func (r *JobRepository) DeleteJobById(id int64) error {
 		s, _, _ := qd.ToSql()
 		log.Errorf("DeleteJobById(%d) with %s : error %#v", id, s, err)
 	} else {
+		r.cache.Del(fmt.Sprintf("metadata:%d", id))
 		log.Debugf("DeleteJobById(%d): Success", id)
 	}
 	return err
@@ -470,86 +851,356 @@ func (r *JobRepository) UpdateMonitoringStatus(job int64, monitoringStatus int32
 	return
 }
 
-// Stop updates the job with the database id jobId using the provided arguments.
+// UpdateOwnership corrects the user and project of the job with database ID
+// id, e.g. if the scheduler reported the wrong ones. Empty values are kept.
+// The meta.json of archived jobs is updated first, the database is only
+// changed if that succeeds. Callers have to make sure that only admins change
+// the ownership of jobs.
+func (r *JobRepository) UpdateOwnership(id int64, user, project string) error {
+	if user == "" && project == "" {
+		return errors.New("REPOSITORY/JOB > user or project required")
+	}
+
+	job, err := r.FindById(id)
+	if err != nil {
+		return err
+	}
+
+	oldUser, oldProject := job.User, job.Project
+	stmt := sq.Update("job").Where("job.id = ?", id)
+	if user != "" {
+		stmt, job.User = stmt.Set("user", user), user
+	}
+	if project != "" {
+		stmt, job.Project = stmt.Set("project", project), project
+	}
+	if err := archive.UpdateOwnership(job); err != nil {
+		log.Warnf("Error while updating ownership of archived job, DB ID '%v'", id)
+		return err
+	}
+
+	if _, err := stmt.RunWith(r.stmtCache).Exec(); err != nil {
+		log.Warnf("Error while updating ownership of job, DB ID '%v'", id)
+		job.User, job.Project = oldUser, oldProject
+		if err := archive.UpdateOwnership(job); err != nil {
+			log.Errorf("restoring ownership of archived job (dbid: %d) failed: %s", id, err.Error())
+		}
+		return err
+	}
+
+	log.Infof("ownership of job (dbid: %d) changed: user=%s, project=%s", id, job.User, job.Project)
+	return nil
+}
+
+// FindByMonitoringStatus returns all jobs visible to the user in ctx with the
+// given monitoring status, ordered by start time. If cluster is not empty,
+// only jobs of that cluster are returned.
+func (r *JobRepository) FindByMonitoringStatus(
+	ctx context.Context,
+	monitoringStatus int32,
+	cluster string) ([]*schema.Job, error) {
+
+	query := sq.Select(jobColumns...).From("job").
+		Where("job.monitoring_status = ?", monitoringStatus).
+		OrderBy("job.start_time ASC")
+	if cluster != "" {
+		query = query.Where("job.cluster = ?", cluster)
+	}
+	query, err := SecurityCheck(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := query.RunWith(r.stmtCache).Query()
+	if err != nil {
+		log.Error("Error while running query")
+		return nil, err
+	}
+	defer rows.Close()
+
+	jobs := make([]*schema.Job, 0, 10)
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			log.Warn("Error while scanning rows")
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// SearchMetadata returns all jobs visible to the user in ctx whose metadata
+// (e.g. the jobScript) contains term, ignoring case, ordered by descending
+// start time. The raw JSON text of the metadata is searched, so term is
+// JSON-encoded first to also find snippets with newlines or quotes. There is
+// no index on the metadata, every search scans the whole job table.
+func (r *JobRepository) SearchMetadata(ctx context.Context, term string) ([]*schema.Job, error) {
+	encoded, err := json.Marshal(strings.ToLower(term))
+	if err != nil {
+		return nil, err
+	}
+	pattern := strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").
+		Replace(string(encoded[1 : len(encoded)-1]))
+
+	query, err := SecurityCheck(ctx, sq.Select(jobColumns...).From("job").
+		Where("LOWER(job.meta_data) LIKE ? ESCAPE '!'", "%"+pattern+"%").
+		OrderBy("job.start_time DESC"))
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := query.RunWith(r.stmtCache).Query()
+	if err != nil {
+		log.Error("Error while running query")
+		return nil, err
+	}
+	defer rows.Close()
+
+	jobs := make([]*schema.Job, 0, 10)
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			log.Warn("Error while scanning rows")
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// JobSpan is the part of a job needed to draw it in a timeline (gantt chart)
+// of a cluster. The duration of running jobs is the time since their start.
+type JobSpan struct {
+	ID        int64
+	JobID     int64
+	Hostnames []string
+	StartTime int64
+	Duration  int64
+	State     schema.JobState
+}
+
+// Timeline returns the jobs visible to the user in ctx of cluster that
+// overlap the window [from, to), ordered by start time. Only the columns
+// needed for a timeline are loaded.
+func (r *JobRepository) Timeline(ctx context.Context, cluster string, from, to int64) ([]JobSpan, error) {
+	start := time.Now()
+	// The current time is passed as parameter, so that the statement cache
+	// does not get a new statement every second.
+	duration := `(CASE WHEN job.job_state = 'running' THEN ? - job.start_time ELSE job.duration END)`
+
+	query, err := SecurityCheck(ctx, sq.Select("job.id", "job.job_id", "job.resources", "job.start_time").
+		Column(duration, start.Unix()).
+		Column("job.job_state").
+		From("job").
+		Where("job.cluster = ?", cluster).
+		Where("job.start_time < ?", to).
+		Where("job.start_time + "+duration+" > ?", start.Unix(), from).
+		OrderBy("job.start_time ASC", "job.id ASC"))
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := query.RunWith(r.stmtCache).Query()
+	if err != nil {
+		log.Error("Error while running query")
+		return nil, err
+	}
+	defer rows.Close()
+
+	spans := make([]JobSpan, 0, 10)
+	for rows.Next() {
+		var span JobSpan
+		var raw []byte
+		if err := rows.Scan(&span.ID, &span.JobID, &raw, &span.StartTime, &span.Duration, &span.State); err != nil {
+			log.Warn("Error while scanning rows")
+			return nil, err
+		}
+
+		var resources []*schema.Resource
+		if err := json.Unmarshal(raw, &resources); err != nil {
+			log.Warn("Error while unmarshaling raw resources json")
+			return nil, err
+		}
+		span.Hostnames = make([]string, 0, len(resources))
+		for _, resource := range resources {
+			span.Hostnames = append(span.Hostnames, resource.Hostname)
+		}
+		spans = append(spans, span)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	log.Debugf("Timer Timeline %s", time.Since(start))
+	return spans, nil
+}
+
+// footprintColumns maps metric names to the job table column holding the
+// footprint value and the statistic stored there. Metrics not listed here
+// are kept in the job metadata under the "statistics" key.
+var footprintColumns = map[string]struct {
+	column string
+	value  func(stats schema.JobStatistics) float64
+}{
+	"flops_any":           {"flops_any_avg", statsAvg},
+	"mem_used":            {"mem_used_max", statsMax},
+	"mem_bw":              {"mem_bw_avg", statsAvg},
+	"load":                {"load_avg", statsAvg},
+	"cpu_load":            {"load_avg", statsAvg},
+	"net_bw":              {"net_bw_avg", statsAvg},
+	"net_data_vol_total":  {"net_data_vol_total", statsMax},
+	"file_bw":             {"file_bw_avg", statsAvg},
+	"file_data_vol_total": {"file_data_vol_total", statsMax},
+}
+
+func statsAvg(stats schema.JobStatistics) float64 { return stats.Avg }
+func statsMax(stats schema.JobStatistics) float64 { return stats.Max }
+
+// MarkArchived sets the monitoring status and the footprint of the job with
+// the database id jobId. The data completeness is only set if not nil.
 func (r *JobRepository) MarkArchived(
 	jobId int64,
 	monitoringStatus int32,
 	metricStats map[string]schema.JobStatistics,
+	dataCompleteness *float64,
 ) error {
 	stmt := sq.Update("job").
 		Set("monitoring_status", monitoringStatus).
 		Where("job.id = ?", jobId)
+	if dataCompleteness != nil {
+		stmt = stmt.Set("data_completeness", *dataCompleteness)
+	}
 
+	unknown := make(map[string]schema.JobStatistics)
 	for metric, stats := range metricStats {
-		switch metric {
-		case "flops_any":
-			stmt = stmt.Set("flops_any_avg", stats.Avg)
-		case "mem_used":
-			stmt = stmt.Set("mem_used_max", stats.Max)
-		case "mem_bw":
-			stmt = stmt.Set("mem_bw_avg", stats.Avg)
-		case "load":
-			stmt = stmt.Set("load_avg", stats.Avg)
-		case "cpu_load":
-			stmt = stmt.Set("load_avg", stats.Avg)
-		case "net_bw":
-			stmt = stmt.Set("net_bw_avg", stats.Avg)
-		case "file_bw":
-			stmt = stmt.Set("file_bw_avg", stats.Avg)
-		default:
-			log.Debugf("MarkArchived() Metric '%v' unknown", metric)
+		if fc, ok := footprintColumns[metric]; ok {
+			stmt = stmt.Set(fc.column, fc.value(stats))
+		} else {
+			log.Debugf("MarkArchived() Metric '%v' has no footprint column", metric)
+			unknown[metric] = stats
+		}
+	}
+
+	var job *schema.Job
+	if len(unknown) != 0 {
+		job = &schema.Job{ID: jobId}
+		if _, err := r.FetchMetadata(job); err != nil {
+			log.Warnf("Error while fetching metadata for job, DB ID '%v'", jobId)
+			return err
 		}
+
+		rawStats, err := json.Marshal(unknown)
+		if err != nil {
+			log.Warn("Error while marshaling job statistics")
+			return err
+		}
+
+		metaData := make(map[string]string, len(job.MetaData)+1)
+		for k, v := range job.MetaData {
+			metaData[k] = v
+		}
+		metaData["statistics"] = string(rawStats)
+		job.MetaData = metaData
+
+		if job.RawMetaData, err = json.Marshal(job.MetaData); err != nil {
+			log.Warnf("Error while marshaling metadata for job, DB ID '%v'", jobId)
+			return err
+		}
+		stmt = stmt.Set("meta_data", job.RawMetaData)
 	}
 
 	if _, err := stmt.RunWith(r.stmtCache).Exec(); err != nil {
 		log.Warn("Error while marking job as archived")
 		return err
 	}
+
+	if job != nil {
+		r.cache.Put(fmt.Sprintf("metadata:%d", jobId), job.MetaData, len(job.RawMetaData), 24*time.Hour)
+	}
 	return nil
 }
 
-// Archiving worker thread
+// Archiving worker thread, config.Keys.ArchiveWorkers of them share the
+// archive channel. The webhooks are notified after every archiving attempt.
 func (r *JobRepository) archivingWorker() {
-	for {
-		select {
-		case job, ok := <-r.archiveChannel:
-			if !ok {
-				break
-			}
-			start := time.Now()
-			// not using meta data, called to load JobMeta into Cache?
-			// will fail if job meta not in repository
-			if _, err := r.FetchMetadata(job); err != nil {
-				log.Errorf("archiving job (dbid: %d) failed: %s", job.ID, err.Error())
-				r.UpdateMonitoringStatus(job.ID, schema.MonitoringStatusArchivingFailed)
-				continue
-			}
+	for task := range r.archiveChannel {
+		var statistics map[string]schema.JobStatistics
+		if ctx, ok := r.startArchiving(task); ok {
+			statistics = r.archiveJob(ctx, task)
+		} else {
+			log.WithFields(log.Fields{"dbid": task.ID, "job_id": task.JobID, "cluster": task.Cluster}).
+				Errorf("archiving job (dbid: %d) failed: cancelled", task.ID)
+			r.UpdateMonitoringStatus(task.ID, schema.MonitoringStatusArchivingFailed)
+		}
+		r.notifyWebhooks(WebhookEventStop, task.job, statistics)
+		r.archivingDone(task)
+	}
+}
 
-			// metricdata.ArchiveJob will fetch all the data from a MetricDataRepository and push into configured archive backend
-			// TODO: Maybe use context with cancel/timeout here
-			jobMeta, err := metricdata.ArchiveJob(job, context.Background())
-			if err != nil {
-				log.Errorf("archiving job (dbid: %d) failed: %s", job.ID, err.Error())
-				r.UpdateMonitoringStatus(job.ID, schema.MonitoringStatusArchivingFailed)
-				continue
-			}
+// archiveJob returns the statistics of the job, nil if archiving failed.
+func (r *JobRepository) archiveJob(ctx context.Context, task *ArchivingTask) map[string]schema.JobStatistics {
+	start, job := time.Now(), task.job
+	logger := log.WithFields(log.Fields{"dbid": job.ID, "job_id": job.JobID, "cluster": job.Cluster})
+	// loads the metadata into the cache (used by the tag rules below),
+	// will fail if job meta not in repository
+	metaData, err := r.FetchMetadata(job)
+	if err != nil {
+		logger.WithFields(log.Fields{"error": err}).Errorf("archiving job (dbid: %d) failed: %s", job.ID, err.Error())
+		r.UpdateMonitoringStatus(job.ID, schema.MonitoringStatusArchivingFailed)
+		return nil
+	}
 
-			// Update the jobs database entry one last time:
-			if err := r.MarkArchived(job.ID, schema.MonitoringStatusArchivingSuccessful, jobMeta.Statistics); err != nil {
-				log.Errorf("archiving job (dbid: %d) failed: %s", job.ID, err.Error())
-				continue
-			}
-			log.Debugf("archiving job %d took %s", job.JobID, time.Since(start))
-			log.Printf("archiving job (dbid: %d) successful", job.ID)
-			r.archivePending.Done()
-		}
+	// metricdata.ArchiveJob will fetch all the data from a MetricDataRepository and push into configured archive backend,
+	// ctx is cancelled by CancelArchiving
+	jobMeta, err := metricdata.ArchiveJob(job, ctx)
+	if err == nil {
+		err = ctx.Err()
+	}
+	if err != nil {
+		logger.WithFields(log.Fields{"error": err}).Errorf("archiving job (dbid: %d) failed: %s", job.ID, err.Error())
+		r.UpdateMonitoringStatus(job.ID, schema.MonitoringStatusArchivingFailed)
+		return nil
+	}
+	r.setArchivingPhase(task, ArchivingUpdating)
+
+	// Update the jobs database entry one last time:
+	if err := r.MarkArchived(job.ID, schema.MonitoringStatusArchivingSuccessful, jobMeta.Statistics, job.DataCompleteness); err != nil {
+		logger.WithFields(log.Fields{"error": err}).Errorf("archiving job (dbid: %d) failed: %s", job.ID, err.Error())
+		return nil
 	}
+
+	if err := r.ApplyTagRules(job, metaData, jobMeta.Statistics); err != nil {
+		logger.WithFields(log.Fields{"error": err}).Warnf("applying tag rules to job (dbid: %d) failed: %s", job.ID, err.Error())
+	}
+	logger = logger.WithFields(log.Fields{"duration": time.Since(start)})
+	logger.Debugf("archiving job %d took %s", job.JobID, time.Since(start))
+	logger.Printf("archiving job (dbid: %d) successful", job.ID)
+	return jobMeta.Statistics
+}
+
+// archivingDone marks an archiving operation of the worker as finished.
+func (r *JobRepository) archivingDone(task *ArchivingTask) {
+	r.removeArchiving(task)
+	metrics.ArchivingPending.Dec()
+	r.archivePending.Done()
 }
 
-// Trigger async archiving
-func (r *JobRepository) TriggerArchiving(job *schema.Job) {
+// Trigger async archiving. Blocks while the archive queue is full. The
+// operation is listed by Archivings until it is finished, then the returned
+// channel is closed. If the job is already queued or being archived, it is
+// not queued again and the channel of the existing operation is returned.
+func (r *JobRepository) TriggerArchiving(job *schema.Job) <-chan struct{} {
+	task, added := r.addArchiving(job)
+	if !added {
+		log.Infof("job (dbid: %d) is already being archived", job.ID)
+		return task.done
+	}
+
 	r.archivePending.Add(1)
-	r.archiveChannel <- job
+	metrics.ArchivingPending.Inc()
+	r.archiveChannel <- task
+	return task.done
 }
 
 // Wait for background thread to finish pending archiving operations
@@ -650,6 +1301,44 @@ func (r *JobRepository) FindColumnValues(user *schema.User, query string, table
 	}
 }
 
+// Projects returns the sorted list of projects with jobs visible to the user
+// in ctx, see SecurityCheck.
+func (r *JobRepository) Projects(ctx context.Context) ([]string, error) {
+	return r.distinctValues(ctx, "job.project")
+}
+
+// Users returns the sorted list of users with jobs visible to the user in
+// ctx, see SecurityCheck.
+func (r *JobRepository) Users(ctx context.Context) ([]string, error) {
+	return r.distinctValues(ctx, "job.user")
+}
+
+func (r *JobRepository) distinctValues(ctx context.Context, column string) ([]string, error) {
+	query, err := SecurityCheck(ctx, sq.Select(column).Distinct().From("job").OrderBy(column+" ASC"))
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := query.RunWith(r.stmtCache).Query()
+	if err != nil {
+		log.Errorf("Error while querying distinct %s: %v", column, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	values := make([]string, 0)
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			log.Warn("Error while scanning rows")
+			return nil, err
+		}
+		values = append(values, value)
+	}
+
+	return values, rows.Err()
+}
+
 func (r *JobRepository) Partitions(cluster string) ([]string, error) {
 	var err error
 	start := time.Now()
@@ -778,6 +1467,51 @@ func (r *JobRepository) FindJobsBetween(startTimeBegin int64, startTimeEnd int64
 	return jobs, nil
 }
 
+// FindJobsStartedOrStoppedBetween returns all jobs visible to the user in
+// ctx that started or stopped between from and to (UNIX epoch seconds),
+// ordered by start time. If cluster is not empty, only jobs of that cluster
+// are returned.
+func (r *JobRepository) FindJobsStartedOrStoppedBetween(
+	ctx context.Context,
+	from, to int64,
+	cluster string) ([]*schema.Job, error) {
+
+	query := sq.Select(jobColumns...).From("job").Where(sq.Or{
+		sq.Expr("job.start_time BETWEEN ? AND ?", from, to),
+		sq.And{
+			sq.NotEq{"job.job_state": schema.JobStateRunning},
+			sq.Expr("job.start_time + job.duration BETWEEN ? AND ?", from, to),
+		},
+	}).OrderBy("job.start_time ASC")
+	if cluster != "" {
+		query = query.Where("job.cluster = ?", cluster)
+	}
+
+	query, err := SecurityCheck(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := query.RunWith(r.stmtCache).Query()
+	if err != nil {
+		log.Error("Error while running query")
+		return nil, err
+	}
+	defer rows.Close()
+
+	jobs := make([]*schema.Job, 0, 50)
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			log.Warn("Error while scanning rows")
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+
+	return jobs, nil
+}
+
 const NamedJobInsert string = `INSERT INTO job (
 	job_id, user, project, cluster, subcluster, ` + "`partition`" + `, array_job_id, num_nodes, num_hwthreads, num_acc,
 	exclusive, monitoring_status, smt, job_state, start_time, duration, walltime, resources, meta_data,
@@ -802,3 +1536,138 @@ func (r *JobRepository) InsertJob(job *schema.Job) (int64, error) {
 
 	return id, nil
 }
+
+// ImportNDJSON reads one JobMeta per line from `r` and inserts the jobs in
+// batches of 100 per transaction. Empty lines are ignored. Jobs already
+// present in the database (same job_id, cluster and start_time) or with an
+// invalid state are skipped and logged with their line number (starting at 1).
+// On an error, the jobs of the current batch are rolled back, the jobs of the
+// batches committed before stay imported and are counted in imported.
+func (r *JobRepository) ImportNDJSON(rd io.Reader) (imported int, skipped int, err error) {
+	t, err := r.TransactionInit()
+	if err != nil {
+		log.Warn("Error while initializing SQL transactions")
+		return 0, 0, err
+	}
+	defer func() {
+		if err != nil {
+			if rerr := t.tx.Rollback(); rerr != nil {
+				log.Warnf("Error while rolling back SQL transaction: %v", rerr)
+			}
+		}
+	}()
+
+	tags := make(map[string]int64)
+	line, pending := 0, 0
+	skip := func(reason string) {
+		log.Infof("ImportNDJSON: skipping line %d: %s", line, reason)
+		skipped++
+	}
+
+	// A job with a lot of resources does not fit into the default buffer of
+	// 64 KiB.
+	scanner := bufio.NewScanner(rd)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		line++
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+
+		var jobMeta schema.JobMeta
+		if err = json.Unmarshal(scanner.Bytes(), &jobMeta); err != nil {
+			log.Warnf("Error while decoding line %d of NDJSON input", line)
+			return imported - pending, skipped, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		// Bundle 100 inserts into one transaction for better performance
+		if pending == 100 {
+			if err = r.TransactionCommit(t); err != nil {
+				return imported - pending, skipped, err
+			}
+			pending = 0
+		}
+
+		var cnt int
+		if err = sq.Select("count(*)").From("job").
+			Where("job.job_id = ?", jobMeta.JobID).
+			Where("job.cluster = ?", jobMeta.Cluster).
+			Where("job.start_time = ?", jobMeta.StartTime).
+			RunWith(t.tx).QueryRow().Scan(&cnt); err != nil {
+			log.Warn("Error while checking for duplicate job")
+			return imported - pending, skipped, err
+		}
+		if cnt != 0 {
+			skip("job already exists")
+			continue
+		}
+		if !jobMeta.State.Valid() {
+			skip(fmt.Sprintf("invalid job state '%s'", jobMeta.State))
+			continue
+		}
+
+		job := schema.Job{
+			BaseJob:       jobMeta.BaseJob,
+			StartTime:     time.Unix(jobMeta.StartTime, 0),
+			StartTimeUnix: jobMeta.StartTime,
+		}
+		if job.RawResources, err = json.Marshal(job.Resources); err != nil {
+			log.Warn("Error while marshaling resources")
+			return imported - pending, skipped, err
+		}
+		if job.RawMetaData, err = json.Marshal(job.MetaData); err != nil {
+			log.Warn("Error while marshaling metadata")
+			return imported - pending, skipped, err
+		}
+
+		id, addErr := r.TransactionAdd(t, job)
+		if addErr != nil {
+			skip(addErr.Error())
+			continue
+		}
+		pending++
+
+		stmt, footprints := sq.Update("job").Where("job.id = ?", id), 0
+		for metric, stats := range jobMeta.Statistics {
+			if fc, ok := footprintColumns[metric]; ok {
+				stmt = stmt.Set(fc.column, fc.value(stats))
+				footprints++
+			}
+		}
+		if footprints != 0 {
+			if _, err := stmt.RunWith(t.tx).Exec(); err != nil {
+				log.Warnf("Error while setting footprint of job, DB ID '%v'", id)
+			}
+		}
+
+		for _, tag := range job.Tags {
+			if tag.Scope == "" {
+				tag.Scope = TagScopeGlobal
+			}
+			tagstr := tag.Name + ":" + tag.Type + ":" + tag.Scope
+			tagId, ok := tags[tagstr]
+			if !ok {
+				if tagId, ok = r.TagId(tag.Type, tag.Name, tag.Scope); !ok {
+					var tagErr error
+					if tagId, tagErr = r.TransactionAddTag(t, tag); tagErr != nil {
+						log.Errorf("Error adding tag: %v", tagErr)
+						continue
+					}
+				}
+				tags[tagstr] = tagId
+			}
+
+			r.TransactionSetTag(t, id, tagId)
+		}
+		imported++
+	}
+	if err = scanner.Err(); err != nil {
+		log.Warnf("Error while reading line %d of NDJSON input", line+1)
+		return imported - pending, skipped, err
+	}
+
+	if err = r.TransactionEnd(t); err != nil {
+		return imported - pending, skipped, err
+	}
+	return imported, skipped, nil
+}
@@ -0,0 +1,64 @@
+// Copyright (C) 2023 NHR@FAU, University Erlangen-Nuremberg.
+// All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+package repository
+
+import (
+	"github.com/ClusterCockpit/cc-backend/pkg/log"
+	"github.com/ClusterCockpit/cc-backend/pkg/schema"
+)
+
+// Number of job state changes buffered per subscriber. Further changes are
+// dropped until the subscriber catches up.
+const jobStateBufferSize = 64
+
+// SubscribeJobStates returns a channel that receives every job whose state
+// was changed by Start, Stop or Resume. The subscription ends and the channel
+// is closed when cancel is called.
+func (r *JobRepository) SubscribeJobStates() (jobs <-chan *schema.Job, cancel func()) {
+	ch := make(chan *schema.Job, jobStateBufferSize)
+
+	r.subscribersLock.Lock()
+	if r.stateSubscribers == nil {
+		r.stateSubscribers = make(map[chan *schema.Job]struct{})
+	}
+	r.stateSubscribers[ch] = struct{}{}
+	r.subscribersLock.Unlock()
+
+	return ch, func() {
+		r.subscribersLock.Lock()
+		defer r.subscribersLock.Unlock()
+		if _, ok := r.stateSubscribers[ch]; ok {
+			delete(r.stateSubscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+// publishJobState sends the job with the database id jobId to all
+// subscribers. The job is only loaded if there are subscribers.
+func (r *JobRepository) publishJobState(jobId int64) {
+	r.subscribersLock.Lock()
+	n := len(r.stateSubscribers)
+	r.subscribersLock.Unlock()
+	if n == 0 {
+		return
+	}
+
+	job, err := r.FindById(jobId)
+	if err != nil {
+		log.Warnf("loading job (dbid: %d) for state subscribers failed: %s", jobId, err.Error())
+		return
+	}
+
+	r.subscribersLock.Lock()
+	defer r.subscribersLock.Unlock()
+	for ch := range r.stateSubscribers {
+		select {
+		case ch <- job:
+		default:
+			log.Warnf("dropping state change of job (dbid: %d) for slow subscriber", jobId)
+		}
+	}
+}
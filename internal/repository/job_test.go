@@ -5,9 +5,22 @@
 package repository
 
 import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
 	"testing"
 
+	"github.com/ClusterCockpit/cc-backend/internal/util"
+	"github.com/ClusterCockpit/cc-backend/pkg/archive"
+	"github.com/ClusterCockpit/cc-backend/pkg/schema"
 	_ "github.com/mattn/go-sqlite3"
 )
 
@@ -15,7 +28,7 @@ func TestFind(t *testing.T) {
 	r := setup(t)
 
 	jobId, cluster, startTime := int64(398998), "fritz", int64(1675957496)
-	job, err := r.Find(&jobId, &cluster, &startTime)
+	job, err := r.Find(context.Background(), &jobId, &cluster, &startTime)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -57,3 +70,471 @@ func TestGetTags(t *testing.T) {
 		t.Errorf("wrong tag count \ngot: %d \nwant: 0", counts["bandwidth"])
 	}
 }
+
+func TestMarkArchived(t *testing.T) {
+	r := setup(t)
+
+	var rawMetaData []byte
+	noErr(t, r.DB.QueryRow(`SELECT meta_data FROM job WHERE id = 5`).Scan(&rawMetaData))
+	t.Cleanup(func() {
+		r.cache.Del("metadata:5")
+		r.DB.Exec(`UPDATE job SET meta_data = ?, net_data_vol_total = 0.0, file_data_vol_total = 0.0 WHERE id = 5`, rawMetaData)
+	})
+
+	stats := map[string]schema.JobStatistics{
+		"flops_any":           {Avg: 719.977, Min: 0, Max: 1024},
+		"net_data_vol_total":  {Avg: 10, Min: 0, Max: 20},
+		"file_data_vol_total": {Avg: 30, Min: 0, Max: 40},
+		"ipc":                 {Unit: schema.Unit{Base: "IPC"}, Avg: 1.5, Min: 0.5, Max: 2.5},
+	}
+	if err := r.MarkArchived(5, schema.MonitoringStatusArchivingSuccessful, stats, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	var flopsAny, netDataVol, fileDataVol float64
+	noErr(t, r.DB.QueryRow(`SELECT flops_any_avg, net_data_vol_total, file_data_vol_total FROM job WHERE id = 5`).
+		Scan(&flopsAny, &netDataVol, &fileDataVol))
+	if flopsAny != 719.977 || netDataVol != 20 || fileDataVol != 40 {
+		t.Errorf("wrong footprint columns\ngot: %f, %f, %f \nwant: 719.977, 20, 40", flopsAny, netDataVol, fileDataVol)
+	}
+
+	r.cache.Del("metadata:5")
+	metaData, err := r.FetchMetadata(&schema.Job{ID: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if metaData["jobName"] != "ams_pipeline" {
+		t.Errorf("existing metadata lost\ngot: %#v \nwant: ams_pipeline", metaData["jobName"])
+	}
+
+	var unknown map[string]schema.JobStatistics
+	if err := json.Unmarshal([]byte(metaData["statistics"]), &unknown); err != nil {
+		t.Fatal(err)
+	}
+	if len(unknown) != 1 || unknown["ipc"].Avg != 1.5 || unknown["ipc"].Max != 2.5 {
+		t.Errorf("wrong statistics metadata\ngot: %#v", unknown)
+	}
+}
+
+func TestImportNDJSON(t *testing.T) {
+	r := setup(t)
+	t.Cleanup(func() {
+		r.DB.Exec(`DELETE FROM job WHERE cluster = 'ndjson'`)
+	})
+
+	const input = `{"jobId": 1001, "user": "u1", "project": "p1", "cluster": "ndjson", "subCluster": "main", "numNodes": 1, "exclusive": 1, "jobState": "completed", "duration": 60, "resources": [{"hostname": "n1"}], "startTime": 1675957000, "statistics": {"flops_any": {"unit": {"base": "F/s"}, "avg": 10, "min": 0, "max": 20}}}
+{"jobId": 1002, "user": "u1", "project": "p1", "cluster": "ndjson", "subCluster": "main", "numNodes": 1, "exclusive": 1, "jobState": "failed", "duration": 60, "resources": [{"hostname": "n2"}], "startTime": 1675957100}
+{"jobId": 1001, "user": "u1", "project": "p1", "cluster": "ndjson", "subCluster": "main", "numNodes": 1, "exclusive": 1, "jobState": "completed", "duration": 60, "resources": [{"hostname": "n1"}], "startTime": 1675957000}
+{"jobId": 398998, "user": "k106eb10", "project": "k106eb", "cluster": "fritz", "subCluster": "main", "numNodes": 1, "exclusive": 1, "jobState": "completed", "duration": 60, "resources": [{"hostname": "f1076"}], "startTime": 1675957496}
+`
+	imported, skipped, err := r.ImportNDJSON(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if imported != 2 || skipped != 2 {
+		t.Errorf("wrong import counts\ngot: %d imported, %d skipped \nwant: 2 imported, 2 skipped", imported, skipped)
+	}
+
+	jobId, cluster := int64(1001), "ndjson"
+	job, err := r.Find(context.Background(), &jobId, &cluster, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if job.FlopsAnyAvg != 10 {
+		t.Errorf("wrong footprint\ngot: %f \nwant: 10", job.FlopsAnyAvg)
+	}
+}
+
+func TestImportNDJSONError(t *testing.T) {
+	r := setup(t)
+	t.Cleanup(func() {
+		r.DB.Exec(`DELETE FROM job WHERE cluster = 'ndjsonerr'`)
+	})
+
+	// The jobs of the batch with the broken line are rolled back.
+	const input = `{"jobId": 6001, "user": "u1", "project": "p1", "cluster": "ndjsonerr", "subCluster": "main", "numNodes": 1, "exclusive": 1, "jobState": "completed", "duration": 60, "resources": [{"hostname": "n1"}], "startTime": 1675957000}
+
+{"jobId": 6002, "user": "u1", "project": "p1", "cluster": "ndjsonerr", "subCluster": "main", "numNodes": 1, "exclusive": 1, "jobState": "completed", "duration": 60, "resources": [{"hostname": "n1"}], "startTime": 1675957100}
+{"jobId": 6003, "user": "u1",
+`
+	imported, skipped, err := r.ImportNDJSON(strings.NewReader(input))
+	if err == nil || !strings.Contains(err.Error(), "line 4") {
+		t.Fatalf("expected an error for line 4, got %v", err)
+	}
+	if imported != 0 || skipped != 0 {
+		t.Errorf("wrong import counts\ngot: %d imported, %d skipped \nwant: 0 imported, 0 skipped", imported, skipped)
+	}
+
+	var cnt int
+	noErr(t, r.DB.Get(&cnt, `SELECT COUNT(*) FROM job WHERE cluster = 'ndjsonerr'`))
+	if cnt != 0 {
+		t.Errorf("expected no imported jobs, got %d", cnt)
+	}
+}
+
+func TestAdditionalJobStates(t *testing.T) {
+	r := setup(t)
+	t.Cleanup(func() {
+		r.DB.Exec(`DELETE FROM job WHERE cluster = 'jobstates'`)
+	})
+
+	const input = `{"jobId": 5001, "user": "u1", "project": "p1", "cluster": "jobstates", "subCluster": "main", "numNodes": 1, "exclusive": 1, "jobState": "suspended", "duration": 60, "resources": [{"hostname": "n1"}], "startTime": 1675957000}
+{"jobId": 5002, "user": "u1", "project": "p1", "cluster": "jobstates", "subCluster": "main", "numNodes": 1, "exclusive": 1, "jobState": "requeue_hold", "duration": 60, "resources": [{"hostname": "n1"}], "startTime": 1675957100}
+`
+	imported, skipped, err := r.ImportNDJSON(strings.NewReader(input))
+	noErr(t, err)
+	if imported != 1 || skipped != 1 {
+		t.Fatalf("wrong import counts\ngot: %d imported, %d skipped \nwant: 1 imported, 1 skipped", imported, skipped)
+	}
+
+	jobId, cluster := int64(5001), "jobstates"
+	job, err := r.Find(context.Background(), &jobId, &cluster, nil)
+	noErr(t, err)
+	if job.State != schema.JobStateSuspended {
+		t.Errorf("wrong job state\ngot: %s \nwant: suspended", job.State)
+	}
+
+	// Site specific states are stored once they are configured.
+	schema.AddJobStates([]string{"requeue_hold"})
+	imported, _, err = r.ImportNDJSON(strings.NewReader(input))
+	noErr(t, err)
+	if imported != 1 {
+		t.Fatalf("wrong import count\ngot: %d \nwant: 1", imported)
+	}
+	jobId = 5002
+	job, err = r.Find(context.Background(), &jobId, &cluster, nil)
+	noErr(t, err)
+	if job.State != "requeue_hold" {
+		t.Errorf("wrong job state\ngot: %s \nwant: requeue_hold", job.State)
+	}
+}
+
+func TestFindByArrayJobId(t *testing.T) {
+	r := setup(t)
+	t.Cleanup(func() {
+		r.DB.Exec(`DELETE FROM job WHERE cluster = 'arrayjob'`)
+	})
+
+	const input = `{"jobId": 3003, "arrayJobId": 3000, "user": "u1", "project": "p1", "cluster": "arrayjob", "subCluster": "main", "numNodes": 1, "exclusive": 1, "jobState": "failed", "duration": 60, "resources": [{"hostname": "n1"}], "startTime": 1675957200}
+{"jobId": 3001, "arrayJobId": 3000, "user": "u1", "project": "p1", "cluster": "arrayjob", "subCluster": "main", "numNodes": 1, "exclusive": 1, "jobState": "completed", "duration": 60, "resources": [{"hostname": "n1"}], "startTime": 1675957000}
+{"jobId": 3002, "arrayJobId": 3000, "user": "u1", "project": "p1", "cluster": "arrayjob", "subCluster": "main", "numNodes": 1, "exclusive": 1, "jobState": "completed", "duration": 60, "resources": [{"hostname": "n1"}], "startTime": 1675957100}
+{"jobId": 3005, "arrayJobId": 3999, "user": "u1", "project": "p1", "cluster": "arrayjob", "subCluster": "main", "numNodes": 1, "exclusive": 1, "jobState": "completed", "duration": 60, "resources": [{"hostname": "n1"}], "startTime": 1675957300}
+{"jobId": 3004, "arrayJobId": 3000, "user": "u2", "project": "p1", "cluster": "arrayjob", "subCluster": "main", "numNodes": 1, "exclusive": 1, "jobState": "completed", "duration": 60, "resources": [{"hostname": "n1"}], "startTime": 1675957400}
+`
+	if _, _, err := r.ImportNDJSON(strings.NewReader(input)); err != nil {
+		t.Fatal(err)
+	}
+
+	jobs, err := r.FindByArrayJobId(getContext(t), 3000, "arrayjob")
+	noErr(t, err)
+
+	if len(jobs) != 4 {
+		t.Fatalf("wrong number of array tasks\ngot: %d \nwant: 4", len(jobs))
+	}
+	for i, state := range []schema.JobState{schema.JobStateCompleted, schema.JobStateCompleted, schema.JobStateFailed, schema.JobStateCompleted} {
+		if jobs[i].JobID != int64(3001+i) || jobs[i].State != state {
+			t.Errorf("wrong array task %d\ngot: %d (%s) \nwant: %d (%s)", i, jobs[i].JobID, jobs[i].State, 3001+i, state)
+		}
+	}
+
+	jobs, err = r.FindByArrayJobId(getContext(t), 3000, "fritz")
+	noErr(t, err)
+	if len(jobs) != 0 {
+		t.Errorf("expected no array tasks on other cluster, got %d", len(jobs))
+	}
+
+	// Users do not see the tasks of other users.
+	ctx := context.WithValue(context.Background(), ContextUserKey, &schema.User{
+		Username: "u2",
+		Roles:    []string{schema.GetRoleString(schema.RoleUser)},
+	})
+	jobs, err = r.FindByArrayJobId(ctx, 3000, "arrayjob")
+	noErr(t, err)
+	if len(jobs) != 1 || jobs[0].JobID != 3004 {
+		t.Errorf("wrong array tasks of user u2\ngot: %v \nwant: [3004]", jobs)
+	}
+}
+
+func TestFindSimilar(t *testing.T) {
+	r := setup(t)
+	t.Cleanup(func() {
+		r.DB.Exec(`DELETE FROM job WHERE cluster = 'similar'`)
+	})
+
+	// 4001 is similar to 4000, 4002 has a much higher flops_any average and
+	// 4003 is too large.
+	const input = `{"jobId": 4000, "user": "u1", "project": "p1", "cluster": "similar", "subCluster": "main", "numNodes": 4, "exclusive": 1, "jobState": "completed", "duration": 600, "resources": [{"hostname": "n1"}], "startTime": 1675957000, "statistics": {"flops_any": {"unit": {"base": "F/s"}, "avg": 10, "min": 0, "max": 20}}}
+{"jobId": 4001, "user": "u1", "project": "p1", "cluster": "similar", "subCluster": "main", "numNodes": 3, "exclusive": 1, "jobState": "completed", "duration": 600, "resources": [{"hostname": "n2"}], "startTime": 1675957100, "statistics": {"flops_any": {"unit": {"base": "F/s"}, "avg": 12, "min": 0, "max": 20}}}
+{"jobId": 4002, "user": "u1", "project": "p1", "cluster": "similar", "subCluster": "main", "numNodes": 4, "exclusive": 1, "jobState": "completed", "duration": 600, "resources": [{"hostname": "n3"}], "startTime": 1675957200, "statistics": {"flops_any": {"unit": {"base": "F/s"}, "avg": 100, "min": 0, "max": 200}}}
+{"jobId": 4003, "user": "u1", "project": "p1", "cluster": "similar", "subCluster": "main", "numNodes": 32, "exclusive": 1, "jobState": "completed", "duration": 600, "resources": [{"hostname": "n4"}], "startTime": 1675957300, "statistics": {"flops_any": {"unit": {"base": "F/s"}, "avg": 10, "min": 0, "max": 20}}}
+`
+	if _, _, err := r.ImportNDJSON(strings.NewReader(input)); err != nil {
+		t.Fatal(err)
+	}
+
+	jobId, cluster := int64(4000), "similar"
+	job, err := r.Find(context.Background(), &jobId, &cluster, nil)
+	noErr(t, err)
+
+	jobs, err := r.FindSimilar(getContext(t), job, 10)
+	noErr(t, err)
+	if len(jobs) != 1 || jobs[0].JobID != 4001 {
+		t.Errorf("wrong similar jobs\ngot: %v \nwant: [4001]", jobs)
+	}
+
+	// Users only get their own jobs.
+	ctx := context.WithValue(context.Background(), ContextUserKey, &schema.User{
+		Username: "u2",
+		Roles:    []string{schema.GetRoleString(schema.RoleUser)},
+	})
+	jobs, err = r.FindSimilar(ctx, job, 10)
+	noErr(t, err)
+	if len(jobs) != 0 {
+		t.Errorf("expected no similar jobs of other users, got %d", len(jobs))
+	}
+}
+
+func TestStopStateTransitions(t *testing.T) {
+	r := setup(t)
+	t.Cleanup(func() {
+		r.DB.Exec(`DELETE FROM job WHERE cluster = 'stoptest'`)
+	})
+
+	const input = `{"jobId": 4001, "user": "u1", "project": "p1", "cluster": "stoptest", "subCluster": "main", "numNodes": 1, "exclusive": 1, "jobState": "running", "duration": 0, "resources": [{"hostname": "n1"}], "startTime": 1675957000}
+`
+	_, _, err := r.ImportNDJSON(strings.NewReader(input))
+	noErr(t, err)
+
+	jobId, cluster := int64(4001), "stoptest"
+	job, err := r.Find(context.Background(), &jobId, &cluster, nil)
+	noErr(t, err)
+
+	var transitionErr *StateTransitionError
+	if err := r.Stop(context.Background(), job.ID, 60, schema.JobStateRunning, schema.MonitoringStatusRunningOrArchiving); !errors.As(err, &transitionErr) {
+		t.Fatalf("expected StateTransitionError for running -> running, got %v", err)
+	}
+
+	noErr(t, r.Stop(context.Background(), job.ID, 60, schema.JobStateCompleted, schema.MonitoringStatusRunningOrArchiving))
+
+	if err := r.Stop(context.Background(), job.ID, 120, schema.JobStateFailed, schema.MonitoringStatusRunningOrArchiving); !errors.As(err, &transitionErr) {
+		t.Fatalf("expected StateTransitionError for completed -> failed, got %v", err)
+	} else if transitionErr.From != schema.JobStateCompleted || transitionErr.To != schema.JobStateFailed {
+		t.Errorf("unexpected transition error: %#v", transitionErr)
+	}
+
+	job, err = r.FindById(job.ID)
+	noErr(t, err)
+	if job.State != schema.JobStateCompleted || job.Duration != 60 {
+		t.Errorf("stopped job was modified: state %s, duration %d", job.State, job.Duration)
+	}
+
+	if err := r.Stop(context.Background(), -1, 60, schema.JobStateCompleted, schema.MonitoringStatusRunningOrArchiving); err != sql.ErrNoRows {
+		t.Errorf("expected sql.ErrNoRows for unknown job, got %v", err)
+	}
+}
+
+func TestSearchMetadata(t *testing.T) {
+	r := setup(t)
+	t.Cleanup(func() {
+		r.DB.Exec(`DELETE FROM job WHERE cluster = 'metasearch'`)
+	})
+
+	const input = `{"jobId": 4001, "user": "u1", "project": "p1", "cluster": "metasearch", "subCluster": "main", "numNodes": 1, "exclusive": 1, "jobState": "completed", "duration": 60, "resources": [{"hostname": "n1"}], "startTime": 1675957000, "metaData": {"jobScript": "#!/bin/bash\nmodule load GROMACS/2023\nsrun gmx_mpi mdrun -s topol.tpr"}}
+{"jobId": 4002, "user": "u2", "project": "p1", "cluster": "metasearch", "subCluster": "main", "numNodes": 1, "exclusive": 1, "jobState": "completed", "duration": 60, "resources": [{"hostname": "n1"}], "startTime": 1675957100, "metaData": {"jobScript": "#!/bin/bash\nsrun ./gmx_100%_test"}}
+{"jobId": 4003, "user": "u1", "project": "p1", "cluster": "metasearch", "subCluster": "main", "numNodes": 1, "exclusive": 1, "jobState": "completed", "duration": 60, "resources": [{"hostname": "n1"}], "startTime": 1675957200, "metaData": {"jobName": "lammps"}}
+`
+	if _, _, err := r.ImportNDJSON(strings.NewReader(input)); err != nil {
+		t.Fatal(err)
+	}
+
+	search := func(ctx context.Context, term string) []int64 {
+		jobs, err := r.SearchMetadata(ctx, term)
+		noErr(t, err)
+
+		ids := make([]int64, 0, len(jobs))
+		for _, job := range jobs {
+			if job.Cluster == "metasearch" {
+				ids = append(ids, job.JobID)
+			}
+		}
+		return ids
+	}
+
+	if ids := search(getContext(t), "module load gromacs"); !reflect.DeepEqual(ids, []int64{4001}) {
+		t.Errorf("case insensitive search: got %v, want [4001]", ids)
+	}
+	if ids := search(getContext(t), "gmx_"); !reflect.DeepEqual(ids, []int64{4002, 4001}) {
+		t.Errorf("search: got %v, want [4002 4001]", ids)
+	}
+	// Wildcards are matched literally, newlines as part of the snippet.
+	if ids := search(getContext(t), "100%_"); !reflect.DeepEqual(ids, []int64{4002}) {
+		t.Errorf("search with wildcards: got %v, want [4002]", ids)
+	}
+	if ids := search(getContext(t), "gromacs/2023\nsrun"); !reflect.DeepEqual(ids, []int64{4001}) {
+		t.Errorf("search with newline: got %v, want [4001]", ids)
+	}
+	if ids := search(getContext(t), "gmx_%"); len(ids) != 0 {
+		t.Errorf("expected no match for literal %%, got %v", ids)
+	}
+
+	// Users only find their own jobs.
+	user := &schema.User{Username: "u2", Roles: []string{schema.GetRoleString(schema.RoleUser)}}
+	ctx := context.WithValue(context.Background(), ContextUserKey, user)
+	if ids := search(ctx, "gmx_"); !reflect.DeepEqual(ids, []int64{4002}) {
+		t.Errorf("search as user: got %v, want [4002]", ids)
+	}
+}
+
+func TestProjectsAndUsers(t *testing.T) {
+	r := setup(t)
+	t.Cleanup(func() {
+		r.DB.Exec(`DELETE FROM job WHERE cluster = 'distinct'`)
+	})
+
+	const input = `{"jobId": 6001, "user": "zoe", "project": "zproj", "cluster": "distinct", "subCluster": "main", "numNodes": 1, "exclusive": 1, "jobState": "completed", "duration": 60, "resources": [{"hostname": "n1"}], "startTime": 1675957000}
+{"jobId": 6002, "user": "zoe", "project": "aproj", "cluster": "distinct", "subCluster": "main", "numNodes": 1, "exclusive": 1, "jobState": "completed", "duration": 60, "resources": [{"hostname": "n1"}], "startTime": 1675957100}
+{"jobId": 6003, "user": "adam", "project": "zproj", "cluster": "distinct", "subCluster": "main", "numNodes": 1, "exclusive": 1, "jobState": "completed", "duration": 60, "resources": [{"hostname": "n1"}], "startTime": 1675957200}
+`
+	if _, _, err := r.ImportNDJSON(strings.NewReader(input)); err != nil {
+		t.Fatal(err)
+	}
+
+	contains := func(values []string, want ...string) bool {
+		for _, w := range want {
+			found := false
+			for _, v := range values {
+				found = found || v == w
+			}
+			if !found {
+				return false
+			}
+		}
+		return true
+	}
+
+	projects, err := r.Projects(getContext(t))
+	noErr(t, err)
+	if !sort.StringsAreSorted(projects) || !contains(projects, "aproj", "zproj", "k106eb") {
+		t.Errorf("unexpected projects for admin: %v", projects)
+	}
+	users, err := r.Users(getContext(t))
+	noErr(t, err)
+	if !sort.StringsAreSorted(users) || !contains(users, "adam", "zoe") {
+		t.Errorf("unexpected users for admin: %v", users)
+	}
+	for i := 1; i < len(users); i++ {
+		if users[i] == users[i-1] {
+			t.Errorf("duplicate user: %s", users[i])
+		}
+	}
+
+	// Users only get the projects of their own jobs.
+	user := &schema.User{Username: "zoe", Roles: []string{schema.GetRoleString(schema.RoleUser)}}
+	ctx := context.WithValue(context.Background(), ContextUserKey, user)
+	projects, err = r.Projects(ctx)
+	noErr(t, err)
+	if !reflect.DeepEqual(projects, []string{"aproj", "zproj"}) {
+		t.Errorf("unexpected projects for user: %v", projects)
+	}
+	users, err = r.Users(ctx)
+	noErr(t, err)
+	if !reflect.DeepEqual(users, []string{"zoe"}) {
+		t.Errorf("unexpected users for user: %v", users)
+	}
+
+	if _, err := r.Projects(context.Background()); err == nil {
+		t.Error("expected error without user")
+	}
+}
+
+func TestTimeline(t *testing.T) {
+	r := setup(t)
+	t.Cleanup(func() {
+		r.DB.Exec(`DELETE FROM job WHERE cluster IN ('timeline', 'timeline2')`)
+	})
+
+	const job = `{"jobId": %d, "user": "u1", "project": "p1", "cluster": "%s", "subCluster": "main", "numNodes": 2, "exclusive": 1, "jobState": "%s", "duration": %d, "resources": [{"hostname": "n1"}, {"hostname": "n2"}], "startTime": %d}` + "\n"
+	input := fmt.Sprintf(job, 5001, "timeline", "completed", 500, 1675956000) + // ends before the window
+		fmt.Sprintf(job, 5002, "timeline", "completed", 200, 1675956900) +
+		fmt.Sprintf(job, 5003, "timeline", "failed", 100, 1675957500) +
+		fmt.Sprintf(job, 5004, "timeline", "completed", 100, 1675958000) + // starts at the end of the window
+		fmt.Sprintf(job, 5005, "timeline", "running", 0, 1675950000) +
+		fmt.Sprintf(job, 5006, "timeline2", "completed", 100, 1675957500)
+	if _, _, err := r.ImportNDJSON(strings.NewReader(input)); err != nil {
+		t.Fatal(err)
+	}
+
+	spans, err := r.Timeline(getContext(t), "timeline", 1675957000, 1675958000)
+	noErr(t, err)
+
+	jobIds := make([]int64, 0, len(spans))
+	for _, span := range spans {
+		jobIds = append(jobIds, span.JobID)
+	}
+	if !reflect.DeepEqual(jobIds, []int64{5005, 5002, 5003}) {
+		t.Fatalf("wrong jobs in timeline\ngot: %v \nwant: [5005 5002 5003]", jobIds)
+	}
+
+	if span := spans[1]; span.StartTime != 1675956900 || span.Duration != 200 ||
+		span.State != schema.JobStateCompleted || !reflect.DeepEqual(span.Hostnames, []string{"n1", "n2"}) {
+		t.Errorf("wrong span: %#v", span)
+	}
+	if span := spans[0]; span.State != schema.JobStateRunning || span.Duration < 1675958000-1675950000 {
+		t.Errorf("wrong span of running job: %#v", span)
+	}
+}
+
+func TestUpdateOwnership(t *testing.T) {
+	r := setup(t)
+
+	jobarchive := filepath.Join(t.TempDir(), "job-archive")
+	if err := util.CopyDir("../../pkg/archive/testdata/archive/", jobarchive); err != nil {
+		t.Fatal(err)
+	}
+	clusters := archive.Clusters
+	if err := archive.Init(json.RawMessage(fmt.Sprintf(`{"kind": "file", "path": "%s"}`, jobarchive)), false); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		archive.Clusters = clusters
+		if _, err := r.DB.Exec(`DELETE FROM job WHERE cluster = 'emmy'`); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	// Job 1403245 is finished, but missing in the job-archive.
+	raw, err := os.ReadFile(filepath.Join(jobarchive, "emmy/1403/244/1608923076/meta.json"))
+	noErr(t, err)
+	var ndjson bytes.Buffer
+	noErr(t, json.Compact(&ndjson, raw))
+	ndjson.WriteByte('\n')
+	ndjson.WriteString(strings.Replace(strings.TrimSpace(ndjson.String()), `"jobId":1403244`, `"jobId":1403245`, 1) + "\n")
+	_, _, err = r.ImportNDJSON(&ndjson)
+	noErr(t, err)
+
+	cluster := "emmy"
+	find := func(jobId int64) *schema.Job {
+		job, err := r.Find(context.Background(), &jobId, &cluster, nil)
+		noErr(t, err)
+		return job
+	}
+
+	missing := find(1403245)
+	if err := r.UpdateOwnership(missing.ID, "", "otherproj"); err == nil {
+		t.Fatal("expected error for job missing in the job-archive")
+	}
+	if job := find(1403245); job.Project != missing.Project {
+		t.Errorf("project changed to %s although the job-archive was not updated", job.Project)
+	}
+
+	archived := find(1403244)
+	noErr(t, r.UpdateOwnership(archived.ID, "", "otherproj"))
+	jobMeta, err := archive.GetHandle().LoadJobMeta(archived)
+	noErr(t, err)
+	if job := find(1403244); job.Project != "otherproj" || jobMeta.Project != "otherproj" || job.User != archived.User {
+		t.Errorf("ownership not updated: user=%s, project=%s (archive: %s)", job.User, job.Project, jobMeta.Project)
+	}
+}
@@ -16,7 +16,7 @@ import (
 	"github.com/golang-migrate/migrate/v4/source/iofs"
 )
 
-const Version uint = 7
+const Version uint = 13
 
 //go:embed migrations/*
 var migrationFiles embed.FS
@@ -97,7 +97,7 @@ func getMigrateInstance(backend string, db string) (m *migrate.Migrate, err erro
 			return m, err
 		}
 
-		m, err = migrate.NewWithSourceInstance("iofs", d, fmt.Sprintf("mysql://%s?multiStatements=true", db))
+		m, err = migrate.NewWithSourceInstance("iofs", d, "mysql://"+withParams(db, "multiStatements=true"))
 		if err != nil {
 			return m, err
 		}
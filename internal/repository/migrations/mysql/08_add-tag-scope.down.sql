@@ -0,0 +1,4 @@
+DELETE FROM tag WHERE tag_scope != "global";
+ALTER TABLE tag DROP INDEX tag_unique;
+ALTER TABLE tag ADD CONSTRAINT tag_type UNIQUE (tag_type, tag_name);
+ALTER TABLE tag DROP COLUMN tag_scope;
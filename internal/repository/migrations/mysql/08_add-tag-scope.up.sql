@@ -0,0 +1,3 @@
+ALTER TABLE tag ADD COLUMN tag_scope VARCHAR(255) NOT NULL DEFAULT "global";
+ALTER TABLE tag DROP INDEX tag_type;
+ALTER TABLE tag ADD CONSTRAINT tag_unique UNIQUE (tag_type, tag_name, tag_scope);
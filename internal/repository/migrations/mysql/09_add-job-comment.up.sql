@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS job_comment (
+    id         INTEGER AUTO_INCREMENT PRIMARY KEY,
+    job_id     INTEGER NOT NULL,
+    author     VARCHAR(255) NOT NULL,
+    created_at BIGINT NOT NULL,
+    text       TEXT NOT NULL,
+    FOREIGN KEY (job_id) REFERENCES job (id) ON DELETE CASCADE);
@@ -0,0 +1,3 @@
+UPDATE job SET job_state = 'failed' WHERE job_state NOT IN ('running', 'completed', 'failed', 'cancelled', 'stopped', 'timeout', 'preempted', 'out_of_memory');
+ALTER TABLE job ADD CONSTRAINT job_state_check
+    CHECK(job_state IN ('running', 'completed', 'failed', 'cancelled', 'stopped', 'timeout', 'preempted', 'out_of_memory'));
@@ -0,0 +1,13 @@
+-- The name of the CHECK constraint of job_state depends on the server.
+SET @job_state_check := (
+    SELECT tc.CONSTRAINT_NAME FROM information_schema.TABLE_CONSTRAINTS tc
+    JOIN information_schema.CHECK_CONSTRAINTS cc
+        ON cc.CONSTRAINT_SCHEMA = tc.CONSTRAINT_SCHEMA AND cc.CONSTRAINT_NAME = tc.CONSTRAINT_NAME
+    WHERE tc.CONSTRAINT_SCHEMA = DATABASE() AND tc.TABLE_NAME = 'job'
+        AND tc.CONSTRAINT_TYPE = 'CHECK' AND cc.CHECK_CLAUSE LIKE '%job_state%'
+    LIMIT 1);
+SET @drop_check := IF(@job_state_check IS NULL, 'DO 0',
+    CONCAT('ALTER TABLE job DROP CONSTRAINT `', @job_state_check, '`'));
+PREPARE drop_check FROM @drop_check;
+EXECUTE drop_check;
+DEALLOCATE PREPARE drop_check;
@@ -0,0 +1,4 @@
+UPDATE job SET monitoring_status = 3 WHERE monitoring_status IN (4, 5);
+ALTER TABLE job DROP CONSTRAINT monitoring_status_check;
+ALTER TABLE job ADD CONSTRAINT monitoring_status_check
+    CHECK(monitoring_status IN (0, 1, 2, 3));
@@ -0,0 +1,16 @@
+-- The name of the CHECK constraint of monitoring_status depends on the server.
+SET @monitoring_status_check := (
+    SELECT tc.CONSTRAINT_NAME FROM information_schema.TABLE_CONSTRAINTS tc
+    JOIN information_schema.CHECK_CONSTRAINTS cc
+        ON cc.CONSTRAINT_SCHEMA = tc.CONSTRAINT_SCHEMA AND cc.CONSTRAINT_NAME = tc.CONSTRAINT_NAME
+    WHERE tc.CONSTRAINT_SCHEMA = DATABASE() AND tc.TABLE_NAME = 'job'
+        AND tc.CONSTRAINT_TYPE = 'CHECK' AND cc.CHECK_CLAUSE LIKE '%monitoring_status%'
+    LIMIT 1);
+SET @drop_check := IF(@monitoring_status_check IS NULL, 'DO 0',
+    CONCAT('ALTER TABLE job DROP CONSTRAINT `', @monitoring_status_check, '`'));
+PREPARE drop_check FROM @drop_check;
+EXECUTE drop_check;
+DEALLOCATE PREPARE drop_check;
+
+ALTER TABLE job ADD CONSTRAINT monitoring_status_check
+    CHECK(monitoring_status IN (0, 1, 2, 3, 4, 5));
@@ -0,0 +1,2 @@
+-- The indexes belong to 02_add-index and are kept.
+DO 0;
@@ -0,0 +1,67 @@
+-- Repairs the indexes of 02_add-index on databases that were forced past it
+-- with the migrate tool ("migrate force 2"), as earlier versions of 02 used
+-- CREATE INDEX IF NOT EXISTS, which MySQL does not support. The missing
+-- indexes are created. job_by_job_id only covered job_id in these versions
+-- and is recreated with the columns used by sqlite3 and Reindex.
+SET @drop_index := IF((SELECT COUNT(*) FROM information_schema.STATISTICS
+        WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'job' AND INDEX_NAME = 'job_by_job_id') = 1,
+    'DROP INDEX job_by_job_id ON job', 'DO 0');
+PREPARE drop_index FROM @drop_index;
+EXECUTE drop_index;
+DEALLOCATE PREPARE drop_index;
+
+SET @create_index := IF((SELECT COUNT(*) FROM information_schema.STATISTICS
+        WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'job' AND INDEX_NAME = 'job_stats') > 0,
+    'DO 0', 'CREATE INDEX job_stats ON job (cluster,subcluster,user)');
+PREPARE create_index FROM @create_index;
+EXECUTE create_index;
+DEALLOCATE PREPARE create_index;
+
+SET @create_index := IF((SELECT COUNT(*) FROM information_schema.STATISTICS
+        WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'job' AND INDEX_NAME = 'job_by_user') > 0,
+    'DO 0', 'CREATE INDEX job_by_user ON job (user)');
+PREPARE create_index FROM @create_index;
+EXECUTE create_index;
+DEALLOCATE PREPARE create_index;
+
+SET @create_index := IF((SELECT COUNT(*) FROM information_schema.STATISTICS
+        WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'job' AND INDEX_NAME = 'job_by_starttime') > 0,
+    'DO 0', 'CREATE INDEX job_by_starttime ON job (start_time)');
+PREPARE create_index FROM @create_index;
+EXECUTE create_index;
+DEALLOCATE PREPARE create_index;
+
+SET @create_index := IF((SELECT COUNT(*) FROM information_schema.STATISTICS
+        WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'job' AND INDEX_NAME = 'job_by_job_id') > 0,
+    'DO 0', 'CREATE INDEX job_by_job_id ON job (job_id, cluster, start_time)');
+PREPARE create_index FROM @create_index;
+EXECUTE create_index;
+DEALLOCATE PREPARE create_index;
+
+SET @create_index := IF((SELECT COUNT(*) FROM information_schema.STATISTICS
+        WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'job' AND INDEX_NAME = 'job_list') > 0,
+    'DO 0', 'CREATE INDEX job_list ON job (cluster, job_state)');
+PREPARE create_index FROM @create_index;
+EXECUTE create_index;
+DEALLOCATE PREPARE create_index;
+
+SET @create_index := IF((SELECT COUNT(*) FROM information_schema.STATISTICS
+        WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'job' AND INDEX_NAME = 'job_list_user') > 0,
+    'DO 0', 'CREATE INDEX job_list_user ON job (user, cluster, job_state)');
+PREPARE create_index FROM @create_index;
+EXECUTE create_index;
+DEALLOCATE PREPARE create_index;
+
+SET @create_index := IF((SELECT COUNT(*) FROM information_schema.STATISTICS
+        WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'job' AND INDEX_NAME = 'job_list_users') > 0,
+    'DO 0', 'CREATE INDEX job_list_users ON job (user, job_state)');
+PREPARE create_index FROM @create_index;
+EXECUTE create_index;
+DEALLOCATE PREPARE create_index;
+
+SET @create_index := IF((SELECT COUNT(*) FROM information_schema.STATISTICS
+        WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'job' AND INDEX_NAME = 'job_list_users_start') > 0,
+    'DO 0', 'CREATE INDEX job_list_users_start ON job (start_time, user, job_state)');
+PREPARE create_index FROM @create_index;
+EXECUTE create_index;
+DEALLOCATE PREPARE create_index;
@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS tag_new (
+id        INTEGER PRIMARY KEY,
+tag_type  VARCHAR(255) NOT NULL,
+tag_name  VARCHAR(255) NOT NULL,
+tag_scope VARCHAR(255) NOT NULL DEFAULT "global",
+insert_ts TEXT DEFAULT CURRENT_TIMESTAMP,
+UNIQUE (tag_type, tag_name, tag_scope));
+
+INSERT INTO tag_new (id, tag_type, tag_name, insert_ts) SELECT id, tag_type, tag_name, insert_ts FROM tag;
+
+-- Dropping tag would cascade to jobtag, keep a copy of the job tags
+CREATE TABLE jobtag_tmp AS SELECT job_id, tag_id, insert_ts FROM jobtag;
+DROP TABLE jobtag;
+DROP TABLE tag;
+ALTER TABLE tag_new RENAME TO tag;
+
+CREATE TABLE IF NOT EXISTS jobtag (
+job_id    INTEGER,
+tag_id    INTEGER,
+insert_ts TEXT DEFAULT CURRENT_TIMESTAMP,
+PRIMARY KEY (job_id, tag_id),
+FOREIGN KEY (job_id) REFERENCES job (id) ON DELETE CASCADE,
+FOREIGN KEY (tag_id) REFERENCES tag (id) ON DELETE CASCADE);
+
+INSERT INTO jobtag (job_id, tag_id, insert_ts) SELECT job_id, tag_id, insert_ts FROM jobtag_tmp;
+DROP TABLE jobtag_tmp;
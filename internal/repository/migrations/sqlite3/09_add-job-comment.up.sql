@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS job_comment (
+id         INTEGER PRIMARY KEY,
+job_id     INTEGER NOT NULL,
+author     VARCHAR(255) NOT NULL,
+created_at BIGINT NOT NULL, -- Unix timestamp
+text       TEXT NOT NULL,
+FOREIGN KEY (job_id) REFERENCES job (id) ON DELETE CASCADE);
+
+CREATE INDEX IF NOT EXISTS job_comment_by_job ON job_comment (job_id);
@@ -0,0 +1 @@
+ALTER TABLE job DROP COLUMN data_completeness;
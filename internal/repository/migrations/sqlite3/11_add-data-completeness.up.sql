@@ -0,0 +1 @@
+ALTER TABLE job ADD COLUMN data_completeness REAL DEFAULT NULL;
@@ -0,0 +1 @@
+-- The indexes of 02_add-index only had to be fixed for MySQL.
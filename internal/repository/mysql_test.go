@@ -0,0 +1,130 @@
+// Copyright (C) 2023 NHR@FAU, University Erlangen-Nuremberg.
+// All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+package repository
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ClusterCockpit/cc-backend/internal/graph/model"
+	"github.com/ClusterCockpit/cc-backend/pkg/lrucache"
+	"github.com/ClusterCockpit/cc-backend/pkg/schema"
+	sq "github.com/Masterminds/squirrel"
+)
+
+func TestWithParams(t *testing.T) {
+	if got := withParams("./var/job.db", "_fk=true"); got != "./var/job.db?_fk=true" {
+		t.Errorf("wrong DSN: %s", got)
+	}
+	if got := withParams("cc:secret@tcp(db:3306)/cc?parseTime=true", "multiStatements=true"); got != "cc:secret@tcp(db:3306)/cc?parseTime=true&multiStatements=true" {
+		t.Errorf("wrong DSN: %s", got)
+	}
+}
+
+// TestMySQL migrates the empty MySQL or MariaDB database given by the DSN in
+// the environment variable CC_TEST_MYSQL_DSN, e.g.
+// "cc:secret@tcp(localhost:3306)/cctest", and empties it again at the end.
+// Changes to the mysql migrations should be tested with both, e.g. with
+//
+//	docker run --rm -p 3306:3306 -e MYSQL_ROOT_PASSWORD=secret -e MYSQL_DATABASE=cctest mysql:8
+//	docker run --rm -p 3306:3306 -e MARIADB_ROOT_PASSWORD=secret -e MARIADB_DATABASE=cctest mariadb:11
+//
+// and CC_TEST_MYSQL_DSN="root:secret@tcp(localhost:3306)/cctest".
+func TestMySQL(t *testing.T) {
+	dsn := os.Getenv("CC_TEST_MYSQL_DSN")
+	if dsn == "" {
+		t.Skip("CC_TEST_MYSQL_DSN not set")
+	}
+
+	noErr(t, MigrateDB("mysql", dsn))
+	t.Cleanup(func() {
+		// The down migrations of 01_init-schema and 02_add-index do not
+		// work on MySQL, the remaining tables are dropped instead.
+		m, err := getMigrateInstance("mysql", dsn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := m.Migrate(2); err != nil {
+			t.Errorf("reverting migrations failed: %v", err)
+		}
+		m.Close()
+
+		db, err := openDB("mysql", dsn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer db.Close()
+		if _, err := db.Exec(`DROP TABLE IF EXISTS jobtag, job, tag, configuration, user, schema_migrations`); err != nil {
+			t.Errorf("dropping tables failed: %v", err)
+		}
+	})
+
+	db, err := openDB("mysql", dsn)
+	noErr(t, err)
+	t.Cleanup(func() { db.Close() })
+	noErr(t, checkDBVersion("mysql", db.DB))
+
+	var columns string
+	noErr(t, db.Get(&columns, `SELECT GROUP_CONCAT(COLUMN_NAME ORDER BY SEQ_IN_INDEX SEPARATOR ', ')
+		FROM information_schema.STATISTICS
+		WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'job' AND INDEX_NAME = 'job_by_job_id'`))
+	for _, index := range JobsDbIndexes {
+		if index.Name == "job_by_job_id" && index.Columns != columns {
+			t.Errorf("job_by_job_id covers (%s), expected (%s)", columns, index.Columns)
+		}
+	}
+
+	r := &JobRepository{
+		DB:        db,
+		driver:    "mysql",
+		stmtCache: sq.NewStmtCache(db.DB),
+		cache:     lrucache.New(1024 * 1024),
+	}
+
+	// Running jobs, so that tagging does not touch the job-archive.
+	const input = `{"jobId": 1, "user": "u1", "project": "p1", "cluster": "mysql", "subCluster": "main", "partition": "batch", "arrayJobId": 0, "numNodes": 2, "numHwthreads": 8, "numAcc": 0, "exclusive": 1, "jobState": "running", "duration": 0, "resources": [{"hostname": "n1"}, {"hostname": "n2"}], "startTime": 1675957000, "tags": [{"type": "app", "name": "gromacs"}]}
+{"jobId": 2, "user": "u2", "project": "p1", "cluster": "mysql", "subCluster": "main", "partition": "batch", "arrayJobId": 0, "numNodes": 1, "numHwthreads": 4, "numAcc": 0, "exclusive": 1, "jobState": "running", "duration": 0, "resources": [{"hostname": "n3"}], "startTime": 1675957100, "tags": [{"type": "app", "name": "gromacs"}]}
+`
+	imported, _, err := r.ImportNDJSON(strings.NewReader(input))
+	noErr(t, err)
+	if imported != 2 {
+		t.Fatalf("expected 2 imported jobs, got %d", imported)
+	}
+
+	jobId, cluster, startTime := int64(1), "mysql", int64(1675957000)
+	job, err := r.Find(context.Background(), &jobId, &cluster, &startTime)
+	noErr(t, err)
+	if job.User != "u1" || job.NumNodes != 2 || len(job.Resources) != 2 || job.State != schema.JobStateRunning {
+		t.Errorf("unexpected job: %#v", job)
+	}
+
+	_, err = r.AddTagOrCreate(nil, job.ID, "perf", "lowflops", "")
+	noErr(t, err)
+	tags, err := r.GetTags(nil, &job.ID)
+	noErr(t, err)
+	if len(tags) != 2 {
+		t.Errorf("expected 2 tags, got %v", tags)
+	}
+
+	filter := &model.JobFilter{Cluster: &model.StringInput{Eq: &cluster}}
+	stats, err := r.JobsStats(getContext(t), []*model.JobFilter{filter})
+	noErr(t, err)
+	if stats[0].TotalJobs != 2 || stats[0].TotalNodes != 3 {
+		t.Errorf("unexpected statistics: %#v", stats[0])
+	}
+
+	_, err = r.Reindex(true)
+	noErr(t, err)
+
+	// InitDB starts with an empty database.
+	noErr(t, r.Flush())
+	var cnt int
+	noErr(t, db.Get(&cnt, `SELECT COUNT(*) FROM job`))
+	if cnt != 0 {
+		t.Errorf("expected empty job table after flush, got %d jobs", cnt)
+	}
+}
@@ -6,13 +6,17 @@ package repository
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"regexp"
 	"strings"
 	"time"
 
+	"github.com/ClusterCockpit/cc-backend/internal/config"
 	"github.com/ClusterCockpit/cc-backend/internal/graph/model"
+	"github.com/ClusterCockpit/cc-backend/internal/tracing"
+	"github.com/ClusterCockpit/cc-backend/pkg/archive"
 	"github.com/ClusterCockpit/cc-backend/pkg/log"
 	"github.com/ClusterCockpit/cc-backend/pkg/schema"
 	sq "github.com/Masterminds/squirrel"
@@ -22,13 +26,174 @@ func (r *JobRepository) QueryJobs(
 	ctx context.Context,
 	filters []*model.JobFilter,
 	page *model.PageRequest,
-	order *model.OrderByInput) ([]*schema.Job, error) {
+	order *model.OrderByInput) (_ []*schema.Job, err error) {
 
-	query, qerr := SecurityCheck(ctx, sq.Select(jobColumns...).From("job"))
+	ctx, span := tracing.Start(ctx, "JobRepository.QueryJobs")
+	defer func() {
+		tracing.SetError(span, err)
+		span.End()
+	}()
+
+	query, qerr := buildJobsQuery(ctx, filters, page, order)
 	if qerr != nil {
 		return nil, qerr
 	}
 
+	rows, err := query.RunWith(r.stmtCache).Query()
+	if err != nil {
+		log.Errorf("Error while running query: %v", err)
+		return nil, err
+	}
+
+	jobs := make([]*schema.Job, 0, 50)
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			rows.Close()
+			log.Warn("Error while scanning rows (Jobs)")
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+
+	return jobs, nil
+}
+
+// IterJobs calls fn for every job matching filters in the given order
+// without loading all jobs into memory first. Iteration stops at the first
+// error returned by fn.
+func (r *JobRepository) IterJobs(
+	ctx context.Context,
+	filters []*model.JobFilter,
+	order *model.OrderByInput,
+	fn func(job *schema.Job) error) error {
+
+	query, qerr := buildJobsQuery(ctx, filters, nil, order)
+	if qerr != nil {
+		return qerr
+	}
+
+	rows, err := query.RunWith(r.stmtCache).Query()
+	if err != nil {
+		log.Errorf("Error while running query: %v", err)
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			log.Warn("Error while scanning rows (Jobs)")
+			return err
+		}
+		if err := fn(job); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// EncodeJobCursor returns the opaque cursor of job for QueryJobsAfter.
+func EncodeJobCursor(job *schema.Job) string {
+	return base64.RawURLEncoding.EncodeToString(
+		[]byte(fmt.Sprintf("%d:%d", job.StartTimeUnix, job.ID)))
+}
+
+func decodeJobCursor(cursor string) (startTime int64, id int64, err error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err == nil {
+		_, err = fmt.Sscanf(string(b), "%d:%d", &startTime, &id)
+	}
+	if err != nil {
+		return 0, 0, fmt.Errorf("REPOSITORY/QUERY > invalid cursor '%s'", cursor)
+	}
+	return startTime, id, nil
+}
+
+// Maximum number of jobs returned by QueryJobsAfter.
+const MaxCursorPageSize = 1000
+
+// QueryJobsAfter returns up to limit (at most MaxCursorPageSize) jobs matching
+// filters that follow the job of cursor, or the first ones if cursor is empty,
+// and whether there are more. Jobs are ordered by start time and id, descending unless order is
+// startTime ASC, so that the next page is selected by comparing with the
+// cursor instead of an OFFSET that gets slow deep into large result sets.
+func (r *JobRepository) QueryJobsAfter(
+	ctx context.Context,
+	filters []*model.JobFilter,
+	order *model.OrderByInput,
+	cursor string,
+	limit int) (_ []*schema.Job, hasNextPage bool, err error) {
+
+	if limit <= 0 {
+		return nil, false, fmt.Errorf("REPOSITORY/QUERY > invalid page size %d", limit)
+	}
+	if limit > MaxCursorPageSize {
+		limit = MaxCursorPageSize
+	}
+	direction, cmp := "DESC", "<"
+	if order != nil {
+		if order.Field != "startTime" {
+			return nil, false, fmt.Errorf("REPOSITORY/QUERY > cursors require ordering by startTime, not %s", order.Field)
+		}
+		if order.Order == model.SortDirectionEnumAsc {
+			direction, cmp = "ASC", ">"
+		}
+	}
+
+	query, err := buildJobsQuery(ctx, filters, nil, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	if cursor != "" {
+		startTime, id, err := decodeJobCursor(cursor)
+		if err != nil {
+			return nil, false, err
+		}
+		query = query.Where(fmt.Sprintf("(job.start_time %s ? OR (job.start_time = ? AND job.id %s ?))", cmp, cmp),
+			startTime, startTime, id)
+	}
+	// One more job than requested tells if there is a next page.
+	query = query.OrderBy("job.start_time "+direction, "job.id "+direction).Limit(uint64(limit) + 1)
+
+	rows, err := query.RunWith(r.stmtCache).Query()
+	if err != nil {
+		log.Errorf("Error while running query: %v", err)
+		return nil, false, err
+	}
+	defer rows.Close()
+
+	jobs := make([]*schema.Job, 0)
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			log.Warn("Error while scanning rows (Jobs)")
+			return nil, false, err
+		}
+		jobs = append(jobs, job)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, false, err
+	}
+
+	if len(jobs) > limit {
+		return jobs[:limit], true, nil
+	}
+	return jobs, false, nil
+}
+
+func buildJobsQuery(
+	ctx context.Context,
+	filters []*model.JobFilter,
+	page *model.PageRequest,
+	order *model.OrderByInput) (sq.SelectBuilder, error) {
+
+	query, qerr := SecurityCheck(ctx, sq.Select(jobColumns...).From("job"))
+	if qerr != nil {
+		return query, qerr
+	}
+
 	if order != nil {
 		field := toSnakeCase(order.Field)
 
@@ -38,7 +203,7 @@ func (r *JobRepository) QueryJobs(
 		case model.SortDirectionEnumDesc:
 			query = query.OrderBy(fmt.Sprintf("job.%s DESC", field))
 		default:
-			return nil, errors.New("REPOSITORY/QUERY > invalid sorting order")
+			return query, errors.New("REPOSITORY/QUERY > invalid sorting order")
 		}
 	}
 
@@ -51,17 +216,70 @@ func (r *JobRepository) QueryJobs(
 		query = BuildWhereClause(f, query)
 	}
 
+	return query, nil
+}
+
+// FindByRelativeFootprint returns all jobs whose footprint value of metric
+// compares with op (one of ">", ">=", "<", "<=") against fractionOfPeak times
+// the peak of the job's subcluster. Subclusters without a configured peak
+// are excluded.
+func (r *JobRepository) FindByRelativeFootprint(
+	ctx context.Context,
+	metric string,
+	fractionOfPeak float64,
+	op string,
+	filters []*model.JobFilter) ([]*schema.Job, error) {
+
+	fc, ok := footprintColumns[metric]
+	if !ok {
+		return nil, fmt.Errorf("REPOSITORY/QUERY > metric '%s' has no footprint column", metric)
+	}
+
+	switch op {
+	case ">", ">=", "<", "<=":
+	default:
+		return nil, fmt.Errorf("REPOSITORY/QUERY > invalid comparison operator '%s'", op)
+	}
+
+	conds := sq.Or{}
+	for _, cluster := range archive.GetClusters() {
+		for _, sc := range cluster.SubClusters {
+			peak := footprintPeak(cluster, sc, metric)
+			if peak <= 0 {
+				continue
+			}
+
+			conds = append(conds, sq.And{
+				sq.Eq{"job.cluster": cluster.Name, "job.subcluster": sc.Name},
+				sq.Expr(fmt.Sprintf("job.%s %s ?", fc.column, op), fractionOfPeak*peak),
+			})
+		}
+	}
+
+	jobs := make([]*schema.Job, 0, 50)
+	if len(conds) == 0 {
+		return jobs, nil
+	}
+
+	query, qerr := SecurityCheck(ctx, sq.Select(jobColumns...).From("job").Where(conds).OrderBy("job.id ASC"))
+	if qerr != nil {
+		return nil, qerr
+	}
+
+	for _, f := range filters {
+		query = BuildWhereClause(f, query)
+	}
+
 	rows, err := query.RunWith(r.stmtCache).Query()
 	if err != nil {
 		log.Errorf("Error while running query: %v", err)
 		return nil, err
 	}
+	defer rows.Close()
 
-	jobs := make([]*schema.Job, 0, 50)
 	for rows.Next() {
 		job, err := scanJob(rows)
 		if err != nil {
-			rows.Close()
 			log.Warn("Error while scanning rows (Jobs)")
 			return nil, err
 		}
@@ -71,6 +289,36 @@ func (r *JobRepository) QueryJobs(
 	return jobs, nil
 }
 
+// footprintPeak returns the peak of metric on a subcluster. A subcluster
+// specific peak in the metric config takes precedence over the cluster wide
+// one. If neither is set, the memory bandwidth (mem_bw) or SIMD flop rate
+// (flops_any) of the subcluster is used.
+func footprintPeak(cluster *schema.Cluster, sc *schema.SubCluster, metric string) float64 {
+	for _, mc := range cluster.MetricConfig {
+		if mc.Name != metric {
+			continue
+		}
+
+		for _, scc := range mc.SubClusters {
+			if scc.Name == sc.Name && scc.Peak > 0 {
+				return scc.Peak
+			}
+		}
+		if mc.Peak > 0 {
+			return mc.Peak
+		}
+	}
+
+	switch metric {
+	case "mem_bw":
+		return sc.MemoryBandwidth.Value
+	case "flops_any":
+		return sc.FlopRateSimd.Value
+	}
+
+	return 0
+}
+
 func (r *JobRepository) CountJobs(
 	ctx context.Context,
 	filters []*model.JobFilter) (int, error) {
@@ -92,12 +340,33 @@ func (r *JobRepository) CountJobs(
 	return count, nil
 }
 
+// CountRunningJobs returns the number of running jobs of user.
+func (r *JobRepository) CountRunningJobs(user string) (int, error) {
+	var count int
+	if err := sq.Select("count(*)").From("job").
+		Where("job.user = ?", user).
+		Where("job.job_state = ?", schema.JobStateRunning).
+		RunWith(r.stmtCache).Scan(&count); err != nil {
+		log.Warnf("Error while counting running jobs of user '%s'", user)
+		return 0, err
+	}
+
+	return count, nil
+}
+
 func SecurityCheck(ctx context.Context, query sq.SelectBuilder) (sq.SelectBuilder, error) {
 	user := GetUserFromContext(ctx)
 	if user == nil {
 		var qnil sq.SelectBuilder
 		return qnil, fmt.Errorf("user context is nil")
-	} else if user.HasAnyRole([]schema.Role{schema.RoleAdmin, schema.RoleSupport, schema.RoleApi}) { // Admin & Co. : All jobs
+	}
+
+	// Jobs of clusters the user may not access are excluded silently.
+	if forbidden := config.ForbiddenClusters(user); len(forbidden) != 0 {
+		query = query.Where(sq.NotEq{"job.cluster": forbidden})
+	}
+
+	if user.HasAnyRole([]schema.Role{schema.RoleAdmin, schema.RoleSupport, schema.RoleApi}) { // Admin & Co. : All jobs
 		return query, nil
 	} else if user.HasRole(schema.RoleManager) { // Manager : Add filter for managed projects' jobs only + personal jobs
 		if len(user.Projects) != 0 {
@@ -122,6 +391,10 @@ func BuildWhereClause(filter *model.JobFilter, query sq.SelectBuilder) sq.Select
 	if filter.Tags != nil {
 		query = query.Join("jobtag ON jobtag.job_id = job.id").Where(sq.Eq{"jobtag.tag_id": filter.Tags})
 	}
+	// Jobs with all of the tags.
+	for _, tag := range filter.AllTags {
+		query = query.Where("EXISTS (SELECT 1 FROM jobtag AS jt WHERE jt.job_id = job.id AND jt.tag_id = ?)", tag)
+	}
 	if filter.JobID != nil {
 		query = buildStringCondition("job.job_id", filter.JobID, query)
 	}
@@ -171,6 +444,9 @@ func BuildWhereClause(filter *model.JobFilter, query sq.SelectBuilder) sq.Select
 	if filter.NumHWThreads != nil {
 		query = buildIntCondition("job.num_hwthreads", filter.NumHWThreads, query)
 	}
+	if filter.Smt != nil {
+		query = query.Where("job.smt = ?", *filter.Smt)
+	}
 	if filter.Node != nil {
 		query = buildStringCondition("job.resources", filter.Node, query)
 	}
@@ -186,6 +462,9 @@ func BuildWhereClause(filter *model.JobFilter, query sq.SelectBuilder) sq.Select
 	if filter.MemUsedMax != nil {
 		query = buildFloatCondition("job.mem_used_max", filter.MemUsedMax, query)
 	}
+	if filter.DataCompleteness != nil {
+		query = buildFloatCondition("job.data_completeness", filter.DataCompleteness, query)
+	}
 	return query
 }
 
@@ -0,0 +1,256 @@
+// Copyright (C) 2022 NHR@FAU, University Erlangen-Nuremberg.
+// All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+package repository
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ClusterCockpit/cc-backend/internal/config"
+	"github.com/ClusterCockpit/cc-backend/internal/graph/model"
+	"github.com/ClusterCockpit/cc-backend/pkg/archive"
+	"github.com/ClusterCockpit/cc-backend/pkg/schema"
+)
+
+func TestQueryJobsSmt(t *testing.T) {
+	r := setup(t)
+
+	smtOff, smtOn := 0, 1
+	jobs, err := r.QueryJobs(getContext(t), []*model.JobFilter{{Smt: &smtOff}}, nil, nil)
+	noErr(t, err)
+
+	if len(jobs) != 6 {
+		t.Errorf("wrong number of jobs with smt disabled\ngot: %d \nwant: 6", len(jobs))
+	}
+
+	jobs, err = r.QueryJobs(getContext(t), []*model.JobFilter{{Smt: &smtOn}}, nil, nil)
+	noErr(t, err)
+
+	if len(jobs) != 0 {
+		t.Errorf("wrong number of jobs with smt enabled\ngot: %d \nwant: 0", len(jobs))
+	}
+}
+
+func TestQueryJobsDataCompleteness(t *testing.T) {
+	r := setup(t)
+
+	t.Cleanup(func() {
+		r.DB.Exec(`UPDATE job SET data_completeness = NULL WHERE id IN (5, 6)`)
+	})
+	for id, completeness := range map[int64]float64{5: 0.75, 6: 1} {
+		noErr(t, r.MarkArchived(id, schema.MonitoringStatusArchivingSuccessful, nil, &completeness))
+	}
+
+	job, err := r.FindById(5)
+	noErr(t, err)
+	if job.DataCompleteness == nil || *job.DataCompleteness != 0.75 {
+		t.Errorf("wrong data completeness: %v", job.DataCompleteness)
+	}
+
+	jobs, err := r.QueryJobs(getContext(t), []*model.JobFilter{{DataCompleteness: &model.FloatRange{From: 0, To: 0.9}}}, nil, nil)
+	noErr(t, err)
+	if len(jobs) != 1 || jobs[0].ID != 5 {
+		t.Errorf("wrong jobs with incomplete data: %v", jobs)
+	}
+}
+
+func TestFindByRelativeFootprint(t *testing.T) {
+	r := setup(t)
+
+	clusters := archive.Clusters
+	archive.Clusters = []*schema.Cluster{{
+		Name:         "relfp",
+		MetricConfig: []*schema.MetricConfig{{Name: "mem_bw", Scope: schema.MetricScopeNode, Timestep: 60}},
+		SubClusters: []*schema.SubCluster{
+			{Name: "sc1", MemoryBandwidth: schema.MetricValue{Value: 100}},
+			{Name: "sc2"},
+		},
+	}}
+	t.Cleanup(func() {
+		archive.Clusters = clusters
+		r.DB.Exec(`DELETE FROM job WHERE cluster = 'relfp'`)
+	})
+
+	const input = `{"jobId": 4001, "user": "u1", "project": "p1", "cluster": "relfp", "subCluster": "sc1", "numNodes": 1, "exclusive": 1, "jobState": "completed", "duration": 60, "resources": [{"hostname": "n1"}], "startTime": 1675957000, "statistics": {"mem_bw": {"unit": {"base": "B/s"}, "avg": 95, "min": 0, "max": 100}}}
+{"jobId": 4002, "user": "u1", "project": "p1", "cluster": "relfp", "subCluster": "sc1", "numNodes": 1, "exclusive": 1, "jobState": "completed", "duration": 60, "resources": [{"hostname": "n1"}], "startTime": 1675957100, "statistics": {"mem_bw": {"unit": {"base": "B/s"}, "avg": 50, "min": 0, "max": 100}}}
+{"jobId": 4003, "user": "u1", "project": "p1", "cluster": "relfp", "subCluster": "sc2", "numNodes": 1, "exclusive": 1, "jobState": "completed", "duration": 60, "resources": [{"hostname": "n2"}], "startTime": 1675957200, "statistics": {"mem_bw": {"unit": {"base": "B/s"}, "avg": 99, "min": 0, "max": 100}}}
+`
+	if _, _, err := r.ImportNDJSON(strings.NewReader(input)); err != nil {
+		t.Fatal(err)
+	}
+
+	jobs, err := r.FindByRelativeFootprint(getContext(t), "mem_bw", 0.9, ">", nil)
+	noErr(t, err)
+
+	if len(jobs) != 1 || jobs[0].JobID != 4001 {
+		t.Fatalf("wrong jobs above 90%% of peak: %#v", jobs)
+	}
+
+	jobs, err = r.FindByRelativeFootprint(getContext(t), "mem_bw", 0.9, "<=", nil)
+	noErr(t, err)
+
+	if len(jobs) != 1 || jobs[0].JobID != 4002 {
+		t.Fatalf("wrong jobs below 90%% of peak: %#v", jobs)
+	}
+
+	if _, err := r.FindByRelativeFootprint(getContext(t), "mem_bw", 0.9, "!=", nil); err == nil {
+		t.Error("expected error for invalid operator")
+	}
+}
+
+func TestQueryJobsAllTags(t *testing.T) {
+	r := setup(t)
+
+	t.Cleanup(func() {
+		r.DB.Exec(`DELETE FROM jobtag WHERE tag_id IN (SELECT id FROM tag WHERE tag_type = 'alltagstest')`)
+		r.DB.Exec(`DELETE FROM tag WHERE tag_type = 'alltagstest'`)
+	})
+
+	tag := func(name string, jobs ...int64) string {
+		id, err := r.CreateTag(nil, "alltagstest", name, TagScopeGlobal)
+		noErr(t, err)
+		for _, job := range jobs {
+			_, err := r.DB.Exec(`INSERT INTO jobtag (job_id, tag_id) VALUES (?, ?)`, job, id)
+			noErr(t, err)
+		}
+		return strconv.FormatInt(id, 10)
+	}
+	a, b, c := tag("a", 1, 2, 3), tag("b", 2, 3, 4), tag("c", 3)
+
+	for _, tc := range []struct {
+		tags []string
+		want []int64
+	}{
+		{[]string{a}, []int64{1, 2, 3}},
+		{[]string{a, b}, []int64{2, 3}},
+		{[]string{a, b, c}, []int64{3}},
+		{[]string{c, tag("d")}, []int64{}},
+	} {
+		jobs, err := r.QueryJobs(getContext(t), []*model.JobFilter{{AllTags: tc.tags}}, nil, nil)
+		noErr(t, err)
+		got := make([]int64, 0, len(jobs))
+		for _, job := range jobs {
+			got = append(got, job.ID)
+		}
+		sort.Slice(got, func(i, j int) bool { return got[i] < got[j] })
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("wrong jobs with all tags %v: want %v, got %v", tc.tags, tc.want, got)
+		}
+	}
+}
+
+func TestQueryJobsAfter(t *testing.T) {
+	r := setup(t)
+	t.Cleanup(func() {
+		r.DB.Exec(`DELETE FROM job WHERE cluster = 'cursor'`)
+	})
+
+	// Several jobs share a start time, so that pages are split within them.
+	const job = `{"jobId": %d, "user": "u1", "project": "p1", "cluster": "cursor", "subCluster": "main", "numNodes": 1, "exclusive": 1, "jobState": "completed", "duration": 60, "resources": [{"hostname": "n1"}], "startTime": %d}` + "\n"
+	input := ""
+	for i := 0; i < 10; i++ {
+		input += fmt.Sprintf(job, 6000+i, 1675957000+int64(i/3)*100)
+	}
+	if _, _, err := r.ImportNDJSON(strings.NewReader(input)); err != nil {
+		t.Fatal(err)
+	}
+
+	cluster := "cursor"
+	filters := []*model.JobFilter{{Cluster: &model.StringInput{Eq: &cluster}}}
+	for _, order := range []*model.OrderByInput{
+		nil,
+		{Field: "startTime", Order: model.SortDirectionEnumAsc},
+	} {
+		all, err := r.QueryJobs(getContext(t), filters, nil, nil)
+		noErr(t, err)
+		sort.Slice(all, func(i, j int) bool {
+			if all[i].StartTimeUnix != all[j].StartTimeUnix {
+				return all[i].StartTimeUnix > all[j].StartTimeUnix
+			}
+			return all[i].ID > all[j].ID
+		})
+		if order != nil {
+			for i, j := 0, len(all)-1; i < j; i, j = i+1, j-1 {
+				all[i], all[j] = all[j], all[i]
+			}
+		}
+
+		got, cursor, pages := []int64{}, "", 0
+		for {
+			jobs, hasNextPage, err := r.QueryJobsAfter(getContext(t), filters, order, cursor, 4)
+			noErr(t, err)
+			pages++
+			for _, job := range jobs {
+				got = append(got, job.ID)
+			}
+			if !hasNextPage {
+				break
+			}
+			cursor = EncodeJobCursor(jobs[len(jobs)-1])
+		}
+
+		want := make([]int64, 0, len(all))
+		for _, job := range all {
+			want = append(want, job.ID)
+		}
+		if pages != 3 || !reflect.DeepEqual(got, want) {
+			t.Errorf("order %v: wrong jobs after %d pages\ngot: %v \nwant: %v", order, pages, got, want)
+		}
+	}
+
+	if _, _, err := r.QueryJobsAfter(getContext(t), filters, nil, "not a cursor", 4); err == nil {
+		t.Error("expected error for invalid cursor")
+	}
+	if _, _, err := r.QueryJobsAfter(getContext(t), filters, &model.OrderByInput{Field: "duration", Order: model.SortDirectionEnumAsc}, "", 4); err == nil {
+		t.Error("expected error when not ordering by startTime")
+	}
+	if _, _, err := r.QueryJobsAfter(getContext(t), filters, nil, "", -1); err == nil {
+		t.Error("expected error for negative page size")
+	}
+	if jobs, hasNextPage, err := r.QueryJobsAfter(getContext(t), filters, nil, "", math.MaxInt); err != nil || hasNextPage || len(jobs) != 10 {
+		t.Errorf("huge page size: expected all 10 jobs, got %d (next page: %v): %v", len(jobs), hasNextPage, err)
+	}
+}
+
+func TestQueryJobsClusterAccess(t *testing.T) {
+	r := setup(t)
+	clusters := config.Keys.Clusters
+	t.Cleanup(func() {
+		config.Keys.Clusters = clusters
+		r.DB.Exec(`DELETE FROM job WHERE cluster IN ('acl-open', 'acl-closed')`)
+	})
+
+	const job = `{"jobId": %d, "user": "u1", "project": "p1", "cluster": "%s", "subCluster": "main", "numNodes": 1, "exclusive": 1, "jobState": "completed", "duration": 60, "resources": [{"hostname": "n1"}], "startTime": 1675957000}` + "\n"
+	input := fmt.Sprintf(job, 6100, "acl-open") + fmt.Sprintf(job, 6101, "acl-closed")
+	if _, _, err := r.ImportNDJSON(strings.NewReader(input)); err != nil {
+		t.Fatal(err)
+	}
+
+	config.Keys.Clusters = []*schema.ClusterConfig{
+		{Name: "acl-open"},
+		{Name: "acl-closed", Access: &schema.ClusterAccessConfig{AllowUsers: []string{"u2"}}},
+	}
+
+	user := &schema.User{Username: "u1", Roles: []string{schema.GetRoleString(schema.RoleUser)}}
+	ctx := context.WithValue(context.Background(), ContextUserKey, user)
+	jobs, err := r.QueryJobs(ctx, nil, nil, nil)
+	noErr(t, err)
+	if len(jobs) != 1 || jobs[0].JobID != 6100 {
+		t.Errorf("expected only the job on acl-open, got %d jobs", len(jobs))
+	}
+
+	// Admins bypass the access configuration.
+	jobs, err = r.QueryJobs(getContext(t), []*model.JobFilter{{User: &model.StringInput{Eq: &user.Username}}}, nil, nil)
+	noErr(t, err)
+	if len(jobs) != 2 {
+		t.Errorf("expected both jobs for admin, got %d", len(jobs))
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright (C) 2023 NHR@FAU, University Erlangen-Nuremberg.
+// All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+package repository
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Replacement of redacted secrets in the metadata of jobs.
+const redacted = "***"
+
+// defaultRedactPatterns are used by RedactMetadata unless InitMetadataRedaction
+// is called with other patterns. They cover assignments to variables named
+// like secrets, bearer tokens, GitHub and AWS access keys and private keys.
+var defaultRedactPatterns = []string{
+	`(?i)(?:password|passwd|secret|token|api[_-]?key|access[_-]?key)[a-z0-9_]*["']?\s*[=:]\s*["']?([^\s"']+)`,
+	`(?i)bearer\s+([a-z0-9\-._~+/]+=*)`,
+	`gh[pousr]_[A-Za-z0-9]{36,}`,
+	`AKIA[0-9A-Z]{16}`,
+	`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`,
+}
+
+// The patterns applied by RedactMetadata, set by InitMetadataRedaction.
+var redactPatterns = compileRedactPatterns(defaultRedactPatterns)
+
+func compileRedactPatterns(patterns []string) []*regexp.Regexp {
+	res := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		res = append(res, regexp.MustCompile(pattern))
+	}
+	return res
+}
+
+// InitMetadataRedaction sets the patterns of secrets removed from the
+// metadata by RedactMetadata. The default patterns are kept if patterns is
+// nil, an empty list disables the redaction.
+func InitMetadataRedaction(patterns []string) error {
+	if patterns == nil {
+		return nil
+	}
+
+	res := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("REPOSITORY/REDACT > pattern %#v: %w", pattern, err)
+		}
+		res = append(res, re)
+	}
+
+	redactPatterns = res
+	return nil
+}
+
+// RedactMetadata replaces the matches of the redaction patterns in the
+// values of metaData (e.g. the jobScript) by "***". For patterns with
+// subexpressions, only the text of the subexpressions is replaced, e.g. the
+// value but not the name of a variable. Returns a redacted copy, metaData
+// itself is not modified.
+func RedactMetadata(metaData map[string]string) map[string]string {
+	if metaData == nil || len(redactPatterns) == 0 {
+		return metaData
+	}
+
+	res := make(map[string]string, len(metaData))
+	for key, value := range metaData {
+		for _, re := range redactPatterns {
+			value = redact(re, value)
+		}
+		res[key] = value
+	}
+	return res
+}
+
+func redact(re *regexp.Regexp, s string) string {
+	matches := re.FindAllStringSubmatchIndex(s, -1)
+	if matches == nil {
+		return s
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, match := range matches {
+		if len(match) == 2 {
+			b.WriteString(s[last:match[0]])
+			b.WriteString(redacted)
+			last = match[1]
+			continue
+		}
+
+		// Replace the subexpressions that matched.
+		for i := 2; i < len(match); i += 2 {
+			if match[i] < 0 || match[i] < last {
+				continue
+			}
+			b.WriteString(s[last:match[i]])
+			b.WriteString(redacted)
+			last = match[i+1]
+		}
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
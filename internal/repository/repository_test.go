@@ -74,7 +74,7 @@ func BenchmarkDB_FindJob(b *testing.B) {
 
 		b.RunParallel(func(pb *testing.PB) {
 			for pb.Next() {
-				_, err := db.Find(&jobId, &cluster, &startTime)
+				_, err := db.Find(context.Background(), &jobId, &cluster, &startTime)
 				noErr(b, err)
 			}
 		})
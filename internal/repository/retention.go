@@ -0,0 +1,111 @@
+// Copyright (C) 2023 NHR@FAU, University Erlangen-Nuremberg.
+// All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+package repository
+
+import (
+	"time"
+
+	"github.com/ClusterCockpit/cc-backend/pkg/archive"
+	"github.com/ClusterCockpit/cc-backend/pkg/log"
+	"github.com/ClusterCockpit/cc-backend/pkg/schema"
+	sq "github.com/Masterminds/squirrel"
+)
+
+// ApplyRetentionTiers reduces the metric data of archived jobs in the
+// job-archive according to the tiers of retention: Jobs which started more
+// than StatsOnlyAge days before now only keep their statistics and get the
+// monitoring status MonitoringStatusArchiveStatsOnly, jobs which started more
+// than DownsampleAge days before now are rewritten at DownsampleTimestep and
+// get the monitoring status MonitoringStatusArchiveDownsampled. Jobs are never
+// moved back to a finer tier. With dryRun, the jobs are only logged. Returns
+// the number of downsampled and reduced jobs, errors of single jobs are
+// logged and skipped.
+func (r *JobRepository) ApplyRetentionTiers(retention schema.Retention, now time.Time, dryRun bool) (downsampled, statsOnly int, err error) {
+	statsOnlyEnd := int64(0)
+	if retention.StatsOnlyAge > 0 {
+		statsOnlyEnd = now.Unix() - int64(retention.StatsOnlyAge*24*3600)
+		statsOnly, err = r.applyRetentionTier(0, statsOnlyEnd,
+			[]int32{schema.MonitoringStatusArchivingSuccessful, schema.MonitoringStatusArchiveDownsampled},
+			schema.MonitoringStatusArchiveStatsOnly, dryRun, archive.ReduceJobDataToStatistics)
+		if err != nil {
+			return
+		}
+	}
+
+	if retention.DownsampleAge > 0 && retention.DownsampleTimestep > 0 {
+		// Jobs of the statistics tier are skipped, even if reducing them
+		// failed or dryRun is set.
+		downsampled, err = r.applyRetentionTier(statsOnlyEnd, now.Unix()-int64(retention.DownsampleAge*24*3600),
+			[]int32{schema.MonitoringStatusArchivingSuccessful},
+			schema.MonitoringStatusArchiveDownsampled, dryRun, func(job *schema.Job) error {
+				return archive.DownsampleJobData(job, retention.DownsampleTimestep)
+			})
+	}
+	return
+}
+
+// applyRetentionTier applies reduce to the archived jobs with one of the
+// monitoring statuses from which started between startTimeBegin (inclusive)
+// and startTimeEnd and sets their monitoring status to to. Jobs are processed
+// ordered by database id in batches of 100.
+func (r *JobRepository) applyRetentionTier(startTimeBegin, startTimeEnd int64, from []int32, to int32,
+	dryRun bool, reduce func(job *schema.Job) error) (int, error) {
+	lastId, count, errorOccured := int64(0), 0, 0
+	for {
+		rows, err := sq.Select(jobColumns...).From("job").
+			Where("job.id > ?", lastId).
+			Where("job.start_time >= ? AND job.start_time < ?", startTimeBegin, startTimeEnd).
+			Where("job.job_state != ?", schema.JobStateRunning).
+			Where(sq.Eq{"job.monitoring_status": from}).
+			OrderBy("job.id").Limit(100).
+			RunWith(r.stmtCache).Query()
+		if err != nil {
+			log.Error("Error while querying archived jobs")
+			return count, err
+		}
+
+		jobs := make([]*schema.Job, 0, 100)
+		for rows.Next() {
+			job, err := scanJob(rows)
+			if err != nil {
+				rows.Close()
+				log.Warn("Error while scanning rows")
+				return count, err
+			}
+			jobs = append(jobs, job)
+		}
+		rows.Close()
+
+		if len(jobs) == 0 {
+			break
+		}
+
+		for _, job := range jobs {
+			lastId = job.ID
+			if dryRun {
+				log.Infof("Retention: would set job (dbid: %d) to monitoring status %d", job.ID, to)
+				count++
+				continue
+			}
+
+			if err := reduce(job); err != nil {
+				log.Errorf("Retention: reducing the data of job (dbid: %d) failed: %v", job.ID, err)
+				errorOccured++
+				continue
+			}
+			if err := r.UpdateMonitoringStatus(job.ID, to); err != nil {
+				log.Errorf("Retention: updating job (dbid: %d) failed: %v", job.ID, err)
+				errorOccured++
+				continue
+			}
+			count++
+		}
+	}
+
+	if errorOccured > 0 {
+		log.Warnf("Retention: reducing the data of %d jobs failed", errorOccured)
+	}
+	return count, nil
+}
@@ -0,0 +1,112 @@
+// Copyright (C) 2023 NHR@FAU, University Erlangen-Nuremberg.
+// All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ClusterCockpit/cc-backend/internal/util"
+	"github.com/ClusterCockpit/cc-backend/pkg/archive"
+	"github.com/ClusterCockpit/cc-backend/pkg/schema"
+)
+
+func TestApplyRetentionTiers(t *testing.T) {
+	r := setup(t)
+
+	jobarchive := filepath.Join(t.TempDir(), "job-archive")
+	if err := util.CopyDir("../../pkg/archive/testdata/archive/", jobarchive); err != nil {
+		t.Fatal(err)
+	}
+	clusters := archive.Clusters
+	if err := archive.Init(json.RawMessage(fmt.Sprintf(`{"kind": "file", "path": "%s"}`, jobarchive)), false); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		archive.Clusters = clusters
+		if _, err := r.DB.Exec(`DELETE FROM job WHERE cluster = 'emmy'`); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	var ndjson bytes.Buffer
+	for _, dir := range []string{"1403/244/1608923076", "1404/397/1609300556"} {
+		raw, err := os.ReadFile(filepath.Join(jobarchive, "emmy", dir, "meta.json"))
+		noErr(t, err)
+		noErr(t, json.Compact(&ndjson, raw))
+		ndjson.WriteByte('\n')
+	}
+	_, _, err := r.ImportNDJSON(&ndjson)
+	noErr(t, err)
+	_, err = r.DB.Exec(`UPDATE job SET monitoring_status = ? WHERE cluster = 'emmy'`,
+		schema.MonitoringStatusArchivingSuccessful)
+	noErr(t, err)
+
+	// Job 1403244 started 5 days before job 1404397: With now 10 days after
+	// the start of job 1404397, the first job is older than statsOnlyAge and
+	// the second one only older than downsampleAge.
+	now := time.Unix(1609300556, 0).Add(10 * 24 * time.Hour)
+	retention := schema.Retention{DownsampleAge: 7, DownsampleTimestep: 600, StatsOnlyAge: 12}
+
+	downsampled, statsOnly, err := r.ApplyRetentionTiers(retention, now, true)
+	noErr(t, err)
+	if downsampled != 1 || statsOnly != 1 {
+		t.Fatalf("dry run: expected 1 downsampled and 1 reduced job, got %d and %d", downsampled, statsOnly)
+	}
+
+	cluster := "emmy"
+	find := func(jobId int64) *schema.Job {
+		job, err := r.Find(context.Background(), &jobId, &cluster, nil)
+		noErr(t, err)
+		return job
+	}
+	if job := find(1403244); job.MonitoringStatus != schema.MonitoringStatusArchivingSuccessful {
+		t.Fatalf("dry run changed the monitoring status to %d", job.MonitoringStatus)
+	}
+
+	downsampled, statsOnly, err = r.ApplyRetentionTiers(retention, now, false)
+	noErr(t, err)
+	if downsampled != 1 || statsOnly != 1 {
+		t.Fatalf("expected 1 downsampled and 1 reduced job, got %d and %d", downsampled, statsOnly)
+	}
+
+	job := find(1403244)
+	if job.MonitoringStatus != schema.MonitoringStatusArchiveStatsOnly {
+		t.Errorf("job 1403244: expected monitoring status %d, got %d", schema.MonitoringStatusArchiveStatsOnly, job.MonitoringStatus)
+	}
+	jobData, err := archive.GetHandle().LoadJobData(job)
+	noErr(t, err)
+	if series := jobData["flops_any"][schema.MetricScopeNode].Series; len(series[0].Data) != 1 {
+		t.Errorf("job 1403244: expected one data point, got %d", len(series[0].Data))
+	}
+
+	job = find(1404397)
+	if job.MonitoringStatus != schema.MonitoringStatusArchiveDownsampled {
+		t.Errorf("job 1404397: expected monitoring status %d, got %d", schema.MonitoringStatusArchiveDownsampled, job.MonitoringStatus)
+	}
+	jobData, err = archive.GetHandle().LoadJobData(job)
+	noErr(t, err)
+	if timestep := jobData["flops_any"][schema.MetricScopeNode].Timestep; timestep != 600 {
+		t.Errorf("job 1404397: expected timestep 600, got %d", timestep)
+	}
+
+	// Once both jobs are older than statsOnlyAge, the downsampled job is
+	// reduced as well, nothing is downsampled again.
+	downsampled, statsOnly, err = r.ApplyRetentionTiers(retention, now.Add(5*24*time.Hour), false)
+	noErr(t, err)
+	if downsampled != 0 || statsOnly != 1 {
+		t.Fatalf("expected 0 downsampled and 1 reduced job, got %d and %d", downsampled, statsOnly)
+	}
+	if job := find(1404397); job.MonitoringStatus != schema.MonitoringStatusArchiveStatsOnly {
+		t.Errorf("job 1404397: expected monitoring status %d, got %d", schema.MonitoringStatusArchiveStatsOnly, job.MonitoringStatus)
+	}
+}
@@ -9,6 +9,8 @@ import (
 	"database/sql"
 	"fmt"
 	"math"
+	"sort"
+	"strconv"
 	"time"
 
 	"github.com/ClusterCockpit/cc-backend/internal/config"
@@ -563,7 +565,7 @@ func (r *JobRepository) jobsMetricStatisticsHistogram(
 	}
 
 	if peak == 0.0 {
-		for _, c := range archive.Clusters {
+		for _, c := range archive.GetClusters() {
 			for _, m := range c.MetricConfig {
 				if m.Name == metric {
 					if m.Peak > peak {
@@ -705,7 +707,7 @@ func (r *JobRepository) runningJobsMetricStatisticsHistogram(
 		}
 
 		if peak == 0.0 {
-			for _, c := range archive.Clusters {
+			for _, c := range archive.GetClusters() {
 				for _, m := range c.MetricConfig {
 					if m.Name == metric {
 						if m.Peak > peak {
@@ -752,3 +754,386 @@ func (r *JobRepository) runningJobsMetricStatisticsHistogram(
 
 	return data
 }
+
+// TagGroupStats holds the aggregated footprint of all jobs tagged with Tag.
+type TagGroupStats struct {
+	Tag   schema.Tag
+	Count int
+	Avg   float64
+	Min   float64
+	Max   float64
+}
+
+// GroupComparison is the result of CompareTagGroups. Delta is the difference
+// of the averages (B.Avg - A.Avg).
+type GroupComparison struct {
+	Metric string
+	A      TagGroupStats
+	B      TagGroupStats
+	Delta  float64
+}
+
+// CompareTagGroups aggregates the footprint of metric over all finished jobs
+// tagged with tagA and tagB respectively. Tags are looked up by ID if set,
+// otherwise by type, name and scope. Only tags and jobs visible to the user
+// in ctx are taken into account.
+func (r *JobRepository) CompareTagGroups(
+	ctx context.Context,
+	tagA, tagB schema.Tag,
+	metric string) (GroupComparison, error) {
+
+	start := time.Now()
+	fc, ok := footprintColumns[metric]
+	if !ok {
+		return GroupComparison{}, fmt.Errorf("REPOSITORY/STATS > metric '%s' has no footprint column", metric)
+	}
+
+	a, err := r.tagGroupStats(ctx, tagA, fc.column)
+	if err != nil {
+		return GroupComparison{}, err
+	}
+	b, err := r.tagGroupStats(ctx, tagB, fc.column)
+	if err != nil {
+		return GroupComparison{}, err
+	}
+
+	log.Debugf("Timer CompareTagGroups %s", time.Since(start))
+	return GroupComparison{Metric: metric, A: a, B: b, Delta: b.Avg - a.Avg}, nil
+}
+
+func (r *JobRepository) tagGroupStats(
+	ctx context.Context,
+	tag schema.Tag,
+	column string) (TagGroupStats, error) {
+
+	if tag.ID == 0 {
+		if tag.Scope == "" {
+			tag.Scope = TagScopeGlobal
+		}
+		id, exists := r.TagId(tag.Type, tag.Name, tag.Scope)
+		if !exists {
+			return TagGroupStats{}, fmt.Errorf("REPOSITORY/STATS > tag %s:%s (%s) does not exist", tag.Type, tag.Name, tag.Scope)
+		}
+		tag.ID = id
+	} else if err := sq.Select("tag_type", "tag_name", "tag_scope").From("tag").Where("tag.id = ?", tag.ID).
+		RunWith(r.stmtCache).QueryRow().Scan(&tag.Type, &tag.Name, &tag.Scope); err != nil {
+		log.Warnf("Error while looking up tag %d", tag.ID)
+		return TagGroupStats{}, err
+	}
+
+	if !tagVisible(GetUserFromContext(ctx), tag.Scope) {
+		return TagGroupStats{}, ErrForbidden
+	}
+
+	query := sq.Select("COUNT(job.id)",
+		fmt.Sprintf("AVG(job.%s)", column),
+		fmt.Sprintf("MIN(job.%s)", column),
+		fmt.Sprintf("MAX(job.%s)", column)).
+		From("job").Where("job.job_state != ?", "running")
+	query = BuildWhereClause(&model.JobFilter{Tags: []string{strconv.FormatInt(tag.ID, 10)}}, query)
+	query, err := SecurityCheck(ctx, query)
+	if err != nil {
+		return TagGroupStats{}, err
+	}
+
+	stats := TagGroupStats{Tag: tag}
+	var avg, min, max sql.NullFloat64
+	if err := query.RunWith(r.DB).QueryRow().Scan(&stats.Count, &avg, &min, &max); err != nil {
+		log.Warn("Error while scanning rows")
+		return TagGroupStats{}, err
+	}
+	stats.Avg, stats.Min, stats.Max = avg.Float64, min.Float64, max.Float64
+
+	return stats, nil
+}
+
+// DurationBucket counts the jobs with a duration in [From, To) seconds.
+// To is 0 for the last, open-ended bucket.
+type DurationBucket struct {
+	Label string
+	From  int
+	To    int
+	Count int
+}
+
+// ClusterJobStats summarizes the jobs of a cluster for the dashboard.
+type ClusterJobStats struct {
+	Cluster         string
+	TotalJobs       int
+	JobsByState     map[schema.JobState]int
+	TotalNodeHours  float64
+	AvgNodeHours    float64
+	DurationBuckets []DurationBucket
+}
+
+var jobDurationBuckets = []DurationBucket{
+	{Label: "<1h", From: 0, To: 3600},
+	{Label: "1-8h", From: 3600, To: 8 * 3600},
+	{Label: "8-24h", From: 8 * 3600, To: 24 * 3600},
+	{Label: ">24h", From: 24 * 3600},
+}
+
+// JobStatsSummary returns per cluster the number of jobs by state, the total
+// and average node hours and the distribution of the job durations. Unlike
+// JobsStats, all of this is computed with a single grouped query. The
+// duration of running jobs is the time since their start.
+func (r *JobRepository) JobStatsSummary(
+	ctx context.Context,
+	filter []*model.JobFilter) ([]*ClusterJobStats, error) {
+
+	start := time.Now()
+	duration := fmt.Sprintf(`(CASE WHEN job.job_state = 'running' THEN %d - job.start_time ELSE job.duration END)`, time.Now().Unix())
+	bucket := "CASE"
+	for i, b := range jobDurationBuckets[:len(jobDurationBuckets)-1] {
+		bucket += fmt.Sprintf(" WHEN %s < %d THEN %d", duration, b.To, i)
+	}
+	bucket += fmt.Sprintf(" ELSE %d END", len(jobDurationBuckets)-1)
+
+	// Scan columns: cluster, state, bucket, count, nodeSeconds
+	query := sq.Select("job.cluster", "job.job_state", bucket+" as bucket", "COUNT(job.id)",
+		fmt.Sprintf("SUM(%s * job.num_nodes)", duration)).
+		From("job").GroupBy("job.cluster", "job.job_state", "bucket").OrderBy("job.cluster")
+	for _, f := range filter {
+		query = BuildWhereClause(f, query)
+	}
+	query, err := SecurityCheck(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := query.RunWith(r.DB).Query()
+	if err != nil {
+		log.Warn("Error while querying DB for job statistics")
+		return nil, err
+	}
+	defer rows.Close()
+
+	stats := make([]*ClusterJobStats, 0)
+	var cs *ClusterJobStats
+	for rows.Next() {
+		var cluster, state string
+		var bucket, count int
+		var nodeSeconds sql.NullInt64
+		if err := rows.Scan(&cluster, &state, &bucket, &count, &nodeSeconds); err != nil {
+			log.Warn("Error while scanning rows")
+			return nil, err
+		}
+
+		if cs == nil || cs.Cluster != cluster {
+			cs = &ClusterJobStats{
+				Cluster:         cluster,
+				JobsByState:     make(map[schema.JobState]int),
+				DurationBuckets: make([]DurationBucket, len(jobDurationBuckets)),
+			}
+			copy(cs.DurationBuckets, jobDurationBuckets)
+			stats = append(stats, cs)
+		}
+
+		cs.TotalJobs += count
+		cs.JobsByState[schema.JobState(state)] += count
+		cs.DurationBuckets[bucket].Count += count
+		cs.TotalNodeHours += float64(nodeSeconds.Int64) / 3600
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, cs := range stats {
+		cs.AvgNodeHours = cs.TotalNodeHours / float64(cs.TotalJobs)
+	}
+
+	log.Debugf("Timer JobStatsSummary %s", time.Since(start))
+	return stats, nil
+}
+
+// UserStat is the usage of a user or project: the number of jobs and the
+// summed node hours (number of nodes times duration). The duration of running
+// jobs is the time since their start.
+type UserStat struct {
+	Name      string
+	TotalJobs int
+	NodeHours float64
+}
+
+// TopUsers returns the users ranked by their node hours of the jobs started
+// in [from, to] on cluster (all clusters if empty). If limit is positive, at
+// most limit users are returned.
+func (r *JobRepository) TopUsers(
+	ctx context.Context,
+	cluster string,
+	from, to int64,
+	limit int) ([]UserStat, error) {
+
+	return r.topUsage(ctx, "job.user", cluster, from, to, limit)
+}
+
+// TopProjects is like TopUsers, but for projects.
+func (r *JobRepository) TopProjects(
+	ctx context.Context,
+	cluster string,
+	from, to int64,
+	limit int) ([]UserStat, error) {
+
+	return r.topUsage(ctx, "job.project", cluster, from, to, limit)
+}
+
+func (r *JobRepository) topUsage(
+	ctx context.Context,
+	col, cluster string,
+	from, to int64,
+	limit int) ([]UserStat, error) {
+
+	start := time.Now()
+	duration := fmt.Sprintf(`(CASE WHEN job.job_state = 'running' THEN %d - job.start_time ELSE job.duration END)`, time.Now().Unix())
+
+	// Scan columns: name, count, nodeSeconds
+	query := sq.Select(col, "COUNT(job.id)", fmt.Sprintf("SUM(%s * job.num_nodes) as nodeSeconds", duration)).
+		From("job").Where("job.start_time BETWEEN ? AND ?", from, to).
+		GroupBy(col).OrderBy("nodeSeconds DESC", col)
+	if cluster != "" {
+		query = query.Where("job.cluster = ?", cluster)
+	}
+	if limit > 0 {
+		query = query.Limit(uint64(limit))
+	}
+	query, err := SecurityCheck(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := query.RunWith(r.DB).Query()
+	if err != nil {
+		log.Warn("Error while querying DB for top usage")
+		return nil, err
+	}
+	defer rows.Close()
+
+	stats := make([]UserStat, 0)
+	for rows.Next() {
+		var s UserStat
+		var nodeSeconds sql.NullInt64
+		if err := rows.Scan(&s.Name, &s.TotalJobs, &nodeSeconds); err != nil {
+			log.Warn("Error while scanning rows")
+			return nil, err
+		}
+		s.NodeHours = float64(nodeSeconds.Int64) / 3600
+		stats = append(stats, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	log.Debugf("Timer topUsage %s %s", col, time.Since(start))
+	return stats, nil
+}
+
+// FootprintPercentile is the percentile rank of the footprint value of a job
+// for one metric among NumJobs comparable jobs.
+type FootprintPercentile struct {
+	Metric     string
+	Value      float64
+	Percentile float64 // In [0, 100]
+	NumJobs    int
+}
+
+// FootprintPercentiles returns the percentile ranks of the footprint values of
+// job among the finished jobs of the same cluster and subcluster started
+// within window before or after it, including the job itself. The rank is the
+// percentage of jobs with a lower value plus half of the ones with an equal
+// value. Footprint values of 0 are not measured, they are ignored. Only jobs
+// visible to the user in ctx are taken into account.
+func (r *JobRepository) FootprintPercentiles(
+	ctx context.Context,
+	job *schema.Job,
+	window time.Duration) ([]*FootprintPercentile, error) {
+
+	start := time.Now()
+
+	// Metrics sharing a column (load and cpu_load) are only reported once,
+	// preferably under the name used by the cluster.
+	metrics, known := make([]string, 0, len(footprintColumns)), make(map[string]bool, len(footprintColumns))
+	for metric := range footprintColumns {
+		metrics = append(metrics, metric)
+		known[metric] = archive.GetMetricConfig(job.Cluster, metric) != nil
+	}
+	sort.Slice(metrics, func(i, j int) bool {
+		if known[metrics[i]] != known[metrics[j]] {
+			return known[metrics[i]]
+		}
+		return metrics[i] < metrics[j]
+	})
+	columns, names := make([]string, 0, len(metrics)), make(map[string]string, len(metrics))
+	for _, metric := range metrics {
+		column := footprintColumns[metric].column
+		if _, ok := names[column]; !ok {
+			columns = append(columns, column)
+			names[column] = metric
+		}
+	}
+	sort.Slice(columns, func(i, j int) bool { return names[columns[i]] < names[columns[j]] })
+
+	values := make([]float64, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err := sq.Select(columns...).From("job").Where("job.id = ?", job.ID).
+		RunWith(r.stmtCache).QueryRow().Scan(dest...); err != nil {
+		log.Warnf("Error while loading the footprint of job (dbid: %d)", job.ID)
+		return nil, err
+	}
+
+	query := sq.Select().From("job").
+		Where("job.cluster = ?", job.Cluster).
+		Where("job.subcluster = ?", job.SubCluster).
+		Where("job.job_state != ?", schema.JobStateRunning).
+		Where("job.start_time BETWEEN ? AND ?",
+			job.StartTime.Add(-window).Unix(), job.StartTime.Add(window).Unix())
+	measured := make([]int, 0, len(columns))
+	for i, column := range columns {
+		if values[i] <= 0 {
+			continue
+		}
+		measured = append(measured, i)
+		query = query.
+			Column(fmt.Sprintf("COUNT(CASE WHEN job.%s > 0 THEN 1 END)", column)).
+			Column(sq.Expr(fmt.Sprintf("COUNT(CASE WHEN job.%s > 0 AND job.%s < ? THEN 1 END)", column, column), values[i])).
+			Column(sq.Expr(fmt.Sprintf("COUNT(CASE WHEN job.%s = ? THEN 1 END)", column), values[i]))
+	}
+
+	percentiles := make([]*FootprintPercentile, 0, len(measured))
+	if len(measured) == 0 {
+		return percentiles, nil
+	}
+
+	query, err := SecurityCheck(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make([]int, 3*len(measured))
+	dest = make([]interface{}, len(counts))
+	for i := range counts {
+		dest[i] = &counts[i]
+	}
+	if err := query.RunWith(r.DB).QueryRow().Scan(dest...); err != nil {
+		log.Warn("Error while counting footprint values")
+		return nil, err
+	}
+
+	for k, i := range measured {
+		n, below, equal := counts[3*k], counts[3*k+1], counts[3*k+2]
+		if n == 0 {
+			continue
+		}
+		percentiles = append(percentiles, &FootprintPercentile{
+			Metric:     names[columns[i]],
+			Value:      values[i],
+			Percentile: 100 * (float64(below) + 0.5*float64(equal)) / float64(n),
+			NumJobs:    n,
+		})
+	}
+
+	log.Debugf("Timer FootprintPercentiles %s", time.Since(start))
+	return percentiles, nil
+}
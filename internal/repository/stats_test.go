@@ -5,10 +5,16 @@
 package repository
 
 import (
+	"context"
 	"fmt"
+	"math"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/ClusterCockpit/cc-backend/internal/graph/model"
+	"github.com/ClusterCockpit/cc-backend/pkg/schema"
 )
 
 func TestBuildJobStatsQuery(t *testing.T) {
@@ -33,3 +39,175 @@ func TestJobStats(t *testing.T) {
 		t.Fatalf("Want 98, Got %d", stats[0].TotalJobs)
 	}
 }
+
+func TestCompareTagGroups(t *testing.T) {
+	r := setup(t)
+	t.Cleanup(func() {
+		r.DB.Exec(`DELETE FROM job WHERE cluster = 'compare'`)
+		r.DB.Exec(`DELETE FROM tag WHERE tag_type = 'opt'`)
+	})
+
+	const input = `{"jobId": 2001, "user": "u1", "project": "p1", "cluster": "compare", "subCluster": "main", "numNodes": 1, "exclusive": 1, "jobState": "completed", "duration": 60, "resources": [{"hostname": "n1"}], "startTime": 1675957000, "tags": [{"type": "opt", "name": "O2"}], "statistics": {"flops_any": {"unit": {"base": "F/s"}, "avg": 10, "min": 0, "max": 20}}}
+{"jobId": 2002, "user": "u1", "project": "p1", "cluster": "compare", "subCluster": "main", "numNodes": 1, "exclusive": 1, "jobState": "completed", "duration": 60, "resources": [{"hostname": "n1"}], "startTime": 1675957100, "tags": [{"type": "opt", "name": "O2"}], "statistics": {"flops_any": {"unit": {"base": "F/s"}, "avg": 20, "min": 0, "max": 40}}}
+{"jobId": 2003, "user": "u1", "project": "p1", "cluster": "compare", "subCluster": "main", "numNodes": 1, "exclusive": 1, "jobState": "completed", "duration": 60, "resources": [{"hostname": "n1"}], "startTime": 1675957200, "tags": [{"type": "opt", "name": "O3"}], "statistics": {"flops_any": {"unit": {"base": "F/s"}, "avg": 40, "min": 0, "max": 80}}}
+`
+	if _, _, err := r.ImportNDJSON(strings.NewReader(input)); err != nil {
+		t.Fatal(err)
+	}
+
+	cmp, err := r.CompareTagGroups(getContext(t),
+		schema.Tag{Type: "opt", Name: "O2"}, schema.Tag{Type: "opt", Name: "O3"}, "flops_any")
+	noErr(t, err)
+
+	if cmp.A.Count != 2 || cmp.A.Avg != 15 || cmp.A.Min != 10 || cmp.A.Max != 20 {
+		t.Errorf("wrong stats for group A: %#v", cmp.A)
+	}
+	if cmp.B.Count != 1 || cmp.B.Avg != 40 {
+		t.Errorf("wrong stats for group B: %#v", cmp.B)
+	}
+	if cmp.Delta != 25 {
+		t.Errorf("wrong delta\ngot: %f \nwant: 25", cmp.Delta)
+	}
+
+	if _, err := r.CompareTagGroups(getContext(t),
+		schema.Tag{Type: "opt", Name: "O2"}, schema.Tag{Type: "opt", Name: "O3"}, "unknown"); err == nil {
+		t.Error("expected error for metric without footprint column")
+	}
+}
+
+func TestJobStatsSummary(t *testing.T) {
+	r := setup(t)
+	t.Cleanup(func() {
+		r.DB.Exec(`DELETE FROM job WHERE cluster IN ('summary1', 'summary2')`)
+	})
+
+	const job = `{"jobId": %d, "user": "%s", "project": "p1", "cluster": "%s", "subCluster": "main", "numNodes": %d, "exclusive": 1, "jobState": "%s", "duration": %d, "resources": [{"hostname": "n1"}], "startTime": %d}` + "\n"
+	running := time.Now().Unix() - 600
+	input := fmt.Sprintf(job, 3001, "u1", "summary1", 2, "completed", 1800, 1675957000) +
+		fmt.Sprintf(job, 3002, "u1", "summary1", 1, "completed", 7200, 1675957100) +
+		fmt.Sprintf(job, 3003, "u1", "summary1", 4, "failed", 36000, 1675957200) +
+		fmt.Sprintf(job, 3004, "u1", "summary1", 1, "timeout", 90000, 1675957300) +
+		fmt.Sprintf(job, 3005, "u1", "summary1", 1, "running", 0, running) +
+		fmt.Sprintf(job, 3006, "u2", "summary2", 1, "completed", 60, 1675957400)
+	if _, _, err := r.ImportNDJSON(strings.NewReader(input)); err != nil {
+		t.Fatal(err)
+	}
+
+	filter := &model.JobFilter{Cluster: &model.StringInput{In: []string{"summary1", "summary2"}}}
+	stats, err := r.JobStatsSummary(getContext(t), []*model.JobFilter{filter})
+	noErr(t, err)
+
+	if len(stats) != 2 || stats[0].Cluster != "summary1" || stats[1].Cluster != "summary2" {
+		t.Fatalf("unexpected clusters: %#v", stats)
+	}
+
+	s := stats[0]
+	if s.TotalJobs != 5 {
+		t.Errorf("wrong total jobs\ngot: %d \nwant: 5", s.TotalJobs)
+	}
+	if s.JobsByState[schema.JobStateCompleted] != 2 || s.JobsByState[schema.JobStateFailed] != 1 ||
+		s.JobsByState[schema.JobStateTimeout] != 1 || s.JobsByState[schema.JobStateRunning] != 1 {
+		t.Errorf("wrong jobs by state: %v", s.JobsByState)
+	}
+	for i, want := range []int{2, 1, 1, 1} {
+		if s.DurationBuckets[i].Count != want {
+			t.Errorf("wrong count in bucket %s\ngot: %d \nwant: %d", s.DurationBuckets[i].Label, s.DurationBuckets[i].Count, want)
+		}
+	}
+	// 1 + 2 + 40 + 25 node hours of the finished jobs plus ~1/6 of the running job
+	if math.Abs(s.TotalNodeHours-68.17) > 0.05 || math.Abs(s.AvgNodeHours-s.TotalNodeHours/5) > 1e-9 {
+		t.Errorf("wrong node hours: total %f, avg %f", s.TotalNodeHours, s.AvgNodeHours)
+	}
+
+	// Users only see their own jobs.
+	ctx := context.WithValue(context.Background(), ContextUserKey, &schema.User{
+		Username: "u2",
+		Roles:    []string{schema.GetRoleString(schema.RoleUser)},
+	})
+	stats, err = r.JobStatsSummary(ctx, []*model.JobFilter{filter})
+	noErr(t, err)
+	if len(stats) != 1 || stats[0].Cluster != "summary2" || stats[0].TotalJobs != 1 || stats[0].DurationBuckets[0].Count != 1 {
+		t.Fatalf("unexpected stats for u2: %#v", stats)
+	}
+}
+
+func TestTopUsers(t *testing.T) {
+	r := setup(t)
+	t.Cleanup(func() {
+		r.DB.Exec(`DELETE FROM job WHERE cluster = 'topusage'`)
+	})
+
+	const job = `{"jobId": %d, "user": "%s", "project": "%s", "cluster": "topusage", "subCluster": "main", "numNodes": %d, "exclusive": 1, "jobState": "completed", "duration": %d, "resources": [{"hostname": "n1"}], "startTime": %d}` + "\n"
+	input := fmt.Sprintf(job, 4001, "u1", "p1", 2, 3600, 1675957000) +
+		fmt.Sprintf(job, 4002, "u1", "p1", 1, 1800, 1675957100) +
+		fmt.Sprintf(job, 4003, "u2", "p2", 4, 7200, 1675957200) +
+		fmt.Sprintf(job, 4004, "u3", "p1", 1, 3600, 1675957300) +
+		fmt.Sprintf(job, 4005, "u3", "p1", 10, 36000, 1675900000) // before the window
+	if _, _, err := r.ImportNDJSON(strings.NewReader(input)); err != nil {
+		t.Fatal(err)
+	}
+
+	users, err := r.TopUsers(getContext(t), "topusage", 1675957000, 1675958000, 0)
+	noErr(t, err)
+	want := []UserStat{{"u2", 1, 8}, {"u1", 2, 2.5}, {"u3", 1, 1}}
+	if !reflect.DeepEqual(users, want) {
+		t.Errorf("wrong top users\ngot: %v \nwant: %v", users, want)
+	}
+
+	users, err = r.TopUsers(getContext(t), "topusage", 1675957000, 1675958000, 1)
+	noErr(t, err)
+	if len(users) != 1 || users[0].Name != "u2" {
+		t.Errorf("limit not applied: %v", users)
+	}
+
+	projects, err := r.TopProjects(getContext(t), "topusage", 1675957000, 1675958000, 0)
+	noErr(t, err)
+	if want := []UserStat{{"p2", 1, 8}, {"p1", 3, 3.5}}; !reflect.DeepEqual(projects, want) {
+		t.Errorf("wrong top projects\ngot: %v \nwant: %v", projects, want)
+	}
+}
+
+func TestFootprintPercentiles(t *testing.T) {
+	r := setup(t)
+	t.Cleanup(func() {
+		r.DB.Exec(`DELETE FROM job WHERE cluster = 'percentiles'`)
+	})
+
+	const job = `{"jobId": %d, "user": "u1", "project": "p1", "cluster": "percentiles", "subCluster": "%s", "numNodes": 1, "exclusive": 1, "jobState": "%s", "duration": 600, "resources": [{"hostname": "n1"}], "startTime": %d, "statistics": {"flops_any": {"unit": {"base": "F/s"}, "avg": %d, "min": 0, "max": 100}, "mem_bw": {"unit": {"base": "B/s"}, "avg": 5, "min": 0, "max": 10}}}` + "\n"
+	// flops_any averages of 1 to 10 and the same mem_bw average for all jobs.
+	input := ""
+	for i := 1; i <= 10; i++ {
+		input += fmt.Sprintf(job, 5000+i, "main", "completed", 1675957000+int64(i)*3600, i)
+	}
+	input += fmt.Sprintf(job, 5100, "main", "completed", 1675957000-40*24*3600, 100) + // before the window
+		fmt.Sprintf(job, 5101, "main", "running", 1675957000, 100) +
+		fmt.Sprintf(job, 5102, "other", "completed", 1675957000, 100)
+	if _, _, err := r.ImportNDJSON(strings.NewReader(input)); err != nil {
+		t.Fatal(err)
+	}
+
+	jobId, cluster := int64(5008), "percentiles"
+	j, err := r.Find(context.Background(), &jobId, &cluster, nil)
+	noErr(t, err)
+
+	percentiles, err := r.FootprintPercentiles(getContext(t), j, 30*24*time.Hour)
+	noErr(t, err)
+	want := []*FootprintPercentile{
+		{Metric: "flops_any", Value: 8, Percentile: 75, NumJobs: 10},
+		{Metric: "mem_bw", Value: 5, Percentile: 50, NumJobs: 10},
+	}
+	if !reflect.DeepEqual(percentiles, want) {
+		t.Errorf("wrong percentiles\ngot: %v \nwant: %v", percentiles, want)
+	}
+
+	// Users only compare with their own jobs.
+	ctx := context.WithValue(context.Background(), ContextUserKey, &schema.User{
+		Username: "u2",
+		Roles:    []string{schema.GetRoleString(schema.RoleUser)},
+	})
+	percentiles, err = r.FootprintPercentiles(ctx, j, 30*24*time.Hour)
+	noErr(t, err)
+	if len(percentiles) != 0 {
+		t.Errorf("expected no percentiles without visible jobs, got %v", percentiles)
+	}
+}
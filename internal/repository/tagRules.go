@@ -0,0 +1,119 @@
+// Copyright (C) 2023 NHR@FAU, University Erlangen-Nuremberg.
+// All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/ClusterCockpit/cc-backend/pkg/log"
+	"github.com/ClusterCockpit/cc-backend/pkg/schema"
+)
+
+type tagRule struct {
+	tagType, tagName string
+	metaData         map[string]*regexp.Regexp
+	minAvg, maxAvg   map[string]float64
+}
+
+// The rules applied to archived jobs, set by InitTagRules.
+var tagRules []*tagRule
+
+// InitTagRules compiles the tag rules of the configuration. Without calling
+// it, no rules are applied.
+func InitTagRules(configs []*schema.TagRuleConfig) error {
+	rules := make([]*tagRule, 0, len(configs))
+	for _, rc := range configs {
+		if rc.Type == "" || rc.Name == "" {
+			return errors.New("REPOSITORY/TAGRULES > tag rules need a tag type and name")
+		}
+
+		rule := &tagRule{
+			tagType:  rc.Type,
+			tagName:  rc.Name,
+			metaData: make(map[string]*regexp.Regexp, len(rc.MetaData)),
+			minAvg:   rc.MinAvg,
+			maxAvg:   rc.MaxAvg,
+		}
+		for key, pattern := range rc.MetaData {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return fmt.Errorf("REPOSITORY/TAGRULES > rule for tag %s:%s: %w", rc.Type, rc.Name, err)
+			}
+			rule.metaData[key] = re
+		}
+		rules = append(rules, rule)
+	}
+
+	tagRules = rules
+	return nil
+}
+
+// matches reports whether all conditions of the rule hold for a job with the
+// given metadata and statistics. Missing metadata or statistics never match.
+func (rule *tagRule) matches(metaData map[string]string, statistics map[string]schema.JobStatistics) bool {
+	for key, re := range rule.metaData {
+		value, ok := metaData[key]
+		if !ok || !re.MatchString(value) {
+			return false
+		}
+	}
+	for metric, min := range rule.minAvg {
+		stats, ok := statistics[metric]
+		if !ok || stats.Avg < min {
+			return false
+		}
+	}
+	for metric, max := range rule.maxAvg {
+		stats, ok := statistics[metric]
+		if !ok || stats.Avg > max {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ApplyTagRules adds the tag of every matching rule to the job. Tags the job
+// already has are skipped.
+func (r *JobRepository) ApplyTagRules(
+	job *schema.Job,
+	metaData map[string]string,
+	statistics map[string]schema.JobStatistics) error {
+
+	if len(tagRules) == 0 {
+		return nil
+	}
+
+	tags, err := r.GetTags(nil, &job.ID)
+	if err != nil {
+		log.Warn("Error while getting tags of job")
+		return err
+	}
+
+	for _, rule := range tagRules {
+		if !rule.matches(metaData, statistics) || hasTag(tags, rule.tagType, rule.tagName, TagScopeGlobal) {
+			continue
+		}
+
+		if _, err := r.AddTagOrCreate(nil, job.ID, rule.tagType, rule.tagName, TagScopeGlobal); err != nil {
+			return err
+		}
+		tags = append(tags, &schema.Tag{Type: rule.tagType, Name: rule.tagName, Scope: TagScopeGlobal})
+		log.Debugf("tag rule: tagged job (dbid: %d) with %s:%s", job.ID, rule.tagType, rule.tagName)
+	}
+
+	return nil
+}
+
+func hasTag(tags []*schema.Tag, tagType, tagName, tagScope string) bool {
+	for _, t := range tags {
+		if t.Type == tagType && t.Name == tagName && t.Scope == tagScope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+// Copyright (C) 2023 NHR@FAU, University Erlangen-Nuremberg.
+// All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ClusterCockpit/cc-backend/internal/util"
+	"github.com/ClusterCockpit/cc-backend/pkg/archive"
+	"github.com/ClusterCockpit/cc-backend/pkg/schema"
+)
+
+func TestApplyTagRules(t *testing.T) {
+	r := setup(t)
+
+	tmpdir := t.TempDir()
+	jobarchive := filepath.Join(tmpdir, "job-archive")
+	if err := util.CopyDir("../../pkg/archive/testdata/archive/", jobarchive); err != nil {
+		t.Fatal(err)
+	}
+	clusters, rules := archive.Clusters, tagRules
+	if err := archive.Init(json.RawMessage(fmt.Sprintf(`{"kind": "file", "path": "%s"}`, jobarchive)), false); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		archive.Clusters, tagRules = clusters, rules
+		if _, err := r.DB.Exec(`DELETE FROM jobtag WHERE tag_id IN (SELECT id FROM tag WHERE tag_type IN ('app', 'perf'))`); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := r.DB.Exec(`DELETE FROM tag WHERE tag_type IN ('app', 'perf')`); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := r.DB.Exec(`DELETE FROM job WHERE cluster = 'emmy'`); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	var ndjson bytes.Buffer
+	raw, err := os.ReadFile(filepath.Join(jobarchive, "emmy", "1403/244/1608923076", "meta.json"))
+	noErr(t, err)
+	noErr(t, json.Compact(&ndjson, raw))
+	_, _, err = r.ImportNDJSON(&ndjson)
+	noErr(t, err)
+
+	jobid, cluster := int64(1403244), "emmy"
+	job, err := r.Find(context.Background(), &jobid, &cluster, nil)
+	noErr(t, err)
+	stats, err := archive.GetStatistics(job)
+	noErr(t, err)
+
+	noErr(t, InitTagRules([]*schema.TagRuleConfig{
+		{Type: "app", Name: "tensorflow", MetaData: map[string]string{"jobScript": `(?i)import\s+tensorflow`}},
+		{Type: "app", Name: "gromacs", MetaData: map[string]string{"jobScript": "gmx mdrun"}},
+		{Type: "perf", Name: "lowflops", MaxAvg: map[string]float64{"flops_any": stats["flops_any"].Avg + 1}},
+		{Type: "perf", Name: "highflops", MinAvg: map[string]float64{"flops_any": stats["flops_any"].Avg + 1}},
+		{Type: "perf", Name: "nodata", MinAvg: map[string]float64{"unknown": 0}},
+	}))
+
+	metaData := map[string]string{"jobScript": "#!/bin/bash\npython -c 'import tensorflow as tf'"}
+	noErr(t, r.ApplyTagRules(job, metaData, stats))
+	// Applying the rules again must not add the tags twice.
+	noErr(t, r.ApplyTagRules(job, metaData, stats))
+
+	tags, err := r.GetTags(nil, &job.ID)
+	noErr(t, err)
+	if len(tags) != 2 || !hasTag(tags, "app", "tensorflow", TagScopeGlobal) || !hasTag(tags, "perf", "lowflops", TagScopeGlobal) {
+		t.Errorf("wrong tags: %v", tags)
+	}
+
+	jobMeta, err := archive.GetHandle().LoadJobMeta(job)
+	noErr(t, err)
+	if len(jobMeta.Tags) != 2 {
+		t.Errorf("tags not written to the archive: %v", jobMeta.Tags)
+	}
+}
+
+func TestInitTagRulesInvalid(t *testing.T) {
+	rules := tagRules
+	t.Cleanup(func() { tagRules = rules })
+
+	for _, rc := range []*schema.TagRuleConfig{
+		{Name: "tensorflow", MetaData: map[string]string{"jobScript": "tensorflow"}},
+		{Type: "app", Name: "tensorflow", MetaData: map[string]string{"jobScript": "tensorflow("}},
+	} {
+		if err := InitTagRules([]*schema.TagRuleConfig{rc}); err == nil {
+			t.Errorf("expected error for rule %#v", rc)
+		}
+	}
+}
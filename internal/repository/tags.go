@@ -5,6 +5,8 @@
 package repository
 
 import (
+	"context"
+	"fmt"
 	"strings"
 
 	"github.com/ClusterCockpit/cc-backend/pkg/archive"
@@ -13,8 +15,22 @@ import (
 	sq "github.com/Masterminds/squirrel"
 )
 
+// Scope of tags visible to all users. Private tags use the username of their
+// owner as scope.
+const TagScopeGlobal string = "global"
+
 // Add the tag with id `tagId` to the job with the database id `jobId`.
-func (r *JobRepository) AddTag(job int64, tag int64) ([]*schema.Tag, error) {
+func (r *JobRepository) AddTag(user *schema.User, job int64, tag int64) ([]*schema.Tag, error) {
+	var scope string
+	if err := sq.Select("tag_scope").From("tag").Where("tag.id = ?", tag).
+		RunWith(r.stmtCache).QueryRow().Scan(&scope); err != nil {
+		log.Warn("Error while getting tag scope")
+		return nil, err
+	}
+	if !tagVisible(user, scope) {
+		return nil, ErrForbidden
+	}
+
 	q := sq.Insert("jobtag").Columns("job_id", "tag_id").Values(job, tag)
 
 	if _, err := q.RunWith(r.stmtCache).Exec(); err != nil {
@@ -23,23 +39,11 @@ func (r *JobRepository) AddTag(job int64, tag int64) ([]*schema.Tag, error) {
 		return nil, err
 	}
 
-	j, err := r.FindById(job)
-	if err != nil {
-		log.Warn("Error while finding job by id")
-		return nil, err
-	}
-
-	tags, err := r.GetTags(&job)
-	if err != nil {
-		log.Warn("Error while getting tags for job")
-		return nil, err
-	}
-
-	return tags, archive.UpdateTags(j, tags)
+	return r.updateArchivedTags(user, job)
 }
 
 // Removes a tag from a job
-func (r *JobRepository) RemoveTag(job, tag int64) ([]*schema.Tag, error) {
+func (r *JobRepository) RemoveTag(user *schema.User, job, tag int64) ([]*schema.Tag, error) {
 	q := sq.Delete("jobtag").Where("jobtag.job_id = ?", job).Where("jobtag.tag_id = ?", tag)
 
 	if _, err := q.RunWith(r.stmtCache).Exec(); err != nil {
@@ -48,24 +52,49 @@ func (r *JobRepository) RemoveTag(job, tag int64) ([]*schema.Tag, error) {
 		return nil, err
 	}
 
+	return r.updateArchivedTags(user, job)
+}
+
+// updateArchivedTags writes the global tags of the job to the archive and
+// returns the tags of the job visible to user.
+func (r *JobRepository) updateArchivedTags(user *schema.User, job int64) ([]*schema.Tag, error) {
 	j, err := r.FindById(job)
 	if err != nil {
 		log.Warn("Error while finding job by id")
 		return nil, err
 	}
 
-	tags, err := r.GetTags(&job)
+	tags, err := r.GetTags(nil, &job)
 	if err != nil {
 		log.Warn("Error while getting tags for job")
 		return nil, err
 	}
 
-	return tags, archive.UpdateTags(j, tags)
+	globalTags := make([]*schema.Tag, 0, len(tags))
+	userTags := make([]*schema.Tag, 0, len(tags))
+	for _, tag := range tags {
+		if tag.Scope == TagScopeGlobal {
+			globalTags = append(globalTags, tag)
+		}
+		if tagVisible(user, tag.Scope) {
+			userTags = append(userTags, tag)
+		}
+	}
+
+	return userTags, archive.UpdateTags(j, globalTags)
 }
 
-// CreateTag creates a new tag with the specified type and name and returns its database id.
-func (r *JobRepository) CreateTag(tagType string, tagName string) (tagId int64, err error) {
-	q := sq.Insert("tag").Columns("tag_type", "tag_name").Values(tagType, tagName)
+// CreateTag creates a new tag with the specified type, name and scope and returns its database id.
+// Global tags can only be created by admins (or api users), private tags only by their owner.
+func (r *JobRepository) CreateTag(user *schema.User, tagType string, tagName string, tagScope string) (tagId int64, err error) {
+	if tagScope == "" {
+		tagScope = TagScopeGlobal
+	}
+	if err := checkTagScope(user, tagScope); err != nil {
+		return 0, err
+	}
+
+	q := sq.Insert("tag").Columns("tag_type", "tag_name", "tag_scope").Values(tagType, tagName, tagScope)
 
 	res, err := q.RunWith(r.stmtCache).Exec()
 	if err != nil {
@@ -77,9 +106,68 @@ func (r *JobRepository) CreateTag(tagType string, tagName string) (tagId int64,
 	return res.LastInsertId()
 }
 
+// createTagIfNotExists returns the id of the tag, which is created first if
+// it does not exist yet. Unlike TagId followed by CreateTag, concurrent calls
+// for the same tag do not fail on the unique constraint of the tag table.
+func (r *JobRepository) createTagIfNotExists(user *schema.User, tagType string, tagName string, tagScope string) (int64, error) {
+	if tagId, exists := r.TagId(tagType, tagName, tagScope); exists {
+		return tagId, nil
+	}
+	if err := checkTagScope(user, tagScope); err != nil {
+		return 0, err
+	}
+
+	q := sq.Insert("tag").Columns("tag_type", "tag_name", "tag_scope").Values(tagType, tagName, tagScope)
+	switch r.driver {
+	case "sqlite3":
+		q = q.Options("OR IGNORE")
+	case "mysql":
+		q = q.Options("IGNORE")
+	}
+
+	if _, err := q.RunWith(r.stmtCache).Exec(); err != nil {
+		s, _, _ := q.ToSql()
+		log.Errorf("Error inserting tag with %s: %v", s, err)
+		return 0, err
+	}
+
+	// The tag was inserted either above or by a concurrent request.
+	var tagId int64
+	if err := sq.Select("id").From("tag").
+		Where("tag.tag_type = ?", tagType).Where("tag.tag_name = ?", tagName).Where("tag.tag_scope = ?", tagScope).
+		RunWith(r.stmtCache).QueryRow().Scan(&tagId); err != nil {
+		log.Warnf("Error while getting id of tag %s:%s", tagType, tagName)
+		return 0, err
+	}
+	return tagId, nil
+}
+
+// checkTagScope returns an error if user, if not nil, may not create tags
+// with the given scope.
+func checkTagScope(user *schema.User, tagScope string) error {
+	if user == nil {
+		return nil
+	}
+	if tagScope == TagScopeGlobal && !user.HasAnyRole([]schema.Role{schema.RoleAdmin, schema.RoleApi}) {
+		return fmt.Errorf("REPOSITORY/TAGS > user %s is not allowed to create global tags", user.Username)
+	} else if tagScope != TagScopeGlobal && tagScope != user.Username {
+		return fmt.Errorf("REPOSITORY/TAGS > user %s is not allowed to create tags with scope %s", user.Username, tagScope)
+	}
+	return nil
+}
+
 func (r *JobRepository) CountTags(user *schema.User) (tags []schema.Tag, counts map[string]int, err error) {
 	tags = make([]schema.Tag, 0, 100)
-	xrows, err := r.DB.Queryx("SELECT id, tag_type, tag_name FROM tag")
+	tq := sq.Select("id", "tag_type", "tag_name", "tag_scope").From("tag")
+	if user != nil {
+		tq = tq.Where("(tag.tag_scope = ? OR tag.tag_scope = ?)", TagScopeGlobal, user.Username)
+	}
+	sql, args, err := tq.ToSql()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	xrows, err := r.DB.Queryx(sql, args...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -126,29 +214,115 @@ func (r *JobRepository) CountTags(user *schema.User) (tags []schema.Tag, counts
 	return
 }
 
-// AddTagOrCreate adds the tag with the specified type and name to the job with the database id `jobId`.
-// If such a tag does not yet exist, it is created.
-func (r *JobRepository) AddTagOrCreate(jobId int64, tagType string, tagName string) (tagId int64, err error) {
-	tagId, exists := r.TagId(tagType, tagName)
-	if !exists {
-		tagId, err = r.CreateTag(tagType, tagName)
+// TagCount is a tag together with the number of jobs it is attached to.
+type TagCount struct {
+	schema.Tag
+	Count int `db:"count"`
+}
+
+// TagCounts returns the tags visible to user with the number of jobs they are
+// attached to, sorted by count descending. Like CountTags, only the jobs the
+// user is allowed to see are counted. If tagType is not empty, only tags of
+// this type are returned; tags attached to less than minCount jobs are
+// skipped.
+func (r *JobRepository) TagCounts(user *schema.User, tagType string, minCount int) ([]TagCount, error) {
+	join, args := "jobtag jt ON t.id = jt.tag_id", []interface{}{}
+	if user != nil && !user.HasAnyRole([]schema.Role{schema.RoleAdmin, schema.RoleSupport}) {
+		jobs := sq.Select("id").From("job")
+		if user.HasRole(schema.RoleManager) {
+			jobs = jobs.Where(sq.Or{sq.Eq{"job.user": user.Username}, sq.Eq{"job.project": user.Projects}})
+		} else {
+			jobs = jobs.Where("job.user = ?", user.Username)
+		}
+
+		sub, subArgs, err := jobs.ToSql()
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
+		join, args = join+" AND jt.job_id IN ("+sub+")", subArgs
+	}
+
+	q := sq.Select("t.id", "t.tag_type", "t.tag_name", "t.tag_scope", "count(jt.job_id) AS count").
+		From("tag t").
+		LeftJoin(join, args...).
+		GroupBy("t.id").
+		Having("count(jt.job_id) >= ?", minCount).
+		OrderBy("count DESC", "t.tag_name")
+	if user != nil {
+		q = q.Where("(t.tag_scope = ? OR t.tag_scope = ?)", TagScopeGlobal, user.Username)
+	}
+	if tagType != "" {
+		q = q.Where("t.tag_type = ?", tagType)
+	}
+
+	sql, sqlArgs, err := q.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make([]TagCount, 0)
+	if err := r.DB.Select(&counts, sql, sqlArgs...); err != nil {
+		log.Warn("Error while counting tags")
+		return nil, err
 	}
 
-	if _, err := r.AddTag(jobId, tagId); err != nil {
+	return counts, nil
+}
+
+// AddTagOrCreate adds the tag with the specified type, name and scope to the job with the database id `jobId`.
+// If such a tag does not yet exist, it is created.
+func (r *JobRepository) AddTagOrCreate(user *schema.User, jobId int64, tagType string, tagName string, tagScope string) (tagId int64, err error) {
+	if tagScope == "" {
+		tagScope = TagScopeGlobal
+	}
+
+	tagId, err = r.createTagIfNotExists(user, tagType, tagName, tagScope)
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := r.AddTag(user, jobId, tagId); err != nil {
 		return 0, err
 	}
 
 	return tagId, nil
 }
 
-// TagId returns the database id of the tag with the specified type and name.
-func (r *JobRepository) TagId(tagType string, tagName string) (tagId int64, exists bool) {
+// AddHardwareTag tags the job with the processor type of its subcluster
+// (tag type "hw"). Jobs that already carry the tag are left untouched.
+func (r *JobRepository) AddHardwareTag(jobId int64, cluster string, subCluster string) error {
+	sc, err := archive.GetSubCluster(cluster, subCluster)
+	if err != nil {
+		log.Warn("Error while getting subcluster")
+		return err
+	}
+
+	if sc.ProcessorType == "" {
+		return nil
+	}
+
+	if tagId, exists := r.TagId("hw", sc.ProcessorType, TagScopeGlobal); exists {
+		var cnt int
+		if err := sq.Select("count(*)").From("jobtag").
+			Where("jobtag.job_id = ?", jobId).Where("jobtag.tag_id = ?", tagId).
+			RunWith(r.stmtCache).QueryRow().Scan(&cnt); err != nil {
+			log.Warn("Error while checking for existing hardware tag")
+			return err
+		}
+		if cnt != 0 {
+			return nil
+		}
+	}
+
+	_, err = r.AddTagOrCreate(nil, jobId, "hw", sc.ProcessorType, TagScopeGlobal)
+	return err
+}
+
+// TagId returns the database id of the tag with the specified type, name and scope.
+func (r *JobRepository) TagId(tagType string, tagName string, tagScope string) (tagId int64, exists bool) {
 	exists = true
 	if err := sq.Select("id").From("tag").
-		Where("tag.tag_type = ?", tagType).Where("tag.tag_name = ?", tagName).
+		Where("tag.tag_type = ?", tagType).Where("tag.tag_name = ?", tagName).Where("tag.tag_scope = ?", tagScope).
 		RunWith(r.stmtCache).QueryRow().Scan(&tagId); err != nil {
 		exists = false
 	}
@@ -156,11 +330,15 @@ func (r *JobRepository) TagId(tagType string, tagName string) (tagId int64, exis
 }
 
 // GetTags returns a list of all tags if job is nil or of the tags that the job with that database ID has.
-func (r *JobRepository) GetTags(job *int64) ([]*schema.Tag, error) {
-	q := sq.Select("id", "tag_type", "tag_name").From("tag")
+// Only global tags and private tags of user are returned, all tags if user is nil.
+func (r *JobRepository) GetTags(user *schema.User, job *int64) ([]*schema.Tag, error) {
+	q := sq.Select("id", "tag_type", "tag_name", "tag_scope").From("tag")
 	if job != nil {
 		q = q.Join("jobtag ON jobtag.tag_id = tag.id").Where("jobtag.job_id = ?", *job)
 	}
+	if user != nil {
+		q = q.Where("(tag.tag_scope = ? OR tag.tag_scope = ?)", TagScopeGlobal, user.Username)
+	}
 
 	rows, err := q.RunWith(r.stmtCache).Query()
 	if err != nil {
@@ -172,7 +350,7 @@ func (r *JobRepository) GetTags(job *int64) ([]*schema.Tag, error) {
 	tags := make([]*schema.Tag, 0)
 	for rows.Next() {
 		tag := &schema.Tag{}
-		if err := rows.Scan(&tag.ID, &tag.Type, &tag.Name); err != nil {
+		if err := rows.Scan(&tag.ID, &tag.Type, &tag.Name, &tag.Scope); err != nil {
 			log.Warn("Error while scanning rows")
 			return nil, err
 		}
@@ -181,3 +359,77 @@ func (r *JobRepository) GetTags(job *int64) ([]*schema.Tag, error) {
 
 	return tags, nil
 }
+
+// GetTagsForJobs returns the tags of all jobs with the database ids in jobIds
+// with a single query, keyed by job id. Jobs without (visible) tags are
+// missing in the result.
+func (r *JobRepository) GetTagsForJobs(user *schema.User, jobIds []int64) (map[int64][]*schema.Tag, error) {
+	tags := make(map[int64][]*schema.Tag, len(jobIds))
+	if len(jobIds) == 0 {
+		return tags, nil
+	}
+
+	q := sq.Select("jobtag.job_id", "tag.id", "tag.tag_type", "tag.tag_name", "tag.tag_scope").From("tag").
+		Join("jobtag ON jobtag.tag_id = tag.id").Where(sq.Eq{"jobtag.job_id": jobIds})
+	if user != nil {
+		q = q.Where("(tag.tag_scope = ? OR tag.tag_scope = ?)", TagScopeGlobal, user.Username)
+	}
+
+	rows, err := q.RunWith(r.stmtCache).Query()
+	if err != nil {
+		s, _, _ := q.ToSql()
+		log.Errorf("Error get tags with %s: %v", s, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var job int64
+		tag := &schema.Tag{}
+		if err := rows.Scan(&job, &tag.ID, &tag.Type, &tag.Name, &tag.Scope); err != nil {
+			log.Warn("Error while scanning rows")
+			return nil, err
+		}
+		tags[job] = append(tags[job], tag)
+	}
+
+	return tags, rows.Err()
+}
+
+func tagVisible(user *schema.User, scope string) bool {
+	return user == nil || scope == TagScopeGlobal || scope == user.Username
+}
+
+// FindByTag returns all jobs visible to the user in ctx that have a tag with
+// the given type and name, ordered by descending start time. Only global tags
+// and private tags of the user are matched.
+func (r *JobRepository) FindByTag(ctx context.Context, tagType string, tagName string) ([]*schema.Job, error) {
+	query, err := SecurityCheck(ctx, sq.Select(jobColumns...).Distinct().From("job").
+		Join("jobtag ON jobtag.job_id = job.id").
+		Join("tag ON tag.id = jobtag.tag_id").
+		Where("tag.tag_type = ?", tagType).Where("tag.tag_name = ?", tagName).
+		OrderBy("job.start_time DESC"))
+	if err != nil {
+		return nil, err
+	}
+	// SecurityCheck fails without a user.
+	query = query.Where("(tag.tag_scope = ? OR tag.tag_scope = ?)", TagScopeGlobal, GetUserFromContext(ctx).Username)
+
+	rows, err := query.RunWith(r.stmtCache).Query()
+	if err != nil {
+		log.Error("Error while running query")
+		return nil, err
+	}
+	defer rows.Close()
+
+	jobs := make([]*schema.Job, 0, 10)
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			log.Warn("Error while scanning rows")
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, rows.Err()
+}
@@ -0,0 +1,302 @@
+// Copyright (C) 2023 NHR@FAU, University Erlangen-Nuremberg.
+// All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+package repository
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/ClusterCockpit/cc-backend/pkg/schema"
+)
+
+func TestTagScopes(t *testing.T) {
+	r := setup(t)
+
+	alice := &schema.User{Username: "alice", Roles: []string{schema.GetRoleString(schema.RoleUser)}}
+	bob := &schema.User{Username: "bob", Roles: []string{schema.GetRoleString(schema.RoleUser)}}
+
+	t.Cleanup(func() {
+		if _, err := r.DB.Exec(`DELETE FROM tag WHERE tag_type = 'scopetest'`); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	aliceTag, err := r.CreateTag(alice, "scopetest", "same", alice.Username)
+	noErr(t, err)
+	bobTag, err := r.CreateTag(bob, "scopetest", "same", bob.Username)
+	noErr(t, err)
+	if aliceTag == bobTag {
+		t.Fatal("expected two distinct tags")
+	}
+
+	if _, err := r.CreateTag(alice, "scopetest", "global", TagScopeGlobal); err == nil {
+		t.Fatal("expected error when a regular user creates a global tag")
+	}
+	if _, err := r.CreateTag(alice, "scopetest", "other", bob.Username); err == nil {
+		t.Fatal("expected error when creating a tag in a foreign scope")
+	}
+
+	tags, err := r.GetTags(alice, nil)
+	noErr(t, err)
+	for _, tag := range tags {
+		if tag.ID == bobTag {
+			t.Fatal("private tag of bob visible to alice")
+		}
+	}
+
+	if id, exists := r.TagId("scopetest", "same", bob.Username); !exists || id != bobTag {
+		t.Errorf("wrong tag id: want %d, got %d", bobTag, id)
+	}
+
+	if _, err := r.AddTag(alice, 1, bobTag); err != ErrForbidden {
+		t.Errorf("expected ErrForbidden, got %v", err)
+	}
+}
+
+// TestCreateTagRoundTrip creates tags and reads back all their columns.
+func TestCreateTagRoundTrip(t *testing.T) {
+	r := setup(t)
+
+	t.Cleanup(func() {
+		if _, err := r.DB.Exec(`DELETE FROM tag WHERE tag_type = 'createtest'`); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	alice := &schema.User{Username: "alice", Roles: []string{schema.GetRoleString(schema.RoleUser)}}
+	for _, tag := range []schema.Tag{
+		{Type: "createtest", Name: "global", Scope: TagScopeGlobal},
+		{Type: "createtest", Name: "private", Scope: alice.Username},
+	} {
+		user := alice
+		if tag.Scope == TagScopeGlobal {
+			user = nil
+		}
+		id, err := r.CreateTag(user, tag.Type, tag.Name, tag.Scope)
+		noErr(t, err)
+
+		var got schema.Tag
+		noErr(t, r.DB.Get(&got, `SELECT id, tag_type, tag_name, tag_scope FROM tag WHERE id = ?`, id))
+		tag.ID = id
+		if got != tag {
+			t.Errorf("wrong tag in database\ngot: %#v \nwant: %#v", got, tag)
+		}
+	}
+}
+
+func TestCreateTagConcurrent(t *testing.T) {
+	r := setup(t)
+
+	t.Cleanup(func() {
+		if _, err := r.DB.Exec(`DELETE FROM tag WHERE tag_type = 'concurrenttest'`); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	const n = 8
+	var wg sync.WaitGroup
+	ids, errs := make([]int64, n), make([]error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ids[i], errs[i] = r.createTagIfNotExists(nil, "concurrenttest", "same", TagScopeGlobal)
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 0; i < n; i++ {
+		noErr(t, errs[i])
+		if ids[i] != ids[0] {
+			t.Errorf("wrong tag id: want %d, got %d", ids[0], ids[i])
+		}
+	}
+
+	var cnt int
+	noErr(t, r.DB.Get(&cnt, `SELECT COUNT(*) FROM tag WHERE tag_type = 'concurrenttest'`))
+	if cnt != 1 {
+		t.Errorf("expected a single tag, got %d", cnt)
+	}
+
+	alice := &schema.User{Username: "alice", Roles: []string{schema.GetRoleString(schema.RoleUser)}}
+	if _, err := r.createTagIfNotExists(alice, "concurrenttest", "other", TagScopeGlobal); err == nil {
+		t.Error("expected error when a regular user creates a global tag")
+	}
+	// Existing global tags can be used by regular users.
+	if id, err := r.createTagIfNotExists(alice, "concurrenttest", "same", TagScopeGlobal); err != nil || id != ids[0] {
+		t.Errorf("wrong tag id: want %d, got %d (%v)", ids[0], id, err)
+	}
+}
+
+func TestTagCounts(t *testing.T) {
+	r := setup(t)
+
+	t.Cleanup(func() {
+		if _, err := r.DB.Exec(`DELETE FROM jobtag WHERE tag_id IN (SELECT id FROM tag WHERE tag_type IN ('counttest', 'counttest2'))`); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := r.DB.Exec(`DELETE FROM tag WHERE tag_type IN ('counttest', 'counttest2')`); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	tag := func(tagType, name string, jobs ...int64) {
+		id, err := r.CreateTag(nil, tagType, name, TagScopeGlobal)
+		noErr(t, err)
+		for _, job := range jobs {
+			_, err := r.DB.Exec(`INSERT INTO jobtag (job_id, tag_id) VALUES (?, ?)`, job, id)
+			noErr(t, err)
+		}
+	}
+	// Jobs 1-3 belong to mppi067h, jobs 4-6 to k106eb10.
+	tag("counttest", "many", 1, 2, 4)
+	tag("counttest", "one", 1)
+	tag("counttest", "none")
+	tag("counttest2", "other", 1, 2, 3, 4)
+
+	names := func(counts []TagCount) []string {
+		res := make([]string, 0, len(counts))
+		for _, tc := range counts {
+			res = append(res, fmt.Sprintf("%s:%d", tc.Name, tc.Count))
+		}
+		return res
+	}
+
+	counts, err := r.TagCounts(nil, "counttest", 0)
+	noErr(t, err)
+	if got, want := names(counts), []string{"many:3", "one:1", "none:0"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("wrong tag counts: want %v, got %v", want, got)
+	}
+
+	counts, err = r.TagCounts(nil, "counttest", 2)
+	noErr(t, err)
+	if got, want := names(counts), []string{"many:3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("wrong tag counts with minCount: want %v, got %v", want, got)
+	}
+
+	user := &schema.User{Username: "mppi067h", Roles: []string{schema.GetRoleString(schema.RoleUser)}}
+	counts, err = r.TagCounts(user, "counttest", 1)
+	noErr(t, err)
+	if got, want := names(counts), []string{"many:2", "one:1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("wrong tag counts for user: want %v, got %v", want, got)
+	}
+}
+
+func TestTagsForJobs(t *testing.T) {
+	r := setup(t)
+
+	t.Cleanup(func() {
+		if _, err := r.DB.Exec(`DELETE FROM jobtag WHERE tag_id IN (SELECT id FROM tag WHERE tag_type = 'batchtest')`); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := r.DB.Exec(`DELETE FROM tag WHERE tag_type = 'batchtest'`); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	alice := &schema.User{Username: "alice", Roles: []string{schema.GetRoleString(schema.RoleUser)}}
+	tag := func(name, scope string, jobs ...int64) {
+		id, err := r.CreateTag(nil, "batchtest", name, scope)
+		noErr(t, err)
+		for _, job := range jobs {
+			_, err := r.DB.Exec(`INSERT INTO jobtag (job_id, tag_id) VALUES (?, ?)`, job, id)
+			noErr(t, err)
+		}
+	}
+	tag("a", TagScopeGlobal, 1, 2)
+	tag("b", TagScopeGlobal, 2)
+	tag("private", "bob", 1, 3)
+
+	ids := func(tags []*schema.Tag) []int64 {
+		res := make([]int64, 0, len(tags))
+		for _, tag := range tags {
+			res = append(res, tag.ID)
+		}
+		sort.Slice(res, func(i, j int) bool { return res[i] < res[j] })
+		return res
+	}
+
+	jobs := []int64{1, 2, 3, 4}
+	for _, user := range []*schema.User{nil, alice} {
+		batch, err := r.GetTagsForJobs(user, jobs)
+		noErr(t, err)
+		for _, job := range jobs {
+			tags, err := r.GetTags(user, &job)
+			noErr(t, err)
+			if got, want := ids(batch[job]), ids(tags); !reflect.DeepEqual(got, want) {
+				t.Errorf("wrong tags of job %d for %v: want %v, got %v", job, user, want, got)
+			}
+		}
+	}
+
+	batch, err := r.GetTagsForJobs(alice, []int64{3})
+	noErr(t, err)
+	if len(batch) != 0 {
+		t.Errorf("expected no visible tags for job 3, got %v", batch)
+	}
+}
+
+func TestFindByTag(t *testing.T) {
+	r := setup(t)
+
+	t.Cleanup(func() {
+		if _, err := r.DB.Exec(`DELETE FROM jobtag WHERE tag_id IN (SELECT id FROM tag WHERE tag_type = 'findtest')`); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := r.DB.Exec(`DELETE FROM tag WHERE tag_type = 'findtest'`); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	tag := func(name, scope string, jobs ...int64) int64 {
+		id, err := r.CreateTag(nil, "findtest", name, scope)
+		noErr(t, err)
+		for _, job := range jobs {
+			_, err := r.DB.Exec(`INSERT INTO jobtag (job_id, tag_id) VALUES (?, ?)`, job, id)
+			noErr(t, err)
+		}
+		return id
+	}
+	tag("a", TagScopeGlobal, 1, 2)
+	// The private tag of the user in the context also matches, the one of bob does not.
+	tag("a", "demo", 2, 4)
+	tag("a", "bob", 5)
+
+	ids := func(jobs []*schema.Job) []int64 {
+		res := make([]int64, 0, len(jobs))
+		for _, job := range jobs {
+			res = append(res, job.ID)
+		}
+		sort.Slice(res, func(i, j int) bool { return res[i] < res[j] })
+		return res
+	}
+
+	jobs, err := r.FindByTag(getContext(t), "findtest", "a")
+	noErr(t, err)
+	if got := ids(jobs); !reflect.DeepEqual(got, []int64{1, 2, 4}) {
+		t.Errorf("wrong jobs with tag: want [1 2 4], got %v", got)
+	}
+
+	jobs, err = r.FindByTag(getContext(t), "findtest", "missing")
+	noErr(t, err)
+	if len(jobs) != 0 {
+		t.Errorf("expected no jobs, got %v", ids(jobs))
+	}
+
+	// Users only get their own jobs.
+	ctx := context.WithValue(context.Background(), ContextUserKey, &schema.User{
+		Username: "k106eb10",
+		Roles:    []string{schema.GetRoleString(schema.RoleUser)},
+	})
+	jobs, err = r.FindByTag(ctx, "findtest", "a")
+	noErr(t, err)
+	if len(jobs) != 0 {
+		t.Errorf("expected no jobs of other users, got %v", ids(jobs))
+	}
+}
@@ -5,11 +5,22 @@
 package repository
 
 import (
+	"database/sql"
+	"errors"
+	"fmt"
+
 	"github.com/ClusterCockpit/cc-backend/pkg/log"
 	"github.com/ClusterCockpit/cc-backend/pkg/schema"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/go-sql-driver/mysql"
 	"github.com/jmoiron/sqlx"
+	"github.com/mattn/go-sqlite3"
 )
 
+// ErrConflict is wrapped by the errors of WithTx caused by a unique or
+// primary key constraint, e.g. a tag added twice to the same job.
+var ErrConflict = errors.New("conflict with existing database entry")
+
 type Transaction struct {
 	tx   *sqlx.Tx
 	stmt *sqlx.NamedStmt
@@ -80,7 +91,10 @@ func (r *JobRepository) TransactionAdd(t *Transaction, job schema.Job) (int64, e
 }
 
 func (r *JobRepository) TransactionAddTag(t *Transaction, tag *schema.Tag) (int64, error) {
-	res, err := t.tx.Exec(`INSERT INTO tag (tag_name, tag_type) VALUES (?, ?)`, tag.Name, tag.Type)
+	if tag.Scope == "" {
+		tag.Scope = TagScopeGlobal
+	}
+	res, err := t.tx.Exec(`INSERT INTO tag (tag_name, tag_type, tag_scope) VALUES (?, ?, ?)`, tag.Name, tag.Type, tag.Scope)
 	if err != nil {
 		log.Errorf("Error while inserting tag into tag table: %v (Type %v)", tag.Name, tag.Type)
 		return 0, err
@@ -102,3 +116,96 @@ func (r *JobRepository) TransactionSetTag(t *Transaction, jobId int64, tagId int
 
 	return nil
 }
+
+// WithTx runs fn with a repository whose queries use one database
+// transaction, e.g. to insert a job and its tags together. The transaction is
+// committed if fn returns nil and rolled back if it returns an error or
+// panics. Notifications like the webhooks of started jobs are only sent after
+// the commit. Calls of WithTx within fn use the same transaction. Only the
+// database operations may be used on the repository passed to fn, archiving
+// is left to r. Errors caused by a unique constraint wrap ErrConflict.
+func (r *JobRepository) WithTx(fn func(*JobRepository) error) error {
+	if r.tx != nil {
+		return fn(r)
+	}
+
+	tx, err := r.DB.Beginx()
+	if err != nil {
+		log.Warn("Error while beginning transaction")
+		return err
+	}
+
+	txRepo := &JobRepository{
+		DB:             r.DB,
+		driver:         r.driver,
+		stmtCache:      sq.NewStmtCache(tx),
+		cache:          r.cache,
+		archiveChannel: r.archiveChannel,
+		webhookChannel: r.webhookChannel,
+		tx:             tx,
+		txParent:       r,
+	}
+
+	committed := false
+	defer func() {
+		txRepo.stmtCache.Clear()
+		if !committed {
+			if rerr := tx.Rollback(); rerr != nil {
+				log.Warnf("Error while rolling back transaction: %v", rerr)
+			}
+		}
+	}()
+
+	if err := fn(txRepo); err != nil {
+		return conflictError(err)
+	}
+	if err := tx.Commit(); err != nil {
+		log.Warn("Error while committing transaction")
+		return conflictError(err)
+	}
+	committed = true
+
+	for _, hook := range txRepo.txHooks {
+		hook()
+	}
+	return nil
+}
+
+// conflictError wraps ErrConflict around err if it is caused by a unique or
+// primary key constraint of sqlite or mysql.
+func conflictError(err error) error {
+	var sqliteErr sqlite3.Error
+	var mysqlErr *mysql.MySQLError
+	if (errors.As(err, &sqliteErr) && (sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique ||
+		sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey)) ||
+		(errors.As(err, &mysqlErr) && mysqlErr.Number == 1062) {
+		return fmt.Errorf("%w: %s", ErrConflict, err.Error())
+	}
+	return err
+}
+
+// base returns the repository r is derived from by WithTx, r itself if it
+// is not bound to a transaction.
+func (r *JobRepository) base() *JobRepository {
+	if r.txParent != nil {
+		return r.txParent
+	}
+	return r
+}
+
+// onCommit runs f once the transaction of r is committed, right away if r is
+// not bound to a transaction.
+func (r *JobRepository) onCommit(f func()) {
+	if r.tx == nil {
+		f()
+		return
+	}
+	r.txHooks = append(r.txHooks, f)
+}
+
+func (r *JobRepository) namedExec(query string, arg interface{}) (sql.Result, error) {
+	if r.tx != nil {
+		return r.tx.NamedExec(query, arg)
+	}
+	return r.DB.NamedExec(query, arg)
+}
@@ -0,0 +1,94 @@
+// Copyright (C) 2023 NHR@FAU, University Erlangen-Nuremberg.
+// All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/ClusterCockpit/cc-backend/pkg/schema"
+)
+
+func TestWithTx(t *testing.T) {
+	r := setup(t)
+	t.Cleanup(func() {
+		r.DB.Exec(`DELETE FROM job WHERE cluster = 'txtest'`)
+		r.DB.Exec(`DELETE FROM tag WHERE tag_type = 'txtest'`)
+	})
+
+	newJob := func(jobId int64) *schema.JobMeta {
+		job := &schema.JobMeta{BaseJob: schema.JobDefaults, StartTime: 1675957000}
+		job.JobID, job.User, job.Project, job.Cluster, job.SubCluster = jobId, "u1", "p1", "txtest", "main"
+		job.NumNodes, job.State = 1, schema.JobStateRunning
+		job.Resources = []*schema.Resource{{Hostname: "n1"}}
+		return job
+	}
+
+	// Adding the same tag twice fails on the primary key of jobtag, the job
+	// has to be rolled back as well.
+	var id int64
+	err := r.WithTx(func(tx *JobRepository) error {
+		var err error
+		if id, err = tx.Start(context.Background(), newJob(5001)); err != nil {
+			return err
+		}
+		for i := 0; i < 2; i++ {
+			if _, err := tx.AddTagOrCreate(nil, id, "txtest", "dup", TagScopeGlobal); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if !errors.Is(err, ErrConflict) {
+		t.Fatalf("expected ErrConflict for duplicate tag, got %v", err)
+	}
+	if _, err := r.FindById(id); err != sql.ErrNoRows {
+		t.Errorf("job (dbid: %d) not rolled back: %v", id, err)
+	}
+	if _, exists := r.TagId("txtest", "dup", TagScopeGlobal); exists {
+		t.Error("tag not rolled back")
+	}
+
+	noErr(t, r.WithTx(func(tx *JobRepository) error {
+		var err error
+		if id, err = tx.Start(context.Background(), newJob(5002)); err != nil {
+			return err
+		}
+		_, err = tx.AddTagOrCreate(nil, id, "txtest", "ok", TagScopeGlobal)
+		return err
+	}))
+	job, err := r.FindById(id)
+	noErr(t, err)
+	tags, err := r.GetTags(nil, &job.ID)
+	noErr(t, err)
+	if job.JobID != 5002 || len(tags) != 1 || tags[0].Name != "ok" {
+		t.Errorf("unexpected job %d with tags %v", job.JobID, tags)
+	}
+
+	// Subscribers only learn about the stop once it is committed.
+	states, cancel := r.SubscribeJobStates()
+	defer cancel()
+	noErr(t, r.WithTx(func(tx *JobRepository) error {
+		if err := tx.Stop(context.Background(), id, 60, schema.JobStateCompleted, schema.MonitoringStatusDisabled); err != nil {
+			return err
+		}
+		select {
+		case job := <-states:
+			t.Errorf("job (dbid: %d) published before commit", job.ID)
+		default:
+		}
+		return nil
+	}))
+	select {
+	case job := <-states:
+		if job.ID != id || job.State != schema.JobStateCompleted {
+			t.Errorf("unexpected published job %d in state %s", job.ID, job.State)
+		}
+	default:
+		t.Error("stopped job not published after commit")
+	}
+}
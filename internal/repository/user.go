@@ -212,6 +212,23 @@ func (r *UserRepository) AddRole(
 	return nil
 }
 
+// SetRoles replaces all roles of the user, e.g. with the roles derived from
+// the LDAP groups of the user at login.
+func (r *UserRepository) SetRoles(username string, roles []string) error {
+	for _, role := range roles {
+		if !schema.IsValidRole(role) {
+			return fmt.Errorf("Supplied role is no valid option : %v", role)
+		}
+	}
+
+	rolesJson, _ := json.Marshal(roles)
+	if _, err := sq.Update("user").Set("roles", string(rolesJson)).Where("user.username = ?", username).RunWith(r.DB).Exec(); err != nil {
+		log.Errorf("Error while setting roles for user '%s'", username)
+		return err
+	}
+	return nil
+}
+
 func (r *UserRepository) RemoveRole(ctx context.Context, username string, queryrole string) error {
 	oldRole := strings.ToLower(queryrole)
 	user, err := r.GetUser(username)
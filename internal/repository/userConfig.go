@@ -5,7 +5,11 @@
 package repository
 
 import (
+	"context"
+	"database/sql"
 	"encoding/json"
+	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -27,6 +31,10 @@ type UserCfgRepo struct {
 	lock       sync.RWMutex
 	uiDefaults map[string]interface{}
 	cache      *lrucache.Cache
+	// Incremented whenever the global defaults change. It is part of the
+	// cache key so that cached per-user configs based on old defaults
+	// are not used anymore.
+	generation uint64
 }
 
 func GetUserCfgRepo() *UserCfgRepo {
@@ -62,11 +70,17 @@ func (uCfg *UserCfgRepo) GetUIConfig(user *schema.User) (map[string]interface{},
 		return copy, nil
 	}
 
-	data := uCfg.cache.Get(user.Username, func() (interface{}, time.Duration, int) {
+	uCfg.lock.RLock()
+	generation := uCfg.generation
+	uCfg.lock.RUnlock()
+
+	data := uCfg.cache.Get(uCfg.cacheKey(user.Username, generation), func() (interface{}, time.Duration, int) {
+		uCfg.lock.RLock()
 		uiconfig := make(map[string]interface{}, len(uCfg.uiDefaults))
 		for k, v := range uCfg.uiDefaults {
 			uiconfig[k] = v
 		}
+		uCfg.lock.RUnlock()
 
 		rows, err := uCfg.Lookup.Query(user.Username)
 		if err != nil {
@@ -82,6 +96,9 @@ func (uCfg *UserCfgRepo) GetUIConfig(user *schema.User) (map[string]interface{},
 				log.Warn("Error while scanning user uiconfig values")
 				return err, 0, 0
 			}
+			if strings.HasPrefix(key, savedFilterPrefix) {
+				continue
+			}
 
 			var val interface{}
 			if err := json.Unmarshal([]byte(rawval), &val); err != nil {
@@ -124,6 +141,7 @@ func (uCfg *UserCfgRepo) UpdateConfig(
 		uCfg.lock.Lock()
 		defer uCfg.lock.Unlock()
 		uCfg.uiDefaults[key] = val
+		uCfg.generation++
 		return nil
 	}
 
@@ -132,6 +150,104 @@ func (uCfg *UserCfgRepo) UpdateConfig(
 		return err
 	}
 
-	uCfg.cache.Del(user.Username)
+	uCfg.lock.RLock()
+	generation := uCfg.generation
+	uCfg.lock.RUnlock()
+
+	uCfg.cache.Del(uCfg.cacheKey(user.Username, generation))
+	return nil
+}
+
+func (uCfg *UserCfgRepo) cacheKey(username string, generation uint64) string {
+	return fmt.Sprintf("%s:%d", username, generation)
+}
+
+// Prefix of the configuration keys of saved job filters. They are not part
+// of the UI config.
+const savedFilterPrefix = "savedFilter:"
+
+// SaveFilter stores the job filter, any JSON value, under name for the user
+// in ctx, replacing a filter with the same name. Saved filters are private to
+// the user, anonymous users cannot save filters.
+func (uCfg *UserCfgRepo) SaveFilter(name string, filter string, ctx context.Context) error {
+	user := GetUserFromContext(ctx)
+	if user == nil || user.Username == "" {
+		return ErrForbidden
+	}
+	if name == "" || len(savedFilterPrefix)+len(name) > 255 {
+		return fmt.Errorf("REPOSITORY/USERCONFIG > invalid filter name '%s'", name)
+	}
+	if !json.Valid([]byte(filter)) {
+		return fmt.Errorf("REPOSITORY/USERCONFIG > filter '%s' is not valid JSON", name)
+	}
+
+	if _, err := uCfg.DB.Exec(`REPLACE INTO configuration (username, confkey, value) VALUES (?, ?, ?)`,
+		user.Username, savedFilterPrefix+name, filter); err != nil {
+		log.Warnf("Error while saving filter for user '%v'", user.Username)
+		return err
+	}
+	return nil
+}
+
+// ListFilters returns the saved job filters of the user in ctx by name.
+func (uCfg *UserCfgRepo) ListFilters(ctx context.Context) (map[string]json.RawMessage, error) {
+	user := GetUserFromContext(ctx)
+	if user == nil {
+		return nil, ErrForbidden
+	}
+
+	rows, err := uCfg.DB.Query(`SELECT confkey, value FROM configuration WHERE username = ? AND confkey LIKE ?`,
+		user.Username, savedFilterPrefix+"%")
+	if err != nil {
+		log.Warnf("Error while listing filters of user '%v'", user.Username)
+		return nil, err
+	}
+	defer rows.Close()
+
+	filters := make(map[string]json.RawMessage)
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			log.Warn("Error while scanning saved filters")
+			return nil, err
+		}
+		filters[strings.TrimPrefix(key, savedFilterPrefix)] = json.RawMessage(value)
+	}
+	return filters, rows.Err()
+}
+
+// GetFilter returns the saved job filter name of the user in ctx, nil if
+// there is none.
+func (uCfg *UserCfgRepo) GetFilter(name string, ctx context.Context) (json.RawMessage, error) {
+	user := GetUserFromContext(ctx)
+	if user == nil {
+		return nil, ErrForbidden
+	}
+
+	var value string
+	err := uCfg.DB.QueryRow(`SELECT value FROM configuration WHERE username = ? AND confkey = ?`,
+		user.Username, savedFilterPrefix+name).Scan(&value)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		log.Warnf("Error while getting filter of user '%v'", user.Username)
+		return nil, err
+	}
+	return json.RawMessage(value), nil
+}
+
+// DeleteFilter removes the saved job filter name of the user in ctx.
+func (uCfg *UserCfgRepo) DeleteFilter(name string, ctx context.Context) error {
+	user := GetUserFromContext(ctx)
+	if user == nil {
+		return ErrForbidden
+	}
+
+	if _, err := uCfg.DB.Exec(`DELETE FROM configuration WHERE username = ? AND confkey = ?`,
+		user.Username, savedFilterPrefix+name); err != nil {
+		log.Warnf("Error while deleting filter of user '%v'", user.Username)
+		return err
+	}
 	return nil
 }
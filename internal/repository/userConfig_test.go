@@ -5,8 +5,10 @@
 package repository
 
 import (
+	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/ClusterCockpit/cc-backend/internal/config"
@@ -70,3 +72,99 @@ func TestGetUIConfig(t *testing.T) {
 		t.Errorf("wrong config\ngot: %s \nwant: flops_any", str)
 	}
 }
+
+func TestUpdateDefaultConfig(t *testing.T) {
+	r := setupUserTest(t)
+	u := schema.User{Username: "demo"}
+
+	cfg, err := r.GetUIConfig(&u)
+	if err != nil {
+		t.Fatal("No config")
+	}
+	old := cfg["plot_view_plotsPerRow"]
+
+	if err := r.UpdateConfig("plot_view_plotsPerRow", "5", nil); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		r.lock.Lock()
+		r.uiDefaults["plot_view_plotsPerRow"] = old
+		r.lock.Unlock()
+	})
+
+	cfg, err = r.GetUIConfig(&u)
+	if err != nil {
+		t.Fatal("No config")
+	}
+
+	if val, ok := cfg["plot_view_plotsPerRow"].(float64); !ok || val != 5 {
+		t.Errorf("wrong config\ngot: %v \nwant: 5", cfg["plot_view_plotsPerRow"])
+	}
+}
+
+func TestSavedFilters(t *testing.T) {
+	r := setupUserTest(t)
+	t.Cleanup(func() {
+		r.DB.Exec(`DELETE FROM configuration WHERE confkey LIKE 'savedFilter:%'`)
+	})
+
+	ctx := context.WithValue(context.Background(), ContextUserKey, &schema.User{Username: "demo"})
+	const filter = `[{"cluster": {"eq": "fritz"}}, {"numNodes": {"from": 2, "to": 4}}]`
+	if err := r.SaveFilter("large fritz jobs", filter, ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.SaveFilter("running", `[{"state": ["running"]}]`, ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.SaveFilter("broken", `[{"state": `, ctx); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+
+	filters, err := r.ListFilters(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(filters) != 2 || string(filters["large fritz jobs"]) != filter {
+		t.Errorf("wrong saved filters: %v", filters)
+	}
+
+	saved, err := r.GetFilter("large fritz jobs", ctx)
+	if err != nil || string(saved) != filter {
+		t.Errorf("wrong saved filter: %s (%v)", saved, err)
+	}
+	if saved, err := r.GetFilter("missing", ctx); err != nil || saved != nil {
+		t.Errorf("expected no filter, got %s (%v)", saved, err)
+	}
+
+	// Saved filters are not part of the UI config.
+	cfg, err := r.GetUIConfig(&schema.User{Username: "demo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for key := range cfg {
+		if strings.HasPrefix(key, "savedFilter:") {
+			t.Errorf("saved filter %s in UI config", key)
+		}
+	}
+
+	// Filters are private to the user.
+	other := context.WithValue(context.Background(), ContextUserKey, &schema.User{Username: "other"})
+	if filters, err := r.ListFilters(other); err != nil || len(filters) != 0 {
+		t.Errorf("expected no filters of other user, got %v (%v)", filters, err)
+	}
+
+	if err := r.DeleteFilter("running", ctx); err != nil {
+		t.Fatal(err)
+	}
+	if filters, err := r.ListFilters(ctx); err != nil || len(filters) != 1 {
+		t.Errorf("expected one filter after delete, got %v (%v)", filters, err)
+	}
+
+	// Anonymous users cannot persist filters.
+	if err := r.SaveFilter("anonymous", filter, context.Background()); err != ErrForbidden {
+		t.Errorf("expected ErrForbidden, got %v", err)
+	}
+	if _, err := r.ListFilters(context.Background()); err != ErrForbidden {
+		t.Errorf("expected ErrForbidden, got %v", err)
+	}
+}
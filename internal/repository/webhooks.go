@@ -0,0 +1,208 @@
+// Copyright (C) 2023 NHR@FAU, University Erlangen-Nuremberg.
+// All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+package repository
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ClusterCockpit/cc-backend/pkg/log"
+	"github.com/ClusterCockpit/cc-backend/pkg/schema"
+)
+
+// Job lifecycle events sent to the webhooks.
+const (
+	WebhookEventStart = "start"
+	WebhookEventStop  = "stop"
+)
+
+const (
+	// Number of webhook requests that can wait for a free worker. Further
+	// requests are dropped so that slow endpoints never block the job ingest.
+	webhookQueueSize = 256
+
+	// Number of times a request is sent before it is given up.
+	webhookAttempts = 3
+)
+
+// Delay before the first retry of a failed request, doubled for every further retry.
+var webhookRetryDelay = time.Second
+
+var webhookClient = &http.Client{Timeout: 10 * time.Second}
+
+type webhook struct {
+	url    string
+	secret []byte
+	events map[string]bool // nil: all events
+}
+
+// The webhooks notified about job events, set by InitWebhooks.
+var webhooks []*webhook
+
+// WebhookPayload is the JSON body posted to the webhooks.
+type WebhookPayload struct {
+	Event     string          `json:"event"`     // "start" or "stop"
+	ID        int64           `json:"id"`        // The database id of the job
+	JobID     int64           `json:"jobId"`     // The job id of the batch scheduler
+	Cluster   string          `json:"cluster"`   // The cluster of the job
+	StartTime int64           `json:"startTime"` // Start epoch time stamp in seconds
+	State     schema.JobState `json:"jobState"`  // The state of the job
+	Duration  int32           `json:"duration"`  // Duration of the job in seconds, 0 for started jobs
+	// Averages of the metrics of the job, only set if the job was archived
+	Footprint map[string]float64 `json:"footprint,omitempty"`
+}
+
+type webhookRequest struct {
+	hook  *webhook
+	jobId int64
+	body  []byte
+}
+
+// InitWebhooks sets the webhooks notified about job events. Without calling
+// it, no requests are sent.
+func InitWebhooks(configs []*schema.WebhookConfig) error {
+	hooks := make([]*webhook, 0, len(configs))
+	for _, wc := range configs {
+		u, err := url.Parse(wc.URL)
+		if err != nil {
+			return fmt.Errorf("REPOSITORY/WEBHOOKS > invalid URL '%s': %w", wc.URL, err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("REPOSITORY/WEBHOOKS > invalid URL '%s': only http and https are supported", wc.URL)
+		}
+
+		hook := &webhook{url: wc.URL}
+		if wc.Secret != "" {
+			hook.secret = []byte(wc.Secret)
+		}
+		if len(wc.Events) != 0 {
+			hook.events = make(map[string]bool, len(wc.Events))
+			for _, event := range wc.Events {
+				if event != WebhookEventStart && event != WebhookEventStop {
+					return fmt.Errorf("REPOSITORY/WEBHOOKS > unknown event '%s' for URL '%s'", event, wc.URL)
+				}
+				hook.events[event] = true
+			}
+		}
+		hooks = append(hooks, hook)
+	}
+
+	webhooks = hooks
+	return nil
+}
+
+// notifyWebhooks queues a request to every webhook subscribed to event. The
+// footprint is computed from statistics, which may be nil.
+func (r *JobRepository) notifyWebhooks(
+	event string,
+	job *schema.Job,
+	statistics map[string]schema.JobStatistics) {
+
+	if len(webhooks) == 0 {
+		return
+	}
+
+	payload := WebhookPayload{
+		Event:     event,
+		ID:        job.ID,
+		JobID:     job.JobID,
+		Cluster:   job.Cluster,
+		StartTime: job.StartTime.Unix(),
+		State:     job.State,
+		Duration:  job.Duration,
+	}
+	if len(statistics) != 0 {
+		payload.Footprint = make(map[string]float64, len(statistics))
+		for metric, stats := range statistics {
+			payload.Footprint[metric] = stats.Avg
+		}
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		log.Warnf("Error while marshaling webhook payload of job (dbid: %d)", job.ID)
+		return
+	}
+
+	for _, hook := range webhooks {
+		if hook.events != nil && !hook.events[event] {
+			continue
+		}
+
+		r.webhooksPending.Add(1)
+		select {
+		case r.webhookChannel <- &webhookRequest{hook: hook, jobId: job.ID, body: body}:
+		default:
+			r.webhooksPending.Done()
+			log.Warnf("webhook queue full, dropping %s event of job (dbid: %d) for %s", event, job.ID, hook.url)
+		}
+	}
+}
+
+// Webhook worker thread, config.Keys.WebhookWorkers of them share the
+// webhook channel.
+func (r *JobRepository) webhookWorker() {
+	for req := range r.webhookChannel {
+		req.send()
+		r.webhooksPending.Done()
+	}
+}
+
+// send posts the request, failed requests are retried.
+func (req *webhookRequest) send() {
+	delay := webhookRetryDelay
+	for attempt := 1; ; attempt++ {
+		err := req.post()
+		if err == nil {
+			return
+		}
+
+		if attempt == webhookAttempts {
+			log.Errorf("webhook request for job (dbid: %d) to %s failed: %s", req.jobId, req.hook.url, err.Error())
+			return
+		}
+		log.Warnf("webhook request for job (dbid: %d) to %s failed, retrying in %s: %s", req.jobId, req.hook.url, delay, err.Error())
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+func (req *webhookRequest) post() error {
+	httpReq, err := http.NewRequest(http.MethodPost, req.hook.url, bytes.NewReader(req.body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if req.hook.secret != nil {
+		mac := hmac.New(sha256.New, req.hook.secret)
+		mac.Write(req.body)
+		httpReq.Header.Set("X-CC-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	res, err := webhookClient.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status: %s", res.Status)
+	}
+	return nil
+}
+
+// Wait for the webhook workers to send all queued requests.
+func (r *JobRepository) WaitForWebhooks() {
+	r.webhooksPending.Wait()
+}
@@ -0,0 +1,106 @@
+// Copyright (C) 2023 NHR@FAU, University Erlangen-Nuremberg.
+// All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+package repository
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ClusterCockpit/cc-backend/pkg/schema"
+)
+
+func TestWebhooks(t *testing.T) {
+	r := setup(t)
+
+	var lock sync.Mutex
+	requests, received := 0, []WebhookPayload{}
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			t.Error(err)
+		}
+
+		mac := hmac.New(sha256.New, []byte("secret"))
+		mac.Write(body)
+		if req.Header.Get("X-CC-Signature") != "sha256="+hex.EncodeToString(mac.Sum(nil)) {
+			t.Errorf("wrong signature: %s", req.Header.Get("X-CC-Signature"))
+		}
+
+		lock.Lock()
+		defer lock.Unlock()
+		// The first request fails and has to be retried.
+		if requests++; requests == 1 {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		var payload WebhookPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Error(err)
+		}
+		received = append(received, payload)
+	}))
+
+	hooks, delay := webhooks, webhookRetryDelay
+	t.Cleanup(func() {
+		webhooks, webhookRetryDelay = hooks, delay
+		server.Close()
+	})
+	webhookRetryDelay = time.Millisecond
+	noErr(t, InitWebhooks([]*schema.WebhookConfig{
+		{URL: server.URL, Secret: "secret"},
+		{URL: server.URL + "/start", Events: []string{WebhookEventStart}},
+	}))
+
+	jobId, cluster, startTime := int64(398998), "fritz", int64(1675957496)
+	job, err := r.Find(context.Background(), &jobId, &cluster, &startTime)
+	noErr(t, err)
+	if job.State != schema.JobStateCompleted {
+		t.Fatalf("expected completed job, got %s", job.State)
+	}
+
+	r.notifyWebhooks(WebhookEventStop, job, map[string]schema.JobStatistics{
+		"flops_any": {Unit: schema.Unit{Base: "F/s", Prefix: "G"}, Avg: 12.5, Min: 0, Max: 20},
+	})
+	r.WaitForWebhooks()
+
+	expected := []WebhookPayload{{
+		Event:     WebhookEventStop,
+		ID:        job.ID,
+		JobID:     398998,
+		Cluster:   "fritz",
+		StartTime: 1675957496,
+		State:     schema.JobStateCompleted,
+		Duration:  job.Duration,
+		Footprint: map[string]float64{"flops_any": 12.5},
+	}}
+	if requests != 2 || !reflect.DeepEqual(received, expected) {
+		t.Errorf("after %d requests, expected %#v, got %#v", requests, expected, received)
+	}
+}
+
+func TestInitWebhooksInvalid(t *testing.T) {
+	hooks := webhooks
+	t.Cleanup(func() { webhooks = hooks })
+
+	for _, wc := range []*schema.WebhookConfig{
+		{URL: "ftp://example.com/hook"},
+		{URL: "http://example.com/hook", Events: []string{"archive"}},
+	} {
+		if err := InitWebhooks([]*schema.WebhookConfig{wc}); err == nil {
+			t.Errorf("expected error for webhook %#v", wc)
+		}
+	}
+}
@@ -32,13 +32,16 @@ type ArchiveBackend interface {
 
 	ImportJob(jobMeta *schema.JobMeta, jobData *schema.JobData) error
 
+	// StoreJobData replaces the metric data of an archived job.
+	StoreJobData(job *schema.Job, jobData schema.JobData) error
+
 	GetClusters() []string
 
 	CleanUp(jobs []*schema.Job)
 
 	Move(jobs []*schema.Job, path string)
 
-	Clean(before int64, after int64)
+	Clean(before int64, after int64, dryRun bool) []string
 
 	Compress(jobs []*schema.Job)
 
@@ -73,6 +76,8 @@ func Init(rawConfig json.RawMessage, disableArchive bool) error {
 	switch cfg.Kind {
 	case "file":
 		ar = &FsArchive{}
+	case "http":
+		ar = &HttpArchive{}
 		// case "s3":
 		// 	ar = &S3Archive{}
 	default:
@@ -116,6 +121,41 @@ func LoadAveragesFromArchive(
 	return nil
 }
 
+// Helper to metricdata.LoadStats(). Returns the node scope statistics of the
+// requested metrics (all metrics if nil) as map metric -> hostname -> stats.
+func LoadStatsFromArchive(
+	job *schema.Job,
+	metrics []string,
+) (map[string]map[string]schema.MetricStatistics, error) {
+	data, err := ar.LoadJobData(job)
+	if err != nil {
+		log.Warn("Error while loading job data from archiveBackend")
+		return nil, err
+	}
+
+	if metrics == nil {
+		for m := range data {
+			metrics = append(metrics, m)
+		}
+	}
+
+	stats := make(map[string]map[string]schema.MetricStatistics, len(metrics))
+	for _, m := range metrics {
+		jm, ok := data[m][schema.MetricScopeNode]
+		if !ok {
+			continue
+		}
+
+		nodes := make(map[string]schema.MetricStatistics, len(jm.Series))
+		for _, series := range jm.Series {
+			nodes[series.Hostname] = series.Statistics
+		}
+		stats[m] = nodes
+	}
+
+	return stats, nil
+}
+
 func GetStatistics(job *schema.Job) (map[string]schema.JobStatistics, error) {
 	metaFile, err := ar.LoadJobMeta(job)
 	if err != nil {
@@ -126,6 +166,31 @@ func GetStatistics(job *schema.Job) (map[string]schema.JobStatistics, error) {
 	return metaFile.Statistics, nil
 }
 
+// DownsampleJobData rewrites the metric data of the archived job at a
+// timestep of at least timestep seconds, see schema.JobData.DownsampleTo.
+func DownsampleJobData(job *schema.Job, timestep int) error {
+	jobData, err := ar.LoadJobData(job)
+	if err != nil {
+		log.Warn("Error while loading job data from archiveBackend")
+		return err
+	}
+
+	return ar.StoreJobData(job, jobData.DownsampleTo(timestep))
+}
+
+// ReduceJobDataToStatistics rewrites the metric data of the archived job with
+// a single data point per series, see schema.JobData.StatisticsOnly. The
+// statistics in meta.json are not touched.
+func ReduceJobDataToStatistics(job *schema.Job) error {
+	jobData, err := ar.LoadJobData(job)
+	if err != nil {
+		log.Warn("Error while loading job data from archiveBackend")
+		return err
+	}
+
+	return ar.StoreJobData(job, jobData.StatisticsOnly())
+}
+
 // If the job is archived, find its `meta.json` file and override the Metadata
 // in that JSON file. If the job is not archived, nothing is done.
 func UpdateMetadata(job *schema.Job, metadata map[string]string) error {
@@ -146,6 +211,23 @@ func UpdateMetadata(job *schema.Job, metadata map[string]string) error {
 	return ar.StoreJobMeta(jobMeta)
 }
 
+// If the job is archived, find its `meta.json` file and override the user and
+// project in that JSON file. If the job is not archived, nothing is done.
+func UpdateOwnership(job *schema.Job) error {
+	if job.State == schema.JobStateRunning || !useArchive {
+		return nil
+	}
+
+	jobMeta, err := ar.LoadJobMeta(job)
+	if err != nil {
+		log.Warn("Error while loading job metadata from archiveBackend")
+		return err
+	}
+
+	jobMeta.User, jobMeta.Project = job.User, job.Project
+	return ar.StoreJobMeta(jobMeta)
+}
+
 // If the job is archived, find its `meta.json` file and override the tags list
 // in that JSON file. If the job is not archived, nothing is done.
 func UpdateTags(job *schema.Job, tags []*schema.Tag) error {
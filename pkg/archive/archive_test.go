@@ -55,6 +55,85 @@ func TestCleanUp(t *testing.T) {
 	}
 }
 
+func TestCleanDryRun(t *testing.T) {
+	a := setup(t)
+
+	removed := a.Clean(1609300557, 0, true)
+	if len(removed) != 2 {
+		t.Fatalf("expected 2 job directories, got %d: %v", len(removed), removed)
+	}
+	for _, path := range removed {
+		if !util.CheckFileExists(path) {
+			t.Fatalf("dry run removed %s", path)
+		}
+	}
+
+	if removed = a.Clean(1609300557, 0, false); len(removed) != 2 {
+		t.Fatalf("expected 2 job directories, got %d: %v", len(removed), removed)
+	}
+	for _, path := range removed {
+		if util.CheckFileExists(path) {
+			t.Errorf("%s still exists", path)
+		}
+	}
+}
+
+func TestDownsampleJobData(t *testing.T) {
+	a := setup(t)
+	job := &schema.Job{}
+	job.JobID, job.Cluster, job.StartTime = 1404397, "emmy", time.Unix(1609300556, 0)
+
+	before, err := a.LoadJobData(job)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := archive.DownsampleJobData(job, 600); err != nil {
+		t.Fatal(err)
+	}
+	after, err := a.LoadJobData(job)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jm, jmBefore := after["flops_any"][schema.MetricScopeNode], before["flops_any"][schema.MetricScopeNode]
+	if jm.Timestep != 600 {
+		t.Errorf("expected timestep 600, got %d", jm.Timestep)
+	}
+	if n, nBefore := len(jm.Series[0].Data), len(jmBefore.Series[0].Data); n != (nBefore+9)/10 {
+		t.Errorf("expected %d data points, got %d", (nBefore+9)/10, n)
+	}
+	if jm.Series[0].Statistics != jmBefore.Series[0].Statistics {
+		t.Errorf("statistics changed: %v, was %v", jm.Series[0].Statistics, jmBefore.Series[0].Statistics)
+	}
+}
+
+func TestReduceJobDataToStatistics(t *testing.T) {
+	a := setup(t)
+	job := &schema.Job{}
+	job.JobID, job.Cluster, job.StartTime = 1404397, "emmy", time.Unix(1609300556, 0)
+
+	if err := archive.ReduceJobDataToStatistics(job); err != nil {
+		t.Fatal(err)
+	}
+	jobData, err := a.LoadJobData(job)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for metric, scopes := range jobData {
+		for scope, jm := range scopes {
+			if jm.StatisticsSeries != nil {
+				t.Errorf("%s (%s): statistics series kept", metric, scope)
+			}
+			for _, series := range jm.Series {
+				if len(series.Data) != 1 {
+					t.Errorf("%s (%s) on %s: expected one data point, got %d", metric, scope, series.Hostname, len(series.Data))
+				}
+			}
+		}
+	}
+}
+
 // func TestCompress(t *testing.T) {
 // 	a := setup(t)
 // 	if !a.Exists(jobs[0]) {
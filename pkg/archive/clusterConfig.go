@@ -7,7 +7,9 @@ package archive
 import (
 	"errors"
 	"fmt"
+	"sync"
 
+	"github.com/ClusterCockpit/cc-backend/internal/config"
 	"github.com/ClusterCockpit/cc-backend/pkg/log"
 	"github.com/ClusterCockpit/cc-backend/pkg/schema"
 )
@@ -15,31 +17,59 @@ import (
 var Clusters []*schema.Cluster
 var nodeLists map[string]map[string]NodeList
 
+// Protects Clusters and nodeLists, which are replaced by ReloadClusterConfig.
+var clusterLock sync.RWMutex
+
 func initClusterConfig() error {
+	clusters, lists, err := loadClusterConfig()
+	if err != nil {
+		return err
+	}
+
+	clusterLock.Lock()
+	defer clusterLock.Unlock()
+	Clusters, nodeLists = clusters, lists
+	return nil
+}
 
-	Clusters = []*schema.Cluster{}
-	nodeLists = map[string]map[string]NodeList{}
+// ReloadClusterConfig re-reads the cluster.json files of all clusters in the
+// job archive and replaces the cluster configurations at once, e.g. after a
+// metric was added. If any of the files is invalid, the current
+// configurations are kept.
+func ReloadClusterConfig() error {
+	if err := initClusterConfig(); err != nil {
+		log.Errorf("Reloading cluster configs failed, keeping the current ones: %v", err)
+		return err
+	}
+
+	log.Info("Reloaded cluster configs")
+	return nil
+}
+
+func loadClusterConfig() ([]*schema.Cluster, map[string]map[string]NodeList, error) {
+	clusters := []*schema.Cluster{}
+	lists := map[string]map[string]NodeList{}
 
 	for _, c := range ar.GetClusters() {
 
 		cluster, err := ar.LoadClusterCfg(c)
 		if err != nil {
 			log.Warnf("Error while loading cluster config for cluster '%v'", c)
-			return err
+			return nil, nil, err
 		}
 
 		if len(cluster.Name) == 0 ||
 			len(cluster.MetricConfig) == 0 ||
 			len(cluster.SubClusters) == 0 {
-			return errors.New("cluster.name, cluster.metricConfig and cluster.SubClusters should not be empty")
+			return nil, nil, errors.New("cluster.name, cluster.metricConfig and cluster.SubClusters should not be empty")
 		}
 
 		for _, mc := range cluster.MetricConfig {
 			if len(mc.Name) == 0 {
-				return errors.New("cluster.metricConfig.name should not be empty")
+				return nil, nil, errors.New("cluster.metricConfig.name should not be empty")
 			}
 			if mc.Timestep < 1 {
-				return errors.New("cluster.metricConfig.timestep should not be smaller than one")
+				return nil, nil, errors.New("cluster.metricConfig.timestep should not be smaller than one")
 			}
 
 			// For backwards compability...
@@ -47,13 +77,13 @@ func initClusterConfig() error {
 				mc.Scope = schema.MetricScopeNode
 			}
 			if !mc.Scope.Valid() {
-				return errors.New("cluster.metricConfig.scope must be a valid scope ('node', 'scocket', ...)")
+				return nil, nil, errors.New("cluster.metricConfig.scope must be a valid scope ('node', 'scocket', ...)")
 			}
 		}
 
-		Clusters = append(Clusters, cluster)
+		clusters = append(clusters, cluster)
 
-		nodeLists[cluster.Name] = make(map[string]NodeList)
+		lists[cluster.Name] = make(map[string]NodeList)
 		for _, sc := range cluster.SubClusters {
 			if sc.Nodes == "*" {
 				continue
@@ -61,17 +91,33 @@ func initClusterConfig() error {
 
 			nl, err := ParseNodeList(sc.Nodes)
 			if err != nil {
-				return fmt.Errorf("ARCHIVE/CLUSTERCONFIG > in %s/cluster.json: %w", cluster.Name, err)
+				return nil, nil, fmt.Errorf("ARCHIVE/CLUSTERCONFIG > in %s/cluster.json: %w", cluster.Name, err)
 			}
-			nodeLists[cluster.Name][sc.Name] = nl
+			lists[cluster.Name][sc.Name] = nl
 		}
 	}
 
-	return nil
+	return clusters, lists, nil
+}
+
+// GetClusters returns the configurations of all clusters. The returned slice
+// is not modified by ReloadClusterConfig.
+func GetClusters() []*schema.Cluster {
+	clusterLock.RLock()
+	defer clusterLock.RUnlock()
+
+	return Clusters
 }
 
 func GetCluster(cluster string) *schema.Cluster {
+	clusterLock.RLock()
+	defer clusterLock.RUnlock()
+
+	return getCluster(cluster)
+}
 
+// getCluster is GetCluster for callers already holding clusterLock.
+func getCluster(cluster string) *schema.Cluster {
 	for _, c := range Clusters {
 		if c.Name == cluster {
 			return c
@@ -80,7 +126,40 @@ func GetCluster(cluster string) *schema.Cluster {
 	return nil
 }
 
+// getClusterNodeLists returns the configuration and node lists of cluster
+// from the same version of the cluster configs.
+func getClusterNodeLists(cluster string) (*schema.Cluster, map[string]NodeList) {
+	clusterLock.RLock()
+	defer clusterLock.RUnlock()
+
+	return getCluster(cluster), nodeLists[cluster]
+}
+
+// GetClusterNodes returns the hostnames of all nodes of cluster in the order
+// of its subclusters. If the nodes of a subcluster are not listed ("*"), the
+// nodes of the cluster are unknown and nil is returned.
+func GetClusterNodes(cluster string) ([]string, error) {
+	c, lists := getClusterNodeLists(cluster)
+	if c == nil {
+		return nil, fmt.Errorf("ARCHIVE/CLUSTERCONFIG > unkown cluster: %v", cluster)
+	}
+
+	nodes := make([]string, 0)
+	for _, sc := range c.SubClusters {
+		nl, ok := lists[sc.Name]
+		if !ok {
+			// Nodes "*"
+			return nil, nil
+		}
+		nodes = append(nodes, nl.PrintList()...)
+	}
+	return nodes, nil
+}
+
 func GetSubCluster(cluster, subcluster string) (*schema.SubCluster, error) {
+	clusterLock.RLock()
+	defer clusterLock.RUnlock()
+
 	for _, c := range Clusters {
 		if c.Name == cluster {
 			for _, p := range c.SubClusters {
@@ -94,6 +173,8 @@ func GetSubCluster(cluster, subcluster string) (*schema.SubCluster, error) {
 }
 
 func GetMetricConfig(cluster, metric string) *schema.MetricConfig {
+	clusterLock.RLock()
+	defer clusterLock.RUnlock()
 
 	for _, c := range Clusters {
 		if c.Name == cluster {
@@ -111,7 +192,7 @@ func GetMetricConfig(cluster, metric string) *schema.MetricConfig {
 // on its cluster and resources.
 func AssignSubCluster(job *schema.BaseJob) error {
 
-	cluster := GetCluster(job.Cluster)
+	cluster, lists := getClusterNodeLists(job.Cluster)
 	if cluster == nil {
 		return fmt.Errorf("ARCHIVE/CLUSTERCONFIG > unkown cluster: %v", job.Cluster)
 	}
@@ -130,7 +211,7 @@ func AssignSubCluster(job *schema.BaseJob) error {
 	}
 
 	host0 := job.Resources[0].Hostname
-	for sc, nl := range nodeLists[job.Cluster] {
+	for sc, nl := range lists {
 		if nl != nil && nl.Contains(host0) {
 			job.SubCluster = sc
 			return nil
@@ -145,15 +226,95 @@ func AssignSubCluster(job *schema.BaseJob) error {
 	return fmt.Errorf("ARCHIVE/CLUSTERCONFIG > no subcluster found for cluster %v and host %v", job.Cluster, host0)
 }
 
+// AssignPartition sets the `job.partition` property of jobs without one to
+// the first partition of the cluster's configuration that contains all hosts
+// of the job, or to its default partition. Clusters declaring neither leave
+// the partition empty.
+func AssignPartition(job *schema.BaseJob) error {
+	if job.Partition != "" {
+		return nil
+	}
+
+	partitions, defaultPartition := config.GetPartitions(job.Cluster)
+	if len(partitions) == 0 && defaultPartition == "" {
+		return nil
+	}
+
+	for _, p := range partitions {
+		nl, err := ParseNodeList(p.Nodes)
+		if err != nil {
+			return fmt.Errorf("ARCHIVE/CLUSTERCONFIG > invalid nodes of partition %v (cluster: %v): %w", p.Name, job.Cluster, err)
+		}
+
+		matches := len(job.Resources) != 0
+		for _, res := range job.Resources {
+			if !nl.Contains(res.Hostname) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			job.Partition = p.Name
+			return nil
+		}
+	}
+
+	if defaultPartition != "" {
+		job.Partition = defaultPartition
+		return nil
+	}
+
+	return fmt.Errorf("ARCHIVE/CLUSTERCONFIG > no partition found for cluster %v and the hosts of the job", job.Cluster)
+}
+
+// CheckResources verifies the resources of a job against the cluster
+// configuration: Every host has to belong to a subcluster of the job's
+// cluster and every hwthread has to be part of that subcluster's topology.
+func CheckResources(job *schema.BaseJob) error {
+	cluster, lists := getClusterNodeLists(job.Cluster)
+	if cluster == nil {
+		return fmt.Errorf("ARCHIVE/CLUSTERCONFIG > unkown cluster: %v", job.Cluster)
+	}
+
+	for _, res := range job.Resources {
+		var subCluster *schema.SubCluster
+		for _, sc := range cluster.SubClusters {
+			if nl, ok := lists[sc.Name]; (ok && nl.Contains(res.Hostname)) || sc.Nodes == "*" {
+				subCluster = sc
+				break
+			}
+		}
+		if subCluster == nil {
+			return fmt.Errorf("ARCHIVE/CLUSTERCONFIG > host %v does not belong to any subcluster of cluster %v", res.Hostname, job.Cluster)
+		}
+
+		for _, hwthread := range res.HWThreads {
+			known := false
+			for _, id := range subCluster.Topology.Node {
+				if id == hwthread {
+					known = true
+					break
+				}
+			}
+			if !known {
+				return fmt.Errorf("ARCHIVE/CLUSTERCONFIG > hwthread %d of host %v out of range for subcluster %v (%d hwthreads per node)",
+					hwthread, res.Hostname, subCluster.Name, len(subCluster.Topology.Node))
+			}
+		}
+	}
+
+	return nil
+}
+
 func GetSubClusterByNode(cluster, hostname string) (string, error) {
 
-	for sc, nl := range nodeLists[cluster] {
+	c, lists := getClusterNodeLists(cluster)
+	for sc, nl := range lists {
 		if nl != nil && nl.Contains(hostname) {
 			return sc, nil
 		}
 	}
 
-	c := GetCluster(cluster)
 	if c == nil {
 		return "", fmt.Errorf("ARCHIVE/CLUSTERCONFIG > unkown cluster: %v", cluster)
 	}
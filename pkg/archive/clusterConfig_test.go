@@ -0,0 +1,132 @@
+// Copyright (C) 2023 NHR@FAU, University Erlangen-Nuremberg.
+// All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+package archive_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/ClusterCockpit/cc-backend/internal/util"
+	"github.com/ClusterCockpit/cc-backend/pkg/archive"
+)
+
+func TestReloadClusterConfig(t *testing.T) {
+	jobarchive := filepath.Join(t.TempDir(), "job-archive")
+	if err := util.CopyDir("./testdata/archive/", jobarchive); err != nil {
+		t.Fatal(err)
+	}
+	if err := archive.Init(json.RawMessage(fmt.Sprintf(`{"kind": "file", "path": "%s"}`, jobarchive)), false); err != nil {
+		t.Fatal(err)
+	}
+
+	if archive.GetMetricConfig("emmy", "new_metric") != nil {
+		t.Fatal("unexpected metric new_metric")
+	}
+
+	clusterJson := filepath.Join(jobarchive, "emmy", "cluster.json")
+	b, err := os.ReadFile(clusterJson)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cluster map[string]interface{}
+	if err := json.Unmarshal(b, &cluster); err != nil {
+		t.Fatal(err)
+	}
+	cluster["metricConfig"] = append(cluster["metricConfig"].([]interface{}), map[string]interface{}{
+		"name":        "new_metric",
+		"scope":       "node",
+		"unit":        map[string]interface{}{"base": "B/s"},
+		"aggregation": "sum",
+		"timestep":    60,
+		"peak":        100,
+		"normal":      50,
+		"caution":     20,
+		"alert":       10,
+	})
+	if b, err = json.Marshal(cluster); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(clusterJson, b, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := archive.ReloadClusterConfig(); err != nil {
+		t.Fatal(err)
+	}
+	mc := archive.GetMetricConfig("emmy", "new_metric")
+	if mc == nil {
+		t.Fatal("metric new_metric missing after reload")
+	}
+	if mc.Timestep != 60 || mc.Peak != 100 {
+		t.Errorf("unexpected metric config: %#v", mc)
+	}
+
+	// An invalid cluster.json keeps the current configuration.
+	if err := os.WriteFile(clusterJson, []byte(`{"name": "emmy"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := archive.ReloadClusterConfig(); err == nil {
+		t.Fatal("expected reload to fail")
+	}
+	if archive.GetMetricConfig("emmy", "new_metric") == nil {
+		t.Error("cluster config lost after failed reload")
+	}
+}
+
+// Run with `go test -race` to detect unprotected accesses of the cluster configs.
+func TestClusterConfigConcurrentReload(t *testing.T) {
+	jobarchive := filepath.Join(t.TempDir(), "job-archive")
+	if err := util.CopyDir("./testdata/archive/", jobarchive); err != nil {
+		t.Fatal(err)
+	}
+	if err := archive.Init(json.RawMessage(fmt.Sprintf(`{"kind": "file", "path": "%s"}`, jobarchive)), false); err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	defer func() {
+		close(done)
+		wg.Wait()
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				if err := archive.ReloadClusterConfig(); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}
+	}()
+
+	for i := 0; i < 100; i++ {
+		if archive.GetCluster("emmy") == nil {
+			t.Fatal("cluster emmy missing")
+		}
+		if _, err := archive.GetSubCluster("emmy", "icelake"); err != nil {
+			t.Fatal(err)
+		}
+		if archive.GetMetricConfig("emmy", "cpu_load") == nil {
+			t.Fatal("metric cpu_load missing")
+		}
+		if len(archive.GetClusters()) == 0 {
+			t.Fatal("no clusters")
+		}
+		if _, err := archive.GetSubClusterByNode("emmy", "w2201"); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -29,13 +29,22 @@ import (
 
 type FsArchiveConfig struct {
 	Path string `json:"path"`
+
+	// Sync the files of a job and their directory to disk after writing them,
+	// so that they survive a crash. Enabled by default, disabling it speeds
+	// up bulk imports.
+	Sync *bool `json:"sync"`
 }
 
 type FsArchive struct {
 	path     string
 	clusters []string
+	sync     bool
 }
 
+// syncFile flushes a file or directory to disk, replaced in tests.
+var syncFile = (*os.File).Sync
+
 type clusterInfo struct {
 	numJobs   int
 	dateFirst int64
@@ -65,6 +74,31 @@ func getPath(
 		getDirectory(job, rootPath), file)
 }
 
+// closeFile syncs f to disk if enabled and closes it.
+func (fsa *FsArchive) closeFile(f *os.File) error {
+	if fsa.sync {
+		if err := syncFile(f); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	return f.Close()
+}
+
+// syncDir syncs the directory dir to disk if enabled, so that the entries of
+// newly written files are durable as well.
+func (fsa *FsArchive) syncDir(dir string) error {
+	if !fsa.sync {
+		return nil
+	}
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return syncFile(d)
+}
+
 func loadJobMeta(filename string) (*schema.JobMeta, error) {
 
 	b, err := os.ReadFile(filename)
@@ -128,19 +162,14 @@ func (fsa *FsArchive) Init(rawConfig json.RawMessage) (uint64, error) {
 		return 0, err
 	}
 	fsa.path = config.Path
+	fsa.sync = config.Sync == nil || *config.Sync
 
-	b, err := os.ReadFile(filepath.Join(fsa.path, "version.txt"))
+	version, err := fsa.readVersion()
 	if err != nil {
 		log.Warnf("fsBackend Init() - %v", err)
 		return 0, err
 	}
 
-	version, err := strconv.ParseUint(strings.TrimSuffix(string(b), "\n"), 10, 64)
-	if err != nil {
-		log.Errorf("fsBackend Init()- %v", err)
-		return 0, err
-	}
-
 	if version != Version {
 		return version, fmt.Errorf("unsupported version %d, need %d", version, Version)
 	}
@@ -161,6 +190,35 @@ func (fsa *FsArchive) Init(rawConfig json.RawMessage) (uint64, error) {
 	return version, nil
 }
 
+// readVersion returns the version of the archive layout from version.txt. An
+// empty archive directory is initialized with the current version. Archives
+// without version.txt predate the versioning and have to be converted with
+// tools/archive-migration first.
+func (fsa *FsArchive) readVersion() (uint64, error) {
+	filename := filepath.Join(fsa.path, "version.txt")
+	b, err := os.ReadFile(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		entries, err := os.ReadDir(fsa.path)
+		if err != nil {
+			return 0, err
+		}
+		if len(entries) != 0 {
+			return 0, fmt.Errorf("%s missing, convert the archive with tools/archive-migration", filename)
+		}
+
+		log.Infof("fsBackend Init() - initializing new job archive version %d in %s", Version, fsa.path)
+		if err := os.WriteFile(filename, []byte(fmt.Sprintf("%d\n", Version)), 0644); err != nil {
+			return 0, err
+		}
+		return Version, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.ParseUint(strings.TrimSuffix(string(b), "\n"), 10, 64)
+}
+
 func (fsa *FsArchive) Info() {
 	fmt.Printf("Job archive %s\n", fsa.path)
 	clusters, err := os.ReadDir(fsa.path)
@@ -241,8 +299,11 @@ func (fsa *FsArchive) Exists(job *schema.Job) bool {
 	return !errors.Is(err, os.ErrNotExist)
 }
 
-func (fsa *FsArchive) Clean(before int64, after int64) {
-
+// Clean removes all job directories with a start time before `before` or
+// after `after` and returns their paths. With dryRun set, the paths are
+// returned but nothing is removed.
+func (fsa *FsArchive) Clean(before int64, after int64, dryRun bool) []string {
+	removed := make([]string, 0)
 	if after == 0 {
 		after = math.MaxInt64
 	}
@@ -286,13 +347,18 @@ func (fsa *FsArchive) Clean(before int64, after int64) {
 						}
 
 						if startTime < before || startTime > after {
-							if err := os.RemoveAll(filepath.Join(dirpath, startTimeDir.Name())); err != nil {
+							path := filepath.Join(dirpath, startTimeDir.Name())
+							removed = append(removed, path)
+							if dryRun {
+								continue
+							}
+							if err := os.RemoveAll(path); err != nil {
 								log.Errorf("JobArchive Cleanup() error: %v", err)
 							}
 						}
 					}
 				}
-				if util.GetFilecount(dirpath) == 0 {
+				if !dryRun && util.GetFilecount(dirpath) == 0 {
 					if err := os.Remove(dirpath); err != nil {
 						log.Errorf("JobArchive Clean() error: %v", err)
 					}
@@ -300,6 +366,8 @@ func (fsa *FsArchive) Clean(before int64, after int64) {
 			}
 		}
 	}
+
+	return removed
 }
 
 func (fsa *FsArchive) Move(jobs []*schema.Job, path string) {
@@ -496,7 +564,7 @@ func (fsa *FsArchive) StoreJobMeta(jobMeta *schema.JobMeta) error {
 		log.Error("Error while encoding job metadata to meta.json file")
 		return err
 	}
-	if err := f.Close(); err != nil {
+	if err := fsa.closeFile(f); err != nil {
 		log.Warn("Error while closing meta.json file")
 		return err
 	}
@@ -504,6 +572,49 @@ func (fsa *FsArchive) StoreJobMeta(jobMeta *schema.JobMeta) error {
 	return nil
 }
 
+// StoreJobData replaces data.json(.gz) of the archived job, a compressed file
+// stays compressed. The new file is written next to the old one and renamed,
+// so that readers never see a partially written file.
+func (fsa *FsArchive) StoreJobData(job *schema.Job, jobData schema.JobData) error {
+	filename, isCompressed := getPath(job, fsa.path, "data.json.gz"), true
+	if !util.CheckFileExists(filename) {
+		filename, isCompressed = getPath(job, fsa.path, "data.json"), false
+	}
+
+	tmpname := filename + ".tmp"
+	f, err := os.Create(tmpname)
+	if err != nil {
+		log.Errorf("Error while creating %s", tmpname)
+		return err
+	}
+
+	if isCompressed {
+		gz := gzip.NewWriter(f)
+		err = EncodeJobData(gz, &jobData)
+		if cerr := gz.Close(); err == nil {
+			err = cerr
+		}
+	} else {
+		err = EncodeJobData(f, &jobData)
+	}
+	if err == nil {
+		err = fsa.closeFile(f)
+	} else {
+		f.Close()
+	}
+	if err == nil {
+		err = os.Rename(tmpname, filename)
+	}
+	if err != nil {
+		log.Warnf("Error while writing %s", filename)
+		os.Remove(tmpname)
+		return err
+	}
+
+	cache.Del(filename)
+	return fsa.syncDir(filepath.Dir(filename))
+}
+
 func (fsa *FsArchive) GetClusters() []string {
 	return fsa.clusters
 }
@@ -532,7 +643,7 @@ func (fsa *FsArchive) ImportJob(
 		log.Error("Error while encoding job metadata to meta.json file")
 		return err
 	}
-	if err := f.Close(); err != nil {
+	if err := fsa.closeFile(f); err != nil {
 		log.Warn("Error while closing meta.json file")
 		return err
 	}
@@ -568,8 +679,13 @@ func (fsa *FsArchive) ImportJob(
 		log.Error("Error while encoding job metricdata to data.json file")
 		return err
 	}
-	if err := f.Close(); err != nil {
+	if err := fsa.closeFile(f); err != nil {
 		log.Warn("Error while closing data.json file")
+		return err
 	}
-	return err
+	if err := fsa.syncDir(dir); err != nil {
+		log.Warn("Error while syncing job directory")
+		return err
+	}
+	return nil
 }
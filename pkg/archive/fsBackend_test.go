@@ -6,8 +6,11 @@ package archive
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -38,6 +41,44 @@ func TestInitNotExists(t *testing.T) {
 	}
 }
 
+func TestInitNewArchive(t *testing.T) {
+	dir := t.TempDir()
+	rawConfig := json.RawMessage(fmt.Sprintf(`{"path": "%s"}`, dir))
+
+	var fsa FsArchive
+	version, err := fsa.Init(rawConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != Version || len(fsa.clusters) != 0 {
+		t.Fatalf("unexpected version %d or clusters %v", version, fsa.clusters)
+	}
+	if b, err := os.ReadFile(filepath.Join(dir, "version.txt")); err != nil || string(b) != fmt.Sprintf("%d\n", Version) {
+		t.Fatalf("version.txt not written: %q, %v", b, err)
+	}
+
+	// The second initialization reads the version written by the first one.
+	if version, err = (&FsArchive{}).Init(rawConfig); err != nil || version != Version {
+		t.Fatalf("unexpected version %d: %v", version, err)
+	}
+}
+
+func TestInitUnversioned(t *testing.T) {
+	dir := t.TempDir()
+	if err := util.CopyDir("testdata/archive/emmy", filepath.Join(dir, "emmy")); err != nil {
+		t.Fatal(err)
+	}
+
+	var fsa FsArchive
+	_, err := fsa.Init(json.RawMessage(fmt.Sprintf(`{"path": "%s"}`, dir)))
+	if err == nil || !strings.Contains(err.Error(), "archive-migration") {
+		t.Fatalf("expected error pointing to archive-migration, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "version.txt")); !errors.Is(err, os.ErrNotExist) {
+		t.Error("version.txt written for unversioned archive")
+	}
+}
+
 func TestInit(t *testing.T) {
 	var fsa FsArchive
 	version, err := fsa.Init(json.RawMessage("{\"path\":\"testdata/archive\"}"))
@@ -209,3 +250,60 @@ func TestIter(t *testing.T) {
 		}
 	}
 }
+
+func TestImportJobSync(t *testing.T) {
+	var synced []string
+	t.Cleanup(func() { syncFile = (*os.File).Sync })
+	syncFile = func(f *os.File) error {
+		synced = append(synced, f.Name())
+		return f.Sync()
+	}
+
+	var src FsArchive
+	if _, err := src.Init(json.RawMessage(`{"path": "testdata/archive"}`)); err != nil {
+		t.Fatal(err)
+	}
+	jobIn := schema.Job{BaseJob: schema.JobDefaults}
+	jobIn.StartTime = time.Unix(1608923076, 0)
+	jobIn.JobID = 1403244
+	jobIn.Cluster = "emmy"
+	jobMeta, err := src.LoadJobMeta(&jobIn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jobData, err := src.LoadJobData(&jobIn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		config string
+		synced []string
+	}{
+		{`{"path": "%s"}`, []string{"meta.json", "data.json", ""}},
+		{`{"path": "%s", "sync": true}`, []string{"meta.json", "data.json", ""}},
+		{`{"path": "%s", "sync": false}`, nil},
+	} {
+		dir := t.TempDir()
+		var fsa FsArchive
+		if _, err := fsa.Init(json.RawMessage(fmt.Sprintf(tc.config, dir))); err != nil {
+			t.Fatal(err)
+		}
+
+		synced = nil
+		if err := fsa.ImportJob(jobMeta, &jobData); err != nil {
+			t.Fatal(err)
+		}
+
+		var want []string
+		for _, file := range tc.synced {
+			want = append(want, getPath(&jobIn, dir, file))
+		}
+		if strings.Join(synced, ",") != strings.Join(want, ",") {
+			t.Errorf("%s: wrong synced files\ngot: %v \nwant: %v", tc.config, synced, want)
+		}
+		if !fsa.Exists(&jobIn) {
+			t.Errorf("%s: job not imported", tc.config)
+		}
+	}
+}
@@ -0,0 +1,240 @@
+// Copyright (C) 2023 NHR@FAU, University Erlangen-Nuremberg.
+// All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+package archive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ClusterCockpit/cc-backend/internal/config"
+	"github.com/ClusterCockpit/cc-backend/pkg/log"
+	"github.com/ClusterCockpit/cc-backend/pkg/schema"
+)
+
+// ErrReadOnlyArchive is returned by all methods of the HttpArchive that would
+// modify the archive.
+var ErrReadOnlyArchive = errors.New("ARCHIVE/HTTP > read-only archive")
+
+type HttpArchiveConfig struct {
+	URL string `json:"url"`
+
+	// Value of the Authorization header sent with every request, e.g. "Bearer <token>".
+	Authorization string `json:"authorization"`
+
+	// Clusters in the archive. As the clusters cannot be listed over HTTP,
+	// the clusters of the configuration are used if empty.
+	Clusters []string `json:"clusters"`
+}
+
+// HttpArchive reads a job-archive with the same layout as the FsArchive from
+// a (read-only) HTTP server.
+type HttpArchive struct {
+	url           string
+	authorization string
+	clusters      []string
+	client        *http.Client
+}
+
+func (ha *HttpArchive) Init(rawConfig json.RawMessage) (uint64, error) {
+	var cfg HttpArchiveConfig
+	if err := json.Unmarshal(rawConfig, &cfg); err != nil {
+		log.Warnf("Init() > Unmarshal error: %#v", err)
+		return 0, err
+	}
+	if cfg.URL == "" {
+		return 0, errors.New("ARCHIVE/HTTP > empty url")
+	}
+
+	ha.url = strings.TrimSuffix(cfg.URL, "/")
+	ha.authorization = cfg.Authorization
+	ha.client = &http.Client{Timeout: 30 * time.Second}
+	ha.clusters = cfg.Clusters
+	if len(ha.clusters) == 0 {
+		for _, c := range config.Keys.Clusters {
+			ha.clusters = append(ha.clusters, c.Name)
+		}
+	}
+
+	b, err := ha.get("version.txt")
+	if err != nil {
+		log.Warnf("httpBackend Init() - %v", err)
+		return 0, err
+	}
+	version, err := strconv.ParseUint(strings.TrimSpace(string(b)), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	if version != Version {
+		return version, fmt.Errorf("unsupported version %d, need %d", version, Version)
+	}
+
+	return version, nil
+}
+
+// jobFile returns the key of file of the job relative to the base URL.
+func jobFile(job *schema.Job, file string) string {
+	return fmt.Sprintf("%s/%d/%03d/%d/%s",
+		job.Cluster, job.JobID/1000, job.JobID%1000, job.StartTime.Unix(), file)
+}
+
+// request sends a request for key, relative to the base URL, and returns the
+// response if its status is 200.
+func (ha *HttpArchive) request(method string, key string) (*http.Response, error) {
+	req, err := http.NewRequest(method, ha.url+"/"+key, nil)
+	if err != nil {
+		return nil, err
+	}
+	if ha.authorization != "" {
+		req.Header.Set("Authorization", ha.authorization)
+	}
+
+	res, err := ha.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		res.Body.Close()
+		return nil, fmt.Errorf("ARCHIVE/HTTP > %s %s: %s", method, key, res.Status)
+	}
+	return res, nil
+}
+
+func (ha *HttpArchive) get(key string) ([]byte, error) {
+	res, err := ha.request(http.MethodGet, key)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	return io.ReadAll(res.Body)
+}
+
+func (ha *HttpArchive) Info() {
+	fmt.Printf("Job archive %s (read-only)\n", ha.url)
+}
+
+func (ha *HttpArchive) Exists(job *schema.Job) bool {
+	res, err := ha.request(http.MethodHead, jobFile(job, "meta.json"))
+	if err != nil {
+		return false
+	}
+	res.Body.Close()
+	return true
+}
+
+func (ha *HttpArchive) LoadJobMeta(job *schema.Job) (*schema.JobMeta, error) {
+	b, err := ha.get(jobFile(job, "meta.json"))
+	if err != nil {
+		log.Errorf("LoadJobMeta() > %v", err)
+		return &schema.JobMeta{}, err
+	}
+	if config.Keys.Validate {
+		if err := schema.Validate(schema.Meta, bytes.NewReader(b)); err != nil {
+			return &schema.JobMeta{}, fmt.Errorf("validate job meta: %v", err)
+		}
+	}
+
+	return DecodeJobMeta(bytes.NewReader(b))
+}
+
+func (ha *HttpArchive) LoadJobData(job *schema.Job) (schema.JobData, error) {
+	key := jobFile(job, "data.json.gz")
+	b, err := ha.get(key)
+	if err == nil {
+		r, err := gzip.NewReader(bytes.NewReader(b))
+		if err != nil {
+			log.Errorf("LoadJobData() > %v", err)
+			return nil, err
+		}
+		defer r.Close()
+		if b, err = io.ReadAll(r); err != nil {
+			log.Errorf("LoadJobData() > %v", err)
+			return nil, err
+		}
+	} else {
+		key = jobFile(job, "data.json")
+		if b, err = ha.get(key); err != nil {
+			log.Errorf("LoadJobData() > %v", err)
+			return nil, err
+		}
+	}
+
+	if config.Keys.Validate {
+		if err := schema.Validate(schema.Data, bytes.NewReader(b)); err != nil {
+			return schema.JobData{}, fmt.Errorf("validate job data: %v", err)
+		}
+	}
+	return DecodeJobData(bytes.NewReader(b), key)
+}
+
+func (ha *HttpArchive) LoadClusterCfg(name string) (*schema.Cluster, error) {
+	b, err := ha.get(name + "/cluster.json")
+	if err != nil {
+		log.Errorf("LoadClusterCfg() > %v", err)
+		return &schema.Cluster{}, err
+	}
+	if config.Keys.Validate {
+		if err := schema.Validate(schema.ClusterCfg, bytes.NewReader(b)); err != nil {
+			log.Warnf("Validate cluster config: %v\n", err)
+			return &schema.Cluster{}, fmt.Errorf("validate cluster config: %v", err)
+		}
+	}
+	return DecodeCluster(bytes.NewReader(b))
+}
+
+func (ha *HttpArchive) GetClusters() []string {
+	return ha.clusters
+}
+
+func (ha *HttpArchive) StoreJobMeta(jobMeta *schema.JobMeta) error {
+	return ErrReadOnlyArchive
+}
+
+func (ha *HttpArchive) ImportJob(jobMeta *schema.JobMeta, jobData *schema.JobData) error {
+	return ErrReadOnlyArchive
+}
+
+func (ha *HttpArchive) StoreJobData(job *schema.Job, jobData schema.JobData) error {
+	return ErrReadOnlyArchive
+}
+
+func (ha *HttpArchive) CleanUp(jobs []*schema.Job) {
+	log.Errorf("JobArchive Cleanup() error: %v", ErrReadOnlyArchive)
+}
+
+func (ha *HttpArchive) Move(jobs []*schema.Job, path string) {
+	log.Errorf("JobArchive Move() error: %v", ErrReadOnlyArchive)
+}
+
+func (ha *HttpArchive) Clean(before int64, after int64, dryRun bool) []string {
+	log.Errorf("JobArchive Clean() error: %v", ErrReadOnlyArchive)
+	return []string{}
+}
+
+func (ha *HttpArchive) Compress(jobs []*schema.Job) {
+	log.Errorf("JobArchive Compress() error: %v", ErrReadOnlyArchive)
+}
+
+func (ha *HttpArchive) CompressLast(starttime int64) int64 {
+	log.Errorf("JobArchive CompressLast() error: %v", ErrReadOnlyArchive)
+	return starttime
+}
+
+// Iter returns a closed channel, the jobs of the archive cannot be listed
+// over HTTP.
+func (ha *HttpArchive) Iter(loadMetricData bool) <-chan JobContainer {
+	log.Errorf("JobArchive Iter() error: the jobs of %s cannot be listed", ha.url)
+	ch := make(chan JobContainer)
+	close(ch)
+	return ch
+}
@@ -0,0 +1,113 @@
+// Copyright (C) 2023 NHR@FAU, University Erlangen-Nuremberg.
+// All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+package archive
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ClusterCockpit/cc-backend/internal/util"
+	"github.com/ClusterCockpit/cc-backend/pkg/schema"
+)
+
+func TestHttpArchive(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "job-archive")
+	if err := util.CopyDir("testdata/archive", dir); err != nil {
+		t.Fatal(err)
+	}
+	// One job with uncompressed metric data.
+	if err := util.UncompressFile(filepath.Join(dir, "emmy/1404/397/1609300556/data.json.gz"),
+		filepath.Join(dir, "emmy/1404/397/1609300556/data.json")); err != nil {
+		t.Fatal(err)
+	}
+
+	files := http.FileServer(http.Dir(dir))
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		files.ServeHTTP(rw, r)
+	}))
+	t.Cleanup(server.Close)
+
+	var unauthorized HttpArchive
+	if _, err := unauthorized.Init(json.RawMessage(fmt.Sprintf(`{"url": "%s"}`, server.URL))); err == nil {
+		t.Fatal("expected error without authorization")
+	}
+
+	var ha HttpArchive
+	version, err := ha.Init(json.RawMessage(fmt.Sprintf(
+		`{"url": "%s/", "authorization": "Bearer secret", "clusters": ["emmy"]}`, server.URL)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != Version {
+		t.Fatalf("unexpected version %d", version)
+	}
+
+	cluster, err := ha.LoadClusterCfg("emmy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cluster.Name != "emmy" || len(cluster.SubClusters) == 0 {
+		t.Errorf("unexpected cluster config: %#v", cluster)
+	}
+
+	for _, job := range []struct {
+		jobId     int64
+		startTime int64
+	}{{1403244, 1608923076}, {1404397, 1609300556}} {
+		jobIn := schema.Job{BaseJob: schema.JobDefaults}
+		jobIn.StartTime = time.Unix(job.startTime, 0)
+		jobIn.JobID = job.jobId
+		jobIn.Cluster = "emmy"
+
+		if !ha.Exists(&jobIn) {
+			t.Errorf("job %d does not exist", job.jobId)
+		}
+
+		jobMeta, err := ha.LoadJobMeta(&jobIn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if jobMeta.JobID != job.jobId || jobMeta.StartTime != job.startTime {
+			t.Errorf("unexpected job meta: %#v", jobMeta)
+		}
+
+		data, err := ha.LoadJobData(&jobIn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(data) == 0 {
+			t.Errorf("no metric data for job %d", job.jobId)
+		}
+		for metric, scopes := range data {
+			if _, ok := scopes[schema.MetricScopeNode]; !ok {
+				t.Errorf("job %d: no node scope data for %s", job.jobId, metric)
+			}
+		}
+
+		if err := ha.ImportJob(jobMeta, &data); err != ErrReadOnlyArchive {
+			t.Errorf("expected ErrReadOnlyArchive, got %v", err)
+		}
+	}
+
+	jobIn := schema.Job{BaseJob: schema.JobDefaults}
+	jobIn.StartTime = time.Unix(1608923077, 0)
+	jobIn.JobID = 1403244
+	jobIn.Cluster = "emmy"
+	if ha.Exists(&jobIn) {
+		t.Error("unexpected job")
+	}
+	if _, err := ha.LoadJobMeta(&jobIn); err == nil {
+		t.Error("expected error for missing job")
+	}
+}
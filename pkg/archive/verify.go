@@ -0,0 +1,215 @@
+// Copyright (C) 2023 NHR@FAU, University Erlangen-Nuremberg.
+// All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+package archive
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/ClusterCockpit/cc-backend/internal/util"
+	"github.com/ClusterCockpit/cc-backend/pkg/schema"
+)
+
+const (
+	// Allowed relative difference between the number of samples of a series
+	// and duration/timestep, at least two samples are always allowed.
+	verifyLengthTolerance = 0.05
+
+	// Allowed difference between the statistics in meta.json and the range
+	// of the metric data. The statistics in meta.json are rounded, an
+	// absolute tolerance covers values near zero.
+	verifyStatsRelTolerance = 0.01
+	verifyStatsAbsTolerance = 0.01
+)
+
+// CorruptJob is a job directory of the job-archive that failed the verification.
+type CorruptJob struct {
+	Path    string   `json:"path"`
+	Reasons []string `json:"reasons"`
+}
+
+// VerifyReport is the result of VerifyArchive.
+type VerifyReport struct {
+	NumJobs int          `json:"numJobs"` // Number of verified job directories
+	Corrupt []CorruptJob `json:"corrupt"` // Sorted by path
+}
+
+// VerifyArchive checks every job directory of the file based job-archive at
+// path: meta.json and data.json(.gz) must parse, the series must have about
+// duration/timestep samples and the statistics in meta.json must be within
+// the range of the node scope data. This detects partial writes, e.g. after a
+// crash. An error is only returned if the archive itself cannot be read.
+func VerifyArchive(path string) (*VerifyReport, error) {
+	report := &VerifyReport{Corrupt: []CorruptJob{}}
+
+	clusterDirs, err := os.ReadDir(path)
+	if err != nil {
+		return nil, fmt.Errorf("ARCHIVE/VERIFY > reading archive failed: %w", err)
+	}
+	for _, clusterDir := range clusterDirs {
+		if !clusterDir.IsDir() {
+			continue
+		}
+		lvl1Dirs, err := os.ReadDir(filepath.Join(path, clusterDir.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("ARCHIVE/VERIFY > reading jobs failed: %w", err)
+		}
+		for _, lvl1Dir := range lvl1Dirs {
+			if !lvl1Dir.IsDir() {
+				continue
+			}
+			lvl2Dirs, err := os.ReadDir(filepath.Join(path, clusterDir.Name(), lvl1Dir.Name()))
+			if err != nil {
+				return nil, fmt.Errorf("ARCHIVE/VERIFY > reading jobs failed: %w", err)
+			}
+			for _, lvl2Dir := range lvl2Dirs {
+				dirpath := filepath.Join(path, clusterDir.Name(), lvl1Dir.Name(), lvl2Dir.Name())
+				startTimeDirs, err := os.ReadDir(dirpath)
+				if err != nil {
+					return nil, fmt.Errorf("ARCHIVE/VERIFY > reading jobs failed: %w", err)
+				}
+				for _, startTimeDir := range startTimeDirs {
+					if !startTimeDir.IsDir() {
+						continue
+					}
+
+					jobDir := filepath.Join(dirpath, startTimeDir.Name())
+					report.NumJobs++
+					if reasons := verifyJob(jobDir); len(reasons) != 0 {
+						report.Corrupt = append(report.Corrupt, CorruptJob{Path: jobDir, Reasons: reasons})
+					}
+				}
+			}
+		}
+	}
+
+	sort.Slice(report.Corrupt, func(i, j int) bool {
+		return report.Corrupt[i].Path < report.Corrupt[j].Path
+	})
+	return report, nil
+}
+
+// verifyJob returns the reasons why the job directory is corrupt.
+func verifyJob(dir string) []string {
+	reasons := []string{}
+
+	var jobMeta schema.JobMeta
+	if err := decodeFile(filepath.Join(dir, "meta.json"), false, &jobMeta); err != nil {
+		reasons = append(reasons, fmt.Sprintf("meta.json: %s", err.Error()))
+	}
+
+	filename, isCompressed := filepath.Join(dir, "data.json.gz"), true
+	if !util.CheckFileExists(filename) {
+		filename, isCompressed = filepath.Join(dir, "data.json"), false
+	}
+	var jobData schema.JobData
+	if err := decodeFile(filename, isCompressed, &jobData); err != nil {
+		reasons = append(reasons, fmt.Sprintf("%s: %s", filepath.Base(filename), err.Error()))
+	}
+
+	// Without both files the data cannot be checked against the meta data.
+	if len(reasons) != 0 {
+		return reasons
+	}
+
+	metrics := make([]string, 0, len(jobData))
+	for metric := range jobData {
+		metrics = append(metrics, metric)
+	}
+	sort.Strings(metrics)
+
+	for _, metric := range metrics {
+		for scope, jm := range jobData[metric] {
+			if jm == nil || jm.Timestep <= 0 {
+				reasons = append(reasons, fmt.Sprintf("%s (%s): invalid timestep", metric, scope))
+				continue
+			}
+
+			expected := float64(jobMeta.Duration) / float64(jm.Timestep)
+			tolerance := math.Max(2, expected*verifyLengthTolerance)
+			for _, series := range jm.Series {
+				if math.Abs(float64(len(series.Data))-expected) > tolerance {
+					reasons = append(reasons, fmt.Sprintf("%s (%s) on %s: %d samples, expected %.0f for a duration of %ds and a timestep of %ds",
+						metric, scope, series.Hostname, len(series.Data), expected, jobMeta.Duration, jm.Timestep))
+				}
+			}
+		}
+
+		stats, ok := jobMeta.Statistics[metric]
+		nodeData, hasNodeData := jobData[metric][schema.MetricScopeNode]
+		if !ok || !hasNodeData || nodeData == nil || len(nodeData.Series) == 0 {
+			continue
+		}
+
+		// The statistics of older archives were not always computed from the
+		// node scope data (e.g. for ipc), so they only have to be within the
+		// range of the data.
+		min, max, n := math.MaxFloat64, -math.MaxFloat64, 0
+		for _, series := range nodeData.Series {
+			for _, x := range series.Data {
+				if x.IsNaN() {
+					continue
+				}
+				min = math.Min(min, float64(x))
+				max = math.Max(max, float64(x))
+				n++
+			}
+		}
+		if n == 0 {
+			continue
+		}
+
+		for _, check := range []struct {
+			name  string
+			value float64
+		}{{"avg", stats.Avg}, {"min", stats.Min}, {"max", stats.Max}} {
+			if check.value < min-verifyTolerance(min) || check.value > max+verifyTolerance(max) {
+				reasons = append(reasons, fmt.Sprintf("%s: %s is %g in meta.json but the metric data is within [%g, %g]",
+					metric, check.name, check.value, min, max))
+			}
+		}
+	}
+
+	return reasons
+}
+
+// decodeFile decodes the JSON file into v, bypassing the cache of DecodeJobData.
+func decodeFile(filename string, isCompressed bool, v interface{}) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var r io.Reader = bufio.NewReader(f)
+	if isCompressed {
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	if err := json.NewDecoder(r).Decode(v); err != nil {
+		return err
+	}
+	// Reading up to the end also verifies the gzip checksum.
+	_, err = io.Copy(io.Discard, r)
+	return err
+}
+
+// verifyTolerance returns the allowed difference to x for the rounded
+// statistics in meta.json.
+func verifyTolerance(x float64) float64 {
+	return math.Max(verifyStatsAbsTolerance, verifyStatsRelTolerance*math.Abs(x))
+}
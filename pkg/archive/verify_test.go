@@ -0,0 +1,58 @@
+// Copyright (C) 2023 NHR@FAU, University Erlangen-Nuremberg.
+// All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+package archive
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ClusterCockpit/cc-backend/internal/util"
+)
+
+func TestVerifyArchive(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "job-archive")
+	if err := util.CopyDir("testdata/archive", dir); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := VerifyArchive(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.NumJobs != 2 || len(report.Corrupt) != 0 {
+		t.Fatalf("unexpected report for intact archive: %#v", report)
+	}
+
+	// Replace the compressed metric data of one job by a truncated data.json.
+	jobDir := filepath.Join(dir, "emmy/1404/397/1609300556")
+	if err := util.UncompressFile(filepath.Join(jobDir, "data.json.gz"), filepath.Join(jobDir, "data.json")); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(filepath.Join(jobDir, "data.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Truncate(filepath.Join(jobDir, "data.json"), info.Size()/2); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err = VerifyArchive(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.NumJobs != 2 || len(report.Corrupt) != 1 {
+		t.Fatalf("expected one corrupt job, got %#v", report)
+	}
+	if corrupt := report.Corrupt[0]; corrupt.Path != jobDir ||
+		len(corrupt.Reasons) != 1 || !strings.HasPrefix(corrupt.Reasons[0], "data.json:") {
+		t.Errorf("unexpected corrupt job: %#v", corrupt)
+	}
+
+	if _, err := VerifyArchive(filepath.Join(dir, "missing")); err == nil {
+		t.Error("expected error for missing archive")
+	}
+}
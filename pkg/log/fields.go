@@ -0,0 +1,193 @@
+// Copyright (C) 2023 NHR@FAU, University Erlangen-Nuremberg.
+// All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Structured logging: Messages logged with WithFields carry key/value pairs
+// like the job id or cluster, e.g.
+//
+//	log.WithFields(log.Fields{"job_id": job.JobID, "cluster": job.Cluster}).Info("archiving job successful")
+//
+// In the text format (default) the fields are appended as `key=value`, in the
+// JSON format (see SetFormat) every message is a JSON object with the keys
+// level, msg, caller, time (with '--logdate true') and the fields.
+
+// Fields are the key/value pairs of a structured log message.
+type Fields map[string]interface{}
+
+// Entry logs messages with fields, see WithFields.
+type Entry struct {
+	fields Fields
+}
+
+// Output formats, see SetFormat.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+var jsonFormat bool = false
+
+// SetFormat selects the output format of all log messages, "text" (or empty)
+// or "json". Must be called after Init.
+func SetFormat(format string) error {
+	switch format {
+	case "", FormatText:
+		jsonFormat = false
+	case FormatJSON:
+		jsonFormat = true
+	default:
+		return fmt.Errorf("pkg/log: invalid log format %#v", format)
+	}
+
+	initLoggers()
+	return nil
+}
+
+// WithFields returns an entry logging its messages with fields.
+func WithFields(fields Fields) *Entry {
+	return &Entry{fields: fields}
+}
+
+// WithFields returns an entry logging its messages with the fields of e and
+// fields, fields take precedence.
+func (e *Entry) WithFields(fields Fields) *Entry {
+	res := make(Fields, len(e.fields)+len(fields))
+	for key, value := range e.fields {
+		res[key] = value
+	}
+	for key, value := range fields {
+		res[key] = value
+	}
+	return &Entry{fields: res}
+}
+
+func (e *Entry) Print(v ...interface{}) {
+	output(InfoLog, "info", 2, printStr(v...), e.fields)
+}
+
+func (e *Entry) Printf(format string, v ...interface{}) {
+	output(InfoLog, "info", 2, printfStr(format, v...), e.fields)
+}
+
+func (e *Entry) Debug(v ...interface{}) {
+	output(DebugLog, "debug", 2, printStr(v...), e.fields)
+}
+
+func (e *Entry) Info(v ...interface{}) {
+	output(InfoLog, "info", 2, printStr(v...), e.fields)
+}
+
+func (e *Entry) Warn(v ...interface{}) {
+	output(WarnLog, "warn", 2, printStr(v...), e.fields)
+}
+
+func (e *Entry) Error(v ...interface{}) {
+	output(ErrLog, "error", 2, printStr(v...), e.fields)
+}
+
+func (e *Entry) Debugf(format string, v ...interface{}) {
+	output(DebugLog, "debug", 2, printfStr(format, v...), e.fields)
+}
+
+func (e *Entry) Infof(format string, v ...interface{}) {
+	output(InfoLog, "info", 2, printfStr(format, v...), e.fields)
+}
+
+func (e *Entry) Warnf(format string, v ...interface{}) {
+	output(WarnLog, "warn", 2, printfStr(format, v...), e.fields)
+}
+
+func (e *Entry) Errorf(format string, v ...interface{}) {
+	output(ErrLog, "error", 2, printfStr(format, v...), e.fields)
+}
+
+// output writes msg and fields to l in the selected format. calldepth is
+// counted like for log.Logger.Output, from the caller of output.
+func output(l *log.Logger, level string, calldepth int, msg string, fields Fields) {
+	if l.Writer() == io.Discard {
+		return
+	}
+
+	if !jsonFormat {
+		l.Output(calldepth+1, msg+formatTextFields(fields))
+		return
+	}
+
+	caller := "???"
+	if _, file, line, ok := runtime.Caller(calldepth); ok {
+		caller = filepath.Base(file) + ":" + strconv.Itoa(line)
+	}
+	l.Output(calldepth+1, formatJSON(level, caller, msg, fields))
+}
+
+func formatTextFields(fields Fields) string {
+	if len(fields) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, key := range keys {
+		value := fmt.Sprint(fields[key])
+		if value == "" || strings.ContainsAny(value, " \t\n\"=") {
+			value = strconv.Quote(value)
+		}
+		fmt.Fprintf(&b, " %s=%s", key, value)
+	}
+	return b.String()
+}
+
+// formatJSON returns the JSON object of a message. Errors are written as
+// their message and durations in seconds, values that cannot be encoded as
+// JSON (e.g. NaN) like by fmt.Sprint. The keys of the message take
+// precedence over fields.
+func formatJSON(level, caller, msg string, fields Fields) string {
+	obj := make(map[string]interface{}, len(fields)+4)
+	for key, value := range fields {
+		switch v := value.(type) {
+		case error:
+			obj[key] = v.Error()
+		case time.Duration:
+			obj[key] = v.Seconds()
+		default:
+			if _, err := json.Marshal(v); err != nil {
+				obj[key] = fmt.Sprint(v)
+			} else {
+				obj[key] = v
+			}
+		}
+	}
+
+	if logDateTime {
+		obj["time"] = time.Now().Format(time.RFC3339)
+	}
+	obj["level"] = level
+	obj["caller"] = caller
+	obj["msg"] = msg
+
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return fmt.Sprintf(`{"level":%q,"caller":%q,"msg":%q}`, level, caller, msg)
+	}
+	return string(b)
+}
@@ -41,11 +41,16 @@ var (
 	CritLog  *log.Logger = log.New(CritWriter, CritPrefix, log.LstdFlags|log.Llongfile)
 )
 
-var loglevel string = "info"
+var (
+	loglevel    string = "info"
+	logDateTime bool   = true
+)
 
 /* CONFIG */
 
 func Init(lvl string, logdate bool) {
+	// Init may be called again, e.g. with the log level of the config.
+	DebugWriter, InfoWriter, WarnWriter, ErrWriter = os.Stderr, os.Stderr, os.Stderr, os.Stderr
 
 	switch lvl {
 	case "crit":
@@ -67,7 +72,22 @@ func Init(lvl string, logdate bool) {
 		//SetLogLevel("debug")
 	}
 
-	if !logdate {
+	logDateTime = logdate
+	initLoggers()
+	loglevel = lvl
+}
+
+// initLoggers creates the loggers for the writers, logDateTime and the
+// output format.
+func initLoggers() {
+	if jsonFormat {
+		// Time and caller are keys of the JSON objects.
+		DebugLog = log.New(DebugWriter, "", 0)
+		InfoLog = log.New(InfoWriter, "", 0)
+		WarnLog = log.New(WarnWriter, "", 0)
+		ErrLog = log.New(ErrWriter, "", 0)
+		CritLog = log.New(CritWriter, "", 0)
+	} else if !logDateTime {
 		DebugLog = log.New(DebugWriter, DebugPrefix, 0)
 		InfoLog = log.New(InfoWriter, InfoPrefix, log.Lshortfile)
 		WarnLog = log.New(WarnWriter, WarnPrefix, log.Lshortfile)
@@ -80,8 +100,6 @@ func Init(lvl string, logdate bool) {
 		ErrLog = log.New(ErrWriter, ErrPrefix, log.LstdFlags|log.Llongfile)
 		CritLog = log.New(CritWriter, CritPrefix, log.LstdFlags|log.Llongfile)
 	}
-
-	loglevel = lvl
 }
 
 /* PRINT */
@@ -98,34 +116,34 @@ func Print(v ...interface{}) {
 }
 
 func Debug(v ...interface{}) {
-	DebugLog.Output(2, printStr(v...))
+	output(DebugLog, "debug", 2, printStr(v...), nil)
 }
 
 func Info(v ...interface{}) {
-	InfoLog.Output(2, printStr(v...))
+	output(InfoLog, "info", 2, printStr(v...), nil)
 }
 
 func Warn(v ...interface{}) {
-	WarnLog.Output(2, printStr(v...))
+	output(WarnLog, "warn", 2, printStr(v...), nil)
 }
 
 func Error(v ...interface{}) {
-	ErrLog.Output(2, printStr(v...))
+	output(ErrLog, "error", 2, printStr(v...), nil)
 }
 
 // Writes panic stacktrace, but keeps application alive
 func Panic(v ...interface{}) {
-	ErrLog.Output(2, printStr(v...))
+	output(ErrLog, "error", 2, printStr(v...), nil)
 	panic("Panic triggered ...")
 }
 
 func Crit(v ...interface{}) {
-	CritLog.Output(2, printStr(v...))
+	output(CritLog, "crit", 2, printStr(v...), nil)
 }
 
 // Writes critical log, stops application
 func Fatal(v ...interface{}) {
-	CritLog.Output(2, printStr(v...))
+	output(CritLog, "crit", 2, printStr(v...), nil)
 	os.Exit(1)
 }
 
@@ -143,34 +161,34 @@ func Printf(format string, v ...interface{}) {
 }
 
 func Debugf(format string, v ...interface{}) {
-	DebugLog.Output(2, printfStr(format, v...))
+	output(DebugLog, "debug", 2, printfStr(format, v...), nil)
 }
 
 func Infof(format string, v ...interface{}) {
-	InfoLog.Output(2, printfStr(format, v...))
+	output(InfoLog, "info", 2, printfStr(format, v...), nil)
 }
 
 func Warnf(format string, v ...interface{}) {
-	WarnLog.Output(2, printfStr(format, v...))
+	output(WarnLog, "warn", 2, printfStr(format, v...), nil)
 }
 
 func Errorf(format string, v ...interface{}) {
-	ErrLog.Output(2, printfStr(format, v...))
+	output(ErrLog, "error", 2, printfStr(format, v...), nil)
 }
 
 // Writes panic stacktrace, but keeps application alive
 func Panicf(format string, v ...interface{}) {
-	ErrLog.Output(2, printfStr(format, v...))
+	output(ErrLog, "error", 2, printfStr(format, v...), nil)
 	panic("Panic triggered ...")
 }
 
 func Critf(format string, v ...interface{}) {
-	CritLog.Output(2, printfStr(format, v...))
+	output(CritLog, "crit", 2, printfStr(format, v...), nil)
 }
 
 // Writes crit log, stops application
 func Fatalf(format string, v ...interface{}) {
-	CritLog.Output(2, printfStr(format, v...))
+	output(CritLog, "crit", 2, printfStr(format, v...), nil)
 	os.Exit(1)
 }
 
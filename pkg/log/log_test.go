@@ -0,0 +1,80 @@
+// Copyright (C) 2023 NHR@FAU, University Erlangen-Nuremberg.
+// All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func captureInfo(t *testing.T, format string) *bytes.Buffer {
+	var buf bytes.Buffer
+	Init("info", false)
+	InfoWriter = &buf
+	if err := SetFormat(format); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		SetFormat(FormatText)
+		Init("warn", true)
+	})
+	return &buf
+}
+
+func TestStructuredJSON(t *testing.T) {
+	buf := captureInfo(t, FormatJSON)
+
+	WithFields(Fields{"job_id": 1403244, "cluster": "emmy", "duration": 1500 * time.Millisecond}).
+		WithFields(Fields{"error": errors.New("timeout")}).
+		Infof("archiving job %d successful", 1403244)
+
+	var line map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatalf("not a JSON object: %q: %v", buf.String(), err)
+	}
+
+	expected := map[string]interface{}{
+		"level":    "info",
+		"msg":      "archiving job 1403244 successful",
+		"job_id":   float64(1403244),
+		"cluster":  "emmy",
+		"duration": 1.5,
+		"error":    "timeout",
+	}
+	for key, value := range expected {
+		if line[key] != value {
+			t.Errorf("%s: expected %v, got %v", key, value, line[key])
+		}
+	}
+	if caller, _ := line["caller"].(string); !strings.HasPrefix(caller, "log_test.go:") {
+		t.Errorf("expected caller in log_test.go, got %v", line["caller"])
+	}
+
+	// The plain functions write JSON objects as well.
+	buf.Reset()
+	Print("plain message")
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil || line["msg"] != "plain message" {
+		t.Errorf("unexpected line %q: %v", buf.String(), err)
+	}
+}
+
+func TestStructuredText(t *testing.T) {
+	buf := captureInfo(t, FormatText)
+
+	WithFields(Fields{"job_id": 1403244, "cluster": "emmy", "state": "out of memory"}).Info("job stopped")
+
+	if line := buf.String(); !strings.HasPrefix(line, InfoPrefix+"log_test.go:") ||
+		!strings.HasSuffix(line, `job stopped cluster=emmy job_id=1403244 state="out of memory"`+"\n") {
+		t.Errorf("unexpected line %q", line)
+	}
+
+	if err := SetFormat("xml"); err == nil {
+		t.Error("expected error for invalid format")
+	}
+}
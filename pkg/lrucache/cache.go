@@ -16,9 +16,21 @@ import (
 // the duration until this value will expire and a size estimate.
 type ComputeValue func() (value interface{}, ttl time.Duration, size int)
 
+// A Codec converts the values of a cache into a (usually more compact) byte
+// representation and back. See `NewWithCodec`.
+type Codec interface {
+	// Return the representation of value to be stored in the cache. If an
+	// error is returned, value is stored as it is.
+	Marshal(value interface{}) ([]byte, error)
+
+	// Return the value for data previously returned by Marshal.
+	Unmarshal(data []byte) (interface{}, error)
+}
+
 type cacheEntry struct {
-	key   string
-	value interface{}
+	key     string
+	value   interface{}
+	encoded bool // value is the []byte returned by Codec.Marshal
 
 	expiration            time.Time
 	size                  int
@@ -33,6 +45,7 @@ type Cache struct {
 	maxmemory, usedmemory int
 	entries               map[string]*cacheEntry
 	head, tail            *cacheEntry
+	codec                 Codec
 }
 
 // Return a new instance of a LRU In-Memory Cache.
@@ -47,6 +60,17 @@ func New(maxmemory int) *Cache {
 	return cache
 }
 
+// Return a new instance of a LRU In-Memory Cache that stores
+// all values encoded by `codec`. The size of an encoded entry
+// is the length of its encoding, not the estimate passed to `Get`
+// or `Put`. Every `Get` of a cached value decodes it again, so callers
+// get a copy they can modify.
+func NewWithCodec(maxmemory int, codec Codec) *Cache {
+	cache := New(maxmemory)
+	cache.codec = codec
+	return cache
+}
+
 // Return the cached value for key `key` or call `computeValue` and
 // store its return value in the cache. If called, the closure will be
 // called synchronous and __shall not call methods on the same cache__
@@ -71,16 +95,14 @@ func (c *Cache) Get(key string, computeValue ComputeValue) interface{} {
 				if entry.expiration.IsZero() {
 					panic("LRUCACHE/CACHE > cache entry that shoud have been waited for could not be evicted.")
 				}
-				c.mutex.Unlock()
-				return entry.value
+				return c.unlockAndLoad(entry, computeValue)
 			}
 		} else {
 			if entry != c.head {
 				c.unlinkEntry(entry)
 				c.insertFront(entry)
 			}
-			c.mutex.Unlock()
-			return entry.value
+			return c.unlockAndLoad(entry, computeValue)
 		}
 	}
 
@@ -109,10 +131,12 @@ func (c *Cache) Get(key string, computeValue ComputeValue) interface{} {
 
 	c.mutex.Unlock()
 	value, ttl, size := computeValue()
+	stored, size, encoded := c.encode(value, size)
 	c.mutex.Lock()
 	hasPaniced = false
 
-	entry.value = value
+	entry.value = stored
+	entry.encoded = encoded
 	entry.expiration = now.Add(ttl)
 	entry.size = size
 	entry.waitingForComputation -= 1
@@ -149,6 +173,7 @@ func (c *Cache) Get(key string, computeValue ComputeValue) interface{} {
 // before it overwrites the value.
 func (c *Cache) Put(key string, value interface{}, size int, ttl time.Duration) {
 	now := time.Now()
+	value, size, encoded := c.encode(value, size)
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
@@ -163,6 +188,7 @@ func (c *Cache) Put(key string, value interface{}, size int, ttl time.Duration)
 		entry.expiration = now.Add(ttl)
 		entry.size = size
 		entry.value = value
+		entry.encoded = encoded
 		c.usedmemory += entry.size
 
 		c.unlinkEntry(entry)
@@ -173,6 +199,7 @@ func (c *Cache) Put(key string, value interface{}, size int, ttl time.Duration)
 	entry := &cacheEntry{
 		key:        key,
 		value:      value,
+		encoded:    encoded,
 		expiration: now.Add(ttl),
 	}
 	c.entries[key] = entry
@@ -230,6 +257,12 @@ func (c *Cache) Keys(f func(key string, val interface{})) {
 		}
 
 		size += e.size
+		if e.encoded {
+			if value, err := c.codec.Unmarshal(e.value.([]byte)); err == nil {
+				f(key, value)
+				continue
+			}
+		}
 		f(key, e.value)
 	}
 
@@ -250,6 +283,39 @@ func (c *Cache) Keys(f func(key string, val interface{})) {
 	}
 }
 
+// Return the value to be stored for value and its size, encoded
+// by the codec of the cache if possible.
+func (c *Cache) encode(value interface{}, size int) (interface{}, int, bool) {
+	if c.codec == nil {
+		return value, size, false
+	}
+
+	data, err := c.codec.Marshal(value)
+	if err != nil {
+		return value, size, false
+	}
+	return data, len(data), true
+}
+
+// Unlock the cache and return the (decoded) value of e. The mutex
+// has to be locked by the caller. Decoding happens after unlocking
+// so that other keys can be accessed in the meantime.
+func (c *Cache) unlockAndLoad(e *cacheEntry, computeValue ComputeValue) interface{} {
+	value, encoded := e.value, e.encoded
+	c.mutex.Unlock()
+	if !encoded {
+		return value
+	}
+
+	decoded, err := c.codec.Unmarshal(value.([]byte))
+	if err != nil {
+		// Treat the entry as missing.
+		c.Del(e.key)
+		return c.Get(e.key, computeValue)
+	}
+	return decoded
+}
+
 func (c *Cache) insertFront(e *cacheEntry) {
 	e.next = c.head
 	c.head = e
@@ -5,6 +5,8 @@
 package lrucache
 
 import (
+	"errors"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -221,3 +223,75 @@ func TestPanic(t *testing.T) {
 
 	testpanic()
 }
+
+// upperCodec stores strings in upper case and refuses other values.
+type upperCodec struct {
+	unmarshalErr error
+}
+
+func (uc *upperCodec) Marshal(value interface{}) ([]byte, error) {
+	str, ok := value.(string)
+	if !ok {
+		return nil, errors.New("not a string")
+	}
+	return []byte(strings.ToUpper(str)), nil
+}
+
+func (uc *upperCodec) Unmarshal(data []byte) (interface{}, error) {
+	if uc.unmarshalErr != nil {
+		return nil, uc.unmarshalErr
+	}
+	return string(data), nil
+}
+
+func TestCodec(t *testing.T) {
+	codec := &upperCodec{}
+	cache := NewWithCodec(123, codec)
+
+	// The computing caller gets the value as computed.
+	value := cache.Get("foo", func() (interface{}, time.Duration, int) {
+		return "bar", 1 * time.Second, 100
+	})
+	if value.(string) != "bar" {
+		t.Error("cache returned wrong value")
+	}
+
+	value = cache.Get("foo", func() (interface{}, time.Duration, int) {
+		t.Error("value should be cached")
+		return "", 0, 0
+	})
+	if value.(string) != "BAR" {
+		t.Errorf("cache returned wrong value: %v", value)
+	}
+	if cache.usedmemory != len("BAR") {
+		t.Errorf("size of encoded value expected, got %d", cache.usedmemory)
+	}
+
+	cache.Put("baz", "qux", 100, 1*time.Second)
+	cache.Keys(func(key string, value interface{}) {
+		if (key == "foo" && value.(string) != "BAR") || (key == "baz" && value.(string) != "QUX") {
+			t.Errorf("cache corrupted: %s = %v", key, value)
+		}
+	})
+
+	// Values the codec does not support are stored as they are.
+	value = cache.Get("num", func() (interface{}, time.Duration, int) {
+		return 42, 1 * time.Second, 1
+	})
+	value = cache.Get("num", func() (interface{}, time.Duration, int) {
+		t.Error("value should be cached")
+		return 0, 0, 0
+	})
+	if value.(int) != 42 {
+		t.Error("cache returned wrong value")
+	}
+
+	// An entry that can not be decoded is computed again.
+	codec.unmarshalErr = errors.New("corrupted")
+	value = cache.Get("foo", func() (interface{}, time.Duration, int) {
+		return "new", 1 * time.Second, 0
+	})
+	if value.(string) != "new" {
+		t.Errorf("cache returned wrong value: %v", value)
+	}
+}
@@ -51,6 +51,14 @@ type SubClusterConfig struct {
 	Remove  bool    `json:"remove"`
 }
 
+// Aggregations of a metric used to derive node scope data from the series of
+// finer scopes, e.g. the sum for a bandwidth measured per socket.
+const (
+	AggregationSum = "sum"
+	AggregationAvg = "avg"
+	AggregationMax = "max"
+)
+
 type MetricConfig struct {
 	Name        string              `json:"name"`
 	Unit        Unit                `json:"unit"`
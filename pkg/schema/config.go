@@ -21,6 +21,14 @@ type LdapConfig struct {
 
 	// Should an non-existent user be added to the DB if user exists in ldap directory
 	SyncUserOnLogin bool `json:"syncUserOnLogin"`
+
+	// Attribute listing the group DNs of a user. Default: memberOf
+	GroupAttr string `json:"group_attr"`
+	// Maps LDAP group DNs to roles. If set, the roles of LDAP users are
+	// derived from their groups at login and sync.
+	GroupRoles map[string]string `json:"group_roles"`
+	// Role of users not in any mapped group. Default: user
+	DefaultRole string `json:"default_role"`
 }
 
 type JWTAuthConfig struct {
@@ -42,6 +50,77 @@ type JWTAuthConfig struct {
 	SyncUserOnLogin bool `json:"syncUserOnLogin"`
 }
 
+// Bearer JWTs signed by an external identity provider, used for programmatic
+// access to the REST API.
+type BearerAuthConfig struct {
+	// PEM encoded public key (RSA, ECDSA or Ed25519) the tokens are signed with.
+	PublicKey string `json:"publicKey"`
+
+	// URL of a JWKS document with the signing keys. Used if no publicKey is set.
+	JwksUrl string `json:"jwksUrl"`
+
+	// Required audience ('aud' claim) of the tokens.
+	Audience string `json:"audience"`
+
+	// Required issuer ('iss' claim) of the tokens. Tokens of other issuers
+	// are passed on to the regular authentication.
+	Issuer string `json:"issuer"`
+
+	// Claims holding the username (default: 'sub') and the list of roles (default: 'roles').
+	UsernameClaim string `json:"usernameClaim"`
+	RolesClaim    string `json:"rolesClaim"`
+}
+
+// Export of OpenTelemetry traces via OTLP/HTTP.
+type TracingConfig struct {
+	// Address (host:port) of the OTLP/HTTP endpoint the traces are sent to.
+	Endpoint string `json:"endpoint"`
+
+	// Use HTTP instead of HTTPS.
+	Insecure bool `json:"insecure"`
+
+	// Fraction of traces to sample, between 0 and 1. Default (0): 1
+	SampleRatio float64 `json:"sampleRatio"`
+}
+
+// A metric computed from the loaded metrics of a job.
+type DerivedMetricConfig struct {
+	Name string `json:"name"`
+
+	// Arithmetic expression (+, -, *, / and parentheses) over metric names
+	// and numbers, e.g. "flops_any / power".
+	Expression string `json:"expression"`
+
+	Unit Unit `json:"unit"`
+}
+
+// A rule tagging jobs when they are archived. All of its conditions must hold.
+type TagRuleConfig struct {
+	// Global tag added to matching jobs.
+	Type string `json:"type"`
+	Name string `json:"name"`
+
+	// Regular expressions the metadata values with the given keys must match,
+	// e.g. {"jobScript": "tensorflow"}.
+	MetaData map[string]string `json:"metaData"`
+
+	// Bounds of the averages of the given metrics over the whole job.
+	MinAvg map[string]float64 `json:"minAvg"`
+	MaxAvg map[string]float64 `json:"maxAvg"`
+}
+
+// A URL notified about job lifecycle events.
+type WebhookConfig struct {
+	URL string `json:"url"`
+
+	// If set, the body of every request is signed with HMAC-SHA256 using this
+	// secret, the signature is sent in the X-CC-Signature header.
+	Secret string `json:"secret"`
+
+	// Events sent to the URL, "start" and/or "stop". All events if empty.
+	Events []string `json:"events"`
+}
+
 type IntRange struct {
 	From int `json:"from"`
 	To   int `json:"to"`
@@ -62,6 +141,59 @@ type ClusterConfig struct {
 	Name                 string          `json:"name"`
 	FilterRanges         *FilterRanges   `json:"filterRanges"`
 	MetricDataRepository json.RawMessage `json:"metricDataRepository"`
+
+	// Metrics and scopes loaded for the job view if the client requests none.
+	// Without them, all configured metrics are loaded at node scope.
+	DefaultMetrics []string      `json:"defaultMetrics"`
+	DefaultScopes  []MetricScope `json:"defaultScopes"`
+
+	// If not zero, requests to the metric data repositories of this cluster are
+	// limited to this many per second, with bursts of up to MetricDataBurst
+	// requests (default: the rate rounded up).
+	MetricDataRateLimit float64 `json:"metricDataRateLimit"`
+	MetricDataBurst     int     `json:"metricDataBurst"`
+
+	// SHA256 hashes (hex encoded) of the API keys allowed to start and stop jobs on this cluster.
+	ApiKeys []string `json:"apiKeys"`
+
+	// Partitions with their nodes, used to infer the partition of jobs started
+	// without one. Jobs whose nodes match no partition get DefaultPartition.
+	Partitions       []*PartitionConfig `json:"partitions"`
+	DefaultPartition string             `json:"defaultPartition"`
+
+	// Forwards requests to cancel running jobs of this cluster to the scheduler.
+	// Without it, jobs cannot be cancelled from cc-backend.
+	CancelHook *CancelHookConfig `json:"cancelHook"`
+
+	// Restricts which users may see the jobs and node data of this cluster.
+	// Without it, the cluster is visible to everybody. Admins always have access.
+	Access *ClusterAccessConfig `json:"access"`
+}
+
+type PartitionConfig struct {
+	Name  string `json:"name"`
+	Nodes string `json:"nodes"` // Hostlist expression like in the cluster.json, e.g. "f[0101-0188]"
+}
+
+type CancelHookConfig struct {
+	// Command run to cancel a job, e.g. ["scancel", "{jobId}"]. The placeholders
+	// {jobId}, {cluster} and {user} are replaced in every argument.
+	Command []string `json:"command"`
+
+	// URL receiving a POST request with the job as JSON if no command is set.
+	URL string `json:"url"`
+}
+
+type ClusterAccessConfig struct {
+	// If one is not empty, only users with one of the roles or usernames
+	// listed may access the cluster.
+	AllowRoles []string `json:"allowRoles"`
+	AllowUsers []string `json:"allowUsers"`
+
+	// Users with one of the roles or usernames listed may not access the
+	// cluster, even if they are allowed above.
+	DenyRoles []string `json:"denyRoles"`
+	DenyUsers []string `json:"denyUsers"`
 }
 
 type Retention struct {
@@ -69,6 +201,14 @@ type Retention struct {
 	IncludeDB bool   `json:"includeDB"`
 	Policy    string `json:"policy"`
 	Location  string `json:"location"`
+
+	// Tiers of the archived metric data, ages are in days since the start of
+	// the job and zero disables a tier: Older jobs are rewritten at a timestep
+	// of at least DownsampleTimestep seconds, even older jobs only keep their
+	// statistics.
+	DownsampleAge      int `json:"downsampleAge"`
+	DownsampleTimestep int `json:"downsampleTimestep"`
+	StatsOnlyAge       int `json:"statsOnlyAge"`
 }
 
 // Format of the configuration (file). See below for the defaults.
@@ -112,6 +252,12 @@ type ProgramConfig struct {
 	LdapConfig *LdapConfig    `json:"ldap"`
 	JwtConfig  *JWTAuthConfig `json:"jwts"`
 
+	// For REST API access with bearer JWTs of an external identity provider.
+	BearerAuth *BearerAuthConfig `json:"bearer-auth"`
+
+	// If set, OpenTelemetry traces are exported.
+	Tracing *TracingConfig `json:"tracing"`
+
 	// If 0 or empty, the session does not expire!
 	SessionMaxAge string `json:"session-max-age"`
 
@@ -136,6 +282,85 @@ type ProgramConfig struct {
 	// Defines time X in seconds in which jobs are considered to be "short" and will be filtered in specific views.
 	ShortRunningJobsDuration int `json:"short-running-jobs-duration"`
 
+	// If true, new jobs are tagged with the processor type of their subcluster.
+	AutoTagHardware bool `json:"auto-tag-hardware"`
+
+	// Behavior of stop_job if the job to stop is unknown: "strict" (default) responds
+	// with 404, "lenient" creates a minimal job record from the stop request.
+	StopJobMode string `json:"stop-job-mode"`
+
+	// Behavior of start_job if the job was already started: "reject" (default) responds
+	// with 422, "merge" adds the metadata and tags of the request to the existing job.
+	DuplicateStartJobMode string `json:"duplicate-start-job-mode"`
+
+	// Job states reported by the batch scheduler in addition to the builtin ones. Other
+	// unknown states are stored as "unknown".
+	JobStates []string `json:"job-states"`
+
+	// If not zero, start_job rejects jobs of users that already have this many running jobs.
+	MaxRunningJobsPerUser int `json:"max-running-jobs-per-user"`
+
+	// Authenticated users (e.g. machine accounts) whose start_job requests are not limited by
+	// max-running-jobs-per-user, regardless of the owner of the job.
+	RunningJobsLimitExempt []string `json:"running-jobs-limit-exempt"`
+
+	// Maximum size in bytes of request bodies to the job ingest endpoints (start_job, stop_job,
+	// resume_job and import). Larger requests are rejected with 413.
+	MaxRequestBodySize int64 `json:"max-request-body-size"`
+
+	// Timeout of requests to the job ingest endpoints, parsed using time.ParseDuration.
+	IngestTimeout string `json:"ingest-timeout"`
+
+	// Number of jobs archived concurrently after they were stopped.
+	ArchiveWorkers int `json:"archive-workers"`
+
+	// Number of stopped jobs that can wait for a free archive worker before stop_job blocks.
+	ArchiveQueueSize int `json:"archive-queue-size"`
+
+	// Timeout of loading the metric data of nodes (node and system views), parsed using
+	// time.ParseDuration. No timeout if empty.
+	NodeDataTimeout string `json:"node-data-timeout"`
+
+	// Metrics computed from other metrics after loading the metric data of a job.
+	DerivedMetrics []*DerivedMetricConfig `json:"derived-metrics"`
+
+	// Percentiles (1 to 99) added to the min/mean/max statistics series of jobs with many
+	// nodes, e.g. [25, 75] for an interquartile band. None by default.
+	StatisticsSeriesPercentiles []int `json:"statistics-series-percentiles"`
+
+	// Rules tagging jobs based on their metadata and statistics when they are archived.
+	TagRules []*TagRuleConfig `json:"tag-rules"`
+
+	// Do not apply the tag-rules.
+	DisableTagRules bool `json:"disable-tag-rules"`
+
+	// Similar jobs have at least numNodes / factor and at most numNodes * factor nodes.
+	SimilarJobsNumNodesFactor float64 `json:"similar-jobs-num-nodes-factor"`
+
+	// Similar jobs have a flops_any average that differs at most by this fraction.
+	SimilarJobsFlopsBand float64 `json:"similar-jobs-flops-band"`
+
+	// URLs notified when jobs are started or stopped.
+	Webhooks []*WebhookConfig `json:"webhooks"`
+
+	// Number of webhook requests sent concurrently.
+	WebhookWorkers int `json:"webhook-workers"`
+
+	// Regular expressions matching secrets (e.g. tokens in the jobScript) that are replaced by "***"
+	// in the metadata of started jobs. Patterns for common secrets are used if not set, an empty
+	// list disables the redaction. For patterns with groups, only the groups are replaced.
+	MetadataRedactPatterns []string `json:"metadata-redact-patterns"`
+
+	// Serve counters and timings of cc-backend for Prometheus at /metrics, without authentication.
+	// Also measures the duration of the database queries.
+	EnableMetrics bool `json:"enable-metrics"`
+
+	// Log level ("debug", "info", "warn", "err", "crit"), the -loglevel flag takes precedence.
+	LogLevel string `json:"log-level"`
+
+	// Output format of the log, "text" (default) or "json" with one object per message.
+	LogFormat string `json:"log-format"`
+
 	// Array of Clusters
 	Clusters []*ClusterConfig `json:"clusters"`
 }
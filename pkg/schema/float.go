@@ -28,11 +28,7 @@ func (f Float) IsNaN() bool {
 
 // NaN will be serialized to `null`.
 func (f Float) MarshalJSON() ([]byte, error) {
-	if f.IsNaN() {
-		return nullAsBytes, nil
-	}
-
-	return strconv.AppendFloat(make([]byte, 0, 10), float64(f), 'f', 2, 64), nil
+	return appendFloat(make([]byte, 0, 10), float64(f)), nil
 }
 
 // `null` will be unserialized to NaN.
@@ -66,11 +62,17 @@ func (f *Float) UnmarshalGQL(v interface{}) error {
 // MarshalGQL implements the graphql.Marshaler interface.
 // NaN will be serialized to `null`.
 func (f Float) MarshalGQL(w io.Writer) {
-	if f.IsNaN() {
-		w.Write(nullAsBytes)
-	} else {
-		w.Write(strconv.AppendFloat(make([]byte, 0, 10), float64(f), 'f', 2, 64))
+	w.Write(appendFloat(make([]byte, 0, 10), float64(f)))
+}
+
+// appendFloat appends the shortest representation of f that parses back to
+// the same value. NaN and infinite values, which are not valid JSON, are
+// appended as `null`.
+func appendFloat(buf []byte, f float64) []byte {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return append(buf, nullAsBytes...)
 	}
+	return strconv.AppendFloat(buf, f, 'f', -1, 64)
 }
 
 // Only used via REST-API, not via GraphQL.
@@ -88,11 +90,11 @@ func (s *Series) MarshalJSON() ([]byte, error) {
 		buf = append(buf, '"')
 	}
 	buf = append(buf, `,"statistics":{"min":`...)
-	buf = strconv.AppendFloat(buf, s.Statistics.Min, 'f', 2, 64)
+	buf = appendFloat(buf, s.Statistics.Min)
 	buf = append(buf, `,"avg":`...)
-	buf = strconv.AppendFloat(buf, s.Statistics.Avg, 'f', 2, 64)
+	buf = appendFloat(buf, s.Statistics.Avg)
 	buf = append(buf, `,"max":`...)
-	buf = strconv.AppendFloat(buf, s.Statistics.Max, 'f', 2, 64)
+	buf = appendFloat(buf, s.Statistics.Max)
 	buf = append(buf, '}')
 	buf = append(buf, `,"data":[`...)
 	for i := 0; i < len(s.Data); i++ {
@@ -100,11 +102,7 @@ func (s *Series) MarshalJSON() ([]byte, error) {
 			buf = append(buf, ',')
 		}
 
-		if s.Data[i].IsNaN() {
-			buf = append(buf, `null`...)
-		} else {
-			buf = strconv.AppendFloat(buf, float64(s.Data[i]), 'f', 2, 32)
-		}
+		buf = appendFloat(buf, float64(s.Data[i]))
 	}
 	buf = append(buf, ']', '}')
 	return buf, nil
@@ -0,0 +1,80 @@
+// Copyright (C) 2023 NHR@FAU, University Erlangen-Nuremberg.
+// All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestFloatJSONRoundTrip(t *testing.T) {
+	id := "0"
+	jm := JobMetric{
+		Unit:     Unit{Base: "F/s", Prefix: "G"},
+		Timestep: 60,
+		Series: []Series{
+			{
+				Hostname:   "n1",
+				Id:         &id,
+				Statistics: MetricStatistics{Min: 0.125, Avg: 1.0 / 3.0, Max: 12345.6789},
+				Data:       []Float{NaN, 0.1, 1.0 / 3.0, 12345.6789, -2, NaN},
+			},
+			{
+				Hostname:   "n2",
+				Statistics: MetricStatistics{Min: math.NaN(), Avg: math.Inf(1), Max: 1},
+				Data:       []Float{NaN, NaN},
+			},
+		},
+	}
+
+	b, err := json.Marshal(&jm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !json.Valid(b) {
+		t.Fatalf("invalid JSON: %s", b)
+	}
+	if !bytes.Contains(b, []byte(`"data":[null,0.1,0.3333333333333333,12345.6789,-2,null]`)) {
+		t.Errorf("unexpected encoding of the series: %s", b)
+	}
+
+	var decoded JobMetric
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded.Series) != len(jm.Series) {
+		t.Fatalf("expected %d series, got %d", len(jm.Series), len(decoded.Series))
+	}
+	for i, series := range decoded.Series {
+		expected := jm.Series[i]
+		if len(series.Data) != len(expected.Data) {
+			t.Fatalf("series %d: expected %v, got %v", i, expected.Data, series.Data)
+		}
+		for j, x := range series.Data {
+			if y := expected.Data[j]; x != y && !(x.IsNaN() && y.IsNaN()) {
+				t.Errorf("series %d: expected %v at %d, got %v", i, y, j, x)
+			}
+		}
+	}
+	if decoded.Series[0].Statistics != jm.Series[0].Statistics || *decoded.Series[0].Id != id {
+		t.Errorf("expected %#v, got %#v", jm.Series[0], decoded.Series[0])
+	}
+
+	for _, f := range []Float{NaN, 0, 1.5, -1e-9, 1e300} {
+		b, err := json.Marshal(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var g Float
+		if err := json.Unmarshal(b, &g); err != nil {
+			t.Fatal(err)
+		}
+		if g != f && !(g.IsNaN() && f.IsNaN()) {
+			t.Errorf("%v encoded as %s decoded to %v", f, b, g)
+		}
+	}
+}
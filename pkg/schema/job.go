@@ -29,7 +29,7 @@ type BaseJob struct {
 	NumHWThreads     int32             `json:"numHwthreads,omitempty" db:"num_hwthreads" example:"20" minimum:"1"`                                           // Number of HWThreads used (Min > 0)
 	NumAcc           int32             `json:"numAcc,omitempty" db:"num_acc" example:"2" minimum:"1"`                                                        // Number of accelerators used (Min > 0)
 	Exclusive        int32             `json:"exclusive" db:"exclusive" example:"1" minimum:"0" maximum:"2"`                                                 // Specifies how nodes are shared: 0 - Shared among multiple jobs of multiple users, 1 - Job exclusive (Default), 2 - Shared among multiple jobs of same user
-	MonitoringStatus int32             `json:"monitoringStatus,omitempty" db:"monitoring_status" example:"1" minimum:"0" maximum:"3"`                        // State of monitoring system during job run: 0 - Disabled, 1 - Running or Archiving (Default), 2 - Archiving Failed, 3 - Archiving Successfull
+	MonitoringStatus int32             `json:"monitoringStatus,omitempty" db:"monitoring_status" example:"1" minimum:"0" maximum:"5"`                        // State of monitoring system during job run: 0 - Disabled, 1 - Running or Archiving (Default), 2 - Archiving Failed, 3 - Archiving Successfull, 4 - Archived and downsampled, 5 - Archived with statistics only
 	SMT              int32             `json:"smt,omitempty" db:"smt" example:"4"`                                                                           // SMT threads used by job
 	State            JobState          `json:"jobState" db:"job_state" example:"completed" enums:"completed,failed,cancelled,stopped,timeout,out_of_memory"` // Final state of job
 	Duration         int32             `json:"duration" db:"duration" example:"43200" minimum:"1"`                                                           // Duration of job in seconds (Min > 0)
@@ -62,6 +62,8 @@ type Job struct {
 	NetDataVolTotal  float64   `json:"-" db:"net_data_vol_total"`              // NetDataVolTotal as Float64
 	FileBwAvg        float64   `json:"-" db:"file_bw_avg"`                     // FileBwAvg as Float64
 	FileDataVolTotal float64   `json:"-" db:"file_data_vol_total"`             // FileDataVolTotal as Float64
+	// Fraction of the expected metric values of the job that are not missing (NaN), set on archiving
+	DataCompleteness *float64 `json:"dataCompleteness,omitempty" db:"data_completeness" example:"0.98"`
 }
 
 //	JobMeta struct type
@@ -100,8 +102,19 @@ const (
 	MonitoringStatusRunningOrArchiving  int32 = 1
 	MonitoringStatusArchivingFailed     int32 = 2
 	MonitoringStatusArchivingSuccessful int32 = 3
+	// Archived, the retention reduced the metric data to a coarser timestep
+	MonitoringStatusArchiveDownsampled int32 = 4
+	// Archived, the retention reduced the metric data to the statistics
+	MonitoringStatusArchiveStatsOnly int32 = 5
 )
 
+// IsArchived reports whether the metric data of a job with the monitoring
+// status is in the job-archive, possibly reduced by the retention.
+func IsArchived(monitoringStatus int32) bool {
+	return monitoringStatus >= MonitoringStatusArchivingSuccessful &&
+		monitoringStatus <= MonitoringStatusArchiveStatsOnly
+}
+
 var JobDefaults BaseJob = BaseJob{
 	Exclusive:        1,
 	MonitoringStatus: MonitoringStatusRunningOrArchiving,
@@ -124,9 +137,20 @@ type JobStatistics struct {
 // Tag model
 // @Description Defines a tag using name and type.
 type Tag struct {
-	ID   int64  `json:"id" db:"id"`                           // The unique DB identifier of a tag
-	Type string `json:"type" db:"tag_type" example:"Debug"`   // Tag Type
-	Name string `json:"name" db:"tag_name" example:"Testjob"` // Tag Name
+	ID    int64  `json:"id" db:"id"`                                      // The unique DB identifier of a tag
+	Type  string `json:"type" db:"tag_type" example:"Debug"`              // Tag Type
+	Name  string `json:"name" db:"tag_name" example:"Testjob"`            // Tag Name
+	Scope string `json:"scope,omitempty" db:"tag_scope" example:"global"` // Tag Scope: 'global' or username of owner
+}
+
+// JobComment model
+// @Description A free-text note of a user about a job.
+type JobComment struct {
+	ID        int64     `json:"id"`                             // The unique DB identifier of the comment
+	JobID     int64     `json:"jobId"`                          // The DB identifier of the commented job
+	Author    string    `json:"author" example:"abcd100h"`      // Username of the author
+	CreatedAt time.Time `json:"createdAt"`                      // Time of creation
+	Text      string    `json:"text" example:"bad node, rerun"` // Text of the comment
 }
 
 // Resource model
@@ -149,8 +173,26 @@ const (
 	JobStateTimeout     JobState = "timeout"
 	JobStatePreempted   JobState = "preempted"
 	JobStateOutOfMemory JobState = "out_of_memory"
+	JobStateSuspended   JobState = "suspended"
+	JobStateNodeFail    JobState = "node_fail"
+	JobStateBootFail    JobState = "boot_fail"
+	JobStateDeadline    JobState = "deadline"
+
+	// Catch-all for states of the batch scheduler that are not valid.
+	JobStateUnknown JobState = "unknown"
 )
 
+// Site specific job states, valid in addition to the ones above.
+var extraJobStates = map[JobState]bool{}
+
+// AddJobStates makes the states valid in addition to the builtin ones. It is
+// not safe to call concurrently with Valid.
+func AddJobStates(states []string) {
+	for _, state := range states {
+		extraJobStates[JobState(state)] = true
+	}
+}
+
 func (e *JobState) UnmarshalGQL(v interface{}) error {
 	str, ok := v.(string)
 	if !ok {
@@ -177,5 +219,11 @@ func (e JobState) Valid() bool {
 		e == JobStateStopped ||
 		e == JobStateTimeout ||
 		e == JobStatePreempted ||
-		e == JobStateOutOfMemory
+		e == JobStateOutOfMemory ||
+		e == JobStateSuspended ||
+		e == JobStateNodeFail ||
+		e == JobStateBootFail ||
+		e == JobStateDeadline ||
+		e == JobStateUnknown ||
+		extraJobStates[e]
 }
@@ -26,6 +26,11 @@ type Series struct {
 	Id         *string          `json:"id,omitempty"`
 	Statistics MetricStatistics `json:"statistics"`
 	Data       []Float          `json:"data"`
+
+	// Minimum and maximum of the data points averaged into each value of
+	// Data by downsampling, nil if the series is not downsampled.
+	Min []Float `json:"min,omitempty"`
+	Max []Float `json:"max,omitempty"`
 }
 
 type MetricStatistics struct {
@@ -122,19 +127,57 @@ func (jd *JobData) Size() int {
 				n += len(metric.StatisticsSeries.Max)
 				n += len(metric.StatisticsSeries.Mean)
 				n += len(metric.StatisticsSeries.Min)
+				for _, p := range metric.StatisticsSeries.Percentiles {
+					n += len(p)
+				}
 			}
 
 			for _, series := range metric.Series {
-				n += len(series.Data)
+				n += len(series.Data) + len(series.Min) + len(series.Max)
 			}
 		}
 	}
 	return n * int(unsafe.Sizeof(Float(0)))
 }
 
+// Completeness returns the fraction of the expected node scope values of the
+// job's metrics that are not NaN. Per metric, a value is expected for each of
+// the numNodes nodes at every timestep of its longest series, so nodes
+// without any series count as missing. Returns 0 without node scope data.
+func (jd *JobData) Completeness(numNodes int) float64 {
+	expected, valid := 0, 0
+	for _, scopes := range *jd {
+		jm, ok := scopes[MetricScopeNode]
+		if !ok {
+			continue
+		}
+
+		timesteps := 0
+		for _, series := range jm.Series {
+			if len(series.Data) > timesteps {
+				timesteps = len(series.Data)
+			}
+			for _, x := range series.Data {
+				if !x.IsNaN() {
+					valid++
+				}
+			}
+		}
+		expected += numNodes * timesteps
+	}
+
+	if expected == 0 {
+		return 0
+	}
+	return math.Min(float64(valid)/float64(expected), 1)
+}
+
 const smooth bool = false
 
-func (jm *JobMetric) AddStatisticsSeries() {
+// AddStatisticsSeries adds the min, mean and max over all series at every
+// timestep if jm has at least 4 series. The percentiles are added as well if
+// any are given, see AddPercentiles.
+func (jm *JobMetric) AddStatisticsSeries(percentiles ...int) {
 	if jm.StatisticsSeries != nil || len(jm.Series) < 4 {
 		return
 	}
@@ -196,9 +239,214 @@ func (jm *JobMetric) AddStatisticsSeries() {
 	}
 
 	jm.StatisticsSeries = &StatsSeries{Mean: mean, Min: min, Max: max}
+	if len(percentiles) != 0 {
+		jm.AddPercentiles(percentiles)
+	}
+}
+
+// ConvertUnit rescales the series, statistics and statistics series of jm
+// to unit. An error is returned if the unit of jm can not be converted to it.
+func (jm *JobMetric) ConvertUnit(unit Unit) error {
+	f, err := UnitFactor(jm.Unit, unit)
+	if err != nil {
+		return err
+	}
+
+	jm.Unit = unit
+	if f == 1 {
+		return nil
+	}
+
+	scale := func(data []Float) {
+		for i := range data {
+			data[i] *= Float(f)
+		}
+	}
+	for i := range jm.Series {
+		scale(jm.Series[i].Data)
+		scale(jm.Series[i].Min)
+		scale(jm.Series[i].Max)
+		stats := &jm.Series[i].Statistics
+		stats.Min, stats.Avg, stats.Max = stats.Min*f, stats.Avg*f, stats.Max*f
+	}
+	if ss := jm.StatisticsSeries; ss != nil {
+		scale(ss.Mean)
+		scale(ss.Min)
+		scale(ss.Max)
+		for _, p := range ss.Percentiles {
+			scale(p)
+		}
+	}
+
+	return nil
+}
+
+// Downsample returns a copy of jd in which every metric has at most
+// maxPoints data points per series, see JobMetric.Downsample. jd itself is
+// not modified. Nothing is done if maxPoints is not positive.
+func (jd JobData) Downsample(maxPoints int) JobData {
+	if maxPoints <= 0 {
+		return jd
+	}
+
+	return jd.mapMetrics(func(jm *JobMetric) *JobMetric { return jm.Downsample(maxPoints) })
+}
+
+// DownsampleTo returns a copy of jd in which every metric has a timestep of
+// at least timestep seconds, see JobMetric.DownsampleTo. jd itself is not
+// modified.
+func (jd JobData) DownsampleTo(timestep int) JobData {
+	return jd.mapMetrics(func(jm *JobMetric) *JobMetric { return jm.DownsampleTo(timestep) })
+}
+
+// StatisticsOnly returns a copy of jd in which every series is reduced to a
+// single data point, its average over the whole job, and the statistics
+// series are dropped. The statistics of the series are kept. jd itself is
+// not modified.
+func (jd JobData) StatisticsOnly() JobData {
+	return jd.mapMetrics(func(jm *JobMetric) *JobMetric {
+		res := jm.Downsample(1)
+		if res == jm {
+			c := *jm
+			res = &c
+		}
+		res.StatisticsSeries = nil
+		return res
+	})
+}
+
+func (jd JobData) mapMetrics(f func(*JobMetric) *JobMetric) JobData {
+	res := make(JobData, len(jd))
+	for metric, scopes := range jd {
+		res[metric] = make(map[MetricScope]*JobMetric, len(scopes))
+		for scope, jm := range scopes {
+			res[metric][scope] = f(jm)
+		}
+	}
+	return res
+}
+
+// Downsample returns a copy of jm with at most maxPoints data points per
+// series. Consecutive data points are combined into buckets of a multiple
+// of the timestep: The data of the series and the mean of the statistics
+// series get the average of every bucket, Min and Max of the series and the
+// min and max of the statistics series the minimum and maximum. NaN values
+// are skipped. The statistics of the series
+// are kept as they are. jm itself is returned if it is short enough.
+func (jm *JobMetric) Downsample(maxPoints int) *JobMetric {
+	n := 0
+	for _, series := range jm.Series {
+		if len(series.Data) > n {
+			n = len(series.Data)
+		}
+	}
+	if maxPoints <= 0 || n <= maxPoints {
+		return jm
+	}
+
+	return jm.downsample((n + maxPoints - 1) / maxPoints)
+}
+
+// DownsampleTo returns a copy of jm whose timestep is the smallest multiple
+// of its timestep that is at least timestep. The data points are combined
+// like by Downsample. jm itself is returned if its timestep is not smaller.
+func (jm *JobMetric) DownsampleTo(timestep int) *JobMetric {
+	if jm.Timestep <= 0 || jm.Timestep >= timestep {
+		return jm
+	}
+
+	return jm.downsample((timestep + jm.Timestep - 1) / jm.Timestep)
+}
+
+// downsample combines every bucket consecutive data points of jm.
+func (jm *JobMetric) downsample(bucket int) *JobMetric {
+	res := &JobMetric{
+		Unit:     jm.Unit,
+		Timestep: jm.Timestep * bucket,
+		Series:   make([]Series, 0, len(jm.Series)),
+	}
+	for _, series := range jm.Series {
+		// A series downsampled before keeps the extremes of its buckets.
+		min, max := series.Min, series.Max
+		if min == nil || max == nil {
+			min, max = series.Data, series.Data
+		}
+		series.Min = downsample(min, bucket, bucketMin)
+		series.Max = downsample(max, bucket, bucketMax)
+		series.Data = downsample(series.Data, bucket, bucketAvg)
+		res.Series = append(res.Series, series)
+	}
+
+	if ss := jm.StatisticsSeries; ss != nil {
+		res.StatisticsSeries = &StatsSeries{
+			Mean: downsample(ss.Mean, bucket, bucketAvg),
+			Min:  downsample(ss.Min, bucket, bucketMin),
+			Max:  downsample(ss.Max, bucket, bucketMax),
+		}
+		if ss.Percentiles != nil {
+			res.StatisticsSeries.Percentiles = make(map[int][]Float, len(ss.Percentiles))
+			for p, data := range ss.Percentiles {
+				res.StatisticsSeries.Percentiles[p] = downsample(data, bucket, bucketAvg)
+			}
+		}
+	}
+
+	return res
+}
+
+// downsample combines every bucket consecutive values of data using combine.
+func downsample(data []Float, bucket int, combine func([]Float) Float) []Float {
+	res := make([]Float, 0, (len(data)+bucket-1)/bucket)
+	for i := 0; i < len(data); i += bucket {
+		end := i + bucket
+		if end > len(data) {
+			end = len(data)
+		}
+		res = append(res, combine(data[i:end]))
+	}
+	return res
+}
+
+func bucketAvg(data []Float) Float {
+	sum, n := 0.0, 0
+	for _, x := range data {
+		if !x.IsNaN() {
+			sum += float64(x)
+			n++
+		}
+	}
+	if n == 0 {
+		return NaN
+	}
+	return Float(sum / float64(n))
+}
+
+func bucketMin(data []Float) Float {
+	min := NaN
+	for _, x := range data {
+		if !x.IsNaN() && (min.IsNaN() || x < min) {
+			min = x
+		}
+	}
+	return min
+}
+
+func bucketMax(data []Float) Float {
+	max := NaN
+	for _, x := range data {
+		if !x.IsNaN() && (max.IsNaN() || x > max) {
+			max = x
+		}
+	}
+	return max
 }
 
-func (jd *JobData) AddNodeScope(metric string) bool {
+// AddNodeScope adds node scope data for metric by aggregating the series of
+// its finest scope per host, using aggregation (AggregationSum, AggregationAvg
+// or AggregationMax, see MetricConfig). The sum is used if aggregation is
+// empty. Returns false if there is no data for metric or it already has node
+// scope data.
+func (jd *JobData) AddNodeScope(metric string, aggregation string) bool {
 	scopes, ok := (*jd)[metric]
 	if !ok {
 		return false
@@ -215,25 +463,24 @@ func (jd *JobData) AddNodeScope(metric string) bool {
 
 	jm := scopes[maxScope]
 	hosts := make(map[string][]Series, 32)
+	hostnames := make([]string, 0, 32)
 	for _, series := range jm.Series {
+		if _, ok := hosts[series.Hostname]; !ok {
+			hostnames = append(hostnames, series.Hostname)
+		}
 		hosts[series.Hostname] = append(hosts[series.Hostname], series)
 	}
+	sort.Strings(hostnames)
 
 	nodeJm := &JobMetric{
 		Unit:     jm.Unit,
 		Timestep: jm.Timestep,
 		Series:   make([]Series, 0, len(hosts)),
 	}
-	for hostname, series := range hosts {
-		min, sum, max := math.MaxFloat32, 0.0, -math.MaxFloat32
+	for _, hostname := range hostnames {
+		series := hosts[hostname]
+		n, m := 0, len(series[0].Data)
 		for _, series := range series {
-			sum += series.Statistics.Avg
-			min = math.Min(min, series.Statistics.Min)
-			max = math.Max(max, series.Statistics.Max)
-		}
-
-		n, m := 0, len(jm.Series[0].Data)
-		for _, series := range jm.Series {
 			if len(series.Data) > n {
 				n = len(series.Data)
 			}
@@ -242,22 +489,34 @@ func (jd *JobData) AddNodeScope(metric string) bool {
 			}
 		}
 
-		i, data := 0, make([]Float, len(series[0].Data))
+		i, data := 0, make([]Float, n)
 		for ; i < m; i++ {
-			x := Float(0.0)
-			for _, series := range jm.Series {
-				x += series.Data[i]
-			}
-			data[i] = x
+			data[i] = aggregate(aggregation, series, i)
 		}
 
 		for ; i < n; i++ {
 			data[i] = NaN
 		}
 
+		min, sum, max, notnan := math.MaxFloat64, 0.0, -math.MaxFloat64, 0
+		for _, x := range data {
+			if x.IsNaN() {
+				continue
+			}
+			sum += float64(x)
+			min = math.Min(min, float64(x))
+			max = math.Max(max, float64(x))
+			notnan++
+		}
+		// The statistics are no Floats, NaN could not be encoded as JSON.
+		var stats MetricStatistics
+		if notnan > 0 {
+			stats = MetricStatistics{Min: min, Avg: sum / float64(notnan), Max: max}
+		}
+
 		nodeJm.Series = append(nodeJm.Series, Series{
 			Hostname:   hostname,
-			Statistics: MetricStatistics{Min: min, Avg: sum / float64(len(series)), Max: max},
+			Statistics: stats,
 			Data:       data,
 		})
 	}
@@ -266,13 +525,46 @@ func (jd *JobData) AddNodeScope(metric string) bool {
 	return true
 }
 
+// aggregate returns the aggregation of the values of series at timestep i,
+// NaN values are skipped.
+func aggregate(aggregation string, series []Series, i int) Float {
+	x, notnan := 0.0, 0
+	for _, series := range series {
+		y := series.Data[i]
+		if y.IsNaN() {
+			continue
+		}
+
+		switch {
+		case aggregation == AggregationMax && notnan > 0:
+			x = math.Max(x, float64(y))
+		case aggregation == AggregationMax:
+			x = float64(y)
+		default:
+			x += float64(y)
+		}
+		notnan++
+	}
+
+	if notnan == 0 {
+		return NaN
+	}
+	if aggregation == AggregationAvg {
+		return Float(x / float64(notnan))
+	}
+	return Float(x)
+}
+
+// AddPercentiles adds the percentiles ps (1 to 99) over all series at every
+// timestep to the statistics series using the nearest-rank method. As for
+// min, mean and max, timesteps with less than 3 values are NaN. Returns false
+// if jm has too few series for a statistics series.
 func (jm *JobMetric) AddPercentiles(ps []int) bool {
 	if jm.StatisticsSeries == nil {
 		jm.AddStatisticsSeries()
-	}
-
-	if len(jm.Series) < 3 {
-		return false
+		if jm.StatisticsSeries == nil {
+			return false
+		}
 	}
 
 	if jm.StatisticsSeries.Percentiles == nil {
@@ -290,7 +582,7 @@ func (jm *JobMetric) AddPercentiles(ps []int) bool {
 	for i := 0; i < n; i++ {
 		vals := make([]float64, 0, len(jm.Series))
 		for _, series := range jm.Series {
-			if i < len(series.Data) {
+			if i < len(series.Data) && !series.Data[i].IsNaN() {
 				vals = append(vals, float64(series.Data[i]))
 			}
 		}
@@ -311,7 +603,13 @@ func (jm *JobMetric) AddPercentiles(ps []int) bool {
 		percentiles := make([]Float, n)
 		for i := 0; i < n; i++ {
 			sorted := data[i]
-			percentiles[i] = Float(sorted[(len(sorted)*p)/100])
+			if len(sorted) < 3 {
+				percentiles[i] = NaN
+				continue
+			}
+
+			rank := int(math.Ceil(float64(len(sorted)*p) / 100))
+			percentiles[i] = Float(sorted[rank-1])
 		}
 
 		jm.StatisticsSeries.Percentiles[p] = percentiles
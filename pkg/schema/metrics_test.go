@@ -0,0 +1,224 @@
+// Copyright (C) 2023 NHR@FAU, University Erlangen-Nuremberg.
+// All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+package schema
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCompleteness(t *testing.T) {
+	jd := JobData{
+		"flops_any": {
+			MetricScopeNode: &JobMetric{Series: []Series{
+				{Hostname: "n1", Data: []Float{1, 2, 3, 4}},
+				{Hostname: "n2", Data: []Float{1, NaN, NaN, 4}},
+			}},
+		},
+		"mem_bw": {
+			// n2 dropped out after the first timestep.
+			MetricScopeNode: &JobMetric{Series: []Series{
+				{Hostname: "n1", Data: []Float{1, 2, 3, 4}},
+				{Hostname: "n2", Data: []Float{1}},
+			}},
+		},
+		"ipc": {
+			// Only node scope data is taken into account.
+			MetricScopeCore: &JobMetric{Series: []Series{
+				{Hostname: "n1", Data: []Float{NaN, NaN, NaN, NaN}},
+			}},
+		},
+	}
+
+	if c := jd.Completeness(2); c != 11.0/16.0 {
+		t.Errorf("wrong completeness\ngot: %f \nwant: %f", c, 11.0/16.0)
+	}
+	// A node without any series is missing entirely.
+	if c := jd.Completeness(4); c != 11.0/32.0 {
+		t.Errorf("wrong completeness with missing nodes\ngot: %f \nwant: %f", c, 11.0/32.0)
+	}
+	if c := (&JobData{}).Completeness(2); c != 0 {
+		t.Errorf("wrong completeness without data\ngot: %f \nwant: 0", c)
+	}
+}
+
+func TestAddNodeScope(t *testing.T) {
+	socketData := func() map[MetricScope]*JobMetric {
+		return map[MetricScope]*JobMetric{
+			MetricScopeSocket: {Timestep: 60, Series: []Series{
+				{Hostname: "n2", Data: []Float{4, 6, 8}},
+				{Hostname: "n1", Data: []Float{1, 2, 3}},
+				{Hostname: "n1", Data: []Float{3, NaN, 5}},
+				{Hostname: "n2", Data: []Float{2, 4}},
+			}},
+		}
+	}
+	jd := JobData{"mem_bw": socketData(), "cpu_load": socketData(), "temp": socketData(), "default": socketData()}
+
+	for metric, aggregation := range map[string]string{
+		"mem_bw":   AggregationSum,
+		"cpu_load": AggregationAvg,
+		"temp":     AggregationMax,
+		"default":  "",
+	} {
+		if !jd.AddNodeScope(metric, aggregation) {
+			t.Fatalf("%s: no node scope added", metric)
+		}
+	}
+	if jd.AddNodeScope("mem_bw", AggregationSum) || jd.AddNodeScope("missing", AggregationSum) {
+		t.Error("expected no node scope for existing node scope or missing metric")
+	}
+
+	for metric, expected := range map[string][]Series{
+		"mem_bw": {
+			{Hostname: "n1", Statistics: MetricStatistics{Min: 2, Avg: 14.0 / 3.0, Max: 8}, Data: []Float{4, 2, 8}},
+			{Hostname: "n2", Statistics: MetricStatistics{Min: 6, Avg: 8, Max: 10}, Data: []Float{6, 10, NaN}},
+		},
+		"cpu_load": {
+			{Hostname: "n1", Statistics: MetricStatistics{Min: 2, Avg: 8.0 / 3.0, Max: 4}, Data: []Float{2, 2, 4}},
+			{Hostname: "n2", Statistics: MetricStatistics{Min: 3, Avg: 4, Max: 5}, Data: []Float{3, 5, NaN}},
+		},
+		"temp": {
+			{Hostname: "n1", Statistics: MetricStatistics{Min: 2, Avg: 10.0 / 3.0, Max: 5}, Data: []Float{3, 2, 5}},
+			{Hostname: "n2", Statistics: MetricStatistics{Min: 4, Avg: 5, Max: 6}, Data: []Float{4, 6, NaN}},
+		},
+		"default": {
+			{Hostname: "n1", Statistics: MetricStatistics{Min: 2, Avg: 14.0 / 3.0, Max: 8}, Data: []Float{4, 2, 8}},
+			{Hostname: "n2", Statistics: MetricStatistics{Min: 6, Avg: 8, Max: 10}, Data: []Float{6, 10, NaN}},
+		},
+	} {
+		node := jd[metric][MetricScopeNode]
+		if node.Timestep != 60 || len(node.Series) != len(expected) {
+			t.Fatalf("%s: unexpected node scope data: %#v", metric, node)
+		}
+		for i, series := range node.Series {
+			if series.Hostname != expected[i].Hostname || series.Statistics != expected[i].Statistics ||
+				len(series.Data) != len(expected[i].Data) {
+				t.Errorf("%s: expected %#v, got %#v", metric, expected[i], series)
+				continue
+			}
+			for j, x := range series.Data {
+				if y := expected[i].Data[j]; x != y && !(x.IsNaN() && y.IsNaN()) {
+					t.Errorf("%s on %s: expected %v at %d, got %v", metric, series.Hostname, y, j, x)
+				}
+			}
+		}
+	}
+}
+
+// The node scope series of every host only aggregates the series of that host,
+// not the ones of all hosts of the job.
+func TestAddNodeScopePerHost(t *testing.T) {
+	jd := JobData{"flops_any": {
+		MetricScopeSocket: {Timestep: 60, Series: []Series{
+			{Hostname: "n1", Data: []Float{1, 1}},
+			{Hostname: "n1", Data: []Float{2, 2}},
+			{Hostname: "n2", Data: []Float{100, 100}},
+			{Hostname: "n2", Data: []Float{200, 200}},
+		}},
+	}}
+	if !jd.AddNodeScope("flops_any", AggregationSum) {
+		t.Fatal("no node scope added")
+	}
+
+	expected := map[string]Float{"n1": 3, "n2": 300}
+	for _, series := range jd["flops_any"][MetricScopeNode].Series {
+		for _, x := range series.Data {
+			if x != expected[series.Hostname] {
+				t.Errorf("%s: expected %v, got %v", series.Hostname, expected[series.Hostname], series.Data)
+				break
+			}
+		}
+	}
+}
+
+func TestDownsample(t *testing.T) {
+	data := make([]Float, 1001)
+	for i := range data {
+		data[i] = Float(i % 10)
+	}
+	data[3] = NaN
+	jm := &JobMetric{
+		Timestep: 60,
+		Series: []Series{
+			{Hostname: "n1", Statistics: MetricStatistics{Min: 0, Avg: 4.5, Max: 9}, Data: data},
+			{Hostname: "n2", Data: []Float{1, 2, 3}},
+		},
+		StatisticsSeries: &StatsSeries{Mean: data, Min: data, Max: data},
+	}
+
+	if jm.Downsample(1001) != jm || jm.Downsample(0) != jm {
+		t.Error("expected short enough metric to be returned as is")
+	}
+
+	// 1001 points in buckets of 11 points.
+	res := jm.Downsample(100)
+	if res.Timestep != 660 || len(res.Series[0].Data) != 91 || len(res.Series[1].Data) != 1 {
+		t.Fatalf("unexpected downsampled metric: timestep %d, %d and %d points",
+			res.Timestep, len(res.Series[0].Data), len(res.Series[1].Data))
+	}
+	if res.Series[0].Statistics != jm.Series[0].Statistics || res.Series[1].Data[0] != 2 {
+		t.Errorf("unexpected series: %#v", res.Series)
+	}
+	if x := res.Series[0].Data[0]; x != (45.0-3+0)/10 {
+		t.Errorf("expected average of the first bucket without NaN, got %v", x)
+	}
+	if ss := res.StatisticsSeries; ss.Min[1] != 0 || ss.Max[1] != 9 || ss.Mean[1] != res.Series[0].Data[1] {
+		t.Errorf("unexpected statistics series: %v %v %v", ss.Min[1], ss.Mean[1], ss.Max[1])
+	}
+
+	sum, downsampledSum, n := 0.0, 0.0, 0
+	for _, x := range data[:990] {
+		if !x.IsNaN() {
+			sum += float64(x)
+			n++
+		}
+	}
+	for _, x := range res.Series[0].Data[:90] {
+		downsampledSum += float64(x) * 11
+	}
+	if avg := downsampledSum / 990; math.Abs(avg-sum/float64(n)) > 0.01 {
+		t.Errorf("average not preserved: %f, got %f", sum/float64(n), avg)
+	}
+
+	if len(jm.Series[0].Data) != 1001 || jm.Timestep != 60 {
+		t.Error("original metric modified")
+	}
+}
+
+func TestDownsampleMinMax(t *testing.T) {
+	jm := &JobMetric{
+		Timestep: 60,
+		Series:   []Series{{Hostname: "n1", Data: []Float{1, 5, NaN, 2, 8, 4, 3, NaN, NaN}}},
+	}
+
+	res := jm.Downsample(3)
+	series := res.Series[0]
+	expected := map[string][]Float{
+		"data": {3, 14.0 / 3, 3},
+		"min":  {1, 2, 3},
+		"max":  {5, 8, 3},
+	}
+	for name, data := range map[string][]Float{"data": series.Data, "min": series.Min, "max": series.Max} {
+		if len(data) != len(expected[name]) {
+			t.Fatalf("%s: expected %v, got %v", name, expected[name], data)
+		}
+		for i, x := range data {
+			if math.Abs(float64(x-expected[name][i])) > 1e-9 {
+				t.Errorf("%s: expected %v, got %v", name, expected[name], data)
+				break
+			}
+		}
+	}
+
+	// Downsampling again keeps the extremes of the buckets.
+	res = res.Downsample(1)
+	if series := res.Series[0]; series.Min[0] != 1 || series.Max[0] != 8 {
+		t.Errorf("expected min 1 and max 8, got %v and %v", series.Min, series.Max)
+	}
+	if jm.Series[0].Min != nil || jm.Series[0].Max != nil {
+		t.Error("original metric modified")
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright (C) 2023 NHR@FAU, University Erlangen-Nuremberg.
+// All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+package schema
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The base units of unit.schema.json. Values can only be converted between
+// units with the same base.
+var unitBases = map[string]bool{
+	"B": true, "F": true, "B/s": true, "F/s": true, "CPI": true,
+	"IPC": true, "Hz": true, "W": true, "°C": true, "": true,
+}
+
+// The prefixes of unit.schema.json and their factors.
+var unitPrefixes = map[string]float64{
+	"": 1, "K": 1e3, "M": 1e6, "G": 1e9, "T": 1e12, "P": 1e15, "E": 1e18,
+}
+
+func (u Unit) String() string {
+	return u.Prefix + u.Base
+}
+
+// ParseUnit splits a unit like "GB/s" into prefix and base.
+func ParseUnit(s string) (Unit, error) {
+	if unitBases[s] {
+		return Unit{Base: s}, nil
+	}
+
+	for prefix := range unitPrefixes {
+		if prefix != "" && strings.HasPrefix(s, prefix) && unitBases[s[len(prefix):]] {
+			return Unit{Base: s[len(prefix):], Prefix: prefix}, nil
+		}
+	}
+
+	return Unit{}, fmt.Errorf("unknown unit: '%s'", s)
+}
+
+// UnitFactor returns the factor to multiply values in unit from with to get
+// them in unit to.
+func UnitFactor(from, to Unit) (float64, error) {
+	for _, u := range []Unit{from, to} {
+		if !unitBases[u.Base] {
+			return 0, fmt.Errorf("unknown unit base: '%s'", u.Base)
+		}
+		if _, ok := unitPrefixes[u.Prefix]; !ok {
+			return 0, fmt.Errorf("unknown unit prefix: '%s'", u.Prefix)
+		}
+	}
+
+	if from.Base != to.Base {
+		return 0, fmt.Errorf("cannot convert '%s' to '%s'", from, to)
+	}
+
+	return unitPrefixes[from.Prefix] / unitPrefixes[to.Prefix], nil
+}
@@ -0,0 +1,100 @@
+// Copyright (C) 2023 NHR@FAU, University Erlangen-Nuremberg.
+// All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+package schema
+
+import "testing"
+
+func TestUnitFactor(t *testing.T) {
+	tests := []struct {
+		from, to string
+		factor   float64
+		wantErr  bool
+	}{
+		{from: "GB/s", to: "MB/s", factor: 1000},
+		{from: "MHz", to: "GHz", factor: 1e-3},
+		{from: "TF/s", to: "TF/s", factor: 1},
+		{from: "KB", to: "B", factor: 1000},
+		{from: "W", to: "W", factor: 1},
+		{from: "B/s", to: "Hz", wantErr: true},
+		{from: "GB", to: "GB/s", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		from, err := ParseUnit(tt.from)
+		if err != nil {
+			t.Fatal(err)
+		}
+		to, err := ParseUnit(tt.to)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		factor, err := UnitFactor(from, to)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s to %s: expected error", tt.from, tt.to)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s to %s: %v", tt.from, tt.to, err)
+		} else if factor != tt.factor {
+			t.Errorf("%s to %s: expected factor %v, got %v", tt.from, tt.to, tt.factor, factor)
+		}
+	}
+}
+
+func TestParseUnit(t *testing.T) {
+	if u, err := ParseUnit("GB/s"); err != nil || u.Prefix != "G" || u.Base != "B/s" {
+		t.Errorf("unexpected result: %#v, %v", u, err)
+	}
+	if u, err := ParseUnit("IPC"); err != nil || u.Prefix != "" || u.Base != "IPC" {
+		t.Errorf("unexpected result: %#v, %v", u, err)
+	}
+	for _, s := range []string{"foo", "GiB", "Gload"} {
+		if _, err := ParseUnit(s); err == nil {
+			t.Errorf("expected error for unit '%s'", s)
+		}
+	}
+	if _, err := UnitFactor(Unit{Base: "load"}, Unit{Base: "load"}); err == nil {
+		t.Error("expected error for unknown base")
+	}
+}
+
+func TestConvertUnit(t *testing.T) {
+	jm := &JobMetric{
+		Unit: Unit{Base: "Hz", Prefix: "M"},
+		Series: []Series{{
+			Data:       []Float{2000, NaN},
+			Statistics: MetricStatistics{Min: 2000, Avg: 2000, Max: 2000},
+		}},
+		StatisticsSeries: &StatsSeries{
+			Mean:        []Float{1000},
+			Min:         []Float{500},
+			Max:         []Float{1500},
+			Percentiles: map[int][]Float{50: {1000}},
+		},
+	}
+
+	if err := jm.ConvertUnit(Unit{Base: "Hz", Prefix: "G"}); err != nil {
+		t.Fatal(err)
+	}
+	if jm.Unit.String() != "GHz" {
+		t.Errorf("unexpected unit: %s", jm.Unit)
+	}
+	if s := jm.Series[0]; s.Data[0] != 2 || !s.Data[1].IsNaN() || s.Statistics.Max != 2 {
+		t.Errorf("series not converted: %v %v", s.Data, s.Statistics)
+	}
+	if ss := jm.StatisticsSeries; ss.Mean[0] != 1 || ss.Min[0] != 0.5 || ss.Max[0] != 1.5 || ss.Percentiles[50][0] != 1 {
+		t.Errorf("statistics series not converted: %#v", ss)
+	}
+
+	if err := jm.ConvertUnit(Unit{Base: "W"}); err == nil {
+		t.Error("expected error for incompatible unit")
+	}
+	if jm.Unit.String() != "GHz" {
+		t.Errorf("unit changed on error: %s", jm.Unit)
+	}
+}
@@ -32,6 +32,34 @@ func TestValidateConfig(t *testing.T) {
 	}
 }
 
+func TestValidateConfigMultipleRepositories(t *testing.T) {
+	json := []byte(`{
+    "jwts": {
+        "max-age": "2m"
+    },
+	"clusters": [
+	{
+	   "name": "testcluster",
+	   "metricDataRepository": [
+		{"kind": "cc-metric-store", "url": "localhost:8082"},
+		{"kind": "influxdb", "url": "localhost:8086", "token": "secret"}],
+	   "filterRanges": {
+		"numNodes": { "from": 1, "to": 64 },
+		"duration": { "from": 0, "to": 86400 },
+		"startTime": { "from": "2022-01-01T00:00:00Z", "to": null }
+	}}]
+}`)
+
+	if err := Validate(Config, bytes.NewReader(json)); err != nil {
+		t.Errorf("Error is not nil! %v", err)
+	}
+
+	invalid := bytes.Replace(json, []byte(`"kind": "influxdb", `), nil, 1)
+	if err := Validate(Config, bytes.NewReader(invalid)); err == nil {
+		t.Error("expected error for repository without kind")
+	}
+}
+
 func TestValidateJobMeta(t *testing.T) {
 
 }
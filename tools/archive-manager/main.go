@@ -33,7 +33,7 @@ func parseDate(in string) int64 {
 
 func main() {
 	var srcPath, flagConfigFile, flagLogLevel, flagRemoveCluster, flagRemoveAfter, flagRemoveBefore string
-	var flagLogDateTime, flagValidate bool
+	var flagLogDateTime, flagValidate, flagVerify, flagDryRun bool
 
 	flag.StringVar(&srcPath, "s", "./var/job-archive", "Specify the source job archive path. Default is ./var/job-archive")
 	flag.BoolVar(&flagLogDateTime, "logdate", false, "Set this flag to add date and time to log messages")
@@ -43,6 +43,8 @@ func main() {
 	flag.StringVar(&flagRemoveBefore, "remove-before", "", "Remove all jobs with start time before date (Format: 2006-Jan-04)")
 	flag.StringVar(&flagRemoveAfter, "remove-after", "", "Remove all jobs with start time after date (Format: 2006-Jan-04)")
 	flag.BoolVar(&flagValidate, "validate", false, "Set this flag to validate a job archive against the json schema")
+	flag.BoolVar(&flagVerify, "verify", false, "Check that the meta and metric data of all jobs parse and are consistent, e.g. after a crash, and list the corrupt jobs")
+	flag.BoolVar(&flagDryRun, "dry-run", false, "Only print the job directories -remove-before/-remove-after would remove")
 	flag.Parse()
 
 	archiveCfg := fmt.Sprintf("{\"kind\": \"file\",\"path\": \"%s\"}", srcPath)
@@ -50,6 +52,24 @@ func main() {
 	log.Init(flagLogLevel, flagLogDateTime)
 	config.Init(flagConfigFile)
 
+	if flagVerify {
+		report, err := archive.VerifyArchive(srcPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, job := range report.Corrupt {
+			fmt.Println(job.Path)
+			for _, reason := range job.Reasons {
+				fmt.Printf("\t%s\n", reason)
+			}
+		}
+		fmt.Printf("Verified %d jobs, %d corrupt\n", report.NumJobs, len(report.Corrupt))
+		if len(report.Corrupt) != 0 {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	if err := archive.Init(json.RawMessage(archiveCfg), false); err != nil {
 		log.Fatal(err)
 	}
@@ -64,7 +84,15 @@ func main() {
 	}
 
 	if flagRemoveBefore != "" || flagRemoveAfter != "" {
-		ar.Clean(parseDate(flagRemoveBefore), parseDate(flagRemoveAfter))
+		removed := ar.Clean(parseDate(flagRemoveBefore), parseDate(flagRemoveAfter), flagDryRun)
+		if flagDryRun {
+			for _, path := range removed {
+				fmt.Println(path)
+			}
+			fmt.Printf("Dry run: would remove %d job directories\n", len(removed))
+		} else {
+			log.Printf("Removed %d job directories\n", len(removed))
+		}
 		os.Exit(0)
 	}
 